@@ -0,0 +1,30 @@
+package main
+
+import (
+	"context"
+	"log"
+	"order-service/internal/config"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	scheduler, err := config.NewCronScheduler()
+	if err != nil {
+		log.Fatalf("failed to create new cron scheduler: %v", err)
+	}
+
+	// Running cron jobs are cancelled on shutdown
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Println("starting the cron scheduler")
+	err = scheduler.Run(ctx)
+	if err != nil {
+		log.Printf("run cron scheduler error = %v\n", err)
+	}
+
+	log.Println("shutting down the cron scheduler")
+	log.Println("cron scheduler gracefully stopped")
+}
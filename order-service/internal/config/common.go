@@ -28,8 +28,37 @@ type ServiceConfig struct {
 
 	AuthServiceJWTSecret string `envconfig:"AUTH_SERVICE_JWT_SECRET" required:"true"`
 
+	BasicAuthUsername string `envconfig:"SERVICE_BASIC_AUTH_USERNAME" required:"true"`
+	BasicAuthPassword string `envconfig:"SERVICE_BASIC_AUTH_PASSWORD" required:"true"`
+
 	OrderExpirationTimeSecond int `envconfig:"SERVICE_ORDER_EXPIRATION_TIME_SECOND" required:"true"`
 
+	OrderOutboxRetryIntervalSecond int `envconfig:"SERVICE_ORDER_OUTBOX_RETRY_INTERVAL_SECOND" default:"30"`
+	OrderOutboxRelayBatchSize      int `envconfig:"SERVICE_ORDER_OUTBOX_RELAY_BATCH_SIZE" default:"100"`
+	OrderReservationGraceSecond    int `envconfig:"SERVICE_ORDER_RESERVATION_GRACE_SECOND" default:"300"`
+	OrderExpiredBatchSize          int `envconfig:"SERVICE_ORDER_EXPIRED_BATCH_SIZE" default:"100"`
+	OrderExpiredWorkerSize         int `envconfig:"SERVICE_ORDER_EXPIRED_WORKER_SIZE" default:"4"`
+	OrderPaidReservationSecond     int `envconfig:"SERVICE_ORDER_PAID_RESERVATION_SECOND" default:"1209600"`
+	OrderIdempotencyKeyLeaseSecond int `envconfig:"SERVICE_ORDER_IDEMPOTENCY_KEY_LEASE_SECOND" default:"60"`
+
+	OrderWarehouseAllocationStrategy string   `envconfig:"SERVICE_ORDER_WAREHOUSE_ALLOCATION_STRATEGY" default:"largest-stock-first"`
+	OrderWarehouseAllocationPriority []string `envconfig:"SERVICE_ORDER_WAREHOUSE_ALLOCATION_PRIORITY"`
+
+	PaymentProvider       string `envconfig:"SERVICE_PAYMENT_PROVIDER" default:"fake"`
+	PaymentCallbackSecret string `envconfig:"SERVICE_PAYMENT_CALLBACK_SECRET" required:"true"`
+
+	WebhookRetryIntervalSecond int `envconfig:"SERVICE_WEBHOOK_RETRY_INTERVAL_SECOND" default:"30"`
+	WebhookRelayBatchSize      int `envconfig:"SERVICE_WEBHOOK_RELAY_BATCH_SIZE" default:"100"`
+	WebhookMaxAttempt          int `envconfig:"SERVICE_WEBHOOK_MAX_ATTEMPT" default:"8"`
+	WebhookTimeoutSecond       int `envconfig:"SERVICE_WEBHOOK_TIMEOUT_SECOND" default:"10"`
+
+	SalesRollupLookbackDay int `envconfig:"SERVICE_SALES_ROLLUP_LOOKBACK_DAY" default:"7"`
+
+	CronExpiredOrderSchedule string `envconfig:"SERVICE_CRON_EXPIRED_ORDER_SCHEDULE" default:"@every 1m"`
+	CronOutboxRelaySchedule  string `envconfig:"SERVICE_CRON_OUTBOX_RELAY_SCHEDULE" default:"@every 30s"`
+	CronWebhookRelaySchedule string `envconfig:"SERVICE_CRON_WEBHOOK_RELAY_SCHEDULE" default:"@every 30s"`
+	CronSalesRollupSchedule  string `envconfig:"SERVICE_CRON_SALES_ROLLUP_SCHEDULE" default:"0 1 * * *"`
+
 	DB     *sqlx.DB    `ignored:"true"`
 	Logger *zap.Logger `ignored:"true"`
 }
@@ -111,6 +140,7 @@ func loadOrderConfig(serviceConfig *ServiceConfig) (*orderConfig.OrderConfig, er
 	}
 
 	cfg.DB = serviceConfig.DB
+	cfg.DBName = serviceConfig.DatabaseConfig.Database
 	cfg.Logger = serviceConfig.Logger
 
 	return cfg, nil
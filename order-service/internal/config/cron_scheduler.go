@@ -0,0 +1,20 @@
+package config
+
+import (
+	"order-service/internal/util/libcron"
+	orderConfig "order-service/module/order/config"
+)
+
+func NewCronScheduler() (*libcron.Scheduler, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	contentCfg, err := loadOrderConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return orderConfig.NewCronScheduler(contentCfg)
+}
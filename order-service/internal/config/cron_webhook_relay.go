@@ -0,0 +1,20 @@
+package config
+
+import (
+	"order-service/internal/util/libcron"
+	orderConfig "order-service/module/order/config"
+)
+
+func NewCronWebhookRelay() (*libcron.Cron, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	contentCfg, err := loadOrderConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return orderConfig.NewCronWebhookRelay(contentCfg)
+}
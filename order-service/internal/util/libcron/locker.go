@@ -0,0 +1,57 @@
+package libcron
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util/liberr"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// Locker acquire the lock of the cron across the instances so only a single instance executes it at a time
+type Locker interface {
+	// TryLock acquire the lock without waiting, the returned unlock func has to be called once the execution is finished
+	TryLock(ctx context.Context, name string) (unlock func(), acquired bool, err error)
+}
+
+// MySQLLocker lock the cron with MySQL GET_LOCK. The lock is owned by the database connection,
+// so it is released along with the connection when the instance dies in the middle of the execution.
+// GET_LOCK names are shared by the whole MySQL server, so the cron name is prefixed with the namespace
+type MySQLLocker struct {
+	db        *sqlx.DB
+	namespace string
+}
+
+func NewMySQLLocker(db *sqlx.DB, namespace string) *MySQLLocker {
+	return &MySQLLocker{db: db, namespace: namespace}
+}
+
+func (m *MySQLLocker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	lockName := fmt.Sprintf("%s.%s", m.namespace, name)
+
+	conn, err := m.db.Connx(ctx)
+	if err != nil {
+		return nil, false, liberr.NewTracer("Error when Connx on mysqlLocker.TryLock").Wrap(err)
+	}
+
+	// GET_LOCK returns 1 when acquired, 0 on timeout and NULL on error
+	var acquired sql.NullInt64
+	if err := conn.QueryRowxContext(ctx, "SELECT GET_LOCK(?, 0)", lockName).Scan(&acquired); err != nil {
+		conn.Close() //nolint
+		return nil, false, liberr.NewTracer("Error when Scan on mysqlLocker.TryLock").Wrap(err)
+	}
+
+	if !acquired.Valid || acquired.Int64 != 1 {
+		conn.Close() //nolint
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		// Released even when the execution context is already cancelled
+		conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName) //nolint
+		conn.Close()                                                               //nolint
+	}
+
+	return unlock, true, nil
+}
@@ -0,0 +1,81 @@
+package libcron_test
+
+import (
+	"context"
+	"order-service/internal/testutil"
+	"order-service/internal/util/libcron"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLLocker_TryLock(t *testing.T) {
+	lockQuery := regexp.QuoteMeta("SELECT GET_LOCK(?, 0)")
+	unlockQuery := regexp.QuoteMeta("SELECT RELEASE_LOCK(?)")
+
+	testCases := []struct {
+		name           string
+		mockDependency func(*testutil.RepositoryDependency)
+		assertFn       func(func(), bool, error)
+	}{
+		{
+			name: "Success on Acquire Lock",
+			mockDependency: func(dependency *testutil.RepositoryDependency) {
+				dependency.MockedSQL.
+					ExpectQuery(lockQuery).
+					WithArgs("sample_db.sample_cron").
+					WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(1))
+				dependency.MockedSQL.
+					ExpectExec(unlockQuery).
+					WithArgs("sample_db.sample_cron").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			assertFn: func(unlock func(), acquired bool, err error) {
+				assert.Nil(t, err)
+				assert.True(t, acquired)
+				assert.NotNil(t, unlock)
+				unlock()
+			},
+		},
+		{
+			name: "Success on Lock Held by Another Instance",
+			mockDependency: func(dependency *testutil.RepositoryDependency) {
+				dependency.MockedSQL.
+					ExpectQuery(lockQuery).
+					WithArgs("sample_db.sample_cron").
+					WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(0))
+			},
+			assertFn: func(unlock func(), acquired bool, err error) {
+				assert.Nil(t, err)
+				assert.False(t, acquired)
+				assert.Nil(t, unlock)
+			},
+		},
+		{
+			name: "Error on Query Lock",
+			mockDependency: func(dependency *testutil.RepositoryDependency) {
+				dependency.MockedSQL.
+					ExpectQuery(lockQuery).
+					WithArgs("sample_db.sample_cron").
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(unlock func(), acquired bool, err error) {
+				assert.NotNil(t, err)
+				assert.False(t, acquired)
+				assert.Nil(t, unlock)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			repositoryDependency := testutil.NewRepositoryDependency()
+			locker := libcron.NewMySQLLocker(repositoryDependency.MockedDB, "sample_db")
+
+			tc.mockDependency(&repositoryDependency)
+			tc.assertFn(locker.TryLock(context.TODO(), "sample_cron"))
+			assert.Nil(t, repositoryDependency.MockedSQL.ExpectationsWereMet())
+		})
+	}
+}
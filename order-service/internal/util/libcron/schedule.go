@@ -0,0 +1,201 @@
+package libcron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const intervalPrefix = "@every "
+
+// predefinedSchedules are the shorthand of the common cron expressions
+var predefinedSchedules = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// Schedule decide the next execution time of a cron
+type Schedule interface {
+	// Next return the next execution time after the given time, zero time when there is no next execution
+	Next(t time.Time) time.Time
+}
+
+// ParseSchedule parse the interval (@every 30s), the predefined schedule (@hourly, @daily, @midnight, @weekly, @monthly)
+// or the standard cron expression with 5 fields (minute hour day-of-month month day-of-week)
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+
+	if strings.HasPrefix(spec, intervalPrefix) {
+		interval, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, intervalPrefix)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule interval %q: %w", spec, err)
+		}
+		if interval <= 0 {
+			return nil, fmt.Errorf("invalid schedule interval %q: interval has to be positive", spec)
+		}
+		return intervalSchedule{interval: interval}, nil
+	}
+
+	if expression, ok := predefinedSchedules[spec]; ok {
+		spec = expression
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, found %d", spec, len(fields))
+	}
+
+	minute, err := parseScheduleField(fields[0], 0, 59)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q minute: %w", spec, err)
+	}
+	hour, err := parseScheduleField(fields[1], 0, 23)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q hour: %w", spec, err)
+	}
+	dayOfMonth, err := parseScheduleField(fields[2], 1, 31)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q day of month: %w", spec, err)
+	}
+	month, err := parseScheduleField(fields[3], 1, 12)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q month: %w", spec, err)
+	}
+	// Sunday is either 0 or 7
+	dayOfWeek, err := parseScheduleField(fields[4], 0, 7)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule %q day of week: %w", spec, err)
+	}
+	if dayOfWeek&(1<<7) != 0 {
+		dayOfWeek |= 1
+	}
+
+	return &cronSchedule{
+		minute:        minute,
+		hour:          hour,
+		dayOfMonth:    dayOfMonth,
+		month:         month,
+		dayOfWeek:     dayOfWeek,
+		anyDayOfMonth: fields[2] == "*",
+		anyDayOfWeek:  fields[4] == "*",
+	}, nil
+}
+
+// parseScheduleField parse the comma separated values, ranges (1-5) and steps (*/15, 0-30/10) into the bit set of allowed values
+func parseScheduleField(field string, min, max int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangeAndStep := strings.SplitN(part, "/", 2)
+
+		step := 1
+		if len(rangeAndStep) == 2 {
+			s, err := strconv.Atoi(rangeAndStep[1])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			step = s
+		}
+
+		low, high := min, max
+		switch {
+		case rangeAndStep[0] == "*":
+		case strings.Contains(rangeAndStep[0], "-"):
+			bounds := strings.SplitN(rangeAndStep[0], "-", 2)
+			l, err := strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+			h, err := strconv.Atoi(bounds[1])
+			if err != nil {
+				return 0, fmt.Errorf("invalid range %q", part)
+			}
+			low, high = l, h
+		default:
+			v, err := strconv.Atoi(rangeAndStep[0])
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			low, high = v, v
+			// A single value with step starts the step from the value
+			if len(rangeAndStep) == 2 {
+				high = max
+			}
+		}
+
+		if low < min || high > max || low > high {
+			return 0, fmt.Errorf("value %q is out of range %d-%d", part, min, max)
+		}
+
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+type intervalSchedule struct {
+	interval time.Duration
+}
+
+func (i intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(i.interval)
+}
+
+type cronSchedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// maxScheduleSearchYear bound the search of the schedule which never matches (e.g. 30th of February)
+const maxScheduleSearchYear = 5
+
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+	yearLimit := t.Year() + maxScheduleSearchYear
+
+	for t.Year() <= yearLimit {
+		if c.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if c.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if c.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// dayMatches follow the cron convention, when both day of month and day of week are restricted either of them matches
+func (c *cronSchedule) dayMatches(t time.Time) bool {
+	dayOfMonth := c.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeek := c.dayOfWeek&(1<<uint(t.Weekday())) != 0
+
+	if c.anyDayOfMonth || c.anyDayOfWeek {
+		return dayOfMonth && dayOfWeek
+	}
+	return dayOfMonth || dayOfWeek
+}
@@ -0,0 +1,145 @@
+package libcron_test
+
+import (
+	"order-service/internal/util/libcron"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSchedule(t *testing.T) {
+	from := time.Date(2025, 1, 10, 11, 12, 13, 0, time.UTC) // Friday
+
+	testCases := []struct {
+		name     string
+		spec     string
+		assertFn func(libcron.Schedule, error)
+	}{
+		{
+			name: "Success on Interval",
+			spec: "@every 30s",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, from.Add(30*time.Second), schedule.Next(from))
+			},
+		},
+		{
+			name: "Success on Predefined Schedule",
+			spec: "@daily",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC), schedule.Next(from))
+			},
+		},
+		{
+			name: "Success on Every Minute",
+			spec: "* * * * *",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, time.Date(2025, 1, 10, 11, 13, 0, 0, time.UTC), schedule.Next(from))
+			},
+		},
+		{
+			name: "Success on Step",
+			spec: "*/15 * * * *",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, time.Date(2025, 1, 10, 11, 15, 0, 0, time.UTC), schedule.Next(from))
+			},
+		},
+		{
+			name: "Success on Range and List",
+			spec: "0 9-10,20 * * *",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, time.Date(2025, 1, 10, 20, 0, 0, 0, time.UTC), schedule.Next(from))
+			},
+		},
+		{
+			name: "Success on Next Month",
+			spec: "30 2 1 * *",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, time.Date(2025, 2, 1, 2, 30, 0, 0, time.UTC), schedule.Next(from))
+			},
+		},
+		{
+			name: "Success on Sunday as 7",
+			spec: "0 0 * * 7",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, time.Date(2025, 1, 12, 0, 0, 0, 0, time.UTC), schedule.Next(from))
+			},
+		},
+		{
+			name: "Success on Day of Month or Day of Week",
+			spec: "0 0 15 * 1",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), schedule.Next(from))
+			},
+		},
+		{
+			name: "Success on Never Matched Schedule",
+			spec: "0 0 30 2 *",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.Nil(t, err)
+				assert.True(t, schedule.Next(from).IsZero())
+			},
+		},
+		{
+			name: "Error on Invalid Interval",
+			spec: "@every soon",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, schedule)
+			},
+		},
+		{
+			name: "Error on Non Positive Interval",
+			spec: "@every 0s",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, schedule)
+			},
+		},
+		{
+			name: "Error on Number of Fields",
+			spec: "* * * *",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, schedule)
+			},
+		},
+		{
+			name: "Error on Out of Range Value",
+			spec: "60 * * * *",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, schedule)
+			},
+		},
+		{
+			name: "Error on Invalid Step",
+			spec: "*/0 * * * *",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, schedule)
+			},
+		},
+		{
+			name: "Error on Invalid Range",
+			spec: "* 5-a * * *",
+			assertFn: func(schedule libcron.Schedule, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, schedule)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.assertFn(libcron.ParseSchedule(tc.spec))
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package libcron
+
+import (
+	"context"
+	"errors"
+	"order-service/internal/util/liberr"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+var ErrNoCronRegistered = errors.New("no cron is registered to the scheduler")
+
+type SchedulerConfig struct {
+	// Locker is optional, without locker every instance executes the crons
+	Locker Locker
+	Logger *zap.Logger
+}
+
+// Scheduler keep executing the registered crons on their schedule, the long-running alternative of Cron.ExecuteCron
+type Scheduler struct {
+	jobs   []*scheduledCron
+	locker Locker
+	logger *zap.Logger
+}
+
+type scheduledCron struct {
+	cron     *Cron
+	schedule Schedule
+}
+
+func NewScheduler(cfg SchedulerConfig) *Scheduler {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	return &Scheduler{
+		locker: cfg.Locker,
+		logger: logger,
+	}
+}
+
+// Register add the cron to be executed on the schedule, see ParseSchedule for the supported schedule
+func (s *Scheduler) Register(spec string, cron *Cron) error {
+	schedule, err := ParseSchedule(spec)
+	if err != nil {
+		return err
+	}
+
+	s.jobs = append(s.jobs, &scheduledCron{
+		cron:     cron,
+		schedule: schedule,
+	})
+
+	return nil
+}
+
+// Run execute the registered crons until the context is cancelled, the cancellation is propagated
+// to the running executions and Run returns once they are finished
+func (s *Scheduler) Run(ctx context.Context) error {
+	if len(s.jobs) == 0 {
+		return ErrNoCronRegistered
+	}
+
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func(job *scheduledCron) {
+			defer wg.Done()
+			s.runCron(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// runCron wait for the next execution time after the previous execution is finished,
+// so executions of the same cron never overlap and the ticks missed by a long execution are skipped
+func (s *Scheduler) runCron(ctx context.Context, job *scheduledCron) {
+	for {
+		next := job.schedule.Next(time.Now())
+		if next.IsZero() {
+			s.logger.Warn("Stop scheduling cron job without next execution", zap.String("name", job.cron.name))
+			return
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.executeCron(ctx, job)
+	}
+}
+
+func (s *Scheduler) executeCron(ctx context.Context, job *scheduledCron) {
+	if s.locker != nil {
+		unlock, acquired, err := s.locker.TryLock(ctx, job.cron.name)
+		if err != nil {
+			fields := liberr.AppendErrorLogField([]zap.Field{zap.String("name", job.cron.name)}, err)
+			s.logger.Error("Failed acquiring cron job lock", fields...)
+			return
+		}
+		if !acquired {
+			s.logger.Info("Skip executing cron job locked by another instance", zap.String("name", job.cron.name))
+			return
+		}
+		defer unlock()
+	}
+
+	// The result is logged by the cron logger
+	job.cron.cronHandler.ExecuteFunction(ctx, os.Args) //nolint
+}
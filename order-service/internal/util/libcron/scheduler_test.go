@@ -0,0 +1,140 @@
+package libcron_test
+
+import (
+	"context"
+	"errors"
+	"order-service/internal/util/libcron"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type locker struct {
+	acquired bool
+	err      error
+	unlocked atomic.Int32
+}
+
+func (l *locker) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	if l.err != nil || !l.acquired {
+		return nil, false, l.err
+	}
+	return func() { l.unlocked.Add(1) }, true, nil
+}
+
+// countingCronHandler count the executions along with the maximum of the concurrent executions
+type countingCronHandler struct {
+	mu            sync.Mutex
+	running       int
+	maxConcurrent int
+	executed      int
+	duration      time.Duration
+}
+
+func (c *countingCronHandler) ExecuteFunction(ctx context.Context, args []string) error {
+	c.mu.Lock()
+	c.running++
+	c.executed++
+	c.maxConcurrent = max(c.maxConcurrent, c.running)
+	c.mu.Unlock()
+
+	select {
+	case <-time.After(c.duration):
+	case <-ctx.Done():
+	}
+
+	c.mu.Lock()
+	c.running--
+	c.mu.Unlock()
+	return nil
+}
+
+func TestScheduler_Run(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+
+	testCases := []struct {
+		name     string
+		locker   *locker
+		handler  *countingCronHandler
+		spec     string
+		assertFn func(*countingCronHandler, *locker, error)
+	}{
+		{
+			name:    "Success on Execute without Overlap",
+			handler: &countingCronHandler{duration: 30 * time.Millisecond},
+			spec:    "@every 5ms",
+			assertFn: func(handler *countingCronHandler, l *locker, err error) {
+				assert.Nil(t, err)
+				assert.Greater(t, handler.executed, 1)
+				assert.Equal(t, 1, handler.maxConcurrent)
+				assert.Equal(t, 0, handler.running)
+			},
+		},
+		{
+			name:    "Success on Execute with Acquired Lock",
+			locker:  &locker{acquired: true},
+			handler: &countingCronHandler{},
+			spec:    "@every 10ms",
+			assertFn: func(handler *countingCronHandler, l *locker, err error) {
+				assert.Nil(t, err)
+				assert.Greater(t, handler.executed, 0)
+				assert.Equal(t, handler.executed, int(l.unlocked.Load()))
+			},
+		},
+		{
+			name:    "Success on Skip Lock Held by Another Instance",
+			locker:  &locker{acquired: false},
+			handler: &countingCronHandler{},
+			spec:    "@every 10ms",
+			assertFn: func(handler *countingCronHandler, l *locker, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, 0, handler.executed)
+			},
+		},
+		{
+			name:    "Success on Skip Failed Lock",
+			locker:  &locker{err: errors.New("error happened")},
+			handler: &countingCronHandler{},
+			spec:    "@every 10ms",
+			assertFn: func(handler *countingCronHandler, l *locker, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, 0, handler.executed)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := libcron.SchedulerConfig{Logger: logger}
+			if tc.locker != nil {
+				cfg.Locker = tc.locker
+			}
+			scheduler := libcron.NewScheduler(cfg)
+
+			err := scheduler.Register(tc.spec, libcron.NewCron(libcron.Config{
+				Name:        "sample_cron",
+				Logger:      logger,
+				CronHandler: tc.handler,
+			}))
+			assert.Nil(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			tc.assertFn(tc.handler, tc.locker, scheduler.Run(ctx))
+		})
+	}
+}
+
+func TestScheduler_Register(t *testing.T) {
+	scheduler := libcron.NewScheduler(libcron.SchedulerConfig{})
+
+	err := scheduler.Register("invalid", libcron.NewCron(libcron.Config{Name: "sample_cron", CronHandler: cronHandler{}}))
+	assert.NotNil(t, err)
+
+	err = scheduler.Run(context.TODO())
+	assert.Equal(t, libcron.ErrNoCronRegistered, err)
+}
@@ -38,6 +38,22 @@ func (rw *ResponseWriter) WriteHeader(code int) {
 	}
 }
 
+// Write mark the header as written, since the first write sends the header with the status OK
+func (rw *ResponseWriter) Write(b []byte) (int, error) {
+	rw.wroteHeader = true
+	return rw.ResponseWriter.Write(b)
+}
+
+// WroteHeader report whether the header is sent, the error of the streamed response can no longer be written once sent
+func (rw *ResponseWriter) WroteHeader() bool {
+	return rw.wroteHeader
+}
+
+// Unwrap return the original http.ResponseWriter, so http.ResponseController can flush the streamed response
+func (rw *ResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 func (rw *ResponseWriter) SetError(err error) {
 	rw.err = err
 }
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"order-service/internal/util"
 	"order-service/module/order/internal/repository"
 	"order-service/module/order/internal/usecase"
@@ -13,6 +14,7 @@ import (
 
 type OrderConfig struct {
 	DB     *sqlx.DB    `ignored:"true"`
+	DBName string      `ignored:"true"`
 	Logger *zap.Logger `ignored:"true"`
 
 	ProductServiceHost              string `envconfig:"PRODUCT_SERVICE_HOST" required:"true"`
@@ -25,14 +27,61 @@ type OrderConfig struct {
 
 	AuthServiceJWTSecret string `envconfig:"AUTH_SERVICE_JWT_SECRET" required:"true"`
 
+	BasicAuthUsername string `envconfig:"SERVICE_BASIC_AUTH_USERNAME" required:"true"`
+	BasicAuthPassword string `envconfig:"SERVICE_BASIC_AUTH_PASSWORD" required:"true"`
+
 	OrderExpirationTimeSecond int `envconfig:"SERVICE_ORDER_EXPIRATION_TIME_SECOND" required:"true"`
+
+	OrderOutboxRetryIntervalSecond int `envconfig:"SERVICE_ORDER_OUTBOX_RETRY_INTERVAL_SECOND" default:"30"`
+	OrderOutboxRelayBatchSize      int `envconfig:"SERVICE_ORDER_OUTBOX_RELAY_BATCH_SIZE" default:"100"`
+	OrderReservationGraceSecond    int `envconfig:"SERVICE_ORDER_RESERVATION_GRACE_SECOND" default:"300"`
+	OrderExpiredBatchSize          int `envconfig:"SERVICE_ORDER_EXPIRED_BATCH_SIZE" default:"100"`
+	OrderExpiredWorkerSize         int `envconfig:"SERVICE_ORDER_EXPIRED_WORKER_SIZE" default:"4"`
+	OrderPaidReservationSecond     int `envconfig:"SERVICE_ORDER_PAID_RESERVATION_SECOND" default:"1209600"`
+	OrderIdempotencyKeyLeaseSecond int `envconfig:"SERVICE_ORDER_IDEMPOTENCY_KEY_LEASE_SECOND" default:"60"`
+
+	OrderWarehouseAllocationStrategy string   `envconfig:"SERVICE_ORDER_WAREHOUSE_ALLOCATION_STRATEGY" default:"largest-stock-first"`
+	OrderWarehouseAllocationPriority []string `envconfig:"SERVICE_ORDER_WAREHOUSE_ALLOCATION_PRIORITY"`
+
+	PaymentProvider       string `envconfig:"SERVICE_PAYMENT_PROVIDER" default:"fake"`
+	PaymentCallbackSecret string `envconfig:"SERVICE_PAYMENT_CALLBACK_SECRET" required:"true"`
+
+	WebhookRetryIntervalSecond int `envconfig:"SERVICE_WEBHOOK_RETRY_INTERVAL_SECOND" default:"30"`
+	WebhookRelayBatchSize      int `envconfig:"SERVICE_WEBHOOK_RELAY_BATCH_SIZE" default:"100"`
+	WebhookMaxAttempt          int `envconfig:"SERVICE_WEBHOOK_MAX_ATTEMPT" default:"8"`
+	WebhookTimeoutSecond       int `envconfig:"SERVICE_WEBHOOK_TIMEOUT_SECOND" default:"10"`
+
+	SalesRollupLookbackDay int `envconfig:"SERVICE_SALES_ROLLUP_LOOKBACK_DAY" default:"7"`
+
+	CronExpiredOrderSchedule string `envconfig:"SERVICE_CRON_EXPIRED_ORDER_SCHEDULE" default:"@every 1m"`
+	CronOutboxRelaySchedule  string `envconfig:"SERVICE_CRON_OUTBOX_RELAY_SCHEDULE" default:"@every 30s"`
+	CronWebhookRelaySchedule string `envconfig:"SERVICE_CRON_WEBHOOK_RELAY_SCHEDULE" default:"@every 30s"`
+	CronSalesRollupSchedule  string `envconfig:"SERVICE_CRON_SALES_ROLLUP_SCHEDULE" default:"0 1 * * *"`
 }
 
 type repositorySet struct {
-	orderRepository       *repository.OrderRepository
-	orderDetailRepository *repository.OrderDetailRepository
-	productRepository     *repository.ProductRepository
-	warehouseRepository   *repository.WarehouseRepository
+	orderRepository               *repository.OrderRepository
+	checkoutRepository            *repository.CheckoutRepository
+	cartItemRepository            *repository.CartItemRepository
+	orderDetailRepository         *repository.OrderDetailRepository
+	productRepository             *repository.ProductRepository
+	warehouseRepository           *repository.WarehouseRepository
+	orderIdempotencyKeyRepository *repository.OrderIdempotencyKeyRepository
+	orderOutboxRepository         *repository.OrderOutboxRepository
+	voucherRepository             *repository.VoucherRepository
+	voucherUsageRepository        *repository.VoucherUsageRepository
+	orderDiscountRepository       *repository.OrderDiscountRepository
+	shopConfigRepository          *repository.ShopConfigRepository
+	taxRateRepository             *repository.TaxRateRepository
+	expirationPolicyRepository    *repository.OrderExpirationPolicyRepository
+	orderReturnRepository         *repository.OrderReturnRepository
+	orderStateHistoryRepository   *repository.OrderStateHistoryRepository
+	salesRollupRepository         *repository.SalesRollupRepository
+	paymentRepository             *repository.PaymentRepository
+	paymentProvider               usecase.PaymentProvider
+	webhookSubscriptionRepository *repository.WebhookSubscriptionRepository
+	webhookDeliveryRepository     *repository.WebhookDeliveryRepository
+	webhookRepository             *repository.WebhookRepository
 }
 
 type usecaseSet struct {
@@ -50,10 +99,60 @@ func newServiceClient() *rh.Client {
 	return client
 }
 
+// newWebhookClient retries the transient failures of the attempt with an exponential backoff, the last
+// response is passed through once the retries are exhausted so its status is kept in the delivery log
+func newWebhookClient(cfg *OrderConfig) *rh.Client {
+	client := rh.NewClient()
+	client.Logger = nil // disable internal logging
+	client.HTTPClient.Timeout = time.Duration(cfg.WebhookTimeoutSecond) * time.Second
+	client.RetryMax = 3
+	client.Backoff = rh.DefaultBackoff
+	client.CheckRetry = rh.DefaultRetryPolicy
+	client.ErrorHandler = rh.PassthroughErrorHandler
+
+	return client
+}
+
+func newPaymentProvider(cfg *OrderConfig) (usecase.PaymentProvider, error) {
+	switch cfg.PaymentProvider {
+	case repository.FakePaymentProviderName:
+		return repository.NewFakePaymentProvider(
+			repository.FakePaymentProviderConfiguration{
+				CallbackSecret: cfg.PaymentCallbackSecret,
+			},
+		), nil
+	}
+
+	return nil, fmt.Errorf("unknown payment provider %q", cfg.PaymentProvider)
+}
+
 func newRepositories(cfg *OrderConfig) (*repositorySet, error) {
+	paymentProvider, err := newPaymentProvider(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return &repositorySet{
-		orderRepository:       repository.NewOrderRepository(cfg.DB),
-		orderDetailRepository: repository.NewOrderDetailRepository(cfg.DB),
+		orderRepository:               repository.NewOrderRepository(cfg.DB),
+		checkoutRepository:            repository.NewCheckoutRepository(cfg.DB),
+		cartItemRepository:            repository.NewCartItemRepository(cfg.DB),
+		orderDetailRepository:         repository.NewOrderDetailRepository(cfg.DB),
+		orderIdempotencyKeyRepository: repository.NewOrderIdempotencyKeyRepository(cfg.DB),
+		orderOutboxRepository:         repository.NewOrderOutboxRepository(cfg.DB),
+		voucherRepository:             repository.NewVoucherRepository(cfg.DB),
+		voucherUsageRepository:        repository.NewVoucherUsageRepository(cfg.DB),
+		orderDiscountRepository:       repository.NewOrderDiscountRepository(cfg.DB),
+		shopConfigRepository:          repository.NewShopConfigRepository(cfg.DB),
+		taxRateRepository:             repository.NewTaxRateRepository(cfg.DB),
+		expirationPolicyRepository:    repository.NewOrderExpirationPolicyRepository(cfg.DB),
+		orderReturnRepository:         repository.NewOrderReturnRepository(cfg.DB),
+		orderStateHistoryRepository:   repository.NewOrderStateHistoryRepository(cfg.DB),
+		salesRollupRepository:         repository.NewSalesRollupRepository(cfg.DB),
+		paymentRepository:             repository.NewPaymentRepository(cfg.DB),
+		paymentProvider:               paymentProvider,
+		webhookSubscriptionRepository: repository.NewWebhookSubscriptionRepository(cfg.DB),
+		webhookDeliveryRepository:     repository.NewWebhookDeliveryRepository(cfg.DB),
+		webhookRepository:             repository.NewWebhookRepository(newWebhookClient(cfg)),
 		warehouseRepository: repository.NewWarehouseRepository(
 			repository.WarehouseConfiguration{
 				ApiHost:           cfg.WarehouseServiceHost,
@@ -76,15 +175,50 @@ func newRepositories(cfg *OrderConfig) (*repositorySet, error) {
 func newUsecase(cfg *OrderConfig, repositories *repositorySet) (*usecaseSet, error) {
 	databaseTransactionHandler := util.NewDatabaseTransactionHandler(cfg.DB)
 
+	warehouseAllocationStrategy, err := usecase.NewWarehouseAllocationStrategy(cfg.OrderWarehouseAllocationStrategy, cfg.OrderWarehouseAllocationPriority)
+	if err != nil {
+		return nil, err
+	}
+
 	return &usecaseSet{
 		orderUsecase: usecase.NewOrderUsecase(&usecase.OrderUsecaseRepos{
 			DatabaseTransactionHandler: databaseTransactionHandler,
 			OrderRepo:                  repositories.orderRepository,
+			CheckoutRepo:               repositories.checkoutRepository,
+			CartItemRepo:               repositories.cartItemRepository,
 			OrderDetailRepo:            repositories.orderDetailRepository,
+			OrderIdempotencyKeyRepo:    repositories.orderIdempotencyKeyRepository,
+			OrderOutboxRepo:            repositories.orderOutboxRepository,
+			VoucherRepo:                repositories.voucherRepository,
+			VoucherUsageRepo:           repositories.voucherUsageRepository,
+			OrderDiscountRepo:          repositories.orderDiscountRepository,
+			ShopConfigRepo:             repositories.shopConfigRepository,
+			TaxRateRepo:                repositories.taxRateRepository,
+			ExpirationPolicyRepo:       repositories.expirationPolicyRepository,
+			OrderReturnRepo:            repositories.orderReturnRepository,
+			OrderStateHistoryRepo:      repositories.orderStateHistoryRepository,
+			SalesRollupRepo:            repositories.salesRollupRepository,
+			PaymentRepo:                repositories.paymentRepository,
+			PaymentProvider:            repositories.paymentProvider,
+			WebhookSubscriptionRepo:    repositories.webhookSubscriptionRepository,
+			WebhookDeliveryRepo:        repositories.webhookDeliveryRepository,
+			WebhookRepo:                repositories.webhookRepository,
 			WarehouseRepo:              repositories.warehouseRepository,
 			ProductRepo:                repositories.productRepository,
 		}, &usecase.OrderUsecaseConfig{
-			OrderExpirationTimeSecond: cfg.OrderExpirationTimeSecond,
+			OrderExpirationTimeSecond:      cfg.OrderExpirationTimeSecond,
+			OrderOutboxRetryIntervalSecond: cfg.OrderOutboxRetryIntervalSecond,
+			OrderOutboxRelayBatchSize:      cfg.OrderOutboxRelayBatchSize,
+			OrderReservationGraceSecond:    cfg.OrderReservationGraceSecond,
+			OrderExpiredBatchSize:          cfg.OrderExpiredBatchSize,
+			OrderExpiredWorkerSize:         cfg.OrderExpiredWorkerSize,
+			OrderPaidReservationSecond:     cfg.OrderPaidReservationSecond,
+			OrderIdempotencyKeyLeaseSecond: cfg.OrderIdempotencyKeyLeaseSecond,
+			WebhookRetryIntervalSecond:     cfg.WebhookRetryIntervalSecond,
+			WebhookRelayBatchSize:          cfg.WebhookRelayBatchSize,
+			WebhookMaxAttempt:              cfg.WebhookMaxAttempt,
+			SalesRollupLookbackDay:         cfg.SalesRollupLookbackDay,
+			WarehouseAllocationStrategy:    warehouseAllocationStrategy,
 		}, cfg.Logger),
 	}, nil
 }
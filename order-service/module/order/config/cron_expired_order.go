@@ -16,11 +16,15 @@ func NewCronExpiredOrder(cfg *OrderConfig) (*libcron.Cron, error) {
 		return nil, err
 	}
 
+	return newCronExpiredOrder(cfg, usecases), nil
+}
+
+func newCronExpiredOrder(cfg *OrderConfig, usecases *usecaseSet) *libcron.Cron {
 	cronHandler := cron.NewExpiredOrderCron(usecases.orderUsecase)
 
 	return libcron.NewCron(libcron.Config{
 		Name:        "CronOrderExpired",
 		CronHandler: cronHandler,
 		Logger:      cfg.Logger,
-	}), nil
+	})
 }
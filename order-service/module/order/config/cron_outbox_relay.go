@@ -0,0 +1,30 @@
+package config
+
+import (
+	"order-service/internal/util/libcron"
+	"order-service/module/order/internal/cron"
+)
+
+func NewCronOutboxRelay(cfg *OrderConfig) (*libcron.Cron, error) {
+	repositories, err := newRepositories(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	usecases, err := newUsecase(cfg, repositories)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCronOutboxRelay(cfg, usecases), nil
+}
+
+func newCronOutboxRelay(cfg *OrderConfig, usecases *usecaseSet) *libcron.Cron {
+	cronHandler := cron.NewOutboxRelayCron(usecases.orderUsecase)
+
+	return libcron.NewCron(libcron.Config{
+		Name:        "CronOrderOutboxRelay",
+		CronHandler: cronHandler,
+		Logger:      cfg.Logger,
+	})
+}
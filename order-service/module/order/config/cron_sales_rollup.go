@@ -0,0 +1,30 @@
+package config
+
+import (
+	"order-service/internal/util/libcron"
+	"order-service/module/order/internal/cron"
+)
+
+func NewCronSalesRollup(cfg *OrderConfig) (*libcron.Cron, error) {
+	repositories, err := newRepositories(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	usecases, err := newUsecase(cfg, repositories)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCronSalesRollup(cfg, usecases), nil
+}
+
+func newCronSalesRollup(cfg *OrderConfig, usecases *usecaseSet) *libcron.Cron {
+	cronHandler := cron.NewSalesRollupCron(usecases.orderUsecase)
+
+	return libcron.NewCron(libcron.Config{
+		Name:        "CronOrderSalesRollup",
+		CronHandler: cronHandler,
+		Logger:      cfg.Logger,
+	})
+}
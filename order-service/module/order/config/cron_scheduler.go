@@ -0,0 +1,42 @@
+package config
+
+import (
+	"order-service/internal/util/libcron"
+)
+
+// NewCronScheduler register every order cron to a single long-running scheduler, the crons are locked
+// with MySQL GET_LOCK prefixed by the database name so only one of the scheduler instances executes each of them at a time
+func NewCronScheduler(cfg *OrderConfig) (*libcron.Scheduler, error) {
+	repositories, err := newRepositories(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	usecases, err := newUsecase(cfg, repositories)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduler := libcron.NewScheduler(libcron.SchedulerConfig{
+		Locker: libcron.NewMySQLLocker(cfg.DB, cfg.DBName),
+		Logger: cfg.Logger,
+	})
+
+	if err := scheduler.Register(cfg.CronExpiredOrderSchedule, newCronExpiredOrder(cfg, usecases)); err != nil {
+		return nil, err
+	}
+
+	if err := scheduler.Register(cfg.CronOutboxRelaySchedule, newCronOutboxRelay(cfg, usecases)); err != nil {
+		return nil, err
+	}
+
+	if err := scheduler.Register(cfg.CronWebhookRelaySchedule, newCronWebhookRelay(cfg, usecases)); err != nil {
+		return nil, err
+	}
+
+	if err := scheduler.Register(cfg.CronSalesRollupSchedule, newCronSalesRollup(cfg, usecases)); err != nil {
+		return nil, err
+	}
+
+	return scheduler, nil
+}
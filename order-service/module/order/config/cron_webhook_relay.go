@@ -0,0 +1,30 @@
+package config
+
+import (
+	"order-service/internal/util/libcron"
+	"order-service/module/order/internal/cron"
+)
+
+func NewCronWebhookRelay(cfg *OrderConfig) (*libcron.Cron, error) {
+	repositories, err := newRepositories(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	usecases, err := newUsecase(cfg, repositories)
+	if err != nil {
+		return nil, err
+	}
+
+	return newCronWebhookRelay(cfg, usecases), nil
+}
+
+func newCronWebhookRelay(cfg *OrderConfig, usecases *usecaseSet) *libcron.Cron {
+	cronHandler := cron.NewWebhookRelayCron(usecases.orderUsecase)
+
+	return libcron.NewCron(libcron.Config{
+		Name:        "CronOrderWebhookRelay",
+		CronHandler: cronHandler,
+		Logger:      cfg.Logger,
+	})
+}
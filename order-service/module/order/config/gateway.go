@@ -23,6 +23,8 @@ func RegisterGatewayHandler(serverMux *mux.Router, cfg *OrderConfig) error {
 		},
 		Logger:               cfg.Logger,
 		AuthServiceJWTSecret: cfg.AuthServiceJWTSecret,
+		BasicAuthUsername:    cfg.BasicAuthUsername,
+		BasicAuthPassword:    cfg.BasicAuthPassword,
 	}
 	if err := server.RegisterRESTHandler(serverMux, serverConfig); err != nil {
 		return err
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS order_idempotency_keys (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id         BIGINT NOT NULL,
+    idempotency_key VARCHAR(255) NOT NULL,
+    request_hash    CHAR(64) NOT NULL,
+    state           TINYINT NOT NULL,
+    order_id        BIGINT NULL,
+    locked_until    TIMESTAMP NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_order_idempotency_keys_user_id_key ON order_idempotency_keys (user_id, idempotency_key);
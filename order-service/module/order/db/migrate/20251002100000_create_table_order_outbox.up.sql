@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS order_outboxes (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    order_id        BIGINT NOT NULL,
+    command         TINYINT NOT NULL,
+    payload         TEXT NOT NULL,
+    state           TINYINT NOT NULL,
+    attempt         INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    last_error      TEXT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_order_outboxes_order_id ON order_outboxes (order_id);
+CREATE INDEX idx_order_outboxes_state_next_attempt_at ON order_outboxes (state, next_attempt_at);
@@ -0,0 +1,3 @@
+DROP INDEX idx_orders_checkout_id ON orders;
+ALTER TABLE orders DROP COLUMN checkout_id;
+DROP TABLE IF EXISTS `checkouts`;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS checkouts (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id         BIGINT NOT NULL,
+    total_stock     INT NOT NULL,
+    total_price     DECIMAL(15,3) NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_checkouts_user_id ON checkouts (user_id);
+
+ALTER TABLE orders ADD COLUMN checkout_id BIGINT NULL AFTER shop_id;
+
+CREATE INDEX idx_orders_checkout_id ON orders (checkout_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS cart_items (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id         BIGINT NOT NULL,
+    shop_id         BIGINT NOT NULL,
+    product_id      BIGINT NOT NULL,
+    stock           INT NOT NULL,
+    price           DECIMAL(15,3) NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_cart_items_user_id_shop_id_product_id ON cart_items (user_id, shop_id, product_id);
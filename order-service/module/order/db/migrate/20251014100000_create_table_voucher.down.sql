@@ -0,0 +1,4 @@
+ALTER TABLE orders DROP COLUMN discount_price;
+DROP TABLE IF EXISTS `order_discounts`;
+DROP TABLE IF EXISTS `voucher_usages`;
+DROP TABLE IF EXISTS `vouchers`;
@@ -0,0 +1,46 @@
+CREATE TABLE IF NOT EXISTS vouchers (
+    id                      BIGINT PRIMARY KEY AUTO_INCREMENT,
+    code                    VARCHAR(64) NOT NULL,
+    shop_id                 BIGINT NULL,
+    product_id              BIGINT NULL,
+    type                    TINYINT NOT NULL,
+    value                   DECIMAL(15,3) NOT NULL DEFAULT 0,
+    max_discount            DECIMAL(15,3) NOT NULL DEFAULT 0,
+    buy_quantity            INT NOT NULL DEFAULT 0,
+    get_quantity            INT NOT NULL DEFAULT 0,
+    min_spend               DECIMAL(15,3) NOT NULL DEFAULT 0,
+    usage_limit             INT NOT NULL DEFAULT 0,
+    usage_limit_per_user    INT NOT NULL DEFAULT 0,
+    used_count              INT NOT NULL DEFAULT 0,
+    starts_at               DATETIME NOT NULL,
+    ends_at                 DATETIME NOT NULL,
+    created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_vouchers_code ON vouchers (code);
+
+CREATE TABLE IF NOT EXISTS voucher_usages (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    voucher_id      BIGINT NOT NULL,
+    user_id         BIGINT NOT NULL,
+    order_id        BIGINT NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_voucher_usages_voucher_id_user_id ON voucher_usages (voucher_id, user_id);
+CREATE INDEX idx_voucher_usages_order_id ON voucher_usages (order_id);
+
+CREATE TABLE IF NOT EXISTS order_discounts (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    order_id        BIGINT NOT NULL,
+    voucher_id      BIGINT NOT NULL,
+    code            VARCHAR(64) NOT NULL,
+    amount          DECIMAL(15,3) NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_order_discounts_order_id ON order_discounts (order_id);
+
+ALTER TABLE orders ADD COLUMN discount_price DECIMAL(15,3) NOT NULL DEFAULT 0 AFTER total_stock;
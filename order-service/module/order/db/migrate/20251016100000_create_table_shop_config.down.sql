@@ -0,0 +1,6 @@
+ALTER TABLE orders
+    DROP COLUMN subtotal_price,
+    DROP COLUMN shipping_price,
+    DROP COLUMN tax_price;
+DROP TABLE IF EXISTS `tax_rates`;
+DROP TABLE IF EXISTS `shop_configs`;
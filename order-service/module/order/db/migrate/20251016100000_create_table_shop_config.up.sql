@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS shop_configs (
+    id                      BIGINT PRIMARY KEY AUTO_INCREMENT,
+    shop_id                 BIGINT NOT NULL,
+    tax_region              VARCHAR(64) NOT NULL DEFAULT '',
+    shipping_method         TINYINT NOT NULL DEFAULT 0,
+    shipping_fee            DECIMAL(15,3) NOT NULL DEFAULT 0,
+    shipping_fee_per_kg     DECIMAL(15,3) NOT NULL DEFAULT 0,
+    item_weight_gram        INT NOT NULL DEFAULT 0,
+    created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_shop_configs_shop_id ON shop_configs (shop_id);
+
+CREATE TABLE IF NOT EXISTS tax_rates (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    region          VARCHAR(64) NOT NULL,
+    rate            DECIMAL(7,4) NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_tax_rates_region ON tax_rates (region);
+
+ALTER TABLE orders
+    ADD COLUMN subtotal_price DECIMAL(15,3) NOT NULL DEFAULT 0 AFTER total_stock,
+    ADD COLUMN shipping_price DECIMAL(15,3) NOT NULL DEFAULT 0 AFTER discount_price,
+    ADD COLUMN tax_price DECIMAL(15,3) NOT NULL DEFAULT 0 AFTER shipping_price;
+
+-- Orders placed before the breakdown have neither shipping nor tax
+UPDATE orders SET subtotal_price = total_price + discount_price;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS payments (
+    id                      BIGINT PRIMARY KEY AUTO_INCREMENT,
+    order_id                BIGINT NOT NULL,
+    user_id                 BIGINT NOT NULL,
+    provider                VARCHAR(32) NOT NULL,
+    provider_reference      VARCHAR(128) NOT NULL,
+    payment_url             VARCHAR(255) NOT NULL DEFAULT '',
+    amount                  DECIMAL(15,3) NOT NULL,
+    state                   TINYINT NOT NULL,
+    captured_at             DATETIME NULL,
+    refunded_at             DATETIME NULL,
+    created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_payments_provider_reference ON payments (provider, provider_reference);
+CREATE INDEX idx_payments_order_id ON payments (order_id);
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id                      BIGINT PRIMARY KEY AUTO_INCREMENT,
+    shop_id                 BIGINT NOT NULL,
+    url                     VARCHAR(255) NOT NULL,
+    secret                  VARCHAR(64) NOT NULL,
+    events                  VARCHAR(255) NOT NULL,
+    active                  TINYINT(1) NOT NULL DEFAULT 1,
+    created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_webhook_subscriptions_shop_id ON webhook_subscriptions (shop_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id                      BIGINT PRIMARY KEY AUTO_INCREMENT,
+    subscription_id         BIGINT NOT NULL,
+    shop_id                 BIGINT NOT NULL,
+    order_id                BIGINT NOT NULL,
+    event                   VARCHAR(32) NOT NULL,
+    payload                 TEXT NOT NULL,
+    state                   TINYINT NOT NULL,
+    attempt                 INT NOT NULL DEFAULT 0,
+    next_attempt_at         DATETIME NOT NULL,
+    response_status         INT NOT NULL DEFAULT 0,
+    last_error              TEXT NULL,
+    delivered_at            DATETIME NULL,
+    created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_webhook_deliveries_shop_id ON webhook_deliveries (shop_id);
+CREATE INDEX idx_webhook_deliveries_order_id ON webhook_deliveries (order_id);
+CREATE INDEX idx_webhook_deliveries_state_next_attempt_at ON webhook_deliveries (state, next_attempt_at);
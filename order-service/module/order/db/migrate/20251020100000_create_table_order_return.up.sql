@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS order_returns (
+    id                      BIGINT PRIMARY KEY AUTO_INCREMENT,
+    order_id                BIGINT NOT NULL,
+    order_detail_id         BIGINT NOT NULL,
+    user_id                 BIGINT NOT NULL,
+    product_id              BIGINT NOT NULL,
+    warehouse_id            BIGINT NOT NULL,
+    quantity                INT NOT NULL,
+    refund_amount           DECIMAL(15,3) NOT NULL,
+    reason                  VARCHAR(255) NOT NULL DEFAULT '',
+    review_note             VARCHAR(255) NOT NULL DEFAULT '',
+    state                   TINYINT NOT NULL,
+    reviewed_at             DATETIME NULL,
+    created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_order_returns_order_id ON order_returns (order_id);
+CREATE INDEX idx_order_returns_order_detail_id ON order_returns (order_detail_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS order_state_histories (
+    id                      BIGINT PRIMARY KEY AUTO_INCREMENT,
+    order_id                BIGINT NOT NULL,
+    from_state              TINYINT NOT NULL,
+    to_state                TINYINT NOT NULL,
+    actor_type              TINYINT NOT NULL,
+    actor_id                VARCHAR(64) NOT NULL,
+    reason                  VARCHAR(255) NOT NULL DEFAULT '',
+    created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_order_state_histories_order_id ON order_state_histories (order_id);
@@ -0,0 +1,2 @@
+DROP INDEX idx_orders_created_at ON orders;
+DROP TABLE IF EXISTS `sales_daily_rollups`;
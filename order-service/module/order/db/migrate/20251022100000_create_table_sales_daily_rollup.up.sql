@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS sales_daily_rollups (
+    id                      BIGINT PRIMARY KEY AUTO_INCREMENT,
+    sales_date              DATE NOT NULL,
+    shop_id                 BIGINT NOT NULL,
+    product_id              BIGINT NOT NULL,
+    order_state             TINYINT NOT NULL,
+    order_count             INT NOT NULL,
+    units                   INT NOT NULL,
+    revenue                 DECIMAL(15,3) NOT NULL,
+    created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_sales_daily_rollups_date_shop_product_state ON sales_daily_rollups (sales_date, shop_id, product_id, order_state);
+CREATE INDEX idx_orders_created_at ON orders (created_at);
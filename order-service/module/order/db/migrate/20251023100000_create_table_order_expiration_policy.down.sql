@@ -0,0 +1,4 @@
+ALTER TABLE orders
+    DROP COLUMN expiration_policy_id,
+    DROP COLUMN expiration_second;
+DROP TABLE IF EXISTS `order_expiration_policies`;
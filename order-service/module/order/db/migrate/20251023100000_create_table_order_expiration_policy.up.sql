@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS order_expiration_policies (
+    id                      BIGINT PRIMARY KEY AUTO_INCREMENT,
+    scope                   TINYINT NOT NULL,
+    scope_id                BIGINT NOT NULL,
+    expiration_second       INT NOT NULL,
+    created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_order_expiration_policies_scope_scope_id ON order_expiration_policies (scope, scope_id);
+
+ALTER TABLE orders
+    ADD COLUMN expiration_policy_id BIGINT NULL AFTER total_price,
+    ADD COLUMN expiration_second INT NOT NULL DEFAULT 0 AFTER expiration_policy_id;
+
+-- Orders placed before the policies were expired by the global expiration
+UPDATE orders SET expiration_second = TIMESTAMPDIFF(SECOND, created_at, expired_at);
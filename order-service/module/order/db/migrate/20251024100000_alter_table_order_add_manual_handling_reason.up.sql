@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN manual_handling_reason VARCHAR(255) NULL AFTER expired_at;
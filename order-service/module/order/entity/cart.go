@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CartItem is the product kept in the user cart, the price is the product price when the item was added
+type CartItem struct {
+	ID        string          `json:"id"`
+	UserID    string          `json:"user_id"`
+	ShopID    string          `json:"shop_id"`
+	ProductID string          `json:"product_id"`
+	Stock     int             `json:"stock"`
+	Price     decimal.Decimal `json:"price"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// CartItemInformation is the cart item revalidated against the current product price and warehouse availability
+type CartItemInformation struct {
+	*CartItem
+	ProductName    string          `json:"product_name"`
+	CurrentPrice   decimal.Decimal `json:"current_price"`
+	AvailableStock int             `json:"available_stock"`
+	TotalPrice     decimal.Decimal `json:"total_price"`
+	Problems       []LineProblem   `json:"problems"`
+}
+
+type Cart struct {
+	UserID     string                 `json:"user_id"`
+	TotalStock int                    `json:"total_stock"`
+	TotalPrice decimal.Decimal        `json:"total_price"`
+	Items      []*CartItemInformation `json:"items"`
+}
+
+type AddCartItemRequest struct {
+	ShopID    string `json:"shop_id" validate:"required"`
+	ProductID string `json:"product_id" validate:"required"`
+	Stock     int    `json:"stock" validate:"required,gt=0"`
+	User      *User  `json:"-"`
+}
+
+type UpdateCartItemRequest struct {
+	CartItemID string `json:"-" validate:"required"`
+	Stock      int    `json:"stock" validate:"required,gt=0"`
+	User       *User  `json:"-"`
+}
+
+type RemoveCartItemRequest struct {
+	CartItemID string `validate:"required"`
+	User       *User
+}
+
+type GetCartRequest struct {
+	User *User
+}
+
+type CheckoutCartRequest struct {
+	ShopID         string   `json:"shop_id" validate:"required"`
+	VoucherCodes   []string `json:"voucher_codes,omitempty" validate:"max=5,unique,dive,required,max=64"`
+	IdempotencyKey string   `json:"-" validate:"max=255"`
+	User           *User    `json:"-"`
+}
+
+type CartItemResponse struct {
+	Message  string    `json:"message"`
+	CartItem *CartItem `json:"cart_item"`
+	Meta     *Meta     `json:"meta"`
+}
+
+type GetCartResponse struct {
+	Cart *Cart `json:"cart"`
+	Meta *Meta `json:"meta"`
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Checkout group the orders placed by a single checkout across multiple shops, the totals cover every order of the group
+type Checkout struct {
+	ID         string          `json:"id"`
+	UserID     string          `json:"user_id"`
+	TotalStock int             `json:"total_stock"`
+	TotalPrice decimal.Decimal `json:"total_price"`
+	CreatedAt  time.Time       `json:"created_at"`
+	UpdatedAt  time.Time       `json:"updated_at"`
+	Orders     []*Order        `json:"orders"`
+}
+
+type CreateCheckoutShop struct {
+	ShopID       string                `json:"shop_id" validate:"required"`
+	Products     []*CreateOrderProduct `json:"products" validate:"required,min=1,dive,required"`
+	VoucherCodes []string              `json:"voucher_codes,omitempty" validate:"max=5,unique,dive,required,max=64"`
+}
+
+type CreateCheckoutRequest struct {
+	Shops []*CreateCheckoutShop `json:"shops" validate:"required,min=1,dive,required"`
+	User  *User                 `json:"-"`
+}
+
+type GetCheckoutRequest struct {
+	CheckoutID string `validate:"required"`
+	User       *User
+}
+
+type CreateCheckoutResponse struct {
+	Message  string    `json:"message"`
+	Checkout *Checkout `json:"checkout"`
+	Meta     *Meta     `json:"meta"`
+}
+
+type GetCheckoutResponse struct {
+	Checkout *Checkout `json:"checkout"`
+	Meta     *Meta     `json:"meta"`
+}
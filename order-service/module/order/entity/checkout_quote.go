@@ -0,0 +1,47 @@
+package entity
+
+import "github.com/shopspring/decimal"
+
+type CheckoutQuoteRequest struct {
+	ShopID       string                `json:"shop_id" validate:"required"`
+	Products     []*CreateOrderProduct `json:"products" validate:"required,min=1,dive,required"`
+	VoucherCodes []string              `json:"voucher_codes,omitempty" validate:"max=5,unique,dive,required,max=64"`
+	User         *User                 `json:"-"`
+}
+
+type CheckoutQuoteAllocation struct {
+	WarehouseID string `json:"warehouse_id"`
+	Stock       int    `json:"stock"`
+}
+
+// CheckoutQuoteLine is the quoted line of the request, the allocations are the warehouses the stock would be taken from
+type CheckoutQuoteLine struct {
+	ProductID      string                     `json:"product_id"`
+	ProductName    string                     `json:"product_name"`
+	WarehouseID    string                     `json:"warehouse_id,omitempty"`
+	Stock          int                        `json:"stock"`
+	Price          decimal.Decimal            `json:"price"`
+	TotalPrice     decimal.Decimal            `json:"total_price"`
+	AvailableStock int                        `json:"available_stock"`
+	Allocations    []*CheckoutQuoteAllocation `json:"allocations"`
+	Problems       []LineProblem              `json:"problems"`
+}
+
+// CheckoutQuote is orderable when none of the lines has a problem. The prices are the same as the order checkout,
+// the shipping is quoted on the allocated lines and the tax on the subtotal once discounted
+type CheckoutQuote struct {
+	ShopID        string               `json:"shop_id"`
+	TotalStock    int                  `json:"total_stock"`
+	SubtotalPrice decimal.Decimal      `json:"subtotal_price"`
+	DiscountPrice decimal.Decimal      `json:"discount_price"`
+	ShippingPrice decimal.Decimal      `json:"shipping_price"`
+	TaxPrice      decimal.Decimal      `json:"tax_price"`
+	TotalPrice    decimal.Decimal      `json:"total_price"`
+	Orderable     bool                 `json:"orderable"`
+	Lines         []*CheckoutQuoteLine `json:"lines"`
+}
+
+type CheckoutQuoteResponse struct {
+	Quote *CheckoutQuote `json:"quote"`
+	Meta  *Meta          `json:"meta"`
+}
@@ -16,35 +16,107 @@ type ErrorResponse struct {
 }
 
 const (
-	ErrorCodeForbidden                = "FORBIDDEN"
-	ErrorCodeTokenNotFound            = "TOKEN_NOT-FOUND"
-	ErrorCodeTokenExpired             = "TOKEN_EXPIRED"
-	ErrorCodeTokenInvalid             = "TOKEN_INVALID"
-	ErrorCodeTokenInvalidBarer        = "TOKEN_INVALID_BEARER"
-	ErrorCodeInvalidBodyJSON          = "BODY-JSON_INVALID"
-	ErrorCodeInvalidParameter         = "PARAMETER_INVALID"
-	ErrorCodeOrderNotFound            = "ORDER_NOT-FOUND"
-	ErrorCodeProductNotFound          = "ORDER-PRODUCT_NOT-FOUND"
-	ErrorCodeProductStockNotFound     = "ORDER-PRODUCT-STOCK_NOT-FOUND"
-	ErrorCodeProductOutOfStock        = "ORDER-PRODUCT_OUT-OF-STOCK"
-	ErrorCodeProductInsufficientStock = "ORDER-PRODUCT_INSUFFICIENT-STOCK"
-	ErrorCodeProductConflicted        = "ORDER-PRODUCT_CONFLICTED"
-	ErrorCodeProductMultiShop         = "ORDER-PRODUCT_MULTI-SHOP"
+	ErrorCodeForbidden                 = "FORBIDDEN"
+	ErrorCodeTokenNotFound             = "TOKEN_NOT-FOUND"
+	ErrorCodeTokenExpired              = "TOKEN_EXPIRED"
+	ErrorCodeTokenInvalid              = "TOKEN_INVALID"
+	ErrorCodeTokenInvalidBarer         = "TOKEN_INVALID_BEARER"
+	ErrorCodeInvalidBodyJSON           = "BODY-JSON_INVALID"
+	ErrorCodeInvalidParameter          = "PARAMETER_INVALID"
+	ErrorCodeOrderNotFound             = "ORDER_NOT-FOUND"
+	ErrorCodeOrderInvalidTransition    = "ORDER_INVALID-STATE-TRANSITION"
+	ErrorCodeCheckoutNotFound          = "CHECKOUT_NOT-FOUND"
+	ErrorCodeCartItemNotFound          = "CART-ITEM_NOT-FOUND"
+	ErrorCodeCartItemDuplicated        = "CART-ITEM_DUPLICATED"
+	ErrorCodeCartEmpty                 = "CART_EMPTY"
+	ErrorCodeProductNotFound           = "ORDER-PRODUCT_NOT-FOUND"
+	ErrorCodeProductStockNotFound      = "ORDER-PRODUCT-STOCK_NOT-FOUND"
+	ErrorCodeProductOutOfStock         = "ORDER-PRODUCT_OUT-OF-STOCK"
+	ErrorCodeProductInsufficientStock  = "ORDER-PRODUCT_INSUFFICIENT-STOCK"
+	ErrorCodeProductConflicted         = "ORDER-PRODUCT_CONFLICTED"
+	ErrorCodeProductMultiShop          = "ORDER-PRODUCT_MULTI-SHOP"
+	ErrorCodeIdempotencyKeyDuplicated  = "ORDER-IDEMPOTENCY-KEY_DUPLICATED"
+	ErrorCodeIdempotencyKeyNotFound    = "ORDER-IDEMPOTENCY-KEY_NOT-FOUND"
+	ErrorCodeIdempotencyKeyConflicted  = "ORDER-IDEMPOTENCY-KEY_CONFLICTED"
+	ErrorCodeIdempotencyKeyInProgress  = "ORDER-IDEMPOTENCY-KEY_IN-PROGRESS"
+	ErrorCodeReservationNotFound       = "ORDER-RESERVATION_NOT-FOUND"
+	ErrorCodeReservationInvalidState   = "ORDER-RESERVATION_INVALID-STATE"
+	ErrorCodeVoucherNotFound           = "ORDER-VOUCHER_NOT-FOUND"
+	ErrorCodeVoucherDuplicated         = "ORDER-VOUCHER_DUPLICATED"
+	ErrorCodeVoucherInvalidRule        = "ORDER-VOUCHER_INVALID-RULE"
+	ErrorCodeVoucherInactive           = "ORDER-VOUCHER_INACTIVE"
+	ErrorCodeVoucherNotApplicable      = "ORDER-VOUCHER_NOT-APPLICABLE"
+	ErrorCodeVoucherMinSpendNotReached = "ORDER-VOUCHER_MIN-SPEND-NOT-REACHED"
+	ErrorCodeVoucherUsageLimitReached  = "ORDER-VOUCHER_USAGE-LIMIT-REACHED"
+	ErrorCodeVoucherUserLimitReached   = "ORDER-VOUCHER_USER-LIMIT-REACHED"
+	ErrorCodeShopConfigNotFound        = "ORDER-SHOP-CONFIG_NOT-FOUND"
+	ErrorCodeShopConfigInvalidRule     = "ORDER-SHOP-CONFIG_INVALID-RULE"
+	ErrorCodeTaxRateInvalid            = "ORDER-TAX-RATE_INVALID"
+	ErrorCodeOrderNotPayable           = "ORDER_NOT-PAYABLE"
+	ErrorCodePaymentNotFound           = "ORDER-PAYMENT_NOT-FOUND"
+	ErrorCodePaymentSignatureInvalid   = "ORDER-PAYMENT_SIGNATURE-INVALID"
+	ErrorCodePaymentAmountMismatch     = "ORDER-PAYMENT_AMOUNT-MISMATCH"
+	ErrorCodePaymentCallbackInvalid    = "ORDER-PAYMENT_CALLBACK-INVALID"
+	ErrorCodeWebhookNotFound           = "ORDER-WEBHOOK_NOT-FOUND"
+	ErrorCodeWebhookDeliveryNotFound   = "ORDER-WEBHOOK-DELIVERY_NOT-FOUND"
+	ErrorCodeOrderNotReturnable        = "ORDER_NOT-RETURNABLE"
+	ErrorCodeOrderDetailNotFound       = "ORDER-DETAIL_NOT-FOUND"
+	ErrorCodeOrderReturnNotFound       = "ORDER-RETURN_NOT-FOUND"
+	ErrorCodeOrderReturnExceeded       = "ORDER-RETURN_QUANTITY-EXCEEDED"
+	ErrorCodeOrderReturnReviewed       = "ORDER-RETURN_ALREADY-REVIEWED"
+	ErrorCodeExpirationPolicyNotFound  = "ORDER-EXPIRATION-POLICY_NOT-FOUND"
+	ErrorCodeOrderNotCancellable       = "ORDER_NOT-CANCELLABLE"
 )
 
 var (
-	ErrorForbidden                = liberr.NewErrorDetails("Forbidden", ErrorCodeForbidden, "")
-	ErrorTokenNotFound            = liberr.NewErrorDetails("Token Not Found", ErrorCodeTokenNotFound, "")
-	ErrorTokenExpired             = liberr.NewErrorDetails("Token Expired", ErrorCodeTokenExpired, "")
-	ErrorTokenInvalid             = liberr.NewErrorDetails("Token Invalid", ErrorCodeTokenInvalid, "")
-	ErrorTokenInvalidBearer       = liberr.NewErrorDetails("Token Invalid Due Bearer", ErrorCodeTokenInvalidBarer, "")
-	ErrorInvalidBodyJSON          = liberr.NewErrorDetails("Invalid body JSON", ErrorCodeInvalidBodyJSON, "")
-	ErrorInvalidParameter         = liberr.NewErrorDetails("Invalid parameter", ErrorCodeInvalidParameter, "")
-	ErrorUserNotFound             = liberr.NewErrorDetails("Order Not Found", ErrorCodeOrderNotFound, "")
-	ErrorProductNotFound          = liberr.NewErrorDetails("Order Product Not Found", ErrorCodeProductNotFound, "")
-	ErrorProductStockNotFound     = liberr.NewErrorDetails("Order Product Stock Not Found", ErrorCodeProductStockNotFound, "")
-	ErrorProductOutOfStock        = liberr.NewErrorDetails("Order Product Out Of Stock", ErrorCodeProductOutOfStock, "")
-	ErrorProductInsufficientStock = liberr.NewErrorDetails("Order Product Insufficient Stock", ErrorCodeProductInsufficientStock, "")
-	ErrorProductConflicted        = liberr.NewErrorDetails("Order Product Conflicted or Out Of Stock", ErrorCodeProductConflicted, "")
-	ErrorProductMultiShop         = liberr.NewErrorDetails("Order Product From Multi Shop", ErrorCodeProductMultiShop, "")
+	ErrorForbidden                 = liberr.NewErrorDetails("Forbidden", ErrorCodeForbidden, "")
+	ErrorTokenNotFound             = liberr.NewErrorDetails("Token Not Found", ErrorCodeTokenNotFound, "")
+	ErrorTokenExpired              = liberr.NewErrorDetails("Token Expired", ErrorCodeTokenExpired, "")
+	ErrorTokenInvalid              = liberr.NewErrorDetails("Token Invalid", ErrorCodeTokenInvalid, "")
+	ErrorTokenInvalidBearer        = liberr.NewErrorDetails("Token Invalid Due Bearer", ErrorCodeTokenInvalidBarer, "")
+	ErrorInvalidBodyJSON           = liberr.NewErrorDetails("Invalid body JSON", ErrorCodeInvalidBodyJSON, "")
+	ErrorInvalidParameter          = liberr.NewErrorDetails("Invalid parameter", ErrorCodeInvalidParameter, "")
+	ErrorOrderNotFound             = liberr.NewErrorDetails("Order Not Found", ErrorCodeOrderNotFound, "")
+	ErrorOrderInvalidTransition    = liberr.NewErrorDetails("Order State Transition Not Allowed", ErrorCodeOrderInvalidTransition, "")
+	ErrorCheckoutNotFound          = liberr.NewErrorDetails("Checkout Not Found", ErrorCodeCheckoutNotFound, "")
+	ErrorCartItemNotFound          = liberr.NewErrorDetails("Cart Item Not Found", ErrorCodeCartItemNotFound, "")
+	ErrorCartItemDuplicated        = liberr.NewErrorDetails("Cart Item Already Exists", ErrorCodeCartItemDuplicated, "")
+	ErrorCartEmpty                 = liberr.NewErrorDetails("Cart Has No Item Of The Shop", ErrorCodeCartEmpty, "")
+	ErrorProductNotFound           = liberr.NewErrorDetails("Order Product Not Found", ErrorCodeProductNotFound, "")
+	ErrorProductStockNotFound      = liberr.NewErrorDetails("Order Product Stock Not Found", ErrorCodeProductStockNotFound, "")
+	ErrorProductOutOfStock         = liberr.NewErrorDetails("Order Product Out Of Stock", ErrorCodeProductOutOfStock, "")
+	ErrorProductInsufficientStock  = liberr.NewErrorDetails("Order Product Insufficient Stock", ErrorCodeProductInsufficientStock, "")
+	ErrorProductConflicted         = liberr.NewErrorDetails("Order Product Conflicted or Out Of Stock", ErrorCodeProductConflicted, "")
+	ErrorProductMultiShop          = liberr.NewErrorDetails("Order Product From Multi Shop", ErrorCodeProductMultiShop, "")
+	ErrorIdempotencyKeyDuplicated  = liberr.NewErrorDetails("Order Idempotency Key Already Exists", ErrorCodeIdempotencyKeyDuplicated, "")
+	ErrorIdempotencyKeyNotFound    = liberr.NewErrorDetails("Order Idempotency Key Not Found", ErrorCodeIdempotencyKeyNotFound, "")
+	ErrorIdempotencyKeyConflicted  = liberr.NewErrorDetails("Order Idempotency Key Already Used With Different Request", ErrorCodeIdempotencyKeyConflicted, "")
+	ErrorIdempotencyKeyInProgress  = liberr.NewErrorDetails("Order Idempotency Key Still In Progress", ErrorCodeIdempotencyKeyInProgress, "")
+	ErrorReservationNotFound       = liberr.NewErrorDetails("Order Stock Reservation Not Found", ErrorCodeReservationNotFound, "")
+	ErrorReservationInvalidState   = liberr.NewErrorDetails("Order Stock Reservation Already Committed Or Released", ErrorCodeReservationInvalidState, "")
+	ErrorVoucherNotFound           = liberr.NewErrorDetails("Voucher Not Found", ErrorCodeVoucherNotFound, "")
+	ErrorVoucherDuplicated         = liberr.NewErrorDetails("Voucher Code Already Exists", ErrorCodeVoucherDuplicated, "")
+	ErrorVoucherInvalidRule        = liberr.NewErrorDetails("Voucher Rule Invalid For The Type", ErrorCodeVoucherInvalidRule, "")
+	ErrorVoucherInactive           = liberr.NewErrorDetails("Voucher Not Active", ErrorCodeVoucherInactive, "")
+	ErrorVoucherNotApplicable      = liberr.NewErrorDetails("Voucher Not Applicable To The Order", ErrorCodeVoucherNotApplicable, "")
+	ErrorVoucherMinSpendNotReached = liberr.NewErrorDetails("Voucher Minimum Spend Not Reached", ErrorCodeVoucherMinSpendNotReached, "")
+	ErrorVoucherUsageLimitReached  = liberr.NewErrorDetails("Voucher Usage Limit Reached", ErrorCodeVoucherUsageLimitReached, "")
+	ErrorVoucherUserLimitReached   = liberr.NewErrorDetails("Voucher Usage Limit Per User Reached", ErrorCodeVoucherUserLimitReached, "")
+	ErrorShopConfigNotFound        = liberr.NewErrorDetails("Shop Config Not Found", ErrorCodeShopConfigNotFound, "")
+	ErrorShopConfigInvalidRule     = liberr.NewErrorDetails("Shop Config Rule Invalid For The Shipping Method", ErrorCodeShopConfigInvalidRule, "")
+	ErrorTaxRateInvalid            = liberr.NewErrorDetails("Tax Rate Has To Be Between 0 And 100", ErrorCodeTaxRateInvalid, "")
+	ErrorOrderNotPayable           = liberr.NewErrorDetails("Order Is Not Waiting For Payment", ErrorCodeOrderNotPayable, "")
+	ErrorPaymentNotFound           = liberr.NewErrorDetails("Payment Not Found", ErrorCodePaymentNotFound, "")
+	ErrorPaymentSignatureInvalid   = liberr.NewErrorDetails("Payment Callback Signature Invalid", ErrorCodePaymentSignatureInvalid, "")
+	ErrorPaymentAmountMismatch     = liberr.NewErrorDetails("Payment Amount Does Not Match The Order", ErrorCodePaymentAmountMismatch, "")
+	ErrorPaymentCallbackInvalid    = liberr.NewErrorDetails("Payment Callback Payload Invalid", ErrorCodePaymentCallbackInvalid, "")
+	ErrorWebhookNotFound           = liberr.NewErrorDetails("Webhook Subscription Not Found", ErrorCodeWebhookNotFound, "")
+	ErrorWebhookDeliveryNotFound   = liberr.NewErrorDetails("Webhook Delivery Not Found", ErrorCodeWebhookDeliveryNotFound, "")
+	ErrorOrderNotReturnable        = liberr.NewErrorDetails("Order Is Not Delivered", ErrorCodeOrderNotReturnable, "")
+	ErrorOrderDetailNotFound       = liberr.NewErrorDetails("Order Detail Not Found", ErrorCodeOrderDetailNotFound, "")
+	ErrorOrderReturnNotFound       = liberr.NewErrorDetails("Order Return Not Found", ErrorCodeOrderReturnNotFound, "")
+	ErrorOrderReturnExceeded       = liberr.NewErrorDetails("Order Return Quantity Exceeds The Remaining Quantity", ErrorCodeOrderReturnExceeded, "")
+	ErrorOrderReturnReviewed       = liberr.NewErrorDetails("Order Return Already Reviewed", ErrorCodeOrderReturnReviewed, "")
+	ErrorExpirationPolicyNotFound  = liberr.NewErrorDetails("Order Expiration Policy Not Found", ErrorCodeExpirationPolicyNotFound, "")
+	ErrorOrderNotCancellable       = liberr.NewErrorDetails("Order Is Not Cancellable Once Paid", ErrorCodeOrderNotCancellable, "")
 )
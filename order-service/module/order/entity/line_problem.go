@@ -0,0 +1,13 @@
+package entity
+
+// LineProblem flag the ordered line which no longer matches the product price or the warehouse availability
+type LineProblem string
+
+const (
+	LineProblemProductNotFound   LineProblem = "PRODUCT_NOT-FOUND"
+	LineProblemPriceChanged      LineProblem = "PRICE_CHANGED"
+	LineProblemStockNotFound     LineProblem = "STOCK_NOT-FOUND"
+	LineProblemMultiShop         LineProblem = "MULTI-SHOP"
+	LineProblemOutOfStock        LineProblem = "OUT-OF-STOCK"
+	LineProblemInsufficientStock LineProblem = "INSUFFICIENT-STOCK"
+)
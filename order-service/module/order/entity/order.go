@@ -12,28 +12,146 @@ const (
 	OrderStateUnspecified OrderState = iota
 	OrderStateCreated
 	OrderStateExpired
+	OrderStatePaid
+	OrderStateProcessing
+	OrderStateShipped
+	OrderStateCompleted
+	OrderStateCancelled
+	OrderStatePartiallyReturned
+	OrderStateReturned
 )
 
+// orderStateTransitions map[from_state][]to_state
+var orderStateTransitions = map[OrderState][]OrderState{
+	OrderStateCreated:           {OrderStatePaid, OrderStateExpired, OrderStateCancelled},
+	OrderStatePaid:              {OrderStateProcessing, OrderStateCancelled},
+	OrderStateProcessing:        {OrderStateShipped, OrderStateCancelled},
+	OrderStateShipped:           {OrderStateCompleted, OrderStatePartiallyReturned, OrderStateReturned},
+	OrderStateCompleted:         {OrderStatePartiallyReturned, OrderStateReturned},
+	OrderStatePartiallyReturned: {OrderStateReturned},
+}
+
+// CanTransitionTo report whether the order state is allowed to move into the next state
+func (s OrderState) CanTransitionTo(next OrderState) bool {
+	for _, state := range orderStateTransitions[s] {
+		if state == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Order is the order of a single shop. SubtotalPrice is the price of the ordered products and DiscountPrice is the sum
+// of the discount lines, TotalPrice is the price to pay, the subtotal after the discount plus the shipping and the tax.
+// ExpirationPolicyID is the expiration policy resolved at checkout, empty when the global expiration applies
 type Order struct {
-	ID         string          `json:"id"`
-	UserID     string          `json:"user_id"`
-	ShopID     string          `json:"shop_id"`
-	State      OrderState      `json:"state"`
-	TotalStock int             `json:"total_stock"`
-	TotalPrice decimal.Decimal `json:"total_price"`
-	ExpiredAt  time.Time       `json:"expired_at"`
-	CreatedAt  time.Time       `json:"created_at"`
-	UpdatedAt  time.Time       `json:"updated_at"`
+	ID                   string          `json:"id"`
+	UserID               string          `json:"user_id"`
+	ShopID               string          `json:"shop_id"`
+	CheckoutID           string          `json:"checkout_id,omitempty"`
+	State                OrderState      `json:"state"`
+	TotalStock           int             `json:"total_stock"`
+	SubtotalPrice        decimal.Decimal `json:"subtotal_price"`
+	DiscountPrice        decimal.Decimal `json:"discount_price"`
+	ShippingPrice        decimal.Decimal `json:"shipping_price"`
+	TaxPrice             decimal.Decimal `json:"tax_price"`
+	TotalPrice           decimal.Decimal `json:"total_price"`
+	ExpirationPolicyID   string          `json:"expiration_policy_id,omitempty"`
+	ExpirationSecond     int             `json:"expiration_second"`
+	ExpiredAt            time.Time       `json:"expired_at"`
+	ManualHandlingReason string          `json:"manual_handling_reason,omitempty"`
+	CreatedAt            time.Time       `json:"created_at"`
+	UpdatedAt            time.Time       `json:"updated_at"`
 }
 
+// CreateOrderProduct is allocated across the shop warehouses when the warehouse is omitted
 type CreateOrderProduct struct {
 	ProductID   string `json:"id" validate:"required"`
-	WarehouseID string `json:"warehouse_id" validate:"required"`
+	WarehouseID string `json:"warehouse_id,omitempty"`
 	Stock       int    `json:"stock" validate:"required,gt=0"`
 }
 
 type CreateOrderRequest struct {
-	ShopID   string                `json:"shop_id" validate:"required"`
-	Products []*CreateOrderProduct `json:"products" validate:"required,min=1,dive,required"`
-	User     *User
+	ShopID         string                `json:"shop_id" validate:"required"`
+	Products       []*CreateOrderProduct `json:"products" validate:"required,min=1,dive,required"`
+	VoucherCodes   []string              `json:"voucher_codes,omitempty" validate:"max=5,unique,dive,required,max=64"`
+	IdempotencyKey string                `json:"-" validate:"max=255"`
+	User           *User                 `json:"-"`
+}
+
+type CancelOrderRequest struct {
+	OrderID string `validate:"required"`
+	User    *User
+}
+
+type CompleteOrderRequest struct {
+	OrderID string `validate:"required"`
+	User    *User
+}
+
+type CompleteOrderResponse struct {
+	Message string `json:"message"`
+	Order   *Order `json:"order"`
+	Meta    *Meta  `json:"meta"`
+}
+
+type ListOrderByParams struct {
+	Page          int
+	Offset        int
+	Limit         int
+	UserID        string
+	ShopID        string
+	States        []OrderState
+	CreatedAtFrom *time.Time
+	CreatedAtTo   *time.Time
+}
+
+type ListOrderResponse struct {
+	Orders []*Order  `json:"orders"`
+	Meta   *ListMeta `json:"meta"`
+}
+
+type CreateOrderResponse struct {
+	Message string `json:"message"`
+	Order   *Order `json:"order"`
+	Meta    *Meta  `json:"meta"`
+}
+
+type GetOrderRequest struct {
+	OrderID string `validate:"required"`
+	User    *User
+}
+
+type OrderItem struct {
+	ID          string          `json:"id"`
+	ProductID   string          `json:"product_id"`
+	ProductName string          `json:"product_name"`
+	WarehouseID string          `json:"warehouse_id"`
+	Stock       int             `json:"stock"`
+	Price       decimal.Decimal `json:"price"`
+	TotalPrice  decimal.Decimal `json:"total_price"`
+}
+
+type OrderWarehouse struct {
+	WarehouseID string          `json:"warehouse_id"`
+	TotalStock  int             `json:"total_stock"`
+	TotalPrice  decimal.Decimal `json:"total_price"`
+	Items       []*OrderItem    `json:"items"`
+}
+
+type OrderInformation struct {
+	*Order
+	ExpiresInSecond int                  `json:"expires_in_second"`
+	Items           []*OrderItem         `json:"items"`
+	Warehouses      []*OrderWarehouse    `json:"warehouses"`
+	Discounts       []*OrderDiscount     `json:"discounts"`
+	Payments        []*Payment           `json:"payments"`
+	Returns         []*OrderReturn       `json:"returns"`
+	RefundPrice     decimal.Decimal      `json:"refund_price"`
+	StateHistories  []*OrderStateHistory `json:"state_histories"`
+}
+
+type GetOrderResponse struct {
+	Order *OrderInformation `json:"order"`
+	Meta  *Meta             `json:"meta"`
 }
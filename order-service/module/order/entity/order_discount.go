@@ -0,0 +1,18 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// OrderDiscount is the discount line of the voucher applied to the order, the code is kept as it was applied
+type OrderDiscount struct {
+	ID        string          `json:"id"`
+	OrderID   string          `json:"order_id"`
+	VoucherID string          `json:"voucher_id"`
+	Code      string          `json:"code"`
+	Amount    decimal.Decimal `json:"amount"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+type OrderExpirationPolicyScope int
+
+const (
+	OrderExpirationPolicyScopeUnspecified OrderExpirationPolicyScope = iota
+	// OrderExpirationPolicyScopeShop apply to every order of the shop
+	OrderExpirationPolicyScopeShop
+	// OrderExpirationPolicyScopeProduct apply to every order with the product
+	OrderExpirationPolicyScopeProduct
+)
+
+// OrderExpirationPolicy override the payment deadline of the orders of the shop or with the product. The policies
+// matching the order are resolved at checkout and the shortest wins, the global expiration applies without any
+type OrderExpirationPolicy struct {
+	ID               string                     `json:"id"`
+	Scope            OrderExpirationPolicyScope `json:"scope"`
+	ScopeID          string                     `json:"scope_id"`
+	ExpirationSecond int                        `json:"expiration_second"`
+	CreatedAt        time.Time                  `json:"created_at"`
+	UpdatedAt        time.Time                  `json:"updated_at"`
+}
+
+type UpsertOrderExpirationPolicyRequest struct {
+	Scope            OrderExpirationPolicyScope `json:"-" validate:"oneof=1 2"`
+	ScopeID          string                     `json:"-" validate:"required"`
+	ExpirationSecond int                        `json:"expiration_second" validate:"gte=60"`
+}
+
+type DeleteOrderExpirationPolicyRequest struct {
+	Scope   OrderExpirationPolicyScope `validate:"oneof=1 2"`
+	ScopeID string                     `validate:"required"`
+}
+
+type UpsertOrderExpirationPolicyResponse struct {
+	Message               string                 `json:"message"`
+	OrderExpirationPolicy *OrderExpirationPolicy `json:"order_expiration_policy"`
+	Meta                  *Meta                  `json:"meta"`
+}
+
+type ListOrderExpirationPolicyResponse struct {
+	OrderExpirationPolicies []*OrderExpirationPolicy `json:"order_expiration_policies"`
+	Meta                    *Meta                    `json:"meta"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+type OrderIdempotencyKeyState int
+
+const (
+	OrderIdempotencyKeyStateUnspecified OrderIdempotencyKeyState = iota
+	OrderIdempotencyKeyStateProcessing
+	OrderIdempotencyKeyStateCompleted
+)
+
+// OrderIdempotencyKey is the order request of the user by the key. The processing key is leased until LockedUntil,
+// the key left processing by a crashed request is taken over by the retry once the lease is over
+type OrderIdempotencyKey struct {
+	ID             string                   `json:"id"`
+	UserID         string                   `json:"user_id"`
+	IdempotencyKey string                   `json:"idempotency_key"`
+	RequestHash    string                   `json:"request_hash"`
+	State          OrderIdempotencyKeyState `json:"state"`
+	OrderID        string                   `json:"order_id"`
+	LockedUntil    time.Time                `json:"locked_until"`
+	CreatedAt      time.Time                `json:"created_at"`
+	UpdatedAt      time.Time                `json:"updated_at"`
+}
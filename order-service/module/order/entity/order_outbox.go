@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+type OrderOutboxCommand int
+
+const (
+	OrderOutboxCommandUnspecified OrderOutboxCommand = iota
+	OrderOutboxCommandReserveStock
+	OrderOutboxCommandReleaseStock
+	OrderOutboxCommandExtendReservation
+	OrderOutboxCommandRestockReturn
+	OrderOutboxCommandCommitReservation
+	OrderOutboxCommandRefundPayment
+)
+
+type OrderOutboxState int
+
+const (
+	OrderOutboxStateUnspecified OrderOutboxState = iota
+	OrderOutboxStatePending
+	OrderOutboxStateProcessed
+	OrderOutboxStateFailed
+)
+
+type OrderOutbox struct {
+	ID            string             `json:"id"`
+	OrderID       string             `json:"order_id"`
+	Command       OrderOutboxCommand `json:"command"`
+	Payload       string             `json:"payload"`
+	State         OrderOutboxState   `json:"state"`
+	Attempt       int                `json:"attempt"`
+	NextAttemptAt time.Time          `json:"next_attempt_at"`
+	LastError     string             `json:"last_error"`
+	CreatedAt     time.Time          `json:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at"`
+}
+
+// OrderOutboxPayload is the stock command sent to warehouse service, or the payment refunded at the payment provider.
+// Outboxes recorded before the stock reservation was introduced have no reservation ID and are delivered as stock adjustments
+type OrderOutboxPayload struct {
+	ReservationID   string                      `json:"reservation_id,omitempty"`
+	PaymentID       string                      `json:"payment_id,omitempty"`
+	ExpiredAt       *time.Time                  `json:"expired_at,omitempty"`
+	WarehouseStocks []*WarehouseStockAdjustment `json:"warehouse_stocks"`
+}
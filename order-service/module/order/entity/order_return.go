@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type OrderReturnState int
+
+const (
+	OrderReturnStateUnspecified OrderReturnState = iota
+	OrderReturnStateRequested
+	OrderReturnStateApproved
+	OrderReturnStateRejected
+)
+
+// OrderReturn is the returned quantity of a single order detail line. The product and the warehouse are copied
+// from the line so the approved return is restocked to the warehouse it was shipped from, and the refund amount
+// is the line price snapshot of the returned quantity
+type OrderReturn struct {
+	ID            string           `json:"id"`
+	OrderID       string           `json:"order_id"`
+	OrderDetailID string           `json:"order_detail_id"`
+	UserID        string           `json:"user_id"`
+	ProductID     string           `json:"product_id"`
+	WarehouseID   string           `json:"warehouse_id"`
+	Quantity      int              `json:"quantity"`
+	RefundAmount  decimal.Decimal  `json:"refund_amount"`
+	Reason        string           `json:"reason"`
+	ReviewNote    string           `json:"review_note"`
+	State         OrderReturnState `json:"state"`
+	ReviewedAt    *time.Time       `json:"reviewed_at,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+type CreateOrderReturnRequest struct {
+	OrderID       string `json:"-" validate:"required"`
+	OrderDetailID string `json:"order_detail_id" validate:"required"`
+	Quantity      int    `json:"quantity" validate:"required,gt=0"`
+	Reason        string `json:"reason" validate:"max=255"`
+	User          *User  `json:"-"`
+}
+
+type ListOrderReturnRequest struct {
+	OrderID string `validate:"required"`
+	User    *User
+}
+
+type ApproveOrderReturnRequest struct {
+	ReturnID   string `json:"-" validate:"required"`
+	ReviewNote string `json:"review_note" validate:"max=255"`
+}
+
+type RejectOrderReturnRequest struct {
+	ReturnID   string `json:"-" validate:"required"`
+	ReviewNote string `json:"review_note" validate:"required,max=255"`
+}
+
+type CreateOrderReturnResponse struct {
+	Message     string       `json:"message"`
+	OrderReturn *OrderReturn `json:"order_return"`
+	Meta        *Meta        `json:"meta"`
+}
+
+type ListOrderReturnResponse struct {
+	OrderReturns []*OrderReturn `json:"order_returns"`
+	Meta         *Meta          `json:"meta"`
+}
+
+type ReviewOrderReturnResponse struct {
+	Message     string       `json:"message"`
+	OrderReturn *OrderReturn `json:"order_return"`
+	Meta        *Meta        `json:"meta"`
+}
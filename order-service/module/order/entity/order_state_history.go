@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+type OrderStateActorType int
+
+const (
+	OrderStateActorTypeUnspecified OrderStateActorType = iota
+	OrderStateActorTypeUser
+	OrderStateActorTypeSystem
+	OrderStateActorTypeSeller
+)
+
+// OrderStateChange is who moves the order into the next state and why, the actor ID is the user ID of the user
+// and the seller, or the name of the process for the system
+type OrderStateChange struct {
+	ActorType OrderStateActorType
+	ActorID   string
+	Reason    string
+}
+
+// OrderStateHistory record a single state change of the order
+type OrderStateHistory struct {
+	ID        string              `json:"id"`
+	OrderID   string              `json:"order_id"`
+	FromState OrderState          `json:"from_state"`
+	ToState   OrderState          `json:"to_state"`
+	ActorType OrderStateActorType `json:"actor_type"`
+	ActorID   string              `json:"actor_id"`
+	Reason    string              `json:"reason"`
+	CreatedAt time.Time           `json:"created_at"`
+}
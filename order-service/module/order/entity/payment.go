@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type PaymentState int
+
+const (
+	PaymentStateUnspecified PaymentState = iota
+	PaymentStatePending
+	PaymentStateCaptured
+	PaymentStateFailed
+	PaymentStateRefundPending
+	PaymentStateRefunded
+)
+
+type PaymentEvent string
+
+const (
+	PaymentEventCaptured PaymentEvent = "payment.captured"
+	PaymentEventFailed   PaymentEvent = "payment.failed"
+)
+
+// Payment is the payment intent of the order at the payment provider, the order has a single pending payment
+// at a time and a failed payment is retried by a new intent. The captured payment of the order which is not going
+// to be fulfilled waits for the refund in refund pending
+type Payment struct {
+	ID                string          `json:"id"`
+	OrderID           string          `json:"order_id"`
+	UserID            string          `json:"user_id"`
+	Provider          string          `json:"provider"`
+	ProviderReference string          `json:"provider_reference"`
+	PaymentURL        string          `json:"payment_url"`
+	Amount            decimal.Decimal `json:"amount"`
+	State             PaymentState    `json:"state"`
+	CapturedAt        *time.Time      `json:"captured_at,omitempty"`
+	RefundedAt        *time.Time      `json:"refunded_at,omitempty"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+// PaymentIntent is the intent registered at the payment provider, the user pays on the payment URL
+// when the provider has a checkout page
+type PaymentIntent struct {
+	Reference  string
+	PaymentURL string
+}
+
+// PaymentCallback is the verified notification of the payment provider about the payment intent
+type PaymentCallback struct {
+	Reference string          `json:"reference" validate:"required"`
+	Event     PaymentEvent    `json:"event" validate:"oneof=payment.captured payment.failed"`
+	Amount    decimal.Decimal `json:"amount"`
+}
+
+type CreatePaymentRequest struct {
+	OrderID string `validate:"required"`
+	User    *User
+}
+
+type PaymentCallbackRequest struct {
+	Payload   []byte `validate:"required"`
+	Signature string `validate:"required"`
+}
+
+type CreatePaymentResponse struct {
+	Message string   `json:"message"`
+	Payment *Payment `json:"payment"`
+	Meta    *Meta    `json:"meta"`
+}
@@ -0,0 +1,66 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type SalesReportPeriod string
+
+const (
+	SalesReportPeriodDay   SalesReportPeriod = "day"
+	SalesReportPeriodWeek  SalesReportPeriod = "week"
+	SalesReportPeriodMonth SalesReportPeriod = "month"
+)
+
+type SalesReportGroup string
+
+const (
+	SalesReportGroupShop    SalesReportGroup = "shop"
+	SalesReportGroupProduct SalesReportGroup = "product"
+)
+
+type SalesReportFormat string
+
+const (
+	SalesReportFormatJSON SalesReportFormat = "json"
+	SalesReportFormatCSV  SalesReportFormat = "csv"
+)
+
+// SalesReportRevenueStates are the states of the orders bearing revenue, reported when no state is filtered.
+// The partially returned order is kept since only a part of it is refunded
+var SalesReportRevenueStates = []OrderState{
+	OrderStatePaid,
+	OrderStateProcessing,
+	OrderStateShipped,
+	OrderStateCompleted,
+	OrderStatePartiallyReturned,
+}
+
+// SalesReport is the sales of the shop, or of the product when grouped by product, within the period. The period
+// is the first day of the day, the week starting on Monday or the month
+type SalesReport struct {
+	Period     time.Time       `json:"period"`
+	ShopID     string          `json:"shop_id"`
+	ProductID  string          `json:"product_id,omitempty"`
+	OrderCount int             `json:"order_count"`
+	Units      int             `json:"units"`
+	Revenue    decimal.Decimal `json:"revenue"`
+}
+
+type SalesReportByParams struct {
+	Period    SalesReportPeriod `validate:"required,oneof=day week month"`
+	GroupBy   SalesReportGroup  `validate:"required,oneof=shop product"`
+	Format    SalesReportFormat `validate:"required,oneof=json csv"`
+	DateFrom  time.Time         `validate:"required"`
+	DateTo    time.Time         `validate:"required,gtefield=DateFrom"`
+	States    []OrderState
+	ShopID    string
+	ProductID string
+}
+
+type ListSalesReportResponse struct {
+	SalesReports []*SalesReport `json:"sales_reports"`
+	Meta         *Meta          `json:"meta"`
+}
@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ShippingMethod int
+
+const (
+	// ShippingMethodUnspecified is the free shipping of the shop without configuration
+	ShippingMethodUnspecified ShippingMethod = iota
+	// ShippingMethodFlat charge the shipping fee once per order
+	ShippingMethodFlat
+	// ShippingMethodWeight charge the shipping fee plus the fee per started kilogram of the order weight
+	ShippingMethodWeight
+	// ShippingMethodPerWarehouse charge the shipping fee once per warehouse the order is shipped from
+	ShippingMethodPerWarehouse
+)
+
+// ShopConfig is the local configuration of the shop used to compute the order charges. Product service does not
+// carry the product weight, so the weight based shipping weighs every ordered item by the item weight of the shop
+type ShopConfig struct {
+	ID               string          `json:"id"`
+	ShopID           string          `json:"shop_id"`
+	TaxRegion        string          `json:"tax_region"`
+	ShippingMethod   ShippingMethod  `json:"shipping_method"`
+	ShippingFee      decimal.Decimal `json:"shipping_fee"`
+	ShippingFeePerKg decimal.Decimal `json:"shipping_fee_per_kg"`
+	ItemWeightGram   int             `json:"item_weight_gram"`
+	CreatedAt        time.Time       `json:"created_at"`
+	UpdatedAt        time.Time       `json:"updated_at"`
+}
+
+// TaxRate is the tax percentage of the region the shop is taxed by
+type TaxRate struct {
+	ID        string          `json:"id"`
+	Region    string          `json:"region"`
+	Rate      decimal.Decimal `json:"rate"`
+	CreatedAt time.Time       `json:"created_at"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+type UpsertShopConfigRequest struct {
+	ShopID           string          `json:"-" validate:"required"`
+	TaxRegion        string          `json:"tax_region" validate:"max=64"`
+	ShippingMethod   ShippingMethod  `json:"shipping_method" validate:"oneof=0 1 2 3"`
+	ShippingFee      decimal.Decimal `json:"shipping_fee"`
+	ShippingFeePerKg decimal.Decimal `json:"shipping_fee_per_kg"`
+	ItemWeightGram   int             `json:"item_weight_gram" validate:"gte=0"`
+}
+
+type GetShopConfigRequest struct {
+	ShopID string `validate:"required"`
+}
+
+type UpsertTaxRateRequest struct {
+	Region string          `json:"-" validate:"required,max=64"`
+	Rate   decimal.Decimal `json:"rate"`
+}
+
+type UpsertShopConfigResponse struct {
+	Message    string      `json:"message"`
+	ShopConfig *ShopConfig `json:"shop_config"`
+	Meta       *Meta       `json:"meta"`
+}
+
+type GetShopConfigResponse struct {
+	ShopConfig *ShopConfig `json:"shop_config"`
+	Meta       *Meta       `json:"meta"`
+}
+
+type UpsertTaxRateResponse struct {
+	Message string   `json:"message"`
+	TaxRate *TaxRate `json:"tax_rate"`
+	Meta    *Meta    `json:"meta"`
+}
+
+type ListTaxRateResponse struct {
+	TaxRates []*TaxRate `json:"tax_rates"`
+	Meta     *Meta      `json:"meta"`
+}
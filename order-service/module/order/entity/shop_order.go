@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+type ListShopOrderRequest struct {
+	ShopID        string `validate:"required"`
+	User          *User
+	Page          int
+	Limit         int
+	States        []OrderState
+	CreatedAtFrom *time.Time
+	CreatedAtTo   *time.Time
+}
+
+type GetShopOrderRequest struct {
+	ShopID  string `validate:"required"`
+	OrderID string `validate:"required"`
+	User    *User
+}
+
+// ShopOrderActionRequest move the order of the shop into processing or shipped by the seller
+type ShopOrderActionRequest struct {
+	ShopID  string `validate:"required"`
+	OrderID string `validate:"required"`
+	User    *User
+}
+
+type RejectShopOrderRequest struct {
+	ShopID  string `json:"-" validate:"required"`
+	OrderID string `json:"-" validate:"required"`
+	Reason  string `json:"reason" validate:"required,max=255"`
+	User    *User  `json:"-"`
+}
+
+type ShopOrderActionResponse struct {
+	Message string `json:"message"`
+	Order   *Order `json:"order"`
+	Meta    *Meta  `json:"meta"`
+}
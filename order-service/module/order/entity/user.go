@@ -1,5 +1,17 @@
 package entity
 
+// User is the authenticated user, ShopIDs is the shops the user operates as a seller
 type User struct {
-	ID string
+	ID      string
+	ShopIDs []string
+}
+
+// CanManageShop report whether the user is bound to the shop by the token
+func (u *User) CanManageShop(shopID string) bool {
+	for _, id := range u.ShopIDs {
+		if id == shopID {
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,86 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type VoucherType int
+
+const (
+	VoucherTypeUnspecified VoucherType = iota
+	// VoucherTypePercentage discount the percentage of the eligible price, capped by the max discount when set
+	VoucherTypePercentage
+	// VoucherTypeFixed discount the fixed amount, capped by the eligible price
+	VoucherTypeFixed
+	// VoucherTypeBuyXGetY give the get quantity for free on every buy + get quantity of the same product
+	VoucherTypeBuyXGetY
+)
+
+// Voucher is the promotion rule applied to the order by the code. The voucher is scoped to the shop
+// and the product when they are set, the usage limits of zero are unlimited
+type Voucher struct {
+	ID                string          `json:"id"`
+	Code              string          `json:"code"`
+	ShopID            string          `json:"shop_id,omitempty"`
+	ProductID         string          `json:"product_id,omitempty"`
+	Type              VoucherType     `json:"type"`
+	Value             decimal.Decimal `json:"value"`
+	MaxDiscount       decimal.Decimal `json:"max_discount"`
+	BuyQuantity       int             `json:"buy_quantity"`
+	GetQuantity       int             `json:"get_quantity"`
+	MinSpend          decimal.Decimal `json:"min_spend"`
+	UsageLimit        int             `json:"usage_limit"`
+	UsageLimitPerUser int             `json:"usage_limit_per_user"`
+	UsedCount         int             `json:"used_count"`
+	StartsAt          time.Time       `json:"starts_at"`
+	EndsAt            time.Time       `json:"ends_at"`
+	CreatedAt         time.Time       `json:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at"`
+}
+
+// IsActive report whether the voucher validity window covers the time, the end is exclusive
+func (v *Voucher) IsActive(t time.Time) bool {
+	return !t.Before(v.StartsAt) && t.Before(v.EndsAt)
+}
+
+// VoucherUsage record the voucher used by the user on the order, it is removed once the order is cancelled or expired
+type VoucherUsage struct {
+	ID        string    `json:"id"`
+	VoucherID string    `json:"voucher_id"`
+	UserID    string    `json:"user_id"`
+	OrderID   string    `json:"order_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateVoucherRequest struct {
+	Code              string          `json:"code" validate:"required,max=64"`
+	ShopID            string          `json:"shop_id"`
+	ProductID         string          `json:"product_id"`
+	Type              VoucherType     `json:"type" validate:"required,oneof=1 2 3"`
+	Value             decimal.Decimal `json:"value"`
+	MaxDiscount       decimal.Decimal `json:"max_discount"`
+	BuyQuantity       int             `json:"buy_quantity" validate:"gte=0"`
+	GetQuantity       int             `json:"get_quantity" validate:"gte=0"`
+	MinSpend          decimal.Decimal `json:"min_spend"`
+	UsageLimit        int             `json:"usage_limit" validate:"gte=0"`
+	UsageLimitPerUser int             `json:"usage_limit_per_user" validate:"gte=0"`
+	StartsAt          time.Time       `json:"starts_at" validate:"required"`
+	EndsAt            time.Time       `json:"ends_at" validate:"required,gtfield=StartsAt"`
+}
+
+type GetVoucherRequest struct {
+	VoucherID string `validate:"required"`
+}
+
+type CreateVoucherResponse struct {
+	Message string   `json:"message"`
+	Voucher *Voucher `json:"voucher"`
+	Meta    *Meta    `json:"meta"`
+}
+
+type GetVoucherResponse struct {
+	Voucher *Voucher `json:"voucher"`
+	Meta    *Meta    `json:"meta"`
+}
@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 type WarehouseStock struct {
 	ID            string
 	WarehouseID   string
@@ -7,6 +9,8 @@ type WarehouseStock struct {
 	ShopID        string
 	ProductID     string
 	Stock         int
+	Reserved      int
+	Available     int
 }
 
 type WarehouseStockAdjustment struct {
@@ -15,6 +19,28 @@ type WarehouseStockAdjustment struct {
 	Stock       int    `json:"stock"`
 }
 
+type WarehouseStockMovementReason int
+
+const (
+	WarehouseStockMovementReasonUnspecified WarehouseStockMovementReason = iota
+	WarehouseStockMovementReasonOrderReservation
+	WarehouseStockMovementReasonOrderRelease
+
+	// Transfer, manual and shipment reasons (3 to 5) are only recorded within warehouse service
+	WarehouseStockMovementReasonOrderReturn WarehouseStockMovementReason = 6
+)
+
 type WarehouseStockAdjustmentParams struct {
+	AdjustmentID    string                       `json:"adjustment_id,omitempty"`
+	Reason          WarehouseStockMovementReason `json:"reason,omitempty"`
+	ReferenceID     string                       `json:"reference_id,omitempty"`
+	Actor           string                       `json:"actor,omitempty"`
+	WarehouseStocks []*WarehouseStockAdjustment  `json:"warehouse_stocks"`
+}
+
+type WarehouseStockReservationParams struct {
+	ReservationID   string                      `json:"reservation_id"`
+	ExpiredAt       time.Time                   `json:"expired_at"`
+	Actor           string                      `json:"actor,omitempty"`
 	WarehouseStocks []*WarehouseStockAdjustment `json:"warehouse_stocks"`
 }
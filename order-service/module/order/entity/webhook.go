@@ -0,0 +1,130 @@
+package entity
+
+import (
+	"time"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventOrderCreated   WebhookEvent = "order.created"
+	WebhookEventOrderExpired   WebhookEvent = "order.expired"
+	WebhookEventOrderCancelled WebhookEvent = "order.cancelled"
+)
+
+type WebhookDeliveryState int
+
+const (
+	WebhookDeliveryStateUnspecified WebhookDeliveryState = iota
+	WebhookDeliveryStatePending
+	WebhookDeliveryStateDelivered
+	WebhookDeliveryStateFailed
+)
+
+// WebhookSubscription is the endpoint of the shop notified on the subscribed order events,
+// the secret signs every delivery and is only shown once the subscription is created
+type WebhookSubscription struct {
+	ID        string         `json:"id"`
+	ShopID    string         `json:"shop_id"`
+	URL       string         `json:"url"`
+	Secret    string         `json:"secret,omitempty"`
+	Events    []WebhookEvent `json:"events"`
+	Active    bool           `json:"active"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// IsSubscribed report whether the active subscription is notified on the event
+func (w *WebhookSubscription) IsSubscribed(event WebhookEvent) bool {
+	if !w.Active {
+		return false
+	}
+
+	for _, e := range w.Events {
+		if e == event {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookDelivery is the event sent to the subscription, the payload is kept as it is sent
+// so the redelivery sends the same body
+type WebhookDelivery struct {
+	ID             string               `json:"id"`
+	SubscriptionID string               `json:"subscription_id"`
+	ShopID         string               `json:"shop_id"`
+	OrderID        string               `json:"order_id"`
+	Event          WebhookEvent         `json:"event"`
+	Payload        string               `json:"payload"`
+	State          WebhookDeliveryState `json:"state"`
+	Attempt        int                  `json:"attempt"`
+	NextAttemptAt  time.Time            `json:"next_attempt_at"`
+	ResponseStatus int                  `json:"response_status"`
+	LastError      string               `json:"last_error"`
+	DeliveredAt    *time.Time           `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// WebhookPayload is the body sent to the subscription. The event ID is the same across the deliveries
+// of the event, so the receiver is able to discard the event it already handled
+type WebhookPayload struct {
+	EventID    string       `json:"event_id"`
+	Event      WebhookEvent `json:"event"`
+	OccurredAt time.Time    `json:"occurred_at"`
+	Order      *Order       `json:"order"`
+}
+
+type CreateWebhookSubscriptionRequest struct {
+	ShopID string         `json:"-" validate:"required"`
+	URL    string         `json:"url" validate:"required,url,max=255"`
+	Events []WebhookEvent `json:"events" validate:"required,min=1,unique,dive,oneof=order.created order.expired order.cancelled"`
+}
+
+type ListWebhookSubscriptionRequest struct {
+	ShopID string `validate:"required"`
+}
+
+type DeleteWebhookSubscriptionRequest struct {
+	ShopID         string `validate:"required"`
+	SubscriptionID string `validate:"required"`
+}
+
+type ListWebhookDeliveryByParams struct {
+	Page           int
+	Offset         int
+	Limit          int
+	ShopID         string `validate:"required"`
+	SubscriptionID string
+	OrderID        string
+	States         []WebhookDeliveryState
+}
+
+type RedeliverWebhookRequest struct {
+	ShopID     string `validate:"required"`
+	DeliveryID string `validate:"required"`
+}
+
+type CreateWebhookSubscriptionResponse struct {
+	Message             string               `json:"message"`
+	WebhookSubscription *WebhookSubscription `json:"webhook_subscription"`
+	Meta                *Meta                `json:"meta"`
+}
+
+type ListWebhookSubscriptionResponse struct {
+	WebhookSubscriptions []*WebhookSubscription `json:"webhook_subscriptions"`
+	Meta                 *Meta                  `json:"meta"`
+}
+
+type ListWebhookDeliveryResponse struct {
+	WebhookDeliveries []*WebhookDelivery `json:"webhook_deliveries"`
+	Meta              *ListMeta          `json:"meta"`
+}
+
+type RedeliverWebhookResponse struct {
+	Message         string           `json:"message"`
+	WebhookDelivery *WebhookDelivery `json:"webhook_delivery"`
+	Meta            *Meta            `json:"meta"`
+}
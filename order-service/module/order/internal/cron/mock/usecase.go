@@ -0,0 +1,91 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderUsecase is a mock of OrderUsecase interface.
+type MockOrderUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderUsecaseMockRecorder
+}
+
+// MockOrderUsecaseMockRecorder is the mock recorder for MockOrderUsecase.
+type MockOrderUsecaseMockRecorder struct {
+	mock *MockOrderUsecase
+}
+
+// NewMockOrderUsecase creates a new mock instance.
+func NewMockOrderUsecase(ctrl *gomock.Controller) *MockOrderUsecase {
+	mock := &MockOrderUsecase{ctrl: ctrl}
+	mock.recorder = &MockOrderUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderUsecase) EXPECT() *MockOrderUsecaseMockRecorder {
+	return m.recorder
+}
+
+// ExecuteExpiredOrder mocks base method.
+func (m *MockOrderUsecase) ExecuteExpiredOrder(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteExpiredOrder", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteExpiredOrder indicates an expected call of ExecuteExpiredOrder.
+func (mr *MockOrderUsecaseMockRecorder) ExecuteExpiredOrder(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteExpiredOrder", reflect.TypeOf((*MockOrderUsecase)(nil).ExecuteExpiredOrder), ctx)
+}
+
+// ExecuteOutboxRelay mocks base method.
+func (m *MockOrderUsecase) ExecuteOutboxRelay(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteOutboxRelay", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteOutboxRelay indicates an expected call of ExecuteOutboxRelay.
+func (mr *MockOrderUsecaseMockRecorder) ExecuteOutboxRelay(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteOutboxRelay", reflect.TypeOf((*MockOrderUsecase)(nil).ExecuteOutboxRelay), ctx)
+}
+
+// ExecuteSalesRollup mocks base method.
+func (m *MockOrderUsecase) ExecuteSalesRollup(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteSalesRollup", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteSalesRollup indicates an expected call of ExecuteSalesRollup.
+func (mr *MockOrderUsecaseMockRecorder) ExecuteSalesRollup(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteSalesRollup", reflect.TypeOf((*MockOrderUsecase)(nil).ExecuteSalesRollup), ctx)
+}
+
+// ExecuteWebhookRelay mocks base method.
+func (m *MockOrderUsecase) ExecuteWebhookRelay(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteWebhookRelay", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExecuteWebhookRelay indicates an expected call of ExecuteWebhookRelay.
+func (mr *MockOrderUsecaseMockRecorder) ExecuteWebhookRelay(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteWebhookRelay", reflect.TypeOf((*MockOrderUsecase)(nil).ExecuteWebhookRelay), ctx)
+}
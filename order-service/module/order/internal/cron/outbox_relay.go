@@ -0,0 +1,19 @@
+package cron
+
+import (
+	"context"
+)
+
+type OutboxRelayCron struct {
+	orderUsecase OrderUsecase
+}
+
+func NewOutboxRelayCron(orderUsecase OrderUsecase) *OutboxRelayCron {
+	return &OutboxRelayCron{
+		orderUsecase: orderUsecase,
+	}
+}
+
+func (o OutboxRelayCron) ExecuteFunction(ctx context.Context, args []string) error {
+	return o.orderUsecase.ExecuteOutboxRelay(ctx)
+}
@@ -0,0 +1,19 @@
+package cron
+
+import (
+	"context"
+)
+
+type SalesRollupCron struct {
+	orderUsecase OrderUsecase
+}
+
+func NewSalesRollupCron(orderUsecase OrderUsecase) *SalesRollupCron {
+	return &SalesRollupCron{
+		orderUsecase: orderUsecase,
+	}
+}
+
+func (o SalesRollupCron) ExecuteFunction(ctx context.Context, args []string) error {
+	return o.orderUsecase.ExecuteSalesRollup(ctx)
+}
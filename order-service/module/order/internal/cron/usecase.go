@@ -8,4 +8,7 @@ import (
 
 type OrderUsecase interface {
 	ExecuteExpiredOrder(ctx context.Context) error
+	ExecuteOutboxRelay(ctx context.Context) error
+	ExecuteWebhookRelay(ctx context.Context) error
+	ExecuteSalesRollup(ctx context.Context) error
 }
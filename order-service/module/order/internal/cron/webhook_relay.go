@@ -0,0 +1,19 @@
+package cron
+
+import (
+	"context"
+)
+
+type WebhookRelayCron struct {
+	orderUsecase OrderUsecase
+}
+
+func NewWebhookRelayCron(orderUsecase OrderUsecase) *WebhookRelayCron {
+	return &WebhookRelayCron{
+		orderUsecase: orderUsecase,
+	}
+}
+
+func (o WebhookRelayCron) ExecuteFunction(ctx context.Context, args []string) error {
+	return o.orderUsecase.ExecuteWebhookRelay(ctx)
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	cartItemTable = "cart_items"
+
+	cartItemInsertColumns = []string{"user_id", "shop_id", "product_id", "stock", "price"}
+	cartItemColumns       = []string{"id", "user_id", "shop_id", "product_id", "stock", "price", "created_at", "updated_at"}
+)
+
+type CartItemRepository struct {
+	db *sqlx.DB
+}
+
+type cartItemObject struct {
+	ID        string          `db:"id"`
+	UserID    string          `db:"user_id"`
+	ShopID    string          `db:"shop_id"`
+	ProductID string          `db:"product_id"`
+	Stock     int             `db:"stock"`
+	Price     decimal.Decimal `db:"price"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+func (c *cartItemObject) toEntity() *entity.CartItem {
+	return &entity.CartItem{
+		ID:        c.ID,
+		UserID:    c.UserID,
+		ShopID:    c.ShopID,
+		ProductID: c.ProductID,
+		Stock:     c.Stock,
+		Price:     c.Price,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func NewCartItemRepository(db *sqlx.DB) *CartItemRepository {
+	return &CartItemRepository{db: db}
+}
+
+func (c *CartItemRepository) Create(ctx context.Context, cartItem *entity.CartItem, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(cartItemTable)
+	ib.Cols(cartItemInsertColumns...)
+	ib.Values(
+		cartItem.UserID,
+		cartItem.ShopID,
+		cartItem.ProductID,
+		cartItem.Stock,
+		cartItem.Price,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(c.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on cartItem.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateError(err) {
+			return entity.ErrorCartItemDuplicated
+		}
+
+		return liberr.NewTracer("Error when ExecContext on cartItem.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on cartItem.Create").Wrap(err)
+	}
+
+	cartItem.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+func (c *CartItemRepository) GetByID(ctx context.Context, id string) (*entity.CartItem, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(cartItemColumns...)
+	sb.From(cartItemTable)
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+
+	row := c.db.QueryRowxContext(ctx, query, args...)
+	obj := &cartItemObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorCartItemNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on cartItem.GetByID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+// GetByUserIDAndProductID retrieve the cart item of the product from the shop, a product is kept once per shop in the cart
+func (c *CartItemRepository) GetByUserIDAndProductID(ctx context.Context, userID, shopID, productID string) (*entity.CartItem, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(cartItemColumns...)
+	sb.From(cartItemTable)
+	sb.Where(
+		sb.Equal("user_id", userID),
+		sb.Equal("shop_id", shopID),
+		sb.Equal("product_id", productID),
+	)
+
+	query, args := sb.Build()
+
+	row := c.db.QueryRowxContext(ctx, query, args...)
+	obj := &cartItemObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorCartItemNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on cartItem.GetByUserIDAndProductID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+func (c *CartItemRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.CartItem, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(cartItemColumns...)
+	sb.From(cartItemTable)
+	sb.Where(sb.Equal("user_id", userID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := c.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on cartItem.ListByUserID").Wrap(err)
+	}
+
+	cartItems := []*entity.CartItem{}
+	for rows.Next() {
+		var obj cartItemObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on cartItem.ListByUserID").Wrap(err)
+		}
+
+		cartItems = append(cartItems, obj.toEntity())
+	}
+
+	return cartItems, nil
+}
+
+func (c *CartItemRepository) UpdateStock(ctx context.Context, id string, stock int, price decimal.Decimal, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(cartItemTable).
+		Set(
+			ub.Assign("stock", stock),
+			ub.Assign("price", price),
+		).
+		Where(
+			ub.E("id", id),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(c.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on cartItem.UpdateStock").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on cartItem.UpdateStock").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// DeleteByIDs remove the cart items, the delete is guarded by the user so only the owned items are removed
+func (c *CartItemRepository) DeleteByIDs(ctx context.Context, userID string, ids []string, tx util.DatabaseTransaction) (int64, error) {
+	inArgs := make([]any, len(ids))
+	for i, v := range ids {
+		inArgs[i] = v
+	}
+
+	deb := sqlbuilder.NewDeleteBuilder()
+	deb.DeleteFrom(cartItemTable)
+	deb.Where(
+		deb.E("user_id", userID),
+		deb.In("id", inArgs...),
+	)
+	query, args := deb.Build()
+
+	db, err := util.GetExecer(c.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on cartItem.DeleteByIDs").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on cartItem.DeleteByIDs").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
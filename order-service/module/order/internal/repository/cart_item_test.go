@@ -0,0 +1,612 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	cartItemInsertAttributes = []string{
+		"user_id",
+		"shop_id",
+		"product_id",
+		"stock",
+		"price",
+	}
+	cartItemAllAttributes = []string{
+		"id",
+		"user_id",
+		"shop_id",
+		"product_id",
+		"stock",
+		"price",
+		"created_at",
+		"updated_at",
+	}
+
+	cartItemInsertColumnsStr = strings.Join(cartItemInsertAttributes, ", ")
+	cartItemAllColumnsStr    = strings.Join(cartItemAllAttributes, ", ")
+)
+
+func TestCartItemRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO cart_items (%s) VALUES (?, ?, ?, ?, ?)", cartItemInsertColumnsStr)
+
+	type input struct {
+		ctx      context.Context
+		cartItem *entity.CartItem
+		tx       util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:      context.TODO(),
+				cartItem: fixtures.NewCartItem(fixtures.CartItem),
+				tx:       nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.cartItem.UserID, in.cartItem.ShopID, in.cartItem.ProductID, in.cartItem.Stock, in.cartItem.Price).
+					WillReturnResult(sqlmock.NewResult(9, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "9", in.cartItem.ID)
+			},
+		},
+		{
+			name: "Error on Duplicate Key",
+			in: input{
+				ctx:      context.TODO(),
+				cartItem: fixtures.NewCartItem(fixtures.CartItem),
+				tx:       nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.cartItem.UserID, in.cartItem.ShopID, in.cartItem.ProductID, in.cartItem.Stock, in.cartItem.Price).
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+			},
+			assertFn: func(in input, err error) {
+				assert.Equal(t, entity.ErrorCartItemDuplicated, err)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:      context.TODO(),
+				cartItem: fixtures.NewCartItem(fixtures.CartItem),
+				tx:       nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.cartItem.UserID, in.cartItem.ShopID, in.cartItem.ProductID, in.cartItem.Stock, in.cartItem.Price).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:      context.TODO(),
+				cartItem: fixtures.NewCartItem(fixtures.CartItem),
+				tx:       nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.cartItem.UserID, in.cartItem.ShopID, in.cartItem.ProductID, in.cartItem.Stock, in.cartItem.Price).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:      context.TODO(),
+				cartItem: fixtures.NewCartItem(fixtures.CartItem),
+				tx:       &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewCartItemRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Create(tc.in.ctx, tc.in.cartItem, tc.in.tx))
+		})
+	}
+}
+
+func TestCartItemRepository_GetByID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM cart_items WHERE id = ?", cartItemAllColumnsStr)
+	rows := cartItemAllAttributes
+	dummyCartItem := fixtures.NewCartItem(fixtures.CartItem)
+
+	type input struct {
+		ctx context.Context
+		id  string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.CartItem, error)
+	}{
+		{
+			name: "Success on GetByID",
+			in: input{
+				ctx: context.TODO(),
+				id:  "8",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetCartItemRow(dummyCartItem)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.CartItem, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyCartItem, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  "8",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.CartItem, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorCartItemNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "8",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.CartItem, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewCartItemRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByID(tc.in.ctx, tc.in.id))
+		})
+	}
+}
+
+func TestCartItemRepository_GetByUserIDAndProductID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM cart_items WHERE user_id = ? AND shop_id = ? AND product_id = ?", cartItemAllColumnsStr)
+	rows := cartItemAllAttributes
+	dummyCartItem := fixtures.NewCartItem(fixtures.CartItem)
+
+	type input struct {
+		ctx       context.Context
+		userID    string
+		shopID    string
+		productID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.CartItem, error)
+	}{
+		{
+			name: "Success on GetByUserIDAndProductID",
+			in: input{
+				ctx:       context.TODO(),
+				userID:    "2",
+				shopID:    "3",
+				productID: "5",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID, in.shopID, in.productID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetCartItemRow(dummyCartItem)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.CartItem, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyCartItem, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx:       context.TODO(),
+				userID:    "2",
+				shopID:    "3",
+				productID: "5",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID, in.shopID, in.productID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.CartItem, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorCartItemNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:       context.TODO(),
+				userID:    "2",
+				shopID:    "3",
+				productID: "5",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID, in.shopID, in.productID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.CartItem, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewCartItemRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByUserIDAndProductID(tc.in.ctx, tc.in.userID, tc.in.shopID, tc.in.productID))
+		})
+	}
+}
+
+func TestCartItemRepository_ListByUserID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM cart_items WHERE user_id = ? ORDER BY id ASC", cartItemAllColumnsStr)
+	rows := cartItemAllAttributes
+	dummyCartItem := fixtures.NewCartItem(fixtures.CartItem)
+
+	type input struct {
+		ctx    context.Context
+		userID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.CartItem, error)
+	}{
+		{
+			name: "Success on Retrieve ListByUserID",
+			in: input{
+				ctx:    context.TODO(),
+				userID: "2",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetCartItemRow(dummyCartItem)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.CartItem, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CartItem{dummyCartItem}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:    context.TODO(),
+				userID: "2",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetCartItemRow(dummyCartItem)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.CartItem, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:    context.TODO(),
+				userID: "2",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.CartItem, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewCartItemRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByUserID(tc.in.ctx, tc.in.userID))
+		})
+	}
+}
+
+func TestCartItemRepository_UpdateStock(t *testing.T) {
+	expectedQuery := "UPDATE cart_items SET stock = ?, price = ? WHERE id = ?"
+
+	type input struct {
+		ctx   context.Context
+		id    string
+		stock int
+		price decimal.Decimal
+		tx    util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on UpdateStock",
+			in: input{
+				ctx:   context.TODO(),
+				id:    "8",
+				stock: 3,
+				price: decimal.NewFromInt(10000),
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stock, in.price, in.id).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:   context.TODO(),
+				id:    "8",
+				stock: 3,
+				price: decimal.NewFromInt(10000),
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stock, in.price, in.id).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:   context.TODO(),
+				id:    "8",
+				stock: 3,
+				price: decimal.NewFromInt(10000),
+				tx:    &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewCartItemRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateStock(tc.in.ctx, tc.in.id, tc.in.stock, tc.in.price, tc.in.tx))
+		})
+	}
+}
+
+func TestCartItemRepository_DeleteByIDs(t *testing.T) {
+	expectedQuery := "DELETE FROM cart_items WHERE user_id = ? AND id IN (?, ?)"
+
+	type input struct {
+		ctx    context.Context
+		userID string
+		ids    []string
+		tx     util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on DeleteByIDs",
+			in: input{
+				ctx:    context.TODO(),
+				userID: "2",
+				ids:    []string{"8", "9"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID, "8", "9").
+					WillReturnResult(sqlmock.NewResult(0, 2)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(2), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:    context.TODO(),
+				userID: "2",
+				ids:    []string{"8", "9"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID, "8", "9").
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:    context.TODO(),
+				userID: "2",
+				ids:    []string{"8", "9"},
+				tx:     &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewCartItemRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.DeleteByIDs(tc.in.ctx, tc.in.userID, tc.in.ids, tc.in.tx))
+		})
+	}
+}
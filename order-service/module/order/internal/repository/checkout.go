@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	checkoutTable = "checkouts"
+
+	checkoutInsertColumns = []string{"user_id", "total_stock", "total_price"}
+	checkoutColumns       = []string{"id", "user_id", "total_stock", "total_price", "created_at", "updated_at"}
+)
+
+type CheckoutRepository struct {
+	db *sqlx.DB
+}
+
+type checkoutObject struct {
+	ID         string          `db:"id"`
+	UserID     string          `db:"user_id"`
+	TotalStock int             `db:"total_stock"`
+	TotalPrice decimal.Decimal `db:"total_price"`
+	CreatedAt  time.Time       `db:"created_at"`
+	UpdatedAt  time.Time       `db:"updated_at"`
+}
+
+func (c *checkoutObject) toEntity() *entity.Checkout {
+	return &entity.Checkout{
+		ID:         c.ID,
+		UserID:     c.UserID,
+		TotalStock: c.TotalStock,
+		TotalPrice: c.TotalPrice,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+}
+
+func NewCheckoutRepository(db *sqlx.DB) *CheckoutRepository {
+	return &CheckoutRepository{db: db}
+}
+
+func (c *CheckoutRepository) Create(ctx context.Context, checkout *entity.Checkout, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(checkoutTable)
+	ib.Cols(checkoutInsertColumns...)
+	ib.Values(
+		checkout.UserID,
+		checkout.TotalStock,
+		checkout.TotalPrice,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(c.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on checkout.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on checkout.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on checkout.Create").Wrap(err)
+	}
+
+	checkout.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+func (c *CheckoutRepository) GetByID(ctx context.Context, id string) (*entity.Checkout, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(checkoutColumns...)
+	sb.From(checkoutTable)
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+
+	row := c.db.QueryRowxContext(ctx, query, args...)
+	obj := &checkoutObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorCheckoutNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on checkout.GetByID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
@@ -0,0 +1,227 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	checkoutInsertAttributes = []string{
+		"user_id",
+		"total_stock",
+		"total_price",
+	}
+	checkoutAllAttributes = []string{
+		"id",
+		"user_id",
+		"total_stock",
+		"total_price",
+		"created_at",
+		"updated_at",
+	}
+
+	checkoutInsertColumnsStr = strings.Join(checkoutInsertAttributes, ", ")
+	checkoutAllColumnsStr    = strings.Join(checkoutAllAttributes, ", ")
+)
+
+func TestCheckoutRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO checkouts (%s) VALUES (?, ?, ?)", checkoutInsertColumnsStr)
+
+	type input struct {
+		ctx      context.Context
+		checkout *entity.Checkout
+		tx       util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:      context.TODO(),
+				checkout: fixtures.NewCheckout(fixtures.Checkout),
+				tx:       nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.checkout.UserID, in.checkout.TotalStock, in.checkout.TotalPrice).
+					WillReturnResult(sqlmock.NewResult(7, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "7", in.checkout.ID)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:      context.TODO(),
+				checkout: fixtures.NewCheckout(fixtures.Checkout),
+				tx:       nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.checkout.UserID, in.checkout.TotalStock, in.checkout.TotalPrice).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:      context.TODO(),
+				checkout: fixtures.NewCheckout(fixtures.Checkout),
+				tx:       nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.checkout.UserID, in.checkout.TotalStock, in.checkout.TotalPrice).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:      context.TODO(),
+				checkout: fixtures.NewCheckout(fixtures.Checkout),
+				tx:       &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewCheckoutRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Create(tc.in.ctx, tc.in.checkout, tc.in.tx))
+		})
+	}
+}
+
+func TestCheckoutRepository_GetByID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM checkouts WHERE id = ?", checkoutAllColumnsStr)
+	rows := checkoutAllAttributes
+	dummyCheckout := fixtures.NewCheckout(fixtures.Checkout)
+
+	type input struct {
+		ctx context.Context
+		id  string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.Checkout, error)
+	}{
+		{
+			name: "Success on GetByID",
+			in: input{
+				ctx: context.TODO(),
+				id:  "6",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetCheckoutRow(dummyCheckout)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Checkout, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyCheckout, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  "6",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.Checkout, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorCheckoutNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "6",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Checkout, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewCheckoutRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByID(tc.in.ctx, tc.in.id))
+		})
+	}
+}
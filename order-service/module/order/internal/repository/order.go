@@ -2,9 +2,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"order-service/internal/util"
 	"order-service/internal/util/liberr"
+	"order-service/internal/util/libpagination"
 	"order-service/module/order/entity"
 	"time"
 
@@ -16,8 +18,8 @@ import (
 var (
 	orderTable = "orders"
 
-	orderInsertColumns = []string{"user_id", "shop_id", "state", "total_stock", "total_price", "expired_at"}
-	orderColumns       = []string{"id", "user_id", "shop_id", "state", "total_stock", "total_price", "expired_at", "created_at", "updated_at"}
+	orderInsertColumns = []string{"user_id", "shop_id", "checkout_id", "state", "total_stock", "subtotal_price", "discount_price", "shipping_price", "tax_price", "total_price", "expiration_policy_id", "expiration_second", "expired_at"}
+	orderColumns       = []string{"id", "user_id", "shop_id", "checkout_id", "state", "total_stock", "subtotal_price", "discount_price", "shipping_price", "tax_price", "total_price", "expiration_policy_id", "expiration_second", "expired_at", "manual_handling_reason", "created_at", "updated_at"}
 )
 
 type OrderRepository struct {
@@ -25,28 +27,44 @@ type OrderRepository struct {
 }
 
 type orderObject struct {
-	ID         string          `db:"id"`
-	UserID     string          `db:"user_id"`
-	ShopID     string          `db:"shop_id"`
-	State      int             `db:"state"`
-	TotalStock int             `db:"total_stock"`
-	TotalPrice decimal.Decimal `db:"total_price"`
-	ExpiredAt  time.Time       `db:"expired_at"`
-	CreatedAt  time.Time       `db:"created_at"`
-	UpdatedAt  time.Time       `db:"updated_at"`
+	ID                   string          `db:"id"`
+	UserID               string          `db:"user_id"`
+	ShopID               string          `db:"shop_id"`
+	CheckoutID           sql.NullString  `db:"checkout_id"`
+	State                int             `db:"state"`
+	TotalStock           int             `db:"total_stock"`
+	SubtotalPrice        decimal.Decimal `db:"subtotal_price"`
+	DiscountPrice        decimal.Decimal `db:"discount_price"`
+	ShippingPrice        decimal.Decimal `db:"shipping_price"`
+	TaxPrice             decimal.Decimal `db:"tax_price"`
+	TotalPrice           decimal.Decimal `db:"total_price"`
+	ExpirationPolicyID   sql.NullString  `db:"expiration_policy_id"`
+	ExpirationSecond     int             `db:"expiration_second"`
+	ExpiredAt            time.Time       `db:"expired_at"`
+	ManualHandlingReason sql.NullString  `db:"manual_handling_reason"`
+	CreatedAt            time.Time       `db:"created_at"`
+	UpdatedAt            time.Time       `db:"updated_at"`
 }
 
 func (o *orderObject) toEntity() *entity.Order {
 	return &entity.Order{
-		ID:         o.ID,
-		UserID:     o.UserID,
-		ShopID:     o.ShopID,
-		State:      entity.OrderState(o.State),
-		TotalStock: o.TotalStock,
-		TotalPrice: o.TotalPrice,
-		ExpiredAt:  o.ExpiredAt,
-		CreatedAt:  o.CreatedAt,
-		UpdatedAt:  o.UpdatedAt,
+		ID:                   o.ID,
+		UserID:               o.UserID,
+		ShopID:               o.ShopID,
+		CheckoutID:           o.CheckoutID.String,
+		State:                entity.OrderState(o.State),
+		TotalStock:           o.TotalStock,
+		SubtotalPrice:        o.SubtotalPrice,
+		DiscountPrice:        o.DiscountPrice,
+		ShippingPrice:        o.ShippingPrice,
+		TaxPrice:             o.TaxPrice,
+		TotalPrice:           o.TotalPrice,
+		ExpirationPolicyID:   o.ExpirationPolicyID.String,
+		ExpirationSecond:     o.ExpirationSecond,
+		ExpiredAt:            o.ExpiredAt,
+		ManualHandlingReason: o.ManualHandlingReason.String,
+		CreatedAt:            o.CreatedAt,
+		UpdatedAt:            o.UpdatedAt,
 	}
 }
 
@@ -61,9 +79,16 @@ func (o *OrderRepository) Create(ctx context.Context, order *entity.Order, tx ut
 	ib.Values(
 		order.UserID,
 		order.ShopID,
+		sql.NullString{String: order.CheckoutID, Valid: order.CheckoutID != ""},
 		entity.OrderStateCreated,
 		order.TotalStock,
+		order.SubtotalPrice,
+		order.DiscountPrice,
+		order.ShippingPrice,
+		order.TaxPrice,
 		order.TotalPrice,
+		sql.NullString{String: order.ExpirationPolicyID, Valid: order.ExpirationPolicyID != ""},
+		order.ExpirationSecond,
 		order.ExpiredAt,
 	)
 
@@ -88,37 +113,120 @@ func (o *OrderRepository) Create(ctx context.Context, order *entity.Order, tx ut
 	return nil
 }
 
-func (o *OrderRepository) UpdateExpired(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error) {
+func (o *OrderRepository) GetByID(ctx context.Context, id string) (*entity.Order, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderColumns...)
+	sb.From(orderTable)
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+
+	row := o.db.QueryRowxContext(ctx, query, args...)
+	obj := &orderObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorOrderNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on order.GetByID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+// GetByIDForUpdate lock the order until the transaction ends, the changes related to the order state
+// are serialized by the order row
+func (o *OrderRepository) GetByIDForUpdate(ctx context.Context, id string, tx util.DatabaseTransaction) (*entity.Order, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderColumns...)
+	sb.From(orderTable)
+	sb.Where(sb.Equal("id", id))
+	sb.ForUpdate()
+
+	query, args := sb.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when GetExecer on order.GetByIDForUpdate").Wrap(err)
+	}
+
+	row := db.QueryRowxContext(ctx, query, args...)
+	obj := &orderObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorOrderNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on order.GetByIDForUpdate").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+func (o *OrderRepository) UpdateState(ctx context.Context, id string, fromState, toState entity.OrderState, tx util.DatabaseTransaction) (int64, error) {
 	ub := sqlbuilder.NewUpdateBuilder()
 	ub.Update(orderTable).
 		Set(
-			ub.Assign("state", entity.OrderStateExpired),
+			ub.Assign("state", toState),
 		).
 		Where(
 			ub.E("id", id),
+			ub.E("state", fromState),
 		)
 	query, args := ub.Build()
 
 	db, err := util.GetExecer(o.db, tx)
 	if err != nil {
-		return 0, liberr.NewTracer("Error when GetExecer on order.UpdateExpired").Wrap(err)
+		return 0, liberr.NewTracer("Error when GetExecer on order.UpdateState").Wrap(err)
 	}
 
 	row, err := db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return 0, liberr.NewTracer("Error when ExecContext on order.UpdateExpired").Wrap(err)
+		return 0, liberr.NewTracer("Error when ExecContext on order.UpdateState").Wrap(err)
 	}
 
 	rowAffected, _ := row.RowsAffected()
 	return rowAffected, nil
 }
 
-func (o *OrderRepository) ListByOrderExpired(ctx context.Context) ([]*entity.Order, error) {
+// UpdateManualHandlingReason flag the order to be handled manually, the order state is left as it is
+func (o *OrderRepository) UpdateManualHandlingReason(ctx context.Context, id string, reason string, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(orderTable).
+		Set(
+			ub.Assign("manual_handling_reason", reason),
+		).
+		Where(
+			ub.E("id", id),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on order.UpdateManualHandlingReason").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on order.UpdateManualHandlingReason").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// ListByOrderExpired retrieve a batch of the created orders past the expiry, ordered by ID after the last ID of the previous batch
+func (o *OrderRepository) ListByOrderExpired(ctx context.Context, lastID string, limit int) ([]*entity.Order, error) {
 	sb := sqlbuilder.NewSelectBuilder()
 	sb.Select(orderColumns...)
 	sb.From(orderTable)
 	sb.Where(sb.Equal("state", entity.OrderStateCreated))
 	sb.Where("expired_at < NOW()")
+	if lastID != "" {
+		sb.Where(sb.GreaterThan("id", lastID))
+	}
+	sb.OrderBy("id").Asc()
+	sb.Limit(limit)
 
 	query, args := sb.Build()
 
@@ -132,7 +240,7 @@ func (o *OrderRepository) ListByOrderExpired(ctx context.Context) ([]*entity.Ord
 		var obj orderObject
 
 		if err := rows.StructScan(&obj); err != nil {
-			return nil, liberr.NewTracer("Error when StructScan on user.ListByWarehouseIDAndProductIDs").Wrap(err)
+			return nil, liberr.NewTracer("Error when StructScan on order.ListByOrderExpired").Wrap(err)
 		}
 
 		orders = append(orders, obj.toEntity())
@@ -140,3 +248,134 @@ func (o *OrderRepository) ListByOrderExpired(ctx context.Context) ([]*entity.Ord
 
 	return orders, nil
 }
+
+// ClaimExpiredByID lock the expired order until the transaction ends. The order locked by another
+// worker is skipped instead of waited, so it is returned as not found along with the order which is no longer created
+func (o *OrderRepository) ClaimExpiredByID(ctx context.Context, id string, tx util.DatabaseTransaction) (*entity.Order, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderColumns...)
+	sb.From(orderTable)
+	sb.Where(
+		sb.Equal("id", id),
+		sb.Equal("state", entity.OrderStateCreated),
+	)
+	sb.Where("expired_at < NOW()")
+	sb.ForUpdate().SQL("SKIP LOCKED")
+
+	query, args := sb.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when GetExecer on order.ClaimExpiredByID").Wrap(err)
+	}
+
+	row := db.QueryRowxContext(ctx, query, args...)
+	obj := &orderObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorOrderNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on order.ClaimExpiredByID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+// ListByCheckoutID retrieve the orders split from the checkout, one order per shop
+func (o *OrderRepository) ListByCheckoutID(ctx context.Context, checkoutID string) ([]*entity.Order, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderColumns...)
+	sb.From(orderTable)
+	sb.Where(sb.Equal("checkout_id", checkoutID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := o.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on order.ListByCheckoutID").Wrap(err)
+	}
+
+	orders := []*entity.Order{}
+	for rows.Next() {
+		var obj orderObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on order.ListByCheckoutID").Wrap(err)
+		}
+
+		orders = append(orders, obj.toEntity())
+	}
+
+	return orders, nil
+}
+
+func (o *OrderRepository) filterByParams(sb *sqlbuilder.SelectBuilder, params *entity.ListOrderByParams) *sqlbuilder.SelectBuilder {
+	if params.UserID != "" {
+		sb.Where(sb.Equal("user_id", params.UserID))
+	}
+	if params.ShopID != "" {
+		sb.Where(sb.Equal("shop_id", params.ShopID))
+	}
+	if len(params.States) > 0 {
+		inArgs := make([]any, len(params.States))
+		for i, v := range params.States {
+			inArgs[i] = v
+		}
+		sb.Where(sb.In("state", inArgs...))
+	}
+	if params.CreatedAtFrom != nil {
+		sb.Where(sb.GTE("created_at", *params.CreatedAtFrom))
+	}
+	if params.CreatedAtTo != nil {
+		sb.Where(sb.LTE("created_at", *params.CreatedAtTo))
+	}
+
+	return sb
+}
+
+func (o *OrderRepository) ListByParams(ctx context.Context, params *entity.ListOrderByParams) ([]*entity.Order, *libpagination.OffsetPagination, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderColumns...)
+	sb.From(orderTable)
+	sb.OrderBy("id").Desc()
+	sb.Limit(params.Limit)
+	sb.Offset(params.Offset)
+
+	query, args := o.filterByParams(sb, params).Build()
+
+	rows, err := o.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, liberr.NewTracer("Error when QueryxContext on order.ListByParams").Wrap(err)
+	}
+
+	orders := []*entity.Order{}
+	for rows.Next() {
+		var obj orderObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, nil, liberr.NewTracer("Error when StructScan on order.ListByParams").Wrap(err)
+		}
+
+		orders = append(orders, obj.toEntity())
+	}
+
+	cb := sqlbuilder.NewSelectBuilder()
+	cb.Select(cb.As("COUNT(id)", "total"))
+	cb.From(orderTable)
+
+	cQuery, cArgs := o.filterByParams(cb, params).Build()
+	row := o.db.QueryRowxContext(ctx, cQuery, cArgs...)
+
+	var total int
+	if err := row.Scan(&total); err != nil {
+		return nil, nil, liberr.NewTracer("Error when Scan on order.ListByParams").Wrap(err)
+	}
+
+	return orders, &libpagination.OffsetPagination{
+		Total:  total,
+		Offset: params.Offset,
+		Limit:  params.Limit,
+	}, nil
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	orderDiscountTable = "order_discounts"
+
+	orderDiscountInsertColumns = []string{"order_id", "voucher_id", "code", "amount"}
+	orderDiscountColumns       = []string{"id", "order_id", "voucher_id", "code", "amount", "created_at", "updated_at"}
+)
+
+type OrderDiscountRepository struct {
+	db *sqlx.DB
+}
+
+type orderDiscountObject struct {
+	ID        string          `db:"id"`
+	OrderID   string          `db:"order_id"`
+	VoucherID string          `db:"voucher_id"`
+	Code      string          `db:"code"`
+	Amount    decimal.Decimal `db:"amount"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+func (od *orderDiscountObject) toEntity() *entity.OrderDiscount {
+	return &entity.OrderDiscount{
+		ID:        od.ID,
+		OrderID:   od.OrderID,
+		VoucherID: od.VoucherID,
+		Code:      od.Code,
+		Amount:    od.Amount,
+		CreatedAt: od.CreatedAt,
+		UpdatedAt: od.UpdatedAt,
+	}
+}
+
+func NewOrderDiscountRepository(db *sqlx.DB) *OrderDiscountRepository {
+	return &OrderDiscountRepository{db: db}
+}
+
+func (o *OrderDiscountRepository) Create(ctx context.Context, orderDiscount *entity.OrderDiscount, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(orderDiscountTable)
+	ib.Cols(orderDiscountInsertColumns...)
+	ib.Values(
+		orderDiscount.OrderID,
+		orderDiscount.VoucherID,
+		orderDiscount.Code,
+		orderDiscount.Amount,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on orderDiscount.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on orderDiscount.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on orderDiscount.Create").Wrap(err)
+	}
+
+	orderDiscount.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+func (o *OrderDiscountRepository) ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderDiscount, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderDiscountColumns...)
+	sb.From(orderDiscountTable)
+	sb.Where(sb.Equal("order_id", orderID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := o.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on orderDiscount.ListByOrderID").Wrap(err)
+	}
+
+	orderDiscounts := []*entity.OrderDiscount{}
+	for rows.Next() {
+		var obj orderDiscountObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on orderDiscount.ListByOrderID").Wrap(err)
+		}
+
+		orderDiscounts = append(orderDiscounts, obj.toEntity())
+	}
+
+	return orderDiscounts, nil
+}
@@ -0,0 +1,229 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	orderDiscountInsertAttributes = []string{
+		"order_id",
+		"voucher_id",
+		"code",
+		"amount",
+	}
+	orderDiscountAllAttributes = []string{
+		"id",
+		"order_id",
+		"voucher_id",
+		"code",
+		"amount",
+		"created_at",
+		"updated_at",
+	}
+
+	orderDiscountInsertColumnsStr = strings.Join(orderDiscountInsertAttributes, ", ")
+	orderDiscountAllColumnsStr    = strings.Join(orderDiscountAllAttributes, ", ")
+)
+
+func TestOrderDiscountRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO order_discounts (%s) VALUES (?, ?, ?, ?)", orderDiscountInsertColumnsStr)
+
+	type input struct {
+		ctx           context.Context
+		orderDiscount *entity.OrderDiscount
+		tx            util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:           context.TODO(),
+				orderDiscount: fixtures.NewOrderDiscount(fixtures.OrderDiscount),
+				tx:            nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderDiscount.OrderID, in.orderDiscount.VoucherID, in.orderDiscount.Code, in.orderDiscount.Amount).
+					WillReturnResult(sqlmock.NewResult(15, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "15", in.orderDiscount.ID)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:           context.TODO(),
+				orderDiscount: fixtures.NewOrderDiscount(fixtures.OrderDiscount),
+				tx:            nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderDiscount.OrderID, in.orderDiscount.VoucherID, in.orderDiscount.Code, in.orderDiscount.Amount).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:           context.TODO(),
+				orderDiscount: fixtures.NewOrderDiscount(fixtures.OrderDiscount),
+				tx:            nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderDiscount.OrderID, in.orderDiscount.VoucherID, in.orderDiscount.Code, in.orderDiscount.Amount).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:           context.TODO(),
+				orderDiscount: fixtures.NewOrderDiscount(fixtures.OrderDiscount),
+				tx:            &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderDiscountRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Create(tc.in.ctx, tc.in.orderDiscount, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderDiscountRepository_ListByOrderID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_discounts WHERE order_id = ? ORDER BY id ASC", orderDiscountAllColumnsStr)
+	rows := orderDiscountAllAttributes
+	dummyOrderDiscount := fixtures.NewOrderDiscount(fixtures.OrderDiscount)
+
+	type input struct {
+		ctx     context.Context
+		orderID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.OrderDiscount, error)
+	}{
+		{
+			name: "Success on Retrieve ListByOrderID",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderDiscountRow(dummyOrderDiscount)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderDiscount, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.OrderDiscount{dummyOrderDiscount}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetOrderDiscountRow(dummyOrderDiscount)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderDiscount, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderDiscount, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderDiscountRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByOrderID(tc.in.ctx, tc.in.orderID))
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	orderExpirationPolicyTable = "order_expiration_policies"
+
+	orderExpirationPolicyInsertColumns = []string{"scope", "scope_id", "expiration_second"}
+	orderExpirationPolicyColumns       = []string{"id", "scope", "scope_id", "expiration_second", "created_at", "updated_at"}
+)
+
+type OrderExpirationPolicyRepository struct {
+	db *sqlx.DB
+}
+
+type orderExpirationPolicyObject struct {
+	ID               string    `db:"id"`
+	Scope            int       `db:"scope"`
+	ScopeID          string    `db:"scope_id"`
+	ExpirationSecond int       `db:"expiration_second"`
+	CreatedAt        time.Time `db:"created_at"`
+	UpdatedAt        time.Time `db:"updated_at"`
+}
+
+func (o *orderExpirationPolicyObject) toEntity() *entity.OrderExpirationPolicy {
+	return &entity.OrderExpirationPolicy{
+		ID:               o.ID,
+		Scope:            entity.OrderExpirationPolicyScope(o.Scope),
+		ScopeID:          o.ScopeID,
+		ExpirationSecond: o.ExpirationSecond,
+		CreatedAt:        o.CreatedAt,
+		UpdatedAt:        o.UpdatedAt,
+	}
+}
+
+func NewOrderExpirationPolicyRepository(db *sqlx.DB) *OrderExpirationPolicyRepository {
+	return &OrderExpirationPolicyRepository{db: db}
+}
+
+// Upsert create the policy of the scope or replace the existing one, a shop or a product has a single policy
+func (o *OrderExpirationPolicyRepository) Upsert(ctx context.Context, policy *entity.OrderExpirationPolicy, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(orderExpirationPolicyTable)
+	ib.Cols(orderExpirationPolicyInsertColumns...)
+	ib.Values(
+		policy.Scope,
+		policy.ScopeID,
+		policy.ExpirationSecond,
+	)
+	ib.SQL("ON DUPLICATE KEY UPDATE expiration_second = VALUES(expiration_second)")
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on orderExpirationPolicy.Upsert").Wrap(err)
+	}
+
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		return liberr.NewTracer("Error when ExecContext on orderExpirationPolicy.Upsert").Wrap(err)
+	}
+
+	return nil
+}
+
+func (o *OrderExpirationPolicyRepository) GetByScope(ctx context.Context, scope entity.OrderExpirationPolicyScope, scopeID string) (*entity.OrderExpirationPolicy, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderExpirationPolicyColumns...)
+	sb.From(orderExpirationPolicyTable)
+	sb.Where(
+		sb.Equal("scope", scope),
+		sb.Equal("scope_id", scopeID),
+	)
+
+	query, args := sb.Build()
+
+	row := o.db.QueryRowxContext(ctx, query, args...)
+	obj := &orderExpirationPolicyObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorExpirationPolicyNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on orderExpirationPolicy.GetByScope").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+func (o *OrderExpirationPolicyRepository) List(ctx context.Context) ([]*entity.OrderExpirationPolicy, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderExpirationPolicyColumns...)
+	sb.From(orderExpirationPolicyTable)
+	sb.OrderBy("scope", "scope_id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := o.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on orderExpirationPolicy.List").Wrap(err)
+	}
+
+	policies := []*entity.OrderExpirationPolicy{}
+	for rows.Next() {
+		var obj orderExpirationPolicyObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on orderExpirationPolicy.List").Wrap(err)
+		}
+
+		policies = append(policies, obj.toEntity())
+	}
+
+	return policies, nil
+}
+
+// ListByScopeIDs retrieve the policies of the shops and of the products, the policies matching an order
+// are resolved by the caller
+func (o *OrderExpirationPolicyRepository) ListByScopeIDs(ctx context.Context, shopIDs, productIDs []string) ([]*entity.OrderExpirationPolicy, error) {
+	shopArgs := make([]any, len(shopIDs))
+	for i, id := range shopIDs {
+		shopArgs[i] = id
+	}
+
+	productArgs := make([]any, len(productIDs))
+	for i, id := range productIDs {
+		productArgs[i] = id
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderExpirationPolicyColumns...)
+	sb.From(orderExpirationPolicyTable)
+	sb.Where(sb.Or(
+		sb.And(sb.Equal("scope", entity.OrderExpirationPolicyScopeShop), sb.In("scope_id", shopArgs...)),
+		sb.And(sb.Equal("scope", entity.OrderExpirationPolicyScopeProduct), sb.In("scope_id", productArgs...)),
+	))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := o.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on orderExpirationPolicy.ListByScopeIDs").Wrap(err)
+	}
+
+	policies := []*entity.OrderExpirationPolicy{}
+	for rows.Next() {
+		var obj orderExpirationPolicyObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on orderExpirationPolicy.ListByScopeIDs").Wrap(err)
+		}
+
+		policies = append(policies, obj.toEntity())
+	}
+
+	return policies, nil
+}
+
+func (o *OrderExpirationPolicyRepository) DeleteByScope(ctx context.Context, scope entity.OrderExpirationPolicyScope, scopeID string) (int64, error) {
+	deb := sqlbuilder.NewDeleteBuilder()
+	deb.DeleteFrom(orderExpirationPolicyTable)
+	deb.Where(
+		deb.Equal("scope", scope),
+		deb.Equal("scope_id", scopeID),
+	)
+
+	query, args := deb.Build()
+
+	row, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on orderExpirationPolicy.DeleteByScope").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
@@ -0,0 +1,451 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	orderExpirationPolicyInsertAttributes = []string{
+		"scope",
+		"scope_id",
+		"expiration_second",
+	}
+	orderExpirationPolicyAllAttributes = []string{
+		"id",
+		"scope",
+		"scope_id",
+		"expiration_second",
+		"created_at",
+		"updated_at",
+	}
+
+	orderExpirationPolicyInsertColumnsStr = strings.Join(orderExpirationPolicyInsertAttributes, ", ")
+	orderExpirationPolicyAllColumnsStr    = strings.Join(orderExpirationPolicyAllAttributes, ", ")
+)
+
+func TestOrderExpirationPolicyRepository_Upsert(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO order_expiration_policies (%s) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE expiration_second = VALUES(expiration_second)", orderExpirationPolicyInsertColumnsStr)
+
+	type input struct {
+		ctx    context.Context
+		policy *entity.OrderExpirationPolicy
+		tx     util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(error)
+	}{
+		{
+			name: "Success on Upsert",
+			in: input{
+				ctx:    context.TODO(),
+				policy: fixtures.NewOrderExpirationPolicy(fixtures.OrderExpirationPolicy),
+				tx:     nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.policy.Scope, in.policy.ScopeID, in.policy.ExpirationSecond).
+					WillReturnResult(sqlmock.NewResult(23, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:    context.TODO(),
+				policy: fixtures.NewOrderExpirationPolicy(fixtures.OrderExpirationPolicy),
+				tx:     nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.policy.Scope, in.policy.ScopeID, in.policy.ExpirationSecond).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:    context.TODO(),
+				policy: fixtures.NewOrderExpirationPolicy(fixtures.OrderExpirationPolicy),
+				tx:     &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderExpirationPolicyRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.Upsert(tc.in.ctx, tc.in.policy, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderExpirationPolicyRepository_GetByScope(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_expiration_policies WHERE scope = ? AND scope_id = ?", orderExpirationPolicyAllColumnsStr)
+	rows := orderExpirationPolicyAllAttributes
+	dummyPolicy := fixtures.NewOrderExpirationPolicy(fixtures.OrderExpirationPolicy)
+
+	type input struct {
+		ctx     context.Context
+		scope   entity.OrderExpirationPolicyScope
+		scopeID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.OrderExpirationPolicy, error)
+	}{
+		{
+			name: "Success on GetByScope",
+			in: input{
+				ctx:     context.TODO(),
+				scope:   entity.OrderExpirationPolicyScopeShop,
+				scopeID: "3",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.scope, in.scopeID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderExpirationPolicyRow(dummyPolicy)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.OrderExpirationPolicy, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyPolicy, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx:     context.TODO(),
+				scope:   entity.OrderExpirationPolicyScopeShop,
+				scopeID: "3",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.scope, in.scopeID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.OrderExpirationPolicy, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorExpirationPolicyNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:     context.TODO(),
+				scope:   entity.OrderExpirationPolicyScopeShop,
+				scopeID: "3",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.scope, in.scopeID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.OrderExpirationPolicy, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderExpirationPolicyRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByScope(tc.in.ctx, tc.in.scope, tc.in.scopeID))
+		})
+	}
+}
+
+func TestOrderExpirationPolicyRepository_List(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_expiration_policies ORDER BY scope, scope_id ASC", orderExpirationPolicyAllColumnsStr)
+	rows := orderExpirationPolicyAllAttributes
+	dummyPolicy := fixtures.NewOrderExpirationPolicy(fixtures.OrderExpirationPolicy)
+
+	testCases := []struct {
+		name           string
+		mockDependency func(*testutil.RepositoryDependency)
+		assertFn       func([]*entity.OrderExpirationPolicy, error)
+	}{
+		{
+			name: "Success on List",
+			mockDependency: func(dependency *testutil.RepositoryDependency) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderExpirationPolicyRow(dummyPolicy)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderExpirationPolicy, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.OrderExpirationPolicy{dummyPolicy}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			mockDependency: func(dependency *testutil.RepositoryDependency) {
+				row := fixtures.GetOrderExpirationPolicyRow(dummyPolicy)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderExpirationPolicy, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			mockDependency: func(dependency *testutil.RepositoryDependency) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderExpirationPolicy, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderExpirationPolicyRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency)
+			tc.assertFn(repo.List(context.TODO()))
+		})
+	}
+}
+
+func TestOrderExpirationPolicyRepository_ListByScopeIDs(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_expiration_policies WHERE ((scope = ? AND scope_id IN (?)) OR (scope = ? AND scope_id IN (?, ?))) ORDER BY id ASC", orderExpirationPolicyAllColumnsStr)
+	rows := orderExpirationPolicyAllAttributes
+	dummyPolicy := fixtures.NewOrderExpirationPolicy(fixtures.OrderExpirationPolicy)
+
+	type input struct {
+		ctx        context.Context
+		shopIDs    []string
+		productIDs []string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.OrderExpirationPolicy, error)
+	}{
+		{
+			name: "Success on ListByScopeIDs",
+			in: input{
+				ctx:        context.TODO(),
+				shopIDs:    []string{"3"},
+				productIDs: []string{"1", "2"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.OrderExpirationPolicyScopeShop, in.shopIDs[0], entity.OrderExpirationPolicyScopeProduct, in.productIDs[0], in.productIDs[1]).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderExpirationPolicyRow(dummyPolicy)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderExpirationPolicy, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.OrderExpirationPolicy{dummyPolicy}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:        context.TODO(),
+				shopIDs:    []string{"3"},
+				productIDs: []string{"1", "2"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetOrderExpirationPolicyRow(dummyPolicy)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.OrderExpirationPolicyScopeShop, in.shopIDs[0], entity.OrderExpirationPolicyScopeProduct, in.productIDs[0], in.productIDs[1]).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderExpirationPolicy, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:        context.TODO(),
+				shopIDs:    []string{"3"},
+				productIDs: []string{"1", "2"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.OrderExpirationPolicyScopeShop, in.shopIDs[0], entity.OrderExpirationPolicyScopeProduct, in.productIDs[0], in.productIDs[1]).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderExpirationPolicy, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderExpirationPolicyRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByScopeIDs(tc.in.ctx, tc.in.shopIDs, tc.in.productIDs))
+		})
+	}
+}
+
+func TestOrderExpirationPolicyRepository_DeleteByScope(t *testing.T) {
+	expectedQuery := "DELETE FROM order_expiration_policies WHERE scope = ? AND scope_id = ?"
+
+	type input struct {
+		ctx     context.Context
+		scope   entity.OrderExpirationPolicyScope
+		scopeID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on DeleteByScope",
+			in: input{
+				ctx:     context.TODO(),
+				scope:   entity.OrderExpirationPolicyScopeProduct,
+				scopeID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.scope, in.scopeID).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:     context.TODO(),
+				scope:   entity.OrderExpirationPolicyScopeProduct,
+				scopeID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.scope, in.scopeID).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderExpirationPolicyRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.DeleteByScope(tc.in.ctx, tc.in.scope, tc.in.scopeID))
+		})
+	}
+}
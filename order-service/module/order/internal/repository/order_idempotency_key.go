@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	orderIdempotencyKeyTable = "order_idempotency_keys"
+
+	orderIdempotencyKeyInsertColumns = []string{"user_id", "idempotency_key", "request_hash", "state", "locked_until"}
+	orderIdempotencyKeyColumns       = []string{"id", "user_id", "idempotency_key", "request_hash", "state", "order_id", "locked_until", "created_at", "updated_at"}
+)
+
+type OrderIdempotencyKeyRepository struct {
+	db *sqlx.DB
+}
+
+type orderIdempotencyKeyObject struct {
+	ID             string         `db:"id"`
+	UserID         string         `db:"user_id"`
+	IdempotencyKey string         `db:"idempotency_key"`
+	RequestHash    string         `db:"request_hash"`
+	State          int            `db:"state"`
+	OrderID        sql.NullString `db:"order_id"`
+	LockedUntil    sql.NullTime   `db:"locked_until"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+func (o *orderIdempotencyKeyObject) toEntity() *entity.OrderIdempotencyKey {
+	return &entity.OrderIdempotencyKey{
+		ID:             o.ID,
+		UserID:         o.UserID,
+		IdempotencyKey: o.IdempotencyKey,
+		RequestHash:    o.RequestHash,
+		State:          entity.OrderIdempotencyKeyState(o.State),
+		OrderID:        o.OrderID.String,
+		LockedUntil:    o.LockedUntil.Time,
+		CreatedAt:      o.CreatedAt,
+		UpdatedAt:      o.UpdatedAt,
+	}
+}
+
+func NewOrderIdempotencyKeyRepository(db *sqlx.DB) *OrderIdempotencyKeyRepository {
+	return &OrderIdempotencyKeyRepository{db: db}
+}
+
+func isDuplicateError(err error) bool {
+	if err != nil {
+		mysqlErr, ok := err.(*mysql.MySQLError)
+		if ok && mysqlErr.Number == 1062 {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *OrderIdempotencyKeyRepository) Create(ctx context.Context, idempotencyKey *entity.OrderIdempotencyKey, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(orderIdempotencyKeyTable)
+	ib.Cols(orderIdempotencyKeyInsertColumns...)
+	ib.Values(
+		idempotencyKey.UserID,
+		idempotencyKey.IdempotencyKey,
+		idempotencyKey.RequestHash,
+		entity.OrderIdempotencyKeyStateProcessing,
+		idempotencyKey.LockedUntil,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on orderIdempotencyKey.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateError(err) {
+			return entity.ErrorIdempotencyKeyDuplicated
+		}
+
+		return liberr.NewTracer("Error when ExecContext on orderIdempotencyKey.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on orderIdempotencyKey.Create").Wrap(err)
+	}
+
+	idempotencyKey.ID = fmt.Sprintf("%d", lastInsertedID)
+	idempotencyKey.State = entity.OrderIdempotencyKeyStateProcessing
+	return nil
+}
+
+func (o *OrderIdempotencyKeyRepository) GetByUserIDAndKey(ctx context.Context, userID string, key string) (*entity.OrderIdempotencyKey, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderIdempotencyKeyColumns...)
+	sb.From(orderIdempotencyKeyTable)
+	sb.Where(
+		sb.Equal("user_id", userID),
+		sb.Equal("idempotency_key", key),
+	)
+
+	query, args := sb.Build()
+
+	row := o.db.QueryRowxContext(ctx, query, args...)
+	obj := &orderIdempotencyKeyObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorIdempotencyKeyNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on orderIdempotencyKey.GetByUserIDAndKey").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+// TakeOver lease the processing key again once its lease is over, the update is guarded by the lease
+// so only one retry takes the key over
+func (o *OrderIdempotencyKeyRepository) TakeOver(ctx context.Context, id string, lockedUntil time.Time, now time.Time) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(orderIdempotencyKeyTable).
+		Set(
+			ub.Assign("locked_until", lockedUntil),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.OrderIdempotencyKeyStateProcessing),
+			ub.Or(
+				ub.IsNull("locked_until"),
+				ub.LT("locked_until", now),
+			),
+		)
+	query, args := ub.Build()
+
+	row, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on orderIdempotencyKey.TakeOver").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// UpdateCompleted complete the key leased until lockedUntil, the key taken over by another request is left as it is
+func (o *OrderIdempotencyKeyRepository) UpdateCompleted(ctx context.Context, id string, lockedUntil time.Time, orderID string, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(orderIdempotencyKeyTable).
+		Set(
+			ub.Assign("state", entity.OrderIdempotencyKeyStateCompleted),
+			ub.Assign("order_id", orderID),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.OrderIdempotencyKeyStateProcessing),
+			ub.E("locked_until", lockedUntil),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on orderIdempotencyKey.UpdateCompleted").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on orderIdempotencyKey.UpdateCompleted").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// DeleteProcessing release the key leased until lockedUntil, the key taken over by another request is left as it is
+func (o *OrderIdempotencyKeyRepository) DeleteProcessing(ctx context.Context, id string, lockedUntil time.Time) error {
+	deb := sqlbuilder.NewDeleteBuilder()
+	deb.DeleteFrom(orderIdempotencyKeyTable)
+	deb.Where(
+		deb.E("id", id),
+		deb.E("state", entity.OrderIdempotencyKeyStateProcessing),
+		deb.E("locked_until", lockedUntil),
+	)
+	query, args := deb.Build()
+
+	_, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on orderIdempotencyKey.DeleteProcessing").Wrap(err)
+	}
+
+	return nil
+}
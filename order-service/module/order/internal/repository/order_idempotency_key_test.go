@@ -0,0 +1,477 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	orderIdempotencyKeyInsertAttributes = []string{
+		"user_id",
+		"idempotency_key",
+		"request_hash",
+		"state",
+		"locked_until",
+	}
+	orderIdempotencyKeyAllAttributes = []string{
+		"id",
+		"user_id",
+		"idempotency_key",
+		"request_hash",
+		"state",
+		"order_id",
+		"locked_until",
+		"created_at",
+		"updated_at",
+	}
+
+	orderIdempotencyKeyInsertColumnsStr = strings.Join(orderIdempotencyKeyInsertAttributes, ", ")
+	orderIdempotencyKeyAllColumnsStr    = strings.Join(orderIdempotencyKeyAllAttributes, ", ")
+)
+
+func TestOrderIdempotencyKeyRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO order_idempotency_keys (%s) VALUES (?, ?, ?, ?, ?)", orderIdempotencyKeyInsertColumnsStr)
+
+	type input struct {
+		ctx            context.Context
+		idempotencyKey *entity.OrderIdempotencyKey
+		tx             util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:            context.TODO(),
+				idempotencyKey: fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey),
+				tx:             nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.idempotencyKey.UserID, in.idempotencyKey.IdempotencyKey, in.idempotencyKey.RequestHash, entity.OrderIdempotencyKeyStateProcessing, in.idempotencyKey.LockedUntil).
+					WillReturnResult(sqlmock.NewResult(2, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error on Duplicate Key",
+			in: input{
+				ctx:            context.TODO(),
+				idempotencyKey: fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey),
+				tx:             nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.idempotencyKey.UserID, in.idempotencyKey.IdempotencyKey, in.idempotencyKey.RequestHash, entity.OrderIdempotencyKeyStateProcessing, in.idempotencyKey.LockedUntil).
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+			},
+			assertFn: func(err error) {
+				assert.Equal(t, entity.ErrorIdempotencyKeyDuplicated, err)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:            context.TODO(),
+				idempotencyKey: fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey),
+				tx:             nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.idempotencyKey.UserID, in.idempotencyKey.IdempotencyKey, in.idempotencyKey.RequestHash, entity.OrderIdempotencyKeyStateProcessing, in.idempotencyKey.LockedUntil).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:            context.TODO(),
+				idempotencyKey: fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey),
+				tx:             nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.idempotencyKey.UserID, in.idempotencyKey.IdempotencyKey, in.idempotencyKey.RequestHash, entity.OrderIdempotencyKeyStateProcessing, in.idempotencyKey.LockedUntil).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:            context.TODO(),
+				idempotencyKey: fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey),
+				tx:             &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderIdempotencyKeyRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.Create(tc.in.ctx, tc.in.idempotencyKey, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderIdempotencyKeyRepository_GetByUserIDAndKey(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_idempotency_keys WHERE user_id = ? AND idempotency_key = ?", orderIdempotencyKeyAllColumnsStr)
+	rows := orderIdempotencyKeyAllAttributes
+	dummyIdempotencyKey := fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey)
+
+	type input struct {
+		ctx    context.Context
+		userID string
+		key    string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.OrderIdempotencyKey, error)
+	}{
+		{
+			name: "Success on GetByUserIDAndKey",
+			in: input{
+				ctx:    context.TODO(),
+				userID: dummyIdempotencyKey.UserID,
+				key:    dummyIdempotencyKey.IdempotencyKey,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID, in.key).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderIdempotencyKeyRow(dummyIdempotencyKey)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.OrderIdempotencyKey, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyIdempotencyKey, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx:    context.TODO(),
+				userID: dummyIdempotencyKey.UserID,
+				key:    dummyIdempotencyKey.IdempotencyKey,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID, in.key).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.OrderIdempotencyKey, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorIdempotencyKeyNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:    context.TODO(),
+				userID: dummyIdempotencyKey.UserID,
+				key:    dummyIdempotencyKey.IdempotencyKey,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID, in.key).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.OrderIdempotencyKey, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderIdempotencyKeyRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByUserIDAndKey(tc.in.ctx, tc.in.userID, tc.in.key))
+		})
+	}
+}
+
+func TestOrderIdempotencyKeyRepository_TakeOver(t *testing.T) {
+	expectedQuery := "UPDATE order_idempotency_keys SET locked_until = ? WHERE id = ? AND state = ? AND (locked_until IS NULL OR locked_until < ?)"
+
+	type input struct {
+		ctx         context.Context
+		id          string
+		lockedUntil time.Time
+		now         time.Time
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx:         context.TODO(),
+				id:          "4",
+				lockedUntil: fixtures.OrderIdempotencyKey.LockedUntil,
+				now:         fixtures.OrderIdempotencyKey.CreatedAt,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.lockedUntil, "4", entity.OrderIdempotencyKeyStateProcessing, in.now).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:         context.TODO(),
+				id:          "4",
+				lockedUntil: fixtures.OrderIdempotencyKey.LockedUntil,
+				now:         fixtures.OrderIdempotencyKey.CreatedAt,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.lockedUntil, "4", entity.OrderIdempotencyKeyStateProcessing, in.now).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderIdempotencyKeyRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.TakeOver(tc.in.ctx, tc.in.id, tc.in.lockedUntil, tc.in.now))
+		})
+	}
+}
+
+func TestOrderIdempotencyKeyRepository_UpdateCompleted(t *testing.T) {
+	expectedQuery := "UPDATE order_idempotency_keys SET state = ?, order_id = ? WHERE id = ? AND state = ? AND locked_until = ?"
+
+	type input struct {
+		ctx         context.Context
+		id          string
+		lockedUntil time.Time
+		orderID     string
+		tx          util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx:         context.TODO(),
+				id:          "4",
+				lockedUntil: fixtures.OrderIdempotencyKey.LockedUntil,
+				orderID:     "1",
+				tx:          nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.OrderIdempotencyKeyStateCompleted, "1", "4", entity.OrderIdempotencyKeyStateProcessing, in.lockedUntil).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:         context.TODO(),
+				id:          "4",
+				lockedUntil: fixtures.OrderIdempotencyKey.LockedUntil,
+				orderID:     "1",
+				tx:          nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.OrderIdempotencyKeyStateCompleted, "1", "4", entity.OrderIdempotencyKeyStateProcessing, in.lockedUntil).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:         context.TODO(),
+				id:          "4",
+				lockedUntil: fixtures.OrderIdempotencyKey.LockedUntil,
+				orderID:     "1",
+				tx:          &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderIdempotencyKeyRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateCompleted(tc.in.ctx, tc.in.id, tc.in.lockedUntil, tc.in.orderID, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderIdempotencyKeyRepository_DeleteProcessing(t *testing.T) {
+	expectedQuery := "DELETE FROM order_idempotency_keys WHERE id = ? AND state = ? AND locked_until = ?"
+
+	type input struct {
+		ctx         context.Context
+		id          string
+		lockedUntil time.Time
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(error)
+	}{
+		{
+			name: "Success on Delete",
+			in: input{
+				ctx:         context.TODO(),
+				id:          "4",
+				lockedUntil: fixtures.OrderIdempotencyKey.LockedUntil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("4", entity.OrderIdempotencyKeyStateProcessing, in.lockedUntil).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:         context.TODO(),
+				id:          "4",
+				lockedUntil: fixtures.OrderIdempotencyKey.LockedUntil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("4", entity.OrderIdempotencyKeyStateProcessing, in.lockedUntil).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderIdempotencyKeyRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.DeleteProcessing(tc.in.ctx, tc.in.id, tc.in.lockedUntil))
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	orderOutboxTable = "order_outboxes"
+
+	orderOutboxInsertColumns = []string{"order_id", "command", "payload", "state", "attempt", "next_attempt_at"}
+	orderOutboxColumns       = []string{"id", "order_id", "command", "payload", "state", "attempt", "next_attempt_at", "last_error", "created_at", "updated_at"}
+)
+
+type OrderOutboxRepository struct {
+	db *sqlx.DB
+}
+
+type orderOutboxObject struct {
+	ID            string         `db:"id"`
+	OrderID       string         `db:"order_id"`
+	Command       int            `db:"command"`
+	Payload       string         `db:"payload"`
+	State         int            `db:"state"`
+	Attempt       int            `db:"attempt"`
+	NextAttemptAt time.Time      `db:"next_attempt_at"`
+	LastError     sql.NullString `db:"last_error"`
+	CreatedAt     time.Time      `db:"created_at"`
+	UpdatedAt     time.Time      `db:"updated_at"`
+}
+
+func (o *orderOutboxObject) toEntity() *entity.OrderOutbox {
+	return &entity.OrderOutbox{
+		ID:            o.ID,
+		OrderID:       o.OrderID,
+		Command:       entity.OrderOutboxCommand(o.Command),
+		Payload:       o.Payload,
+		State:         entity.OrderOutboxState(o.State),
+		Attempt:       o.Attempt,
+		NextAttemptAt: o.NextAttemptAt,
+		LastError:     o.LastError.String,
+		CreatedAt:     o.CreatedAt,
+		UpdatedAt:     o.UpdatedAt,
+	}
+}
+
+func NewOrderOutboxRepository(db *sqlx.DB) *OrderOutboxRepository {
+	return &OrderOutboxRepository{db: db}
+}
+
+func (o *OrderOutboxRepository) Create(ctx context.Context, outbox *entity.OrderOutbox, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(orderOutboxTable)
+	ib.Cols(orderOutboxInsertColumns...)
+	ib.Values(
+		outbox.OrderID,
+		outbox.Command,
+		outbox.Payload,
+		entity.OrderOutboxStatePending,
+		0,
+		outbox.NextAttemptAt,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on orderOutbox.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on orderOutbox.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on orderOutbox.Create").Wrap(err)
+	}
+
+	outbox.ID = fmt.Sprintf("%d", lastInsertedID)
+	outbox.State = entity.OrderOutboxStatePending
+	outbox.Attempt = 0
+	return nil
+}
+
+func (o *OrderOutboxRepository) ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderOutbox, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderOutboxColumns...)
+	sb.From(orderOutboxTable)
+	sb.Where(sb.Equal("order_id", orderID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := o.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on orderOutbox.ListByOrderID").Wrap(err)
+	}
+
+	outboxes := []*entity.OrderOutbox{}
+	for rows.Next() {
+		var obj orderOutboxObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on orderOutbox.ListByOrderID").Wrap(err)
+		}
+
+		outboxes = append(outboxes, obj.toEntity())
+	}
+
+	return outboxes, nil
+}
+
+// ListByOrderIDForUpdate list the outboxes of the order with a locking read, so the outbox is not claimed by
+// the relay until the transaction is finished
+func (o *OrderOutboxRepository) ListByOrderIDForUpdate(ctx context.Context, orderID string, tx util.DatabaseTransaction) ([]*entity.OrderOutbox, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderOutboxColumns...)
+	sb.From(orderOutboxTable)
+	sb.Where(sb.Equal("order_id", orderID))
+	sb.OrderBy("id").Asc()
+	sb.ForUpdate()
+
+	query, args := sb.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when GetExecer on orderOutbox.ListByOrderIDForUpdate").Wrap(err)
+	}
+
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on orderOutbox.ListByOrderIDForUpdate").Wrap(err)
+	}
+
+	outboxes := []*entity.OrderOutbox{}
+	for rows.Next() {
+		var obj orderOutboxObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on orderOutbox.ListByOrderIDForUpdate").Wrap(err)
+		}
+
+		outboxes = append(outboxes, obj.toEntity())
+	}
+
+	return outboxes, nil
+}
+
+func (o *OrderOutboxRepository) ListPending(ctx context.Context, now time.Time, limit int) ([]*entity.OrderOutbox, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderOutboxColumns...)
+	sb.From(orderOutboxTable)
+	sb.Where(
+		sb.Equal("state", entity.OrderOutboxStatePending),
+		sb.LTE("next_attempt_at", now),
+	)
+	sb.OrderBy("id").Asc()
+	sb.Limit(limit)
+
+	query, args := sb.Build()
+
+	rows, err := o.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on orderOutbox.ListPending").Wrap(err)
+	}
+
+	outboxes := []*entity.OrderOutbox{}
+	for rows.Next() {
+		var obj orderOutboxObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on orderOutbox.ListPending").Wrap(err)
+		}
+
+		outboxes = append(outboxes, obj.toEntity())
+	}
+
+	return outboxes, nil
+}
+
+// Claim mark the outbox as taken by increasing the attempt and postponing the next attempt,
+// the update is guarded by the current attempt so only a single worker is able to deliver it
+func (o *OrderOutboxRepository) Claim(ctx context.Context, id string, attempt int, nextAttemptAt time.Time) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(orderOutboxTable).
+		Set(
+			ub.Assign("attempt", attempt+1),
+			ub.Assign("next_attempt_at", nextAttemptAt),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.OrderOutboxStatePending),
+			ub.E("attempt", attempt),
+		)
+	query, args := ub.Build()
+
+	row, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on orderOutbox.Claim").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+func (o *OrderOutboxRepository) UpdateState(ctx context.Context, id string, toState entity.OrderOutboxState, lastError string, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(orderOutboxTable).
+		Set(
+			ub.Assign("state", toState),
+			ub.Assign("last_error", sql.NullString{String: lastError, Valid: lastError != ""}),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.OrderOutboxStatePending),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on orderOutbox.UpdateState").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on orderOutbox.UpdateState").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+func (o *OrderOutboxRepository) UpdateLastError(ctx context.Context, id string, lastError string) error {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(orderOutboxTable).
+		Set(
+			ub.Assign("last_error", lastError),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.OrderOutboxStatePending),
+		)
+	query, args := ub.Build()
+
+	_, err := o.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on orderOutbox.UpdateLastError").Wrap(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,667 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	orderOutboxInsertAttributes = []string{
+		"order_id",
+		"command",
+		"payload",
+		"state",
+		"attempt",
+		"next_attempt_at",
+	}
+	orderOutboxAllAttributes = []string{
+		"id",
+		"order_id",
+		"command",
+		"payload",
+		"state",
+		"attempt",
+		"next_attempt_at",
+		"last_error",
+		"created_at",
+		"updated_at",
+	}
+
+	orderOutboxInsertColumnsStr = strings.Join(orderOutboxInsertAttributes, ", ")
+	orderOutboxAllColumnsStr    = strings.Join(orderOutboxAllAttributes, ", ")
+)
+
+func TestOrderOutboxRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO order_outboxes (%s) VALUES (?, ?, ?, ?, ?, ?)", orderOutboxInsertColumnsStr)
+
+	type input struct {
+		ctx    context.Context
+		outbox *entity.OrderOutbox
+		tx     util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.OrderOutbox, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:    context.TODO(),
+				outbox: fixtures.NewOrderOutbox(fixtures.OrderOutbox),
+				tx:     nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.outbox.OrderID, in.outbox.Command, in.outbox.Payload, entity.OrderOutboxStatePending, 0, in.outbox.NextAttemptAt).
+					WillReturnResult(sqlmock.NewResult(6, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(outbox *entity.OrderOutbox, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "6", outbox.ID)
+				assert.Equal(t, 0, outbox.Attempt)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:    context.TODO(),
+				outbox: fixtures.NewOrderOutbox(fixtures.OrderOutbox),
+				tx:     nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.outbox.OrderID, in.outbox.Command, in.outbox.Payload, entity.OrderOutboxStatePending, 0, in.outbox.NextAttemptAt).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(outbox *entity.OrderOutbox, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:    context.TODO(),
+				outbox: fixtures.NewOrderOutbox(fixtures.OrderOutbox),
+				tx:     nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.outbox.OrderID, in.outbox.Command, in.outbox.Payload, entity.OrderOutboxStatePending, 0, in.outbox.NextAttemptAt).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(outbox *entity.OrderOutbox, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:    context.TODO(),
+				outbox: fixtures.NewOrderOutbox(fixtures.OrderOutbox),
+				tx:     &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(outbox *entity.OrderOutbox, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderOutboxRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in.outbox, repo.Create(tc.in.ctx, tc.in.outbox, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderOutboxRepository_ListByOrderID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_outboxes WHERE order_id = ? ORDER BY id ASC", orderOutboxAllColumnsStr)
+	rows := orderOutboxAllAttributes
+	dummyOutbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+
+	type input struct {
+		ctx     context.Context
+		orderID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.OrderOutbox, error)
+	}{
+		{
+			name: "Success on ListByOrderID",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderOutboxRow(dummyOutbox)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderOutbox, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.OrderOutbox{dummyOutbox}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetOrderOutboxRow(dummyOutbox)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderOutbox, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result []*entity.OrderOutbox, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderOutboxRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByOrderID(tc.in.ctx, tc.in.orderID))
+		})
+	}
+}
+
+func TestOrderOutboxRepository_ListByOrderIDForUpdate(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_outboxes WHERE order_id = ? ORDER BY id ASC FOR UPDATE", orderOutboxAllColumnsStr)
+	rows := orderOutboxAllAttributes
+	dummyOutbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+
+	type input struct {
+		ctx     context.Context
+		orderID string
+		tx      util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.OrderOutbox, error)
+	}{
+		{
+			name: "Success on ListByOrderIDForUpdate",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderOutboxRow(dummyOutbox)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderOutbox, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.OrderOutbox{dummyOutbox}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetOrderOutboxRow(dummyOutbox)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderOutbox, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result []*entity.OrderOutbox, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result []*entity.OrderOutbox, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderOutboxRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByOrderIDForUpdate(tc.in.ctx, tc.in.orderID, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderOutboxRepository_ListPending(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_outboxes WHERE state = ? AND next_attempt_at <= ? ORDER BY id ASC LIMIT ?", orderOutboxAllColumnsStr)
+	rows := orderOutboxAllAttributes
+	dummyOutbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+	now := time.Date(2025, 1, 10, 11, 13, 0, 0, time.UTC)
+
+	type input struct {
+		ctx   context.Context
+		now   time.Time
+		limit int
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.OrderOutbox, error)
+	}{
+		{
+			name: "Success on ListPending",
+			in: input{
+				ctx:   context.TODO(),
+				now:   now,
+				limit: 10,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.OrderOutboxStatePending, in.now, in.limit).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderOutboxRow(dummyOutbox)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderOutbox, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.OrderOutbox{dummyOutbox}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:   context.TODO(),
+				now:   now,
+				limit: 10,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetOrderOutboxRow(dummyOutbox)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.OrderOutboxStatePending, in.now, in.limit).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderOutbox, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:   context.TODO(),
+				now:   now,
+				limit: 10,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.OrderOutboxStatePending, in.now, in.limit).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result []*entity.OrderOutbox, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderOutboxRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListPending(tc.in.ctx, tc.in.now, tc.in.limit))
+		})
+	}
+}
+
+func TestOrderOutboxRepository_Claim(t *testing.T) {
+	expectedQuery := "UPDATE order_outboxes SET attempt = ?, next_attempt_at = ? WHERE id = ? AND state = ? AND attempt = ?"
+	nextAttemptAt := time.Date(2025, 1, 10, 11, 13, 0, 0, time.UTC)
+
+	type input struct {
+		ctx           context.Context
+		id            string
+		attempt       int
+		nextAttemptAt time.Time
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Claim",
+			in: input{
+				ctx:           context.TODO(),
+				id:            "5",
+				attempt:       1,
+				nextAttemptAt: nextAttemptAt,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(2, in.nextAttemptAt, in.id, entity.OrderOutboxStatePending, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:           context.TODO(),
+				id:            "5",
+				attempt:       1,
+				nextAttemptAt: nextAttemptAt,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(2, in.nextAttemptAt, in.id, entity.OrderOutboxStatePending, 1).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderOutboxRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.Claim(tc.in.ctx, tc.in.id, tc.in.attempt, tc.in.nextAttemptAt))
+		})
+	}
+}
+
+func TestOrderOutboxRepository_UpdateState(t *testing.T) {
+	expectedQuery := "UPDATE order_outboxes SET state = ?, last_error = ? WHERE id = ? AND state = ?"
+
+	type input struct {
+		ctx       context.Context
+		id        string
+		toState   entity.OrderOutboxState
+		lastError string
+		tx        util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "5",
+				toState:   entity.OrderOutboxStateFailed,
+				lastError: "out of stock",
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, "out of stock", in.id, entity.OrderOutboxStatePending).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "5",
+				toState:   entity.OrderOutboxStateProcessed,
+				lastError: "",
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, nil, in.id, entity.OrderOutboxStatePending).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "5",
+				toState:   entity.OrderOutboxStateProcessed,
+				lastError: "",
+				tx:        &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderOutboxRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateState(tc.in.ctx, tc.in.id, tc.in.toState, tc.in.lastError, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderOutboxRepository_UpdateLastError(t *testing.T) {
+	expectedQuery := "UPDATE order_outboxes SET last_error = ? WHERE id = ? AND state = ?"
+
+	type input struct {
+		ctx       context.Context
+		id        string
+		lastError string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "5",
+				lastError: "timeout",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.lastError, in.id, entity.OrderOutboxStatePending).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "5",
+				lastError: "timeout",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.lastError, in.id, entity.OrderOutboxStatePending).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderOutboxRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateLastError(tc.in.ctx, tc.in.id, tc.in.lastError))
+		})
+	}
+}
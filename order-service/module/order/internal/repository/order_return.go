@@ -0,0 +1,194 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	orderReturnTable = "order_returns"
+
+	orderReturnInsertColumns = []string{"order_id", "order_detail_id", "user_id", "product_id", "warehouse_id", "quantity", "refund_amount", "reason", "state"}
+	orderReturnColumns       = []string{"id", "order_id", "order_detail_id", "user_id", "product_id", "warehouse_id", "quantity", "refund_amount", "reason", "review_note", "state", "reviewed_at", "created_at", "updated_at"}
+)
+
+type OrderReturnRepository struct {
+	db *sqlx.DB
+}
+
+type orderReturnObject struct {
+	ID            string          `db:"id"`
+	OrderID       string          `db:"order_id"`
+	OrderDetailID string          `db:"order_detail_id"`
+	UserID        string          `db:"user_id"`
+	ProductID     string          `db:"product_id"`
+	WarehouseID   string          `db:"warehouse_id"`
+	Quantity      int             `db:"quantity"`
+	RefundAmount  decimal.Decimal `db:"refund_amount"`
+	Reason        string          `db:"reason"`
+	ReviewNote    string          `db:"review_note"`
+	State         int             `db:"state"`
+	ReviewedAt    sql.NullTime    `db:"reviewed_at"`
+	CreatedAt     time.Time       `db:"created_at"`
+	UpdatedAt     time.Time       `db:"updated_at"`
+}
+
+func (o *orderReturnObject) toEntity() *entity.OrderReturn {
+	orderReturn := &entity.OrderReturn{
+		ID:            o.ID,
+		OrderID:       o.OrderID,
+		OrderDetailID: o.OrderDetailID,
+		UserID:        o.UserID,
+		ProductID:     o.ProductID,
+		WarehouseID:   o.WarehouseID,
+		Quantity:      o.Quantity,
+		RefundAmount:  o.RefundAmount,
+		Reason:        o.Reason,
+		ReviewNote:    o.ReviewNote,
+		State:         entity.OrderReturnState(o.State),
+		CreatedAt:     o.CreatedAt,
+		UpdatedAt:     o.UpdatedAt,
+	}
+
+	if o.ReviewedAt.Valid {
+		orderReturn.ReviewedAt = &o.ReviewedAt.Time
+	}
+
+	return orderReturn
+}
+
+func NewOrderReturnRepository(db *sqlx.DB) *OrderReturnRepository {
+	return &OrderReturnRepository{db: db}
+}
+
+func (o *OrderReturnRepository) Create(ctx context.Context, orderReturn *entity.OrderReturn, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(orderReturnTable)
+	ib.Cols(orderReturnInsertColumns...)
+	ib.Values(
+		orderReturn.OrderID,
+		orderReturn.OrderDetailID,
+		orderReturn.UserID,
+		orderReturn.ProductID,
+		orderReturn.WarehouseID,
+		orderReturn.Quantity,
+		orderReturn.RefundAmount,
+		orderReturn.Reason,
+		orderReturn.State,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on orderReturn.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on orderReturn.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on orderReturn.Create").Wrap(err)
+	}
+
+	orderReturn.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+func (o *OrderReturnRepository) GetByID(ctx context.Context, id string) (*entity.OrderReturn, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderReturnColumns...)
+	sb.From(orderReturnTable)
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+
+	row := o.db.QueryRowxContext(ctx, query, args...)
+	obj := &orderReturnObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorOrderReturnNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on orderReturn.GetByID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+// ListByOrderID retrieve the returns of the order, the reading is within the transaction when it is given
+// so the returns are read after the order row is locked
+func (o *OrderReturnRepository) ListByOrderID(ctx context.Context, orderID string, tx util.DatabaseTransaction) ([]*entity.OrderReturn, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderReturnColumns...)
+	sb.From(orderReturnTable)
+	sb.Where(sb.Equal("order_id", orderID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when GetExecer on orderReturn.ListByOrderID").Wrap(err)
+	}
+
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on orderReturn.ListByOrderID").Wrap(err)
+	}
+
+	orderReturns := []*entity.OrderReturn{}
+	for rows.Next() {
+		var obj orderReturnObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on orderReturn.ListByOrderID").Wrap(err)
+		}
+
+		orderReturns = append(orderReturns, obj.toEntity())
+	}
+
+	return orderReturns, nil
+}
+
+// UpdateReviewed move the requested return into approved or rejected, the update is guarded by the requested
+// state so the return is only reviewed once
+func (o *OrderReturnRepository) UpdateReviewed(ctx context.Context, id string, toState entity.OrderReturnState, reviewNote string, reviewedAt time.Time, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(orderReturnTable).
+		Set(
+			ub.Assign("state", toState),
+			ub.Assign("review_note", reviewNote),
+			ub.Assign("reviewed_at", reviewedAt),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.OrderReturnStateRequested),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on orderReturn.UpdateReviewed").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on orderReturn.UpdateReviewed").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
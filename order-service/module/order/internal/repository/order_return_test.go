@@ -0,0 +1,475 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	orderReturnInsertAttributes = []string{
+		"order_id",
+		"order_detail_id",
+		"user_id",
+		"product_id",
+		"warehouse_id",
+		"quantity",
+		"refund_amount",
+		"reason",
+		"state",
+	}
+	orderReturnAllAttributes = []string{
+		"id",
+		"order_id",
+		"order_detail_id",
+		"user_id",
+		"product_id",
+		"warehouse_id",
+		"quantity",
+		"refund_amount",
+		"reason",
+		"review_note",
+		"state",
+		"reviewed_at",
+		"created_at",
+		"updated_at",
+	}
+
+	orderReturnInsertColumnsStr = strings.Join(orderReturnInsertAttributes, ", ")
+	orderReturnAllColumnsStr    = strings.Join(orderReturnAllAttributes, ", ")
+)
+
+func TestOrderReturnRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO order_returns (%s) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)", orderReturnInsertColumnsStr)
+
+	type input struct {
+		ctx         context.Context
+		orderReturn *entity.OrderReturn
+		tx          util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:         context.TODO(),
+				orderReturn: fixtures.NewOrderReturn(fixtures.OrderReturn),
+				tx:          nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderReturn.OrderID, in.orderReturn.OrderDetailID, in.orderReturn.UserID, in.orderReturn.ProductID,
+						in.orderReturn.WarehouseID, in.orderReturn.Quantity, in.orderReturn.RefundAmount, in.orderReturn.Reason, in.orderReturn.State).
+					WillReturnResult(sqlmock.NewResult(19, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "19", in.orderReturn.ID)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:         context.TODO(),
+				orderReturn: fixtures.NewOrderReturn(fixtures.OrderReturn),
+				tx:          nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderReturn.OrderID, in.orderReturn.OrderDetailID, in.orderReturn.UserID, in.orderReturn.ProductID,
+						in.orderReturn.WarehouseID, in.orderReturn.Quantity, in.orderReturn.RefundAmount, in.orderReturn.Reason, in.orderReturn.State).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:         context.TODO(),
+				orderReturn: fixtures.NewOrderReturn(fixtures.OrderReturn),
+				tx:          nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderReturn.OrderID, in.orderReturn.OrderDetailID, in.orderReturn.UserID, in.orderReturn.ProductID,
+						in.orderReturn.WarehouseID, in.orderReturn.Quantity, in.orderReturn.RefundAmount, in.orderReturn.Reason, in.orderReturn.State).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:         context.TODO(),
+				orderReturn: fixtures.NewOrderReturn(fixtures.OrderReturn),
+				tx:          &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderReturnRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Create(tc.in.ctx, tc.in.orderReturn, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderReturnRepository_GetByID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_returns WHERE id = ?", orderReturnAllColumnsStr)
+	rows := orderReturnAllAttributes
+	dummyOrderReturn := fixtures.NewOrderReturn(fixtures.OrderReturn)
+	reviewedAt := time.Date(2025, 1, 11, 9, 0, 0, 0, time.UTC)
+	dummyApprovedOrderReturn := fixtures.NewOrderReturn(fixtures.OrderReturn)
+	dummyApprovedOrderReturn.State = entity.OrderReturnStateApproved
+	dummyApprovedOrderReturn.ReviewNote = "Received in the warehouse"
+	dummyApprovedOrderReturn.ReviewedAt = &reviewedAt
+
+	type input struct {
+		ctx context.Context
+		id  string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.OrderReturn, error)
+	}{
+		{
+			name: "Success on GetByID",
+			in: input{
+				ctx: context.TODO(),
+				id:  "18",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderReturnRow(dummyOrderReturn)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.OrderReturn, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyOrderReturn, result)
+			},
+		},
+		{
+			name: "Success on GetByID with Approved Return",
+			in: input{
+				ctx: context.TODO(),
+				id:  "18",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderReturnRow(dummyApprovedOrderReturn)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.OrderReturn, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyApprovedOrderReturn, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  "18",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.OrderReturn, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorOrderReturnNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "18",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.OrderReturn, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderReturnRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByID(tc.in.ctx, tc.in.id))
+		})
+	}
+}
+
+func TestOrderReturnRepository_ListByOrderID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_returns WHERE order_id = ? ORDER BY id ASC", orderReturnAllColumnsStr)
+	rows := orderReturnAllAttributes
+	dummyOrderReturn := fixtures.NewOrderReturn(fixtures.OrderReturn)
+
+	type input struct {
+		ctx     context.Context
+		orderID string
+		tx      util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.OrderReturn, error)
+	}{
+		{
+			name: "Success on Retrieve ListByOrderID",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderReturnRow(dummyOrderReturn)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderReturn, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.OrderReturn{dummyOrderReturn}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetOrderReturnRow(dummyOrderReturn)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderReturn, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderReturn, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result []*entity.OrderReturn, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderReturnRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByOrderID(tc.in.ctx, tc.in.orderID, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderReturnRepository_UpdateReviewed(t *testing.T) {
+	expectedQuery := "UPDATE order_returns SET state = ?, review_note = ?, reviewed_at = ? WHERE id = ? AND state = ?"
+	reviewedAt := time.Date(2025, 1, 11, 9, 0, 0, 0, time.UTC)
+
+	type input struct {
+		ctx        context.Context
+		id         string
+		toState    entity.OrderReturnState
+		reviewNote string
+		reviewedAt time.Time
+		tx         util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on UpdateReviewed",
+			in: input{
+				ctx:        context.TODO(),
+				id:         "18",
+				toState:    entity.OrderReturnStateRejected,
+				reviewNote: "Item is not in the original condition",
+				reviewedAt: reviewedAt,
+				tx:         nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, in.reviewNote, in.reviewedAt, in.id, entity.OrderReturnStateRequested).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:        context.TODO(),
+				id:         "18",
+				toState:    entity.OrderReturnStateApproved,
+				reviewNote: "",
+				reviewedAt: reviewedAt,
+				tx:         nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, in.reviewNote, in.reviewedAt, in.id, entity.OrderReturnStateRequested).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:        context.TODO(),
+				id:         "18",
+				toState:    entity.OrderReturnStateApproved,
+				reviewNote: "",
+				reviewedAt: reviewedAt,
+				tx:         &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderReturnRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateReviewed(tc.in.ctx, tc.in.id, tc.in.toState, tc.in.reviewNote, tc.in.reviewedAt, tc.in.tx))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	orderStateHistoryTable = "order_state_histories"
+
+	orderStateHistoryInsertColumns = []string{"order_id", "from_state", "to_state", "actor_type", "actor_id", "reason"}
+	orderStateHistoryColumns       = []string{"id", "order_id", "from_state", "to_state", "actor_type", "actor_id", "reason", "created_at"}
+)
+
+type OrderStateHistoryRepository struct {
+	db *sqlx.DB
+}
+
+type orderStateHistoryObject struct {
+	ID        string    `db:"id"`
+	OrderID   string    `db:"order_id"`
+	FromState int       `db:"from_state"`
+	ToState   int       `db:"to_state"`
+	ActorType int       `db:"actor_type"`
+	ActorID   string    `db:"actor_id"`
+	Reason    string    `db:"reason"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (o *orderStateHistoryObject) toEntity() *entity.OrderStateHistory {
+	return &entity.OrderStateHistory{
+		ID:        o.ID,
+		OrderID:   o.OrderID,
+		FromState: entity.OrderState(o.FromState),
+		ToState:   entity.OrderState(o.ToState),
+		ActorType: entity.OrderStateActorType(o.ActorType),
+		ActorID:   o.ActorID,
+		Reason:    o.Reason,
+		CreatedAt: o.CreatedAt,
+	}
+}
+
+func NewOrderStateHistoryRepository(db *sqlx.DB) *OrderStateHistoryRepository {
+	return &OrderStateHistoryRepository{db: db}
+}
+
+func (o *OrderStateHistoryRepository) Create(ctx context.Context, history *entity.OrderStateHistory, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(orderStateHistoryTable)
+	ib.Cols(orderStateHistoryInsertColumns...)
+	ib.Values(
+		history.OrderID,
+		history.FromState,
+		history.ToState,
+		history.ActorType,
+		history.ActorID,
+		history.Reason,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(o.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on orderStateHistory.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on orderStateHistory.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on orderStateHistory.Create").Wrap(err)
+	}
+
+	history.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+// ListByOrderID retrieve the state changes of the order in the order they happened
+func (o *OrderStateHistoryRepository) ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderStateHistory, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(orderStateHistoryColumns...)
+	sb.From(orderStateHistoryTable)
+	sb.Where(sb.Equal("order_id", orderID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := o.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on orderStateHistory.ListByOrderID").Wrap(err)
+	}
+
+	histories := []*entity.OrderStateHistory{}
+	for rows.Next() {
+		var obj orderStateHistoryObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on orderStateHistory.ListByOrderID").Wrap(err)
+		}
+
+		histories = append(histories, obj.toEntity())
+	}
+
+	return histories, nil
+}
@@ -0,0 +1,232 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	orderStateHistoryInsertAttributes = []string{
+		"order_id",
+		"from_state",
+		"to_state",
+		"actor_type",
+		"actor_id",
+		"reason",
+	}
+	orderStateHistoryAllAttributes = []string{
+		"id",
+		"order_id",
+		"from_state",
+		"to_state",
+		"actor_type",
+		"actor_id",
+		"reason",
+		"created_at",
+	}
+
+	orderStateHistoryInsertColumnsStr = strings.Join(orderStateHistoryInsertAttributes, ", ")
+	orderStateHistoryAllColumnsStr    = strings.Join(orderStateHistoryAllAttributes, ", ")
+)
+
+func TestOrderStateHistoryRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO order_state_histories (%s) VALUES (?, ?, ?, ?, ?, ?)", orderStateHistoryInsertColumnsStr)
+
+	type input struct {
+		ctx     context.Context
+		history *entity.OrderStateHistory
+		tx      util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:     context.TODO(),
+				history: fixtures.NewOrderStateHistory(fixtures.OrderStateHistory),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.history.OrderID, in.history.FromState, in.history.ToState, in.history.ActorType, in.history.ActorID, in.history.Reason).
+					WillReturnResult(sqlmock.NewResult(22, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "22", in.history.ID)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:     context.TODO(),
+				history: fixtures.NewOrderStateHistory(fixtures.OrderStateHistory),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.history.OrderID, in.history.FromState, in.history.ToState, in.history.ActorType, in.history.ActorID, in.history.Reason).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:     context.TODO(),
+				history: fixtures.NewOrderStateHistory(fixtures.OrderStateHistory),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.history.OrderID, in.history.FromState, in.history.ToState, in.history.ActorType, in.history.ActorID, in.history.Reason).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:     context.TODO(),
+				history: fixtures.NewOrderStateHistory(fixtures.OrderStateHistory),
+				tx:      &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderStateHistoryRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Create(tc.in.ctx, tc.in.history, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderStateHistoryRepository_ListByOrderID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM order_state_histories WHERE order_id = ? ORDER BY id ASC", orderStateHistoryAllColumnsStr)
+	rows := orderStateHistoryAllAttributes
+	dummyHistory := fixtures.NewOrderStateHistory(fixtures.OrderStateHistory)
+
+	type input struct {
+		ctx     context.Context
+		orderID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.OrderStateHistory, error)
+	}{
+		{
+			name: "Success on Retrieve ListByOrderID",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderStateHistoryRow(dummyHistory)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderStateHistory, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.OrderStateHistory{dummyHistory}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetOrderStateHistoryRow(dummyHistory)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderStateHistory, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.OrderStateHistory, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderStateHistoryRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByOrderID(tc.in.ctx, tc.in.orderID))
+		})
+	}
+}
@@ -2,16 +2,20 @@ package repository_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"order-service/internal/testutil"
 	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libpagination"
 	"order-service/module/order/entity"
 	"order-service/module/order/internal/repository"
 	"order-service/module/order/testutil/fixtures"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/golang/mock/gomock"
@@ -22,19 +26,34 @@ var (
 	orderInsertAttributes = []string{
 		"user_id",
 		"shop_id",
+		"checkout_id",
 		"state",
 		"total_stock",
+		"subtotal_price",
+		"discount_price",
+		"shipping_price",
+		"tax_price",
 		"total_price",
+		"expiration_policy_id",
+		"expiration_second",
 		"expired_at",
 	}
 	orderAllAttributes = []string{
 		"id",
 		"user_id",
 		"shop_id",
+		"checkout_id",
 		"state",
 		"total_stock",
+		"subtotal_price",
+		"discount_price",
+		"shipping_price",
+		"tax_price",
 		"total_price",
+		"expiration_policy_id",
+		"expiration_second",
 		"expired_at",
+		"manual_handling_reason",
 		"created_at",
 		"updated_at",
 	}
@@ -44,7 +63,7 @@ var (
 )
 
 func TestOrderRepository_Create(t *testing.T) {
-	expectedQuery := fmt.Sprintf("INSERT INTO orders (%s) VALUES (?, ?, ?, ?, ?, ?)", orderInsertColumnsStr)
+	expectedQuery := fmt.Sprintf("INSERT INTO orders (%s) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", orderInsertColumnsStr)
 
 	type input struct {
 		ctx   context.Context
@@ -69,7 +88,7 @@ func TestOrderRepository_Create(t *testing.T) {
 				expectedQuery := regexp.QuoteMeta(expectedQuery)
 				dependency.MockedSQL.
 					ExpectExec(expectedQuery).
-					WithArgs(in.order.UserID, in.order.ShopID, entity.OrderStateCreated, in.order.TotalStock, in.order.TotalPrice, in.order.ExpiredAt).
+					WithArgs(in.order.UserID, in.order.ShopID, sql.NullString{}, entity.OrderStateCreated, in.order.TotalStock, in.order.SubtotalPrice, in.order.DiscountPrice, in.order.ShippingPrice, in.order.TaxPrice, in.order.TotalPrice, sql.NullString{}, in.order.ExpirationSecond, in.order.ExpiredAt).
 					WillReturnResult(sqlmock.NewResult(2, 1)).
 					WillReturnError(nil)
 			},
@@ -88,7 +107,7 @@ func TestOrderRepository_Create(t *testing.T) {
 				expectedQuery := regexp.QuoteMeta(expectedQuery)
 				dependency.MockedSQL.
 					ExpectExec(expectedQuery).
-					WithArgs(in.order.UserID, in.order.ShopID, entity.OrderStateCreated, in.order.TotalStock, in.order.TotalPrice, in.order.ExpiredAt).
+					WithArgs(in.order.UserID, in.order.ShopID, sql.NullString{}, entity.OrderStateCreated, in.order.TotalStock, in.order.SubtotalPrice, in.order.DiscountPrice, in.order.ShippingPrice, in.order.TaxPrice, in.order.TotalPrice, sql.NullString{}, in.order.ExpirationSecond, in.order.ExpiredAt).
 					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
 			},
 			assertFn: func(err error) {
@@ -106,7 +125,7 @@ func TestOrderRepository_Create(t *testing.T) {
 				expectedQuery := regexp.QuoteMeta(expectedQuery)
 				dependency.MockedSQL.
 					ExpectExec(expectedQuery).
-					WithArgs(in.order.UserID, in.order.ShopID, entity.OrderStateCreated, in.order.TotalStock, in.order.TotalPrice, in.order.ExpiredAt).
+					WithArgs(in.order.UserID, in.order.ShopID, sql.NullString{}, entity.OrderStateCreated, in.order.TotalStock, in.order.SubtotalPrice, in.order.DiscountPrice, in.order.ShippingPrice, in.order.TaxPrice, in.order.TotalPrice, sql.NullString{}, in.order.ExpirationSecond, in.order.ExpiredAt).
 					WillReturnResult(sqlmock.NewResult(2, 1)).
 					WillReturnError(errors.New("error"))
 			},
@@ -141,33 +160,129 @@ func TestOrderRepository_Create(t *testing.T) {
 	}
 }
 
-func TestOrderRepository_UpdateExpired(t *testing.T) {
-	expectedQuery := "UPDATE orders SET state = ? WHERE id = ?"
+func TestOrderRepository_GetByID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE id = ?", orderAllColumnsStr)
+	rows := orderAllAttributes
+	dummyOrder := fixtures.NewOrder(fixtures.Order)
 
 	type input struct {
 		ctx context.Context
 		id  string
-		tx  util.DatabaseTransaction
 	}
 
 	testCases := []struct {
 		name           string
 		in             input
 		mockDependency func(*testutil.RepositoryDependency, input)
-		assertFn       func(int64, error)
+		assertFn       func(*entity.Order, error)
 	}{
 		{
-			name: "Success on Update",
+			name: "Success on GetByID",
 			in: input{
 				ctx: context.TODO(),
 				id:  "1",
-				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderRow(dummyOrder)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyOrder, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorOrderNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByID(tc.in.ctx, tc.in.id))
+		})
+	}
+}
+
+func TestOrderRepository_UpdateState(t *testing.T) {
+	expectedQuery := "UPDATE orders SET state = ? WHERE id = ? AND state = ?"
+
+	type input struct {
+		ctx       context.Context
+		id        string
+		fromState entity.OrderState
+		toState   entity.OrderState
+		tx        util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "1",
+				fromState: entity.OrderStateCreated,
+				toState:   entity.OrderStatePaid,
+				tx:        nil,
 			},
 			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
 				expectedQuery := regexp.QuoteMeta(expectedQuery)
 				dependency.MockedSQL.
 					ExpectExec(expectedQuery).
-					WithArgs(entity.OrderStateExpired, "1").
+					WithArgs(entity.OrderStatePaid, "1", entity.OrderStateCreated).
 					WillReturnResult(sqlmock.NewResult(2, 1)).
 					WillReturnError(nil)
 			},
@@ -176,17 +291,41 @@ func TestOrderRepository_UpdateExpired(t *testing.T) {
 				assert.Equal(t, int64(1), result)
 			},
 		},
+		{
+			name: "Success on Update With State Already Changed",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "1",
+				fromState: entity.OrderStateCreated,
+				toState:   entity.OrderStatePaid,
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := regexp.QuoteMeta(expectedQuery)
+				dependency.MockedSQL.
+					ExpectExec(expectedQuery).
+					WithArgs(entity.OrderStatePaid, "1", entity.OrderStateCreated).
+					WillReturnResult(sqlmock.NewResult(0, 0)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
 		{
 			name: "Error on Execute Query",
 			in: input{
-				ctx: context.TODO(),
-				id:  "1",
+				ctx:       context.TODO(),
+				id:        "1",
+				fromState: entity.OrderStateCreated,
+				toState:   entity.OrderStatePaid,
 			},
 			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
 				expectedQuery := regexp.QuoteMeta(expectedQuery)
 				dependency.MockedSQL.
 					ExpectExec(expectedQuery).
-					WithArgs(entity.OrderStateExpired, "1").
+					WithArgs(entity.OrderStatePaid, "1", entity.OrderStateCreated).
 					WillReturnResult(sqlmock.NewResult(2, 1)).
 					WillReturnError(errors.New("error"))
 			},
@@ -198,9 +337,95 @@ func TestOrderRepository_UpdateExpired(t *testing.T) {
 		{
 			name: "Error on GetExecer",
 			in: input{
-				ctx: context.TODO(),
-				id:  "1",
-				tx:  &testutil.UnknownDatabaseTransaction{},
+				ctx:       context.TODO(),
+				id:        "1",
+				fromState: entity.OrderStateCreated,
+				toState:   entity.OrderStatePaid,
+				tx:        &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateState(tc.in.ctx, tc.in.id, tc.in.fromState, tc.in.toState, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderRepository_UpdateManualHandlingReason(t *testing.T) {
+	expectedQuery := "UPDATE orders SET manual_handling_reason = ? WHERE id = ?"
+
+	type input struct {
+		ctx    context.Context
+		id     string
+		reason string
+		tx     util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx:    context.TODO(),
+				id:     "1",
+				reason: "Order outbox 8 failed, see the last error of the outbox",
+				tx:     nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.reason, in.id).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:    context.TODO(),
+				id:     "1",
+				reason: "Order outbox 8 failed, see the last error of the outbox",
+				tx:     nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.reason, in.id).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:    context.TODO(),
+				id:     "1",
+				reason: "Order outbox 8 failed, see the last error of the outbox",
+				tx:     &testutil.UnknownDatabaseTransaction{},
 			},
 			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
 			assertFn: func(result int64, err error) {
@@ -218,7 +443,7 @@ func TestOrderRepository_UpdateExpired(t *testing.T) {
 			defer ctrl.Finish()
 
 			tc.mockDependency(&repositoryDependency, tc.in)
-			tc.assertFn(repo.UpdateExpired(tc.in.ctx, tc.in.id, tc.in.tx))
+			tc.assertFn(repo.UpdateManualHandlingReason(tc.in.ctx, tc.in.id, tc.in.reason, tc.in.tx))
 		})
 	}
 }
@@ -229,7 +454,9 @@ func TestOrderRepository_ListByOrderExpired(t *testing.T) {
 	dummyOrder := fixtures.NewOrder(fixtures.Order)
 
 	type input struct {
-		ctx context.Context
+		ctx    context.Context
+		lastID string
+		limit  int
 	}
 
 	testCases := []struct {
@@ -241,13 +468,37 @@ func TestOrderRepository_ListByOrderExpired(t *testing.T) {
 		{
 			name: "Success on Retrieve ListByOrderExpired",
 			in: input{
-				ctx: context.TODO(),
+				ctx:   context.TODO(),
+				limit: 100,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE state = ? AND expired_at < NOW() ORDER BY id ASC LIMIT ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.OrderStateCreated, in.limit).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderRow(dummyOrder)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.Order{dummyOrder}, result)
+			},
+		},
+		{
+			name: "Success on Retrieve ListByOrderExpired after Last ID",
+			in: input{
+				ctx:    context.TODO(),
+				lastID: "10",
+				limit:  100,
 			},
 			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
-				expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE state = ? AND expired_at < NOW()", columns)
+				expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE state = ? AND expired_at < NOW() AND id > ? ORDER BY id ASC LIMIT ?", columns)
 				dependency.MockedSQL.
 					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
-					WithArgs(entity.OrderStateCreated).
+					WithArgs(entity.OrderStateCreated, in.lastID, in.limit).
 					WillReturnRows(
 						sqlmock.
 							NewRows(rows).
@@ -262,21 +513,21 @@ func TestOrderRepository_ListByOrderExpired(t *testing.T) {
 		{
 			name: "Error on StructScan",
 			in: input{
-				ctx: context.TODO(),
+				ctx:   context.TODO(),
+				limit: 100,
 			},
 			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
-				expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE state = ? AND expired_at < NOW()", columns)
+				row := fixtures.GetOrderRow(dummyOrder)
+				row[len(row)-1] = "invalid"
+
+				expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE state = ? AND expired_at < NOW() ORDER BY id ASC LIMIT ?", columns)
 				dependency.MockedSQL.
 					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
-					WithArgs(entity.OrderStateCreated).
+					WithArgs(entity.OrderStateCreated, in.limit).
 					WillReturnRows(
 						sqlmock.
 							NewRows(rows).
-							AddRow(
-								dummyOrder.ID,
-								dummyOrder.UserID, dummyOrder.ShopID, dummyOrder.State,
-								dummyOrder.TotalStock, dummyOrder.TotalPrice,
-								dummyOrder.ExpiredAt, dummyOrder.CreatedAt, "invalid"),
+							AddRow(row...),
 					).RowsWillBeClosed()
 			},
 			assertFn: func(result []*entity.Order, err error) {
@@ -287,13 +538,14 @@ func TestOrderRepository_ListByOrderExpired(t *testing.T) {
 		{
 			name: "Error on QueryxContext",
 			in: input{
-				ctx: context.TODO(),
+				ctx:   context.TODO(),
+				limit: 100,
 			},
 			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
-				expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE state = ? AND expired_at < NOW()", columns)
+				expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE state = ? AND expired_at < NOW() ORDER BY id ASC LIMIT ?", columns)
 				dependency.MockedSQL.
 					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
-					WithArgs(entity.OrderStateCreated).
+					WithArgs(entity.OrderStateCreated, in.limit).
 					WillReturnError(sqlmock.ErrCancelled).
 					RowsWillBeClosed()
 			},
@@ -312,7 +564,541 @@ func TestOrderRepository_ListByOrderExpired(t *testing.T) {
 			defer ctrl.Finish()
 
 			tc.mockDependency(&repositoryDependency, tc.in)
-			tc.assertFn(repo.ListByOrderExpired(tc.in.ctx))
+			tc.assertFn(repo.ListByOrderExpired(tc.in.ctx, tc.in.lastID, tc.in.limit))
+		})
+	}
+}
+
+func TestOrderRepository_GetByIDForUpdate(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE id = ? FOR UPDATE", orderAllColumnsStr)
+	rows := orderAllAttributes
+	dummyOrder := fixtures.NewOrder(fixtures.Order)
+
+	type input struct {
+		ctx context.Context
+		id  string
+		tx  util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.Order, error)
+	}{
+		{
+			name: "Success on GetByIDForUpdate",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyOrder.ID,
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderRow(dummyOrder)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyOrder, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyOrder.ID,
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorOrderNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyOrder.ID,
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyOrder.ID,
+				tx:  &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result *entity.Order, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByIDForUpdate(tc.in.ctx, tc.in.id, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderRepository_ClaimExpiredByID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE id = ? AND state = ? AND expired_at < NOW() FOR UPDATE SKIP LOCKED", orderAllColumnsStr)
+	rows := orderAllAttributes
+	dummyOrder := fixtures.NewOrder(fixtures.Order)
+
+	type input struct {
+		ctx context.Context
+		id  string
+		tx  util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.Order, error)
+	}{
+		{
+			name: "Success on ClaimExpiredByID",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyOrder.ID,
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id, entity.OrderStateCreated).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderRow(dummyOrder)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyOrder, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Locked or Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyOrder.ID,
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id, entity.OrderStateCreated).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorOrderNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyOrder.ID,
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id, entity.OrderStateCreated).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyOrder.ID,
+				tx:  &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result *entity.Order, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ClaimExpiredByID(tc.in.ctx, tc.in.id, tc.in.tx))
+		})
+	}
+}
+
+func TestOrderRepository_ListByCheckoutID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE checkout_id = ? ORDER BY id ASC", orderAllColumnsStr)
+	rows := orderAllAttributes
+	dummyOrder := fixtures.NewOrder(fixtures.Order)
+	dummyOrder.CheckoutID = "6"
+
+	type input struct {
+		ctx        context.Context
+		checkoutID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.Order, error)
+	}{
+		{
+			name: "Success on Retrieve ListByCheckoutID",
+			in: input{
+				ctx:        context.TODO(),
+				checkoutID: "6",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.checkoutID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderRow(dummyOrder)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.Order{dummyOrder}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:        context.TODO(),
+				checkoutID: "6",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetOrderRow(dummyOrder)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.checkoutID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Order, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:        context.TODO(),
+				checkoutID: "6",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.checkoutID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Order, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByCheckoutID(tc.in.ctx, tc.in.checkoutID))
+		})
+	}
+}
+
+func TestOrderRepository_ListByParams(t *testing.T) {
+	columns := orderAllColumnsStr
+	rows := orderAllAttributes
+	dummyOrder := fixtures.NewOrder(fixtures.Order)
+	createdAtFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdAtTo := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	type input struct {
+		ctx    context.Context
+		params *entity.ListOrderByParams
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.Order, *libpagination.OffsetPagination, error)
+	}{
+		{
+			name: "Success on Retrieve List By Params",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.ListOrderByParams{
+					Offset:        5,
+					Limit:         20,
+					UserID:        "2",
+					States:        []entity.OrderState{entity.OrderStateCreated, entity.OrderStatePaid},
+					CreatedAtFrom: &createdAtFrom,
+					CreatedAtTo:   &createdAtTo,
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE user_id = ? AND state IN (?, ?) AND created_at >= ? AND created_at <= ? ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.UserID, entity.OrderStateCreated, entity.OrderStatePaid, createdAtFrom, createdAtTo, in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderRow(dummyOrder)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM orders WHERE user_id = ? AND state IN (?, ?) AND created_at >= ? AND created_at <= ?"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WithArgs(in.params.UserID, entity.OrderStateCreated, entity.OrderStatePaid, createdAtFrom, createdAtTo).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(100)).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Order, pagination *libpagination.OffsetPagination, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.Order{dummyOrder}, result)
+				assert.Equal(t, &libpagination.OffsetPagination{
+					Offset: 5,
+					Limit:  20,
+					Total:  100,
+				}, pagination)
+			},
+		},
+		{
+			name: "Success on Retrieve List By Params With Shop ID",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.ListOrderByParams{
+					Offset: 0,
+					Limit:  10,
+					ShopID: "3",
+					States: []entity.OrderState{entity.OrderStatePaid},
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM orders WHERE shop_id = ? AND state IN (?) ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.ShopID, entity.OrderStatePaid, in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderRow(dummyOrder)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM orders WHERE shop_id = ? AND state IN (?)"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WithArgs(in.params.ShopID, entity.OrderStatePaid).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1)).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Order, pagination *libpagination.OffsetPagination, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.Order{dummyOrder}, result)
+				assert.Equal(t, &libpagination.OffsetPagination{
+					Offset: 0,
+					Limit:  10,
+					Total:  1,
+				}, pagination)
+			},
+		},
+		{
+			name: "Success on Retrieve List By Params With Empty Params",
+			in: input{
+				ctx:    context.TODO(),
+				params: &entity.ListOrderByParams{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM orders ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderRow(dummyOrder)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM orders"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(100)).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Order, pagination *libpagination.OffsetPagination, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.Order{dummyOrder}, result)
+				assert.Equal(t, &libpagination.OffsetPagination{
+					Offset: 0,
+					Limit:  0,
+					Total:  100,
+				}, pagination)
+			},
+		},
+		{
+			name: "Error on Scan Count Query",
+			in: input{
+				ctx:    context.TODO(),
+				params: &entity.ListOrderByParams{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM orders ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetOrderRow(dummyOrder)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM orders"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Order, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:    context.TODO(),
+				params: &entity.ListOrderByParams{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM orders ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(
+								dummyOrder.ID,
+								dummyOrder.UserID, dummyOrder.ShopID, dummyOrder.CheckoutID, dummyOrder.State,
+								dummyOrder.TotalStock, dummyOrder.SubtotalPrice, dummyOrder.DiscountPrice,
+								dummyOrder.ShippingPrice, dummyOrder.TaxPrice, dummyOrder.TotalPrice,
+								dummyOrder.ExpirationPolicyID, dummyOrder.ExpirationSecond, dummyOrder.ExpiredAt, dummyOrder.ManualHandlingReason, dummyOrder.CreatedAt, "invalid"),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Order, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:    context.TODO(),
+				params: &entity.ListOrderByParams{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM orders ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.Limit, in.params.Offset).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Order, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewOrderRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByParams(tc.in.ctx, tc.in.params))
 		})
 	}
 }
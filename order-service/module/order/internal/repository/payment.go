@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	paymentTable = "payments"
+
+	paymentInsertColumns = []string{"order_id", "user_id", "provider", "provider_reference", "payment_url", "amount", "state"}
+	paymentColumns       = []string{"id", "order_id", "user_id", "provider", "provider_reference", "payment_url", "amount", "state", "captured_at", "refunded_at", "created_at", "updated_at"}
+)
+
+type PaymentRepository struct {
+	db *sqlx.DB
+}
+
+type paymentObject struct {
+	ID                string          `db:"id"`
+	OrderID           string          `db:"order_id"`
+	UserID            string          `db:"user_id"`
+	Provider          string          `db:"provider"`
+	ProviderReference string          `db:"provider_reference"`
+	PaymentURL        string          `db:"payment_url"`
+	Amount            decimal.Decimal `db:"amount"`
+	State             int             `db:"state"`
+	CapturedAt        sql.NullTime    `db:"captured_at"`
+	RefundedAt        sql.NullTime    `db:"refunded_at"`
+	CreatedAt         time.Time       `db:"created_at"`
+	UpdatedAt         time.Time       `db:"updated_at"`
+}
+
+func (p *paymentObject) toEntity() *entity.Payment {
+	payment := &entity.Payment{
+		ID:                p.ID,
+		OrderID:           p.OrderID,
+		UserID:            p.UserID,
+		Provider:          p.Provider,
+		ProviderReference: p.ProviderReference,
+		PaymentURL:        p.PaymentURL,
+		Amount:            p.Amount,
+		State:             entity.PaymentState(p.State),
+		CreatedAt:         p.CreatedAt,
+		UpdatedAt:         p.UpdatedAt,
+	}
+
+	if p.CapturedAt.Valid {
+		payment.CapturedAt = &p.CapturedAt.Time
+	}
+
+	if p.RefundedAt.Valid {
+		payment.RefundedAt = &p.RefundedAt.Time
+	}
+
+	return payment
+}
+
+func NewPaymentRepository(db *sqlx.DB) *PaymentRepository {
+	return &PaymentRepository{db: db}
+}
+
+func (p *PaymentRepository) Create(ctx context.Context, payment *entity.Payment, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(paymentTable)
+	ib.Cols(paymentInsertColumns...)
+	ib.Values(
+		payment.OrderID,
+		payment.UserID,
+		payment.Provider,
+		payment.ProviderReference,
+		payment.PaymentURL,
+		payment.Amount,
+		payment.State,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(p.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on payment.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on payment.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on payment.Create").Wrap(err)
+	}
+
+	payment.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+func (p *PaymentRepository) GetByID(ctx context.Context, id string) (*entity.Payment, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(paymentColumns...)
+	sb.From(paymentTable)
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+
+	row := p.db.QueryRowxContext(ctx, query, args...)
+	obj := &paymentObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorPaymentNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on payment.GetByID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+func (p *PaymentRepository) GetByProviderReference(ctx context.Context, provider, reference string) (*entity.Payment, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(paymentColumns...)
+	sb.From(paymentTable)
+	sb.Where(
+		sb.Equal("provider", provider),
+		sb.Equal("provider_reference", reference),
+	)
+
+	query, args := sb.Build()
+
+	row := p.db.QueryRowxContext(ctx, query, args...)
+	obj := &paymentObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorPaymentNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on payment.GetByProviderReference").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+// ListByOrderID retrieve the payments of the order, the reading is within the transaction when it is given
+// so the payments are read after the order row is locked
+func (p *PaymentRepository) ListByOrderID(ctx context.Context, orderID string, tx util.DatabaseTransaction) ([]*entity.Payment, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(paymentColumns...)
+	sb.From(paymentTable)
+	sb.Where(sb.Equal("order_id", orderID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	db, err := util.GetExecer(p.db, tx)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when GetExecer on payment.ListByOrderID").Wrap(err)
+	}
+
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on payment.ListByOrderID").Wrap(err)
+	}
+
+	payments := []*entity.Payment{}
+	for rows.Next() {
+		var obj paymentObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on payment.ListByOrderID").Wrap(err)
+		}
+
+		payments = append(payments, obj.toEntity())
+	}
+
+	return payments, nil
+}
+
+// UpdateCaptured move the pending payment into captured, the update is guarded by the pending state
+// so a redelivered callback only captures the payment once
+func (p *PaymentRepository) UpdateCaptured(ctx context.Context, id string, capturedAt time.Time, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(paymentTable).
+		Set(
+			ub.Assign("state", entity.PaymentStateCaptured),
+			ub.Assign("captured_at", capturedAt),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.PaymentStatePending),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(p.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on payment.UpdateCaptured").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on payment.UpdateCaptured").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// UpdateFailed move the pending payment into failed, the order stays waiting for another payment
+func (p *PaymentRepository) UpdateFailed(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(paymentTable).
+		Set(
+			ub.Assign("state", entity.PaymentStateFailed),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.PaymentStatePending),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(p.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on payment.UpdateFailed").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on payment.UpdateFailed").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// UpdateRefundPending move the captured payment into refund pending, the refund is recorded once
+// even when the order is cancelled or the callback is delivered twice
+func (p *PaymentRepository) UpdateRefundPending(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(paymentTable).
+		Set(
+			ub.Assign("state", entity.PaymentStateRefundPending),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.PaymentStateCaptured),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(p.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on payment.UpdateRefundPending").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on payment.UpdateRefundPending").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// UpdateRefunded move the refund pending payment into refunded once the payment provider refunded it
+func (p *PaymentRepository) UpdateRefunded(ctx context.Context, id string, refundedAt time.Time, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(paymentTable).
+		Set(
+			ub.Assign("state", entity.PaymentStateRefunded),
+			ub.Assign("refunded_at", refundedAt),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.PaymentStateRefundPending),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(p.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on payment.UpdateRefunded").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on payment.UpdateRefunded").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
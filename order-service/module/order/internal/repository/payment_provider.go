@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+)
+
+const FakePaymentProviderName = "fake"
+
+type FakePaymentProviderConfiguration struct {
+	CallbackSecret string
+}
+
+// FakePaymentProvider is the local payment provider, no money is moved. It has no checkout page so the intent
+// has no payment URL, the intent is paid by sending the callback signed by the callback secret, the same as
+// a real provider notifies the payment
+type FakePaymentProvider struct {
+	Config FakePaymentProviderConfiguration
+}
+
+func NewFakePaymentProvider(config FakePaymentProviderConfiguration) *FakePaymentProvider {
+	return &FakePaymentProvider{Config: config}
+}
+
+func (f *FakePaymentProvider) Name() string {
+	return FakePaymentProviderName
+}
+
+func (f *FakePaymentProvider) CreateIntent(ctx context.Context, payment *entity.Payment) (*entity.PaymentIntent, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, liberr.NewTracer("Error when generate reference on FakePaymentProvider.CreateIntent").Wrap(err)
+	}
+
+	return &entity.PaymentIntent{
+		Reference: "fake-" + hex.EncodeToString(buf),
+	}, nil
+}
+
+// Refund give the captured amount back, the fake provider has nothing to give back
+func (f *FakePaymentProvider) Refund(ctx context.Context, payment *entity.Payment) error {
+	return nil
+}
+
+// VerifyCallback check the signature of the callback before reading it, the signature is
+// the hex encoded HMAC-SHA256 of the raw payload keyed by the callback secret
+func (f *FakePaymentProvider) VerifyCallback(payload []byte, signature string) (*entity.PaymentCallback, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, f.Sign(payload)) {
+		return nil, entity.ErrorPaymentSignatureInvalid
+	}
+
+	callback := &entity.PaymentCallback{}
+	if err := json.Unmarshal(payload, callback); err != nil {
+		return nil, entity.ErrorPaymentCallbackInvalid
+	}
+
+	return callback, nil
+}
+
+// Sign compute the signature of the payload, the same signature is expected by VerifyCallback
+func (f *FakePaymentProvider) Sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(f.Config.CallbackSecret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
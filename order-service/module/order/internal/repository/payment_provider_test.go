@@ -0,0 +1,120 @@
+package repository_test
+
+import (
+	"context"
+	"encoding/hex"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakePaymentProvider_CreateIntent(t *testing.T) {
+	provider := repository.NewFakePaymentProvider(repository.FakePaymentProviderConfiguration{
+		CallbackSecret: "secret",
+	})
+
+	intent, err := provider.CreateIntent(context.TODO(), fixtures.NewPayment(fixtures.Payment))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(intent.Reference, "fake-"))
+	assert.Empty(t, intent.PaymentURL)
+
+	other, err := provider.CreateIntent(context.TODO(), fixtures.NewPayment(fixtures.Payment))
+	assert.Nil(t, err)
+	assert.NotEqual(t, intent.Reference, other.Reference)
+}
+
+func TestFakePaymentProvider_Refund(t *testing.T) {
+	provider := repository.NewFakePaymentProvider(repository.FakePaymentProviderConfiguration{
+		CallbackSecret: "secret",
+	})
+
+	assert.Nil(t, provider.Refund(context.TODO(), fixtures.NewPayment(fixtures.Payment)))
+}
+
+func TestFakePaymentProvider_VerifyCallback(t *testing.T) {
+	provider := repository.NewFakePaymentProvider(repository.FakePaymentProviderConfiguration{
+		CallbackSecret: "secret",
+	})
+	payload := []byte(`{"reference":"fake-4f1c2a9b7d3e5f60","event":"payment.captured","amount":"59950"}`)
+
+	type input struct {
+		payload   []byte
+		signature string
+	}
+
+	testCases := []struct {
+		name     string
+		in       input
+		assertFn func(*entity.PaymentCallback, error)
+	}{
+		{
+			name: "Success on VerifyCallback",
+			in: input{
+				payload:   payload,
+				signature: hex.EncodeToString(provider.Sign(payload)),
+			},
+			assertFn: func(result *entity.PaymentCallback, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "fake-4f1c2a9b7d3e5f60", result.Reference)
+				assert.Equal(t, entity.PaymentEventCaptured, result.Event)
+				assert.True(t, decimal.NewFromInt(59950).Equal(result.Amount))
+			},
+		},
+		{
+			name: "Error on Signature Signed by Another Secret",
+			in: input{
+				payload: payload,
+				signature: hex.EncodeToString(repository.NewFakePaymentProvider(repository.FakePaymentProviderConfiguration{
+					CallbackSecret: "other",
+				}).Sign(payload)),
+			},
+			assertFn: func(result *entity.PaymentCallback, err error) {
+				assert.Equal(t, entity.ErrorPaymentSignatureInvalid, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Signature of Tampered Payload",
+			in: input{
+				payload:   []byte(`{"reference":"fake-4f1c2a9b7d3e5f60","event":"payment.captured","amount":"1"}`),
+				signature: hex.EncodeToString(provider.Sign(payload)),
+			},
+			assertFn: func(result *entity.PaymentCallback, err error) {
+				assert.Equal(t, entity.ErrorPaymentSignatureInvalid, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Signature Not Hex Encoded",
+			in: input{
+				payload:   payload,
+				signature: "not-hex",
+			},
+			assertFn: func(result *entity.PaymentCallback, err error) {
+				assert.Equal(t, entity.ErrorPaymentSignatureInvalid, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Malformed Payload",
+			in: input{
+				payload:   []byte(`{"reference":`),
+				signature: hex.EncodeToString(provider.Sign([]byte(`{"reference":`))),
+			},
+			assertFn: func(result *entity.PaymentCallback, err error) {
+				assert.Equal(t, entity.ErrorPaymentCallbackInvalid, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.assertFn(provider.VerifyCallback(tc.in.payload, tc.in.signature))
+		})
+	}
+}
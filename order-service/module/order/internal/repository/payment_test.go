@@ -0,0 +1,807 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	paymentInsertAttributes = []string{
+		"order_id",
+		"user_id",
+		"provider",
+		"provider_reference",
+		"payment_url",
+		"amount",
+		"state",
+	}
+	paymentAllAttributes = []string{
+		"id",
+		"order_id",
+		"user_id",
+		"provider",
+		"provider_reference",
+		"payment_url",
+		"amount",
+		"state",
+		"captured_at",
+		"refunded_at",
+		"created_at",
+		"updated_at",
+	}
+
+	paymentInsertColumnsStr = strings.Join(paymentInsertAttributes, ", ")
+	paymentAllColumnsStr    = strings.Join(paymentAllAttributes, ", ")
+)
+
+func TestPaymentRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO payments (%s) VALUES (?, ?, ?, ?, ?, ?, ?)", paymentInsertColumnsStr)
+
+	type input struct {
+		ctx     context.Context
+		payment *entity.Payment
+		tx      util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:     context.TODO(),
+				payment: fixtures.NewPayment(fixtures.Payment),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.payment.OrderID, in.payment.UserID, in.payment.Provider, in.payment.ProviderReference,
+						in.payment.PaymentURL, in.payment.Amount, in.payment.State).
+					WillReturnResult(sqlmock.NewResult(16, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "16", in.payment.ID)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:     context.TODO(),
+				payment: fixtures.NewPayment(fixtures.Payment),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.payment.OrderID, in.payment.UserID, in.payment.Provider, in.payment.ProviderReference,
+						in.payment.PaymentURL, in.payment.Amount, in.payment.State).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:     context.TODO(),
+				payment: fixtures.NewPayment(fixtures.Payment),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.payment.OrderID, in.payment.UserID, in.payment.Provider, in.payment.ProviderReference,
+						in.payment.PaymentURL, in.payment.Amount, in.payment.State).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:     context.TODO(),
+				payment: fixtures.NewPayment(fixtures.Payment),
+				tx:      &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewPaymentRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Create(tc.in.ctx, tc.in.payment, tc.in.tx))
+		})
+	}
+}
+
+func TestPaymentRepository_GetByID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM payments WHERE id = ?", paymentAllColumnsStr)
+	rows := paymentAllAttributes
+	refundedAt := time.Date(2025, 1, 10, 11, 30, 0, 0, time.UTC)
+	dummyRefundedPayment := fixtures.NewPayment(fixtures.Payment)
+	dummyRefundedPayment.State = entity.PaymentStateRefunded
+	dummyRefundedPayment.RefundedAt = &refundedAt
+
+	type input struct {
+		ctx context.Context
+		id  string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.Payment, error)
+	}{
+		{
+			name: "Success on GetByID with Refunded Payment",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyRefundedPayment.ID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetPaymentRow(dummyRefundedPayment)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Payment, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyRefundedPayment, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyRefundedPayment.ID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.Payment, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorPaymentNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyRefundedPayment.ID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Payment, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewPaymentRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByID(tc.in.ctx, tc.in.id))
+		})
+	}
+}
+
+func TestPaymentRepository_GetByProviderReference(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM payments WHERE provider = ? AND provider_reference = ?", paymentAllColumnsStr)
+	rows := paymentAllAttributes
+	dummyPayment := fixtures.NewPayment(fixtures.Payment)
+	capturedAt := time.Date(2025, 1, 10, 11, 20, 0, 0, time.UTC)
+	dummyCapturedPayment := fixtures.NewPayment(fixtures.Payment)
+	dummyCapturedPayment.State = entity.PaymentStateCaptured
+	dummyCapturedPayment.CapturedAt = &capturedAt
+
+	type input struct {
+		ctx       context.Context
+		provider  string
+		reference string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.Payment, error)
+	}{
+		{
+			name: "Success on GetByProviderReference",
+			in: input{
+				ctx:       context.TODO(),
+				provider:  dummyPayment.Provider,
+				reference: dummyPayment.ProviderReference,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.provider, in.reference).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetPaymentRow(dummyPayment)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Payment, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyPayment, result)
+			},
+		},
+		{
+			name: "Success on GetByProviderReference with Captured Payment",
+			in: input{
+				ctx:       context.TODO(),
+				provider:  dummyPayment.Provider,
+				reference: dummyPayment.ProviderReference,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.provider, in.reference).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetPaymentRow(dummyCapturedPayment)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Payment, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyCapturedPayment, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx:       context.TODO(),
+				provider:  dummyPayment.Provider,
+				reference: dummyPayment.ProviderReference,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.provider, in.reference).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.Payment, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorPaymentNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:       context.TODO(),
+				provider:  dummyPayment.Provider,
+				reference: dummyPayment.ProviderReference,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.provider, in.reference).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Payment, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewPaymentRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByProviderReference(tc.in.ctx, tc.in.provider, tc.in.reference))
+		})
+	}
+}
+
+func TestPaymentRepository_ListByOrderID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM payments WHERE order_id = ? ORDER BY id ASC", paymentAllColumnsStr)
+	rows := paymentAllAttributes
+	dummyPayment := fixtures.NewPayment(fixtures.Payment)
+
+	type input struct {
+		ctx     context.Context
+		orderID string
+		tx      util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.Payment, error)
+	}{
+		{
+			name: "Success on Retrieve ListByOrderID",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetPaymentRow(dummyPayment)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Payment, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.Payment{dummyPayment}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetPaymentRow(dummyPayment)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Payment, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Payment, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result []*entity.Payment, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewPaymentRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByOrderID(tc.in.ctx, tc.in.orderID, tc.in.tx))
+		})
+	}
+}
+
+func TestPaymentRepository_UpdateCaptured(t *testing.T) {
+	expectedQuery := "UPDATE payments SET state = ?, captured_at = ? WHERE id = ? AND state = ?"
+	capturedAt := time.Date(2025, 1, 10, 11, 20, 0, 0, time.UTC)
+
+	type input struct {
+		ctx        context.Context
+		id         string
+		capturedAt time.Time
+		tx         util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on UpdateCaptured",
+			in: input{
+				ctx:        context.TODO(),
+				id:         "15",
+				capturedAt: capturedAt,
+				tx:         nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.PaymentStateCaptured, in.capturedAt, in.id, entity.PaymentStatePending).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:        context.TODO(),
+				id:         "15",
+				capturedAt: capturedAt,
+				tx:         nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.PaymentStateCaptured, in.capturedAt, in.id, entity.PaymentStatePending).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:        context.TODO(),
+				id:         "15",
+				capturedAt: capturedAt,
+				tx:         &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewPaymentRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateCaptured(tc.in.ctx, tc.in.id, tc.in.capturedAt, tc.in.tx))
+		})
+	}
+}
+
+func TestPaymentRepository_UpdateFailed(t *testing.T) {
+	expectedQuery := "UPDATE payments SET state = ? WHERE id = ? AND state = ?"
+
+	type input struct {
+		ctx context.Context
+		id  string
+		tx  util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on UpdateFailed",
+			in: input{
+				ctx: context.TODO(),
+				id:  "15",
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.PaymentStateFailed, in.id, entity.PaymentStatePending).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "15",
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.PaymentStateFailed, in.id, entity.PaymentStatePending).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx: context.TODO(),
+				id:  "15",
+				tx:  &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewPaymentRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateFailed(tc.in.ctx, tc.in.id, tc.in.tx))
+		})
+	}
+}
+
+func TestPaymentRepository_UpdateRefundPending(t *testing.T) {
+	expectedQuery := "UPDATE payments SET state = ? WHERE id = ? AND state = ?"
+
+	type input struct {
+		ctx context.Context
+		id  string
+		tx  util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on UpdateRefundPending",
+			in: input{
+				ctx: context.TODO(),
+				id:  "15",
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.PaymentStateRefundPending, in.id, entity.PaymentStateCaptured).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "15",
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.PaymentStateRefundPending, in.id, entity.PaymentStateCaptured).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx: context.TODO(),
+				id:  "15",
+				tx:  &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewPaymentRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateRefundPending(tc.in.ctx, tc.in.id, tc.in.tx))
+		})
+	}
+}
+
+func TestPaymentRepository_UpdateRefunded(t *testing.T) {
+	expectedQuery := "UPDATE payments SET state = ?, refunded_at = ? WHERE id = ? AND state = ?"
+	refundedAt := time.Date(2025, 1, 10, 11, 30, 0, 0, time.UTC)
+
+	type input struct {
+		ctx        context.Context
+		id         string
+		refundedAt time.Time
+		tx         util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on UpdateRefunded",
+			in: input{
+				ctx:        context.TODO(),
+				id:         "15",
+				refundedAt: refundedAt,
+				tx:         nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.PaymentStateRefunded, in.refundedAt, in.id, entity.PaymentStateRefundPending).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:        context.TODO(),
+				id:         "15",
+				refundedAt: refundedAt,
+				tx:         nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.PaymentStateRefunded, in.refundedAt, in.id, entity.PaymentStateRefundPending).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:        context.TODO(),
+				id:         "15",
+				refundedAt: refundedAt,
+				tx:         &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewPaymentRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateRefunded(tc.in.ctx, tc.in.id, tc.in.refundedAt, tc.in.tx))
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"context"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"strings"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	salesRollupTable = "sales_daily_rollups"
+
+	salesRollupInsertColumns = []string{"sales_date", "shop_id", "product_id", "order_state", "order_count", "units", "revenue"}
+
+	// salesReportPeriodColumns truncate the sales date into the first day of the period, the week starts on Monday
+	salesReportPeriodColumns = map[entity.SalesReportPeriod]string{
+		entity.SalesReportPeriodDay:   "sales_date",
+		entity.SalesReportPeriodWeek:  "DATE_SUB(sales_date, INTERVAL WEEKDAY(sales_date) DAY)",
+		entity.SalesReportPeriodMonth: "DATE_SUB(sales_date, INTERVAL DAYOFMONTH(sales_date) - 1 DAY)",
+	}
+)
+
+// shopSalesProductID is the product ID of the shop total rollup, the order count of the shop is counted once
+// per order rather than summed from the products of the order
+const shopSalesProductID = 0
+
+type SalesRollupRepository struct {
+	db *sqlx.DB
+}
+
+type salesReportObject struct {
+	Period     time.Time       `db:"period"`
+	ShopID     string          `db:"shop_id"`
+	ProductID  string          `db:"product_id"`
+	OrderCount int             `db:"order_count"`
+	Units      int             `db:"units"`
+	Revenue    decimal.Decimal `db:"revenue"`
+}
+
+func (s *salesReportObject) toEntity() *entity.SalesReport {
+	return &entity.SalesReport{
+		Period:     s.Period,
+		ShopID:     s.ShopID,
+		ProductID:  s.ProductID,
+		OrderCount: s.OrderCount,
+		Units:      s.Units,
+		Revenue:    s.Revenue,
+	}
+}
+
+func NewSalesRollupRepository(db *sqlx.DB) *SalesRollupRepository {
+	return &SalesRollupRepository{db: db}
+}
+
+// DeleteBySalesDate remove the rollups of the day, so the day is rolled up again from the current orders
+func (s *SalesRollupRepository) DeleteBySalesDate(ctx context.Context, salesDate time.Time, tx util.DatabaseTransaction) (int64, error) {
+	deb := sqlbuilder.NewDeleteBuilder()
+	deb.DeleteFrom(salesRollupTable)
+	deb.Where(deb.E("sales_date", salesDate))
+	query, args := deb.Build()
+
+	db, err := util.GetExecer(s.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on salesRollup.DeleteBySalesDate").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on salesRollup.DeleteBySalesDate").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// CreateProductRollups aggregate the order details of the orders created on the day by shop, product and order state
+func (s *SalesRollupRepository) CreateProductRollups(ctx context.Context, salesDate time.Time, tx util.DatabaseTransaction) error {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		sb.Var(salesDate),
+		"o.shop_id",
+		"od.product_id",
+		"o.state",
+		"COUNT(DISTINCT o.id)",
+		"SUM(od.stock)",
+		"SUM(od.price * od.stock)",
+	)
+	sb.From("orders o")
+	sb.Join("order_details od", "od.order_id = o.id")
+	sb.Where(
+		sb.GTE("o.created_at", salesDate),
+		sb.LessThan("o.created_at", salesDate.AddDate(0, 0, 1)),
+	)
+	sb.GroupBy("o.shop_id", "od.product_id", "o.state")
+
+	query, args := sqlbuilder.Buildf("INSERT INTO "+salesRollupTable+" ("+strings.Join(salesRollupInsertColumns, ", ")+") %v", sb).Build()
+
+	db, err := util.GetExecer(s.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on salesRollup.CreateProductRollups").Wrap(err)
+	}
+
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		return liberr.NewTracer("Error when ExecContext on salesRollup.CreateProductRollups").Wrap(err)
+	}
+
+	return nil
+}
+
+// CreateShopRollups aggregate the orders created on the day by shop and order state, the rollup is kept
+// with the shop total product ID
+func (s *SalesRollupRepository) CreateShopRollups(ctx context.Context, salesDate time.Time, tx util.DatabaseTransaction) error {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		sb.Var(salesDate),
+		"shop_id",
+		sb.Var(shopSalesProductID),
+		"state",
+		"COUNT(id)",
+		"SUM(total_stock)",
+		"SUM(subtotal_price)",
+	)
+	sb.From(orderTable)
+	sb.Where(
+		sb.GTE("created_at", salesDate),
+		sb.LessThan("created_at", salesDate.AddDate(0, 0, 1)),
+	)
+	sb.GroupBy("shop_id", "state")
+
+	query, args := sqlbuilder.Buildf("INSERT INTO "+salesRollupTable+" ("+strings.Join(salesRollupInsertColumns, ", ")+") %v", sb).Build()
+
+	db, err := util.GetExecer(s.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on salesRollup.CreateShopRollups").Wrap(err)
+	}
+
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		return liberr.NewTracer("Error when ExecContext on salesRollup.CreateShopRollups").Wrap(err)
+	}
+
+	return nil
+}
+
+// StreamReport aggregate the rollups into the period and pass the reports one by one to the handle function,
+// so the report is never loaded at once
+func (s *SalesRollupRepository) StreamReport(ctx context.Context, params *entity.SalesReportByParams, handle func(*entity.SalesReport) error) error {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(
+		sb.As(salesReportPeriodColumns[params.Period], "period"),
+		"shop_id",
+		"product_id",
+		sb.As("SUM(order_count)", "order_count"),
+		sb.As("SUM(units)", "units"),
+		sb.As("SUM(revenue)", "revenue"),
+	)
+	sb.From(salesRollupTable)
+	sb.Where(
+		sb.GTE("sales_date", params.DateFrom),
+		sb.LTE("sales_date", params.DateTo),
+	)
+	if params.GroupBy == entity.SalesReportGroupShop {
+		sb.Where(sb.E("product_id", shopSalesProductID))
+	} else {
+		sb.Where(sb.NE("product_id", shopSalesProductID))
+	}
+	if len(params.States) > 0 {
+		inArgs := make([]any, len(params.States))
+		for i, v := range params.States {
+			inArgs[i] = v
+		}
+		sb.Where(sb.In("order_state", inArgs...))
+	}
+	if params.ShopID != "" {
+		sb.Where(sb.E("shop_id", params.ShopID))
+	}
+	if params.ProductID != "" {
+		sb.Where(sb.E("product_id", params.ProductID))
+	}
+	sb.GroupBy("period", "shop_id", "product_id")
+	sb.OrderBy("period", "shop_id", "product_id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when QueryxContext on salesRollup.StreamReport").Wrap(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var obj salesReportObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return liberr.NewTracer("Error when StructScan on salesRollup.StreamReport").Wrap(err)
+		}
+
+		report := obj.toEntity()
+		if params.GroupBy == entity.SalesReportGroupShop {
+			report.ProductID = ""
+		}
+
+		if err := handle(report); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return liberr.NewTracer("Error when iterate rows on salesRollup.StreamReport").Wrap(err)
+	}
+
+	return nil
+}
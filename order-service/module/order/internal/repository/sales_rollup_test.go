@@ -0,0 +1,466 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	salesRollupInsertAttributes = []string{
+		"sales_date",
+		"shop_id",
+		"product_id",
+		"order_state",
+		"order_count",
+		"units",
+		"revenue",
+	}
+	salesReportAttributes = []string{
+		"period",
+		"shop_id",
+		"product_id",
+		"order_count",
+		"units",
+		"revenue",
+	}
+
+	salesRollupInsertColumnsStr = strings.Join(salesRollupInsertAttributes, ", ")
+)
+
+func TestSalesRollupRepository_DeleteBySalesDate(t *testing.T) {
+	expectedQuery := "DELETE FROM sales_daily_rollups WHERE sales_date = ?"
+	salesDate := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	type input struct {
+		ctx       context.Context
+		salesDate time.Time
+		tx        util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on DeleteBySalesDate",
+			in: input{
+				ctx:       context.TODO(),
+				salesDate: salesDate,
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.salesDate).
+					WillReturnResult(sqlmock.NewResult(0, 4)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(4), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:       context.TODO(),
+				salesDate: salesDate,
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.salesDate).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:       context.TODO(),
+				salesDate: salesDate,
+				tx:        &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewSalesRollupRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.DeleteBySalesDate(tc.in.ctx, tc.in.salesDate, tc.in.tx))
+		})
+	}
+}
+
+func TestSalesRollupRepository_CreateProductRollups(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO sales_daily_rollups (%s) SELECT ?, o.shop_id, od.product_id, o.state, COUNT(DISTINCT o.id), SUM(od.stock), SUM(od.price * od.stock) FROM orders o JOIN order_details od ON od.order_id = o.id WHERE o.created_at >= ? AND o.created_at < ? GROUP BY o.shop_id, od.product_id, o.state", salesRollupInsertColumnsStr)
+	salesDate := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	type input struct {
+		ctx       context.Context
+		salesDate time.Time
+		tx        util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(error)
+	}{
+		{
+			name: "Success on CreateProductRollups",
+			in: input{
+				ctx:       context.TODO(),
+				salesDate: salesDate,
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.salesDate, in.salesDate, in.salesDate.AddDate(0, 0, 1)).
+					WillReturnResult(sqlmock.NewResult(0, 2)).
+					WillReturnError(nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:       context.TODO(),
+				salesDate: salesDate,
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.salesDate, in.salesDate, in.salesDate.AddDate(0, 0, 1)).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:       context.TODO(),
+				salesDate: salesDate,
+				tx:        &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewSalesRollupRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.CreateProductRollups(tc.in.ctx, tc.in.salesDate, tc.in.tx))
+		})
+	}
+}
+
+func TestSalesRollupRepository_CreateShopRollups(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO sales_daily_rollups (%s) SELECT ?, shop_id, ?, state, COUNT(id), SUM(total_stock), SUM(subtotal_price) FROM orders WHERE created_at >= ? AND created_at < ? GROUP BY shop_id, state", salesRollupInsertColumnsStr)
+	salesDate := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	type input struct {
+		ctx       context.Context
+		salesDate time.Time
+		tx        util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(error)
+	}{
+		{
+			name: "Success on CreateShopRollups",
+			in: input{
+				ctx:       context.TODO(),
+				salesDate: salesDate,
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.salesDate, 0, in.salesDate, in.salesDate.AddDate(0, 0, 1)).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:       context.TODO(),
+				salesDate: salesDate,
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.salesDate, 0, in.salesDate, in.salesDate.AddDate(0, 0, 1)).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:       context.TODO(),
+				salesDate: salesDate,
+				tx:        &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewSalesRollupRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.CreateShopRollups(tc.in.ctx, tc.in.salesDate, tc.in.tx))
+		})
+	}
+}
+
+func TestSalesRollupRepository_StreamReport(t *testing.T) {
+	expectedQuery := "SELECT DATE_SUB(sales_date, INTERVAL WEEKDAY(sales_date) DAY) AS period, shop_id, product_id, SUM(order_count) AS order_count, SUM(units) AS units, SUM(revenue) AS revenue FROM sales_daily_rollups WHERE sales_date >= ? AND sales_date <= ? AND product_id <> ? GROUP BY period, shop_id, product_id ORDER BY period, shop_id, product_id ASC"
+	expectedQueryWithFilter := "SELECT sales_date AS period, shop_id, product_id, SUM(order_count) AS order_count, SUM(units) AS units, SUM(revenue) AS revenue FROM sales_daily_rollups WHERE sales_date >= ? AND sales_date <= ? AND product_id = ? AND order_state IN (?, ?) AND shop_id = ? GROUP BY period, shop_id, product_id ORDER BY period, shop_id, product_id ASC"
+	rows := salesReportAttributes
+	dummyReport := fixtures.NewSalesReport(fixtures.SalesReport)
+	dateFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	dateTo := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	type input struct {
+		ctx    context.Context
+		params *entity.SalesReportByParams
+	}
+
+	type result struct {
+		reports []*entity.SalesReport
+		err     error
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		handleErr      error
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(result)
+	}{
+		{
+			name: "Success on StreamReport By Product",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.SalesReportByParams{
+					Period:   entity.SalesReportPeriodWeek,
+					GroupBy:  entity.SalesReportGroupProduct,
+					DateFrom: dateFrom,
+					DateTo:   dateTo,
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.DateFrom, in.params.DateTo, 0).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetSalesReportRow(dummyReport)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(res result) {
+				assert.Nil(t, res.err)
+				assert.Equal(t, []*entity.SalesReport{dummyReport}, res.reports)
+			},
+		},
+		{
+			name: "Success on StreamReport By Shop With Filter",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.SalesReportByParams{
+					Period:   entity.SalesReportPeriodDay,
+					GroupBy:  entity.SalesReportGroupShop,
+					DateFrom: dateFrom,
+					DateTo:   dateTo,
+					States:   []entity.OrderState{entity.OrderStateCompleted, entity.OrderStateShipped},
+					ShopID:   "1",
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetSalesReportRow(dummyReport)
+				row[2] = "0"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQueryWithFilter)).
+					WithArgs(in.params.DateFrom, in.params.DateTo, 0, entity.OrderStateCompleted, entity.OrderStateShipped, in.params.ShopID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(res result) {
+				report := fixtures.NewSalesReport(dummyReport)
+				report.ProductID = ""
+
+				assert.Nil(t, res.err)
+				assert.Equal(t, []*entity.SalesReport{report}, res.reports)
+			},
+		},
+		{
+			name: "Error on Handle",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.SalesReportByParams{
+					Period:   entity.SalesReportPeriodWeek,
+					GroupBy:  entity.SalesReportGroupProduct,
+					DateFrom: dateFrom,
+					DateTo:   dateTo,
+				},
+			},
+			handleErr: errors.New("error"),
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.DateFrom, in.params.DateTo, 0).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetSalesReportRow(dummyReport)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(res result) {
+				assert.NotNil(t, res.err)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.SalesReportByParams{
+					Period:   entity.SalesReportPeriodWeek,
+					GroupBy:  entity.SalesReportGroupProduct,
+					DateFrom: dateFrom,
+					DateTo:   dateTo,
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetSalesReportRow(dummyReport)
+				row[0] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.DateFrom, in.params.DateTo, 0).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(res result) {
+				assert.NotNil(t, res.err)
+				assert.Nil(t, res.reports)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.SalesReportByParams{
+					Period:   entity.SalesReportPeriodWeek,
+					GroupBy:  entity.SalesReportGroupProduct,
+					DateFrom: dateFrom,
+					DateTo:   dateTo,
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.DateFrom, in.params.DateTo, 0).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(res result) {
+				assert.NotNil(t, res.err)
+				assert.Nil(t, res.reports)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewSalesRollupRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+
+			var res result
+			res.err = repo.StreamReport(tc.in.ctx, tc.in.params, func(report *entity.SalesReport) error {
+				if tc.handleErr != nil {
+					return tc.handleErr
+				}
+				res.reports = append(res.reports, report)
+				return nil
+			})
+			tc.assertFn(res)
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	shopConfigTable = "shop_configs"
+
+	shopConfigInsertColumns = []string{"shop_id", "tax_region", "shipping_method", "shipping_fee", "shipping_fee_per_kg", "item_weight_gram"}
+	shopConfigColumns       = []string{"id", "shop_id", "tax_region", "shipping_method", "shipping_fee", "shipping_fee_per_kg", "item_weight_gram", "created_at", "updated_at"}
+)
+
+type ShopConfigRepository struct {
+	db *sqlx.DB
+}
+
+type shopConfigObject struct {
+	ID               string          `db:"id"`
+	ShopID           string          `db:"shop_id"`
+	TaxRegion        string          `db:"tax_region"`
+	ShippingMethod   int             `db:"shipping_method"`
+	ShippingFee      decimal.Decimal `db:"shipping_fee"`
+	ShippingFeePerKg decimal.Decimal `db:"shipping_fee_per_kg"`
+	ItemWeightGram   int             `db:"item_weight_gram"`
+	CreatedAt        time.Time       `db:"created_at"`
+	UpdatedAt        time.Time       `db:"updated_at"`
+}
+
+func (s *shopConfigObject) toEntity() *entity.ShopConfig {
+	return &entity.ShopConfig{
+		ID:               s.ID,
+		ShopID:           s.ShopID,
+		TaxRegion:        s.TaxRegion,
+		ShippingMethod:   entity.ShippingMethod(s.ShippingMethod),
+		ShippingFee:      s.ShippingFee,
+		ShippingFeePerKg: s.ShippingFeePerKg,
+		ItemWeightGram:   s.ItemWeightGram,
+		CreatedAt:        s.CreatedAt,
+		UpdatedAt:        s.UpdatedAt,
+	}
+}
+
+func NewShopConfigRepository(db *sqlx.DB) *ShopConfigRepository {
+	return &ShopConfigRepository{db: db}
+}
+
+// Upsert create the configuration of the shop or replace the existing one, a shop has a single configuration
+func (s *ShopConfigRepository) Upsert(ctx context.Context, shopConfig *entity.ShopConfig, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(shopConfigTable)
+	ib.Cols(shopConfigInsertColumns...)
+	ib.Values(
+		shopConfig.ShopID,
+		shopConfig.TaxRegion,
+		shopConfig.ShippingMethod,
+		shopConfig.ShippingFee,
+		shopConfig.ShippingFeePerKg,
+		shopConfig.ItemWeightGram,
+	)
+	ib.SQL("ON DUPLICATE KEY UPDATE tax_region = VALUES(tax_region), shipping_method = VALUES(shipping_method), " +
+		"shipping_fee = VALUES(shipping_fee), shipping_fee_per_kg = VALUES(shipping_fee_per_kg), item_weight_gram = VALUES(item_weight_gram)")
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(s.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on shopConfig.Upsert").Wrap(err)
+	}
+
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		return liberr.NewTracer("Error when ExecContext on shopConfig.Upsert").Wrap(err)
+	}
+
+	return nil
+}
+
+func (s *ShopConfigRepository) GetByShopID(ctx context.Context, shopID string) (*entity.ShopConfig, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(shopConfigColumns...)
+	sb.From(shopConfigTable)
+	sb.Where(sb.Equal("shop_id", shopID))
+
+	query, args := sb.Build()
+
+	row := s.db.QueryRowxContext(ctx, query, args...)
+	obj := &shopConfigObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorShopConfigNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on shopConfig.GetByShopID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+func (s *ShopConfigRepository) ListByShopIDs(ctx context.Context, shopIDs []string) ([]*entity.ShopConfig, error) {
+	inArgs := make([]any, len(shopIDs))
+	for i, id := range shopIDs {
+		inArgs[i] = id
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(shopConfigColumns...)
+	sb.From(shopConfigTable)
+	sb.Where(sb.In("shop_id", inArgs...))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on shopConfig.ListByShopIDs").Wrap(err)
+	}
+
+	shopConfigs := []*entity.ShopConfig{}
+	for rows.Next() {
+		var obj shopConfigObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on shopConfig.ListByShopIDs").Wrap(err)
+		}
+
+		shopConfigs = append(shopConfigs, obj.toEntity())
+	}
+
+	return shopConfigs, nil
+}
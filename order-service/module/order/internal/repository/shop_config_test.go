@@ -0,0 +1,313 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	shopConfigInsertAttributes = []string{
+		"shop_id",
+		"tax_region",
+		"shipping_method",
+		"shipping_fee",
+		"shipping_fee_per_kg",
+		"item_weight_gram",
+	}
+	shopConfigAllAttributes = []string{
+		"id",
+		"shop_id",
+		"tax_region",
+		"shipping_method",
+		"shipping_fee",
+		"shipping_fee_per_kg",
+		"item_weight_gram",
+		"created_at",
+		"updated_at",
+	}
+
+	shopConfigInsertColumnsStr = strings.Join(shopConfigInsertAttributes, ", ")
+	shopConfigAllColumnsStr    = strings.Join(shopConfigAllAttributes, ", ")
+)
+
+func TestShopConfigRepository_Upsert(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO shop_configs (%s) VALUES (?, ?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE tax_region = VALUES(tax_region), "+
+		"shipping_method = VALUES(shipping_method), shipping_fee = VALUES(shipping_fee), shipping_fee_per_kg = VALUES(shipping_fee_per_kg), "+
+		"item_weight_gram = VALUES(item_weight_gram)", shopConfigInsertColumnsStr)
+
+	type input struct {
+		ctx        context.Context
+		shopConfig *entity.ShopConfig
+		tx         util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Upsert",
+			in: input{
+				ctx:        context.TODO(),
+				shopConfig: fixtures.NewShopConfig(fixtures.ShopConfig),
+				tx:         nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.shopConfig.ShopID, in.shopConfig.TaxRegion, in.shopConfig.ShippingMethod, in.shopConfig.ShippingFee,
+						in.shopConfig.ShippingFeePerKg, in.shopConfig.ItemWeightGram).
+					WillReturnResult(sqlmock.NewResult(13, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:        context.TODO(),
+				shopConfig: fixtures.NewShopConfig(fixtures.ShopConfig),
+				tx:         nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.shopConfig.ShopID, in.shopConfig.TaxRegion, in.shopConfig.ShippingMethod, in.shopConfig.ShippingFee,
+						in.shopConfig.ShippingFeePerKg, in.shopConfig.ItemWeightGram).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:        context.TODO(),
+				shopConfig: fixtures.NewShopConfig(fixtures.ShopConfig),
+				tx:         &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewShopConfigRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Upsert(tc.in.ctx, tc.in.shopConfig, tc.in.tx))
+		})
+	}
+}
+
+func TestShopConfigRepository_GetByShopID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM shop_configs WHERE shop_id = ?", shopConfigAllColumnsStr)
+	rows := shopConfigAllAttributes
+	dummyShopConfig := fixtures.NewShopConfig(fixtures.ShopConfig)
+
+	type input struct {
+		ctx    context.Context
+		shopID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.ShopConfig, error)
+	}{
+		{
+			name: "Success on GetByShopID",
+			in: input{
+				ctx:    context.TODO(),
+				shopID: "3",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.shopID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetShopConfigRow(dummyShopConfig)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.ShopConfig, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyShopConfig, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx:    context.TODO(),
+				shopID: "3",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.shopID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.ShopConfig, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorShopConfigNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:    context.TODO(),
+				shopID: "3",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.shopID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.ShopConfig, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewShopConfigRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByShopID(tc.in.ctx, tc.in.shopID))
+		})
+	}
+}
+
+func TestShopConfigRepository_ListByShopIDs(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM shop_configs WHERE shop_id IN (?, ?) ORDER BY id ASC", shopConfigAllColumnsStr)
+	rows := shopConfigAllAttributes
+	dummyShopConfig := fixtures.NewShopConfig(fixtures.ShopConfig)
+
+	type input struct {
+		ctx     context.Context
+		shopIDs []string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.ShopConfig, error)
+	}{
+		{
+			name: "Success on Retrieve ListByShopIDs",
+			in: input{
+				ctx:     context.TODO(),
+				shopIDs: []string{"3", "4"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("3", "4").
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetShopConfigRow(dummyShopConfig)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.ShopConfig, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.ShopConfig{dummyShopConfig}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:     context.TODO(),
+				shopIDs: []string{"3", "4"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetShopConfigRow(dummyShopConfig)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("3", "4").
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.ShopConfig, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:     context.TODO(),
+				shopIDs: []string{"3", "4"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("3", "4").
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.ShopConfig, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewShopConfigRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByShopIDs(tc.in.ctx, tc.in.shopIDs))
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	taxRateTable = "tax_rates"
+
+	taxRateInsertColumns = []string{"region", "rate"}
+	taxRateColumns       = []string{"id", "region", "rate", "created_at", "updated_at"}
+)
+
+type TaxRateRepository struct {
+	db *sqlx.DB
+}
+
+type taxRateObject struct {
+	ID        string          `db:"id"`
+	Region    string          `db:"region"`
+	Rate      decimal.Decimal `db:"rate"`
+	CreatedAt time.Time       `db:"created_at"`
+	UpdatedAt time.Time       `db:"updated_at"`
+}
+
+func (t *taxRateObject) toEntity() *entity.TaxRate {
+	return &entity.TaxRate{
+		ID:        t.ID,
+		Region:    t.Region,
+		Rate:      t.Rate,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
+
+func NewTaxRateRepository(db *sqlx.DB) *TaxRateRepository {
+	return &TaxRateRepository{db: db}
+}
+
+// Upsert create the tax rate of the region or replace the existing one
+func (t *TaxRateRepository) Upsert(ctx context.Context, taxRate *entity.TaxRate, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(taxRateTable)
+	ib.Cols(taxRateInsertColumns...)
+	ib.Values(
+		taxRate.Region,
+		taxRate.Rate,
+	)
+	ib.SQL("ON DUPLICATE KEY UPDATE rate = VALUES(rate)")
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(t.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on taxRate.Upsert").Wrap(err)
+	}
+
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		return liberr.NewTracer("Error when ExecContext on taxRate.Upsert").Wrap(err)
+	}
+
+	return nil
+}
+
+func (t *TaxRateRepository) List(ctx context.Context) ([]*entity.TaxRate, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(taxRateColumns...)
+	sb.From(taxRateTable)
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := t.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on taxRate.List").Wrap(err)
+	}
+
+	taxRates := []*entity.TaxRate{}
+	for rows.Next() {
+		var obj taxRateObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on taxRate.List").Wrap(err)
+		}
+
+		taxRates = append(taxRates, obj.toEntity())
+	}
+
+	return taxRates, nil
+}
+
+func (t *TaxRateRepository) ListByRegions(ctx context.Context, regions []string) ([]*entity.TaxRate, error) {
+	inArgs := make([]any, len(regions))
+	for i, r := range regions {
+		inArgs[i] = r
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(taxRateColumns...)
+	sb.From(taxRateTable)
+	sb.Where(sb.In("region", inArgs...))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := t.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on taxRate.ListByRegions").Wrap(err)
+	}
+
+	taxRates := []*entity.TaxRate{}
+	for rows.Next() {
+		var obj taxRateObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on taxRate.ListByRegions").Wrap(err)
+		}
+
+		taxRates = append(taxRates, obj.toEntity())
+	}
+
+	return taxRates, nil
+}
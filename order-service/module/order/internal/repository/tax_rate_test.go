@@ -0,0 +1,294 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	taxRateInsertAttributes = []string{
+		"region",
+		"rate",
+	}
+	taxRateAllAttributes = []string{
+		"id",
+		"region",
+		"rate",
+		"created_at",
+		"updated_at",
+	}
+
+	taxRateInsertColumnsStr = strings.Join(taxRateInsertAttributes, ", ")
+	taxRateAllColumnsStr    = strings.Join(taxRateAllAttributes, ", ")
+)
+
+func TestTaxRateRepository_Upsert(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO tax_rates (%s) VALUES (?, ?) ON DUPLICATE KEY UPDATE rate = VALUES(rate)", taxRateInsertColumnsStr)
+
+	type input struct {
+		ctx     context.Context
+		taxRate *entity.TaxRate
+		tx      util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Upsert",
+			in: input{
+				ctx:     context.TODO(),
+				taxRate: fixtures.NewTaxRate(fixtures.TaxRate),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.taxRate.Region, in.taxRate.Rate).
+					WillReturnResult(sqlmock.NewResult(14, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:     context.TODO(),
+				taxRate: fixtures.NewTaxRate(fixtures.TaxRate),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.taxRate.Region, in.taxRate.Rate).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:     context.TODO(),
+				taxRate: fixtures.NewTaxRate(fixtures.TaxRate),
+				tx:      &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewTaxRateRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Upsert(tc.in.ctx, tc.in.taxRate, tc.in.tx))
+		})
+	}
+}
+
+func TestTaxRateRepository_List(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM tax_rates ORDER BY id ASC", taxRateAllColumnsStr)
+	rows := taxRateAllAttributes
+	dummyTaxRate := fixtures.NewTaxRate(fixtures.TaxRate)
+
+	type input struct {
+		ctx context.Context
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.TaxRate, error)
+	}{
+		{
+			name: "Success on Retrieve List",
+			in: input{
+				ctx: context.TODO(),
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetTaxRateRow(dummyTaxRate)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.TaxRate, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.TaxRate{dummyTaxRate}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx: context.TODO(),
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetTaxRateRow(dummyTaxRate)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.TaxRate, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx: context.TODO(),
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.TaxRate, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewTaxRateRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.List(tc.in.ctx))
+		})
+	}
+}
+
+func TestTaxRateRepository_ListByRegions(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM tax_rates WHERE region IN (?, ?) ORDER BY id ASC", taxRateAllColumnsStr)
+	rows := taxRateAllAttributes
+	dummyTaxRate := fixtures.NewTaxRate(fixtures.TaxRate)
+
+	type input struct {
+		ctx     context.Context
+		regions []string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.TaxRate, error)
+	}{
+		{
+			name: "Success on Retrieve ListByRegions",
+			in: input{
+				ctx:     context.TODO(),
+				regions: []string{"ID-JK", "ID-JB"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("ID-JK", "ID-JB").
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetTaxRateRow(dummyTaxRate)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.TaxRate, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.TaxRate{dummyTaxRate}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:     context.TODO(),
+				regions: []string{"ID-JK", "ID-JB"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetTaxRateRow(dummyTaxRate)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("ID-JK", "ID-JB").
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.TaxRate, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:     context.TODO(),
+				regions: []string{"ID-JK", "ID-JB"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("ID-JK", "ID-JB").
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.TaxRate, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewTaxRateRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByRegions(tc.in.ctx, tc.in.regions))
+		})
+	}
+}
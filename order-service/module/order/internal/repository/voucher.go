@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	voucherTable = "vouchers"
+
+	voucherInsertColumns = []string{"code", "shop_id", "product_id", "type", "value", "max_discount", "buy_quantity", "get_quantity", "min_spend", "usage_limit", "usage_limit_per_user", "starts_at", "ends_at"}
+	voucherColumns       = []string{"id", "code", "shop_id", "product_id", "type", "value", "max_discount", "buy_quantity", "get_quantity", "min_spend", "usage_limit", "usage_limit_per_user", "used_count", "starts_at", "ends_at", "created_at", "updated_at"}
+)
+
+type VoucherRepository struct {
+	db *sqlx.DB
+}
+
+type voucherObject struct {
+	ID                string          `db:"id"`
+	Code              string          `db:"code"`
+	ShopID            sql.NullString  `db:"shop_id"`
+	ProductID         sql.NullString  `db:"product_id"`
+	Type              int             `db:"type"`
+	Value             decimal.Decimal `db:"value"`
+	MaxDiscount       decimal.Decimal `db:"max_discount"`
+	BuyQuantity       int             `db:"buy_quantity"`
+	GetQuantity       int             `db:"get_quantity"`
+	MinSpend          decimal.Decimal `db:"min_spend"`
+	UsageLimit        int             `db:"usage_limit"`
+	UsageLimitPerUser int             `db:"usage_limit_per_user"`
+	UsedCount         int             `db:"used_count"`
+	StartsAt          time.Time       `db:"starts_at"`
+	EndsAt            time.Time       `db:"ends_at"`
+	CreatedAt         time.Time       `db:"created_at"`
+	UpdatedAt         time.Time       `db:"updated_at"`
+}
+
+func (v *voucherObject) toEntity() *entity.Voucher {
+	return &entity.Voucher{
+		ID:                v.ID,
+		Code:              v.Code,
+		ShopID:            v.ShopID.String,
+		ProductID:         v.ProductID.String,
+		Type:              entity.VoucherType(v.Type),
+		Value:             v.Value,
+		MaxDiscount:       v.MaxDiscount,
+		BuyQuantity:       v.BuyQuantity,
+		GetQuantity:       v.GetQuantity,
+		MinSpend:          v.MinSpend,
+		UsageLimit:        v.UsageLimit,
+		UsageLimitPerUser: v.UsageLimitPerUser,
+		UsedCount:         v.UsedCount,
+		StartsAt:          v.StartsAt,
+		EndsAt:            v.EndsAt,
+		CreatedAt:         v.CreatedAt,
+		UpdatedAt:         v.UpdatedAt,
+	}
+}
+
+func NewVoucherRepository(db *sqlx.DB) *VoucherRepository {
+	return &VoucherRepository{db: db}
+}
+
+func (v *VoucherRepository) Create(ctx context.Context, voucher *entity.Voucher, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(voucherTable)
+	ib.Cols(voucherInsertColumns...)
+	ib.Values(
+		voucher.Code,
+		sql.NullString{String: voucher.ShopID, Valid: voucher.ShopID != ""},
+		sql.NullString{String: voucher.ProductID, Valid: voucher.ProductID != ""},
+		voucher.Type,
+		voucher.Value,
+		voucher.MaxDiscount,
+		voucher.BuyQuantity,
+		voucher.GetQuantity,
+		voucher.MinSpend,
+		voucher.UsageLimit,
+		voucher.UsageLimitPerUser,
+		voucher.StartsAt,
+		voucher.EndsAt,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(v.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on voucher.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateError(err) {
+			return entity.ErrorVoucherDuplicated
+		}
+
+		return liberr.NewTracer("Error when ExecContext on voucher.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on voucher.Create").Wrap(err)
+	}
+
+	voucher.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+func (v *VoucherRepository) GetByID(ctx context.Context, id string) (*entity.Voucher, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(voucherColumns...)
+	sb.From(voucherTable)
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+
+	row := v.db.QueryRowxContext(ctx, query, args...)
+	obj := &voucherObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorVoucherNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on voucher.GetByID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+func (v *VoucherRepository) ListByCodes(ctx context.Context, codes []string) ([]*entity.Voucher, error) {
+	inArgs := make([]any, len(codes))
+	for i, c := range codes {
+		inArgs[i] = c
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(voucherColumns...)
+	sb.From(voucherTable)
+	sb.Where(sb.In("code", inArgs...))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := v.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on voucher.ListByCodes").Wrap(err)
+	}
+
+	vouchers := []*entity.Voucher{}
+	for rows.Next() {
+		var obj voucherObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on voucher.ListByCodes").Wrap(err)
+		}
+
+		vouchers = append(vouchers, obj.toEntity())
+	}
+
+	return vouchers, nil
+}
+
+// IncrementUsedCount count the usage of the voucher, the update is guarded by the usage limit so the voucher
+// is never used beyond the limit. The row stays locked until the transaction ends, which serializes
+// the concurrent usages of the same voucher
+func (v *VoucherRepository) IncrementUsedCount(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(voucherTable).
+		Set(
+			ub.Incr("used_count"),
+		).
+		Where(
+			ub.E("id", id),
+			ub.Or(
+				ub.E("usage_limit", 0),
+				"used_count < usage_limit",
+			),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(v.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on voucher.IncrementUsedCount").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on voucher.IncrementUsedCount").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// DecrementUsedCount give the usage back once the order is cancelled or expired
+func (v *VoucherRepository) DecrementUsedCount(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(voucherTable).
+		Set(
+			ub.Decr("used_count"),
+		).
+		Where(
+			ub.E("id", id),
+			ub.G("used_count", 0),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(v.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on voucher.DecrementUsedCount").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on voucher.DecrementUsedCount").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
@@ -0,0 +1,553 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	voucherInsertAttributes = []string{
+		"code",
+		"shop_id",
+		"product_id",
+		"type",
+		"value",
+		"max_discount",
+		"buy_quantity",
+		"get_quantity",
+		"min_spend",
+		"usage_limit",
+		"usage_limit_per_user",
+		"starts_at",
+		"ends_at",
+	}
+	voucherAllAttributes = []string{
+		"id",
+		"code",
+		"shop_id",
+		"product_id",
+		"type",
+		"value",
+		"max_discount",
+		"buy_quantity",
+		"get_quantity",
+		"min_spend",
+		"usage_limit",
+		"usage_limit_per_user",
+		"used_count",
+		"starts_at",
+		"ends_at",
+		"created_at",
+		"updated_at",
+	}
+
+	voucherInsertColumnsStr = strings.Join(voucherInsertAttributes, ", ")
+	voucherAllColumnsStr    = strings.Join(voucherAllAttributes, ", ")
+)
+
+func TestVoucherRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO vouchers (%s) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", voucherInsertColumnsStr)
+
+	type input struct {
+		ctx     context.Context
+		voucher *entity.Voucher
+		tx      util.DatabaseTransaction
+	}
+
+	expectedArgs := func(in input) []driver.Value {
+		return []driver.Value{
+			in.voucher.Code,
+			sql.NullString{String: in.voucher.ShopID, Valid: true},
+			sql.NullString{},
+			in.voucher.Type,
+			in.voucher.Value,
+			in.voucher.MaxDiscount,
+			in.voucher.BuyQuantity,
+			in.voucher.GetQuantity,
+			in.voucher.MinSpend,
+			in.voucher.UsageLimit,
+			in.voucher.UsageLimitPerUser,
+			in.voucher.StartsAt,
+			in.voucher.EndsAt,
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:     context.TODO(),
+				voucher: fixtures.NewVoucher(fixtures.Voucher),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(expectedArgs(in)...).
+					WillReturnResult(sqlmock.NewResult(13, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "13", in.voucher.ID)
+			},
+		},
+		{
+			name: "Error on Duplicate Key",
+			in: input{
+				ctx:     context.TODO(),
+				voucher: fixtures.NewVoucher(fixtures.Voucher),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(expectedArgs(in)...).
+					WillReturnError(&mysql.MySQLError{Number: 1062})
+			},
+			assertFn: func(in input, err error) {
+				assert.Equal(t, entity.ErrorVoucherDuplicated, err)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:     context.TODO(),
+				voucher: fixtures.NewVoucher(fixtures.Voucher),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(expectedArgs(in)...).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:     context.TODO(),
+				voucher: fixtures.NewVoucher(fixtures.Voucher),
+				tx:      nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(expectedArgs(in)...).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:     context.TODO(),
+				voucher: fixtures.NewVoucher(fixtures.Voucher),
+				tx:      &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewVoucherRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Create(tc.in.ctx, tc.in.voucher, tc.in.tx))
+		})
+	}
+}
+
+func TestVoucherRepository_GetByID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM vouchers WHERE id = ?", voucherAllColumnsStr)
+	rows := voucherAllAttributes
+	dummyVoucher := fixtures.NewVoucher(fixtures.Voucher)
+
+	type input struct {
+		ctx context.Context
+		id  string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.Voucher, error)
+	}{
+		{
+			name: "Success on GetByID",
+			in: input{
+				ctx: context.TODO(),
+				id:  "10",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetVoucherRow(dummyVoucher)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Voucher, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyVoucher, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  "10",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.Voucher, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorVoucherNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "10",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.Voucher, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewVoucherRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByID(tc.in.ctx, tc.in.id))
+		})
+	}
+}
+
+func TestVoucherRepository_ListByCodes(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM vouchers WHERE code IN (?, ?) ORDER BY id ASC", voucherAllColumnsStr)
+	rows := voucherAllAttributes
+	dummyVoucher := fixtures.NewVoucher(fixtures.Voucher)
+
+	type input struct {
+		ctx   context.Context
+		codes []string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.Voucher, error)
+	}{
+		{
+			name: "Success on Retrieve ListByCodes",
+			in: input{
+				ctx:   context.TODO(),
+				codes: []string{"SHOP10", "FREESHIP"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("SHOP10", "FREESHIP").
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetVoucherRow(dummyVoucher)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Voucher, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.Voucher{dummyVoucher}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:   context.TODO(),
+				codes: []string{"SHOP10", "FREESHIP"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetVoucherRow(dummyVoucher)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("SHOP10", "FREESHIP").
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Voucher, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:   context.TODO(),
+				codes: []string{"SHOP10", "FREESHIP"},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs("SHOP10", "FREESHIP").
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.Voucher, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewVoucherRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByCodes(tc.in.ctx, tc.in.codes))
+		})
+	}
+}
+
+func TestVoucherRepository_IncrementUsedCount(t *testing.T) {
+	expectedQuery := "UPDATE vouchers SET used_count = used_count + 1 WHERE id = ? AND (usage_limit = ? OR used_count < usage_limit)"
+
+	type input struct {
+		ctx context.Context
+		id  string
+		tx  util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on IncrementUsedCount",
+			in: input{
+				ctx: context.TODO(),
+				id:  "10",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id, 0).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Success on IncrementUsedCount with Usage Limit Reached",
+			in: input{
+				ctx: context.TODO(),
+				id:  "10",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id, 0).
+					WillReturnResult(sqlmock.NewResult(0, 0)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "10",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id, 0).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx: context.TODO(),
+				id:  "10",
+				tx:  &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewVoucherRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.IncrementUsedCount(tc.in.ctx, tc.in.id, tc.in.tx))
+		})
+	}
+}
+
+func TestVoucherRepository_DecrementUsedCount(t *testing.T) {
+	expectedQuery := "UPDATE vouchers SET used_count = used_count - 1 WHERE id = ? AND used_count > ?"
+
+	type input struct {
+		ctx context.Context
+		id  string
+		tx  util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on DecrementUsedCount",
+			in: input{
+				ctx: context.TODO(),
+				id:  "10",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id, 0).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "10",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id, 0).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx: context.TODO(),
+				id:  "10",
+				tx:  &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewVoucherRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.DecrementUsedCount(tc.in.ctx, tc.in.id, tc.in.tx))
+		})
+	}
+}
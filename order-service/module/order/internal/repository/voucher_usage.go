@@ -0,0 +1,152 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	voucherUsageTable = "voucher_usages"
+
+	voucherUsageInsertColumns = []string{"voucher_id", "user_id", "order_id"}
+	voucherUsageColumns       = []string{"id", "voucher_id", "user_id", "order_id", "created_at"}
+)
+
+type VoucherUsageRepository struct {
+	db *sqlx.DB
+}
+
+type voucherUsageObject struct {
+	ID        string    `db:"id"`
+	VoucherID string    `db:"voucher_id"`
+	UserID    string    `db:"user_id"`
+	OrderID   string    `db:"order_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (v *voucherUsageObject) toEntity() *entity.VoucherUsage {
+	return &entity.VoucherUsage{
+		ID:        v.ID,
+		VoucherID: v.VoucherID,
+		UserID:    v.UserID,
+		OrderID:   v.OrderID,
+		CreatedAt: v.CreatedAt,
+	}
+}
+
+func NewVoucherUsageRepository(db *sqlx.DB) *VoucherUsageRepository {
+	return &VoucherUsageRepository{db: db}
+}
+
+func (v *VoucherUsageRepository) Create(ctx context.Context, voucherUsage *entity.VoucherUsage, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(voucherUsageTable)
+	ib.Cols(voucherUsageInsertColumns...)
+	ib.Values(
+		voucherUsage.VoucherID,
+		voucherUsage.UserID,
+		voucherUsage.OrderID,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(v.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on voucherUsage.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on voucherUsage.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on voucherUsage.Create").Wrap(err)
+	}
+
+	voucherUsage.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+// CountByVoucherIDAndUserID count the usages of the voucher by the user with a locking read,
+// so the usage committed by a concurrent order is counted instead of the transaction snapshot
+func (v *VoucherUsageRepository) CountByVoucherIDAndUserID(ctx context.Context, voucherID, userID string, tx util.DatabaseTransaction) (int, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("COUNT(*)")
+	sb.From(voucherUsageTable)
+	sb.Where(
+		sb.Equal("voucher_id", voucherID),
+		sb.Equal("user_id", userID),
+	)
+	sb.ForUpdate()
+
+	query, args := sb.Build()
+
+	db, err := util.GetExecer(v.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on voucherUsage.CountByVoucherIDAndUserID").Wrap(err)
+	}
+
+	var count int
+	if err := db.QueryRowxContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, liberr.NewTracer("Error when Scan on voucherUsage.CountByVoucherIDAndUserID").Wrap(err)
+	}
+
+	return count, nil
+}
+
+func (v *VoucherUsageRepository) ListByOrderID(ctx context.Context, orderID string) ([]*entity.VoucherUsage, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(voucherUsageColumns...)
+	sb.From(voucherUsageTable)
+	sb.Where(sb.Equal("order_id", orderID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := v.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on voucherUsage.ListByOrderID").Wrap(err)
+	}
+
+	voucherUsages := []*entity.VoucherUsage{}
+	for rows.Next() {
+		var obj voucherUsageObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on voucherUsage.ListByOrderID").Wrap(err)
+		}
+
+		voucherUsages = append(voucherUsages, obj.toEntity())
+	}
+
+	return voucherUsages, nil
+}
+
+func (v *VoucherUsageRepository) DeleteByOrderID(ctx context.Context, orderID string, tx util.DatabaseTransaction) (int64, error) {
+	deb := sqlbuilder.NewDeleteBuilder()
+	deb.DeleteFrom(voucherUsageTable)
+	deb.Where(deb.E("order_id", orderID))
+	query, args := deb.Build()
+
+	db, err := util.GetExecer(v.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on voucherUsage.DeleteByOrderID").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on voucherUsage.DeleteByOrderID").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
@@ -0,0 +1,386 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	voucherUsageInsertAttributes = []string{
+		"voucher_id",
+		"user_id",
+		"order_id",
+	}
+	voucherUsageAllAttributes = []string{
+		"id",
+		"voucher_id",
+		"user_id",
+		"order_id",
+		"created_at",
+	}
+
+	voucherUsageInsertColumnsStr = strings.Join(voucherUsageInsertAttributes, ", ")
+	voucherUsageAllColumnsStr    = strings.Join(voucherUsageAllAttributes, ", ")
+)
+
+func TestVoucherUsageRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO voucher_usages (%s) VALUES (?, ?, ?)", voucherUsageInsertColumnsStr)
+
+	type input struct {
+		ctx          context.Context
+		voucherUsage *entity.VoucherUsage
+		tx           util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:          context.TODO(),
+				voucherUsage: fixtures.NewVoucherUsage(fixtures.VoucherUsage),
+				tx:           nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.voucherUsage.VoucherID, in.voucherUsage.UserID, in.voucherUsage.OrderID).
+					WillReturnResult(sqlmock.NewResult(14, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "14", in.voucherUsage.ID)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:          context.TODO(),
+				voucherUsage: fixtures.NewVoucherUsage(fixtures.VoucherUsage),
+				tx:           nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.voucherUsage.VoucherID, in.voucherUsage.UserID, in.voucherUsage.OrderID).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:          context.TODO(),
+				voucherUsage: fixtures.NewVoucherUsage(fixtures.VoucherUsage),
+				tx:           nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.voucherUsage.VoucherID, in.voucherUsage.UserID, in.voucherUsage.OrderID).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:          context.TODO(),
+				voucherUsage: fixtures.NewVoucherUsage(fixtures.VoucherUsage),
+				tx:           &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewVoucherUsageRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Create(tc.in.ctx, tc.in.voucherUsage, tc.in.tx))
+		})
+	}
+}
+
+func TestVoucherUsageRepository_CountByVoucherIDAndUserID(t *testing.T) {
+	expectedQuery := "SELECT COUNT(*) FROM voucher_usages WHERE voucher_id = ? AND user_id = ? FOR UPDATE"
+
+	type input struct {
+		ctx       context.Context
+		voucherID string
+		userID    string
+		tx        util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int, error)
+	}{
+		{
+			name: "Success on CountByVoucherIDAndUserID",
+			in: input{
+				ctx:       context.TODO(),
+				voucherID: "10",
+				userID:    "2",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.voucherID, in.userID).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1)).
+					RowsWillBeClosed()
+			},
+			assertFn: func(count int, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, 1, count)
+			},
+		},
+		{
+			name: "Error on Scan",
+			in: input{
+				ctx:       context.TODO(),
+				voucherID: "10",
+				userID:    "2",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.voucherID, in.userID).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(count int, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, 0, count)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:       context.TODO(),
+				voucherID: "10",
+				userID:    "2",
+				tx:        &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(count int, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, 0, count)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewVoucherUsageRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.CountByVoucherIDAndUserID(tc.in.ctx, tc.in.voucherID, tc.in.userID, tc.in.tx))
+		})
+	}
+}
+
+func TestVoucherUsageRepository_ListByOrderID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM voucher_usages WHERE order_id = ? ORDER BY id ASC", voucherUsageAllColumnsStr)
+	rows := voucherUsageAllAttributes
+	dummyVoucherUsage := fixtures.NewVoucherUsage(fixtures.VoucherUsage)
+
+	type input struct {
+		ctx     context.Context
+		orderID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.VoucherUsage, error)
+	}{
+		{
+			name: "Success on Retrieve ListByOrderID",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetVoucherUsageRow(dummyVoucherUsage)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.VoucherUsage, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.VoucherUsage{dummyVoucherUsage}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetVoucherUsageRow(dummyVoucherUsage)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.VoucherUsage, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.VoucherUsage, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewVoucherUsageRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByOrderID(tc.in.ctx, tc.in.orderID))
+		})
+	}
+}
+
+func TestVoucherUsageRepository_DeleteByOrderID(t *testing.T) {
+	expectedQuery := "DELETE FROM voucher_usages WHERE order_id = ?"
+
+	type input struct {
+		ctx     context.Context
+		orderID string
+		tx      util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on DeleteByOrderID",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.orderID).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:     context.TODO(),
+				orderID: "1",
+				tx:      &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewVoucherUsageRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.DeleteByOrderID(tc.in.ctx, tc.in.orderID, tc.in.tx))
+		})
+	}
+}
@@ -39,6 +39,8 @@ type warehouseStock struct {
 	WarehouseID string    `json:"warehouse_id"`
 	ProductID   string    `json:"product_id"`
 	Stock       int       `json:"stock"`
+	Reserved    int       `json:"reserved"`
+	Available   int       `json:"available"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -113,6 +115,8 @@ func (w *WarehouseRepository) ActiveStock(ctx context.Context, productIDs []stri
 				ShopID:        warehouseShopMap[ws.WarehouseID].ShopID,
 				ProductID:     ws.ProductID,
 				Stock:         ws.Stock,
+				Reserved:      ws.Reserved,
+				Available:     ws.Available,
 			})
 		}
 	}
@@ -164,3 +168,162 @@ func (w *WarehouseRepository) AdjustmentStock(ctx context.Context, params *entit
 
 	return nil
 }
+
+func (w *WarehouseRepository) ReserveStock(ctx context.Context, params *entity.WarehouseStockReservationParams) error {
+	path := w.Config.ApiHost + "/stock-reservations"
+
+	requestBody, _ := json.Marshal(params)
+
+	req, _ := rh.NewRequest("POST", path, bytes.NewBuffer(requestBody))
+	req.Header.Add("Authorization", w.basicAuth())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return liberr.NewTracer("Error when request on Warehouse.ReserveStock").Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return liberr.NewTracer("Error happened when read body response on Warehouse.ReserveStock").Wrap(err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return entity.ErrorProductStockNotFound
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return entity.ErrorProductConflicted
+	}
+
+	if resp.StatusCode == http.StatusBadRequest {
+		responseObj := entity.ErrorResponse{}
+		err = json.Unmarshal(responseBody, &responseObj)
+
+		if len(responseObj.Errors) > 0 {
+			if responseObj.Errors[0].ErrorCode == "WAREHOUSE-STOCK_ADJUSTMENT-OUT-OF-STOCK" {
+				return entity.ErrorProductOutOfStock
+			}
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return liberr.NewTracer(fmt.Sprintf("Error with http status %d on Warehouse.ReserveStock", resp.StatusCode)).Wrap(err)
+	}
+
+	return nil
+}
+
+func (w *WarehouseRepository) ReleaseStock(ctx context.Context, reservationID string, actor string) error {
+	path := w.Config.ApiHost + "/stock-reservations/" + url.PathEscape(reservationID) + "/release"
+
+	requestBody, _ := json.Marshal(map[string]string{"actor": actor})
+
+	req, _ := rh.NewRequest("POST", path, bytes.NewBuffer(requestBody))
+	req.Header.Add("Authorization", w.basicAuth())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return liberr.NewTracer("Error when request on Warehouse.ReleaseStock").Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return liberr.NewTracer("Error happened when read body response on Warehouse.ReleaseStock").Wrap(err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return entity.ErrorReservationNotFound
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return reservationConflictError(responseBody)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return liberr.NewTracer(fmt.Sprintf("Error with http status %d on Warehouse.ReleaseStock", resp.StatusCode)).Wrap(err)
+	}
+
+	return nil
+}
+
+func (w *WarehouseRepository) CommitStock(ctx context.Context, reservationID string, actor string) error {
+	path := w.Config.ApiHost + "/stock-reservations/" + url.PathEscape(reservationID) + "/commit"
+
+	requestBody, _ := json.Marshal(map[string]string{"actor": actor})
+
+	req, _ := rh.NewRequest("POST", path, bytes.NewBuffer(requestBody))
+	req.Header.Add("Authorization", w.basicAuth())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return liberr.NewTracer("Error when request on Warehouse.CommitStock").Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return liberr.NewTracer("Error happened when read body response on Warehouse.CommitStock").Wrap(err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return entity.ErrorReservationNotFound
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return reservationConflictError(responseBody)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return liberr.NewTracer(fmt.Sprintf("Error with http status %d on Warehouse.CommitStock", resp.StatusCode)).Wrap(err)
+	}
+
+	return nil
+}
+
+func (w *WarehouseRepository) ExtendStock(ctx context.Context, reservationID string, expiredAt time.Time) error {
+	path := w.Config.ApiHost + "/stock-reservations/" + url.PathEscape(reservationID) + "/extend"
+
+	requestBody, _ := json.Marshal(map[string]time.Time{"expired_at": expiredAt})
+
+	req, _ := rh.NewRequest("POST", path, bytes.NewBuffer(requestBody))
+	req.Header.Add("Authorization", w.basicAuth())
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return liberr.NewTracer("Error when request on Warehouse.ExtendStock").Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return liberr.NewTracer("Error happened when read body response on Warehouse.ExtendStock").Wrap(err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return entity.ErrorReservationNotFound
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return reservationConflictError(responseBody)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return liberr.NewTracer(fmt.Sprintf("Error with http status %d on Warehouse.ExtendStock", resp.StatusCode)).Wrap(err)
+	}
+
+	return nil
+}
+
+// reservationConflictError tell the reservation already committed or released from the other conflicts
+func reservationConflictError(responseBody []byte) error {
+	responseObj := entity.ErrorResponse{}
+	json.Unmarshal(responseBody, &responseObj) //nolint
+
+	if len(responseObj.Errors) > 0 && responseObj.Errors[0].ErrorCode == "STOCK-RESERVATION_INVALID-STATE" {
+		return entity.ErrorReservationInvalidState
+	}
+
+	return entity.ErrorProductConflicted
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+
+	rh "github.com/hashicorp/go-retryablehttp"
+)
+
+const (
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+	WebhookSignatureHeader = "X-Webhook-Signature"
+)
+
+// WebhookRepository send the deliveries to the endpoints of the shop subscriptions. The http client
+// retries the transient failures of a single attempt, the delivery itself is retried by the webhook relay
+type WebhookRepository struct {
+	httpClient *rh.Client
+}
+
+func NewWebhookRepository(httpClient *rh.Client) *WebhookRepository {
+	return &WebhookRepository{
+		httpClient: httpClient,
+	}
+}
+
+// Send post the payload of the delivery signed by the subscription secret, the delivery is successful
+// once the endpoint answers with 2xx. The response status is returned along with the error when it is known
+func (w *WebhookRepository) Send(ctx context.Context, subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery) (int, error) {
+	payload := []byte(delivery.Payload)
+
+	req, err := rh.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewBuffer(payload))
+	if err != nil {
+		return 0, liberr.NewTracer("Error when create request on Webhook.Send").Wrap(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(delivery.Event))
+	req.Header.Set(WebhookDeliveryHeader, delivery.ID)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(subscription.Secret, payload))
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when request on Webhook.Send").Wrap(err)
+	}
+	defer resp.Body.Close()
+
+	// The body is drained so the connection is reused, the content is never read
+	io.Copy(io.Discard, resp.Body) //nolint
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return resp.StatusCode, liberr.NewTracer(fmt.Sprintf("Error with http status %d on Webhook.Send", resp.StatusCode)).Wrap(errors.New(resp.Status))
+	}
+
+	return resp.StatusCode, nil
+}
+
+// SignWebhookPayload compute the hex encoded HMAC-SHA256 of the payload keyed by the subscription secret
+func SignWebhookPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,300 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libpagination"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	webhookDeliveryTable = "webhook_deliveries"
+
+	webhookDeliveryInsertColumns = []string{"subscription_id", "shop_id", "order_id", "event", "payload", "state", "attempt", "next_attempt_at"}
+	webhookDeliveryColumns       = []string{"id", "subscription_id", "shop_id", "order_id", "event", "payload", "state", "attempt", "next_attempt_at", "response_status", "last_error", "delivered_at", "created_at", "updated_at"}
+)
+
+type WebhookDeliveryRepository struct {
+	db *sqlx.DB
+}
+
+type webhookDeliveryObject struct {
+	ID             string         `db:"id"`
+	SubscriptionID string         `db:"subscription_id"`
+	ShopID         string         `db:"shop_id"`
+	OrderID        string         `db:"order_id"`
+	Event          string         `db:"event"`
+	Payload        string         `db:"payload"`
+	State          int            `db:"state"`
+	Attempt        int            `db:"attempt"`
+	NextAttemptAt  time.Time      `db:"next_attempt_at"`
+	ResponseStatus int            `db:"response_status"`
+	LastError      sql.NullString `db:"last_error"`
+	DeliveredAt    sql.NullTime   `db:"delivered_at"`
+	CreatedAt      time.Time      `db:"created_at"`
+	UpdatedAt      time.Time      `db:"updated_at"`
+}
+
+func (w *webhookDeliveryObject) toEntity() *entity.WebhookDelivery {
+	delivery := &entity.WebhookDelivery{
+		ID:             w.ID,
+		SubscriptionID: w.SubscriptionID,
+		ShopID:         w.ShopID,
+		OrderID:        w.OrderID,
+		Event:          entity.WebhookEvent(w.Event),
+		Payload:        w.Payload,
+		State:          entity.WebhookDeliveryState(w.State),
+		Attempt:        w.Attempt,
+		NextAttemptAt:  w.NextAttemptAt,
+		ResponseStatus: w.ResponseStatus,
+		LastError:      w.LastError.String,
+		CreatedAt:      w.CreatedAt,
+		UpdatedAt:      w.UpdatedAt,
+	}
+
+	if w.DeliveredAt.Valid {
+		delivery.DeliveredAt = &w.DeliveredAt.Time
+	}
+
+	return delivery
+}
+
+func NewWebhookDeliveryRepository(db *sqlx.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+func (w *WebhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(webhookDeliveryTable)
+	ib.Cols(webhookDeliveryInsertColumns...)
+	ib.Values(
+		delivery.SubscriptionID,
+		delivery.ShopID,
+		delivery.OrderID,
+		delivery.Event,
+		delivery.Payload,
+		entity.WebhookDeliveryStatePending,
+		0,
+		delivery.NextAttemptAt,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on webhookDelivery.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on webhookDelivery.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on webhookDelivery.Create").Wrap(err)
+	}
+
+	delivery.ID = fmt.Sprintf("%d", lastInsertedID)
+	delivery.State = entity.WebhookDeliveryStatePending
+	delivery.Attempt = 0
+	return nil
+}
+
+func (w *WebhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(webhookDeliveryColumns...)
+	sb.From(webhookDeliveryTable)
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+
+	row := w.db.QueryRowxContext(ctx, query, args...)
+	obj := &webhookDeliveryObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorWebhookDeliveryNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on webhookDelivery.GetByID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+func (w *WebhookDeliveryRepository) filterByParams(sb *sqlbuilder.SelectBuilder, params *entity.ListWebhookDeliveryByParams) *sqlbuilder.SelectBuilder {
+	sb.Where(sb.Equal("shop_id", params.ShopID))
+	if params.SubscriptionID != "" {
+		sb.Where(sb.Equal("subscription_id", params.SubscriptionID))
+	}
+	if params.OrderID != "" {
+		sb.Where(sb.Equal("order_id", params.OrderID))
+	}
+	if len(params.States) > 0 {
+		inArgs := make([]any, len(params.States))
+		for i, v := range params.States {
+			inArgs[i] = v
+		}
+		sb.Where(sb.In("state", inArgs...))
+	}
+
+	return sb
+}
+
+// ListByParams retrieve the delivery log of the shop, the latest delivery first
+func (w *WebhookDeliveryRepository) ListByParams(ctx context.Context, params *entity.ListWebhookDeliveryByParams) ([]*entity.WebhookDelivery, *libpagination.OffsetPagination, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(webhookDeliveryColumns...)
+	sb.From(webhookDeliveryTable)
+	sb.OrderBy("id").Desc()
+	sb.Limit(params.Limit)
+	sb.Offset(params.Offset)
+
+	query, args := w.filterByParams(sb, params).Build()
+
+	rows, err := w.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, liberr.NewTracer("Error when QueryxContext on webhookDelivery.ListByParams").Wrap(err)
+	}
+
+	deliveries := []*entity.WebhookDelivery{}
+	for rows.Next() {
+		var obj webhookDeliveryObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, nil, liberr.NewTracer("Error when StructScan on webhookDelivery.ListByParams").Wrap(err)
+		}
+
+		deliveries = append(deliveries, obj.toEntity())
+	}
+
+	cb := sqlbuilder.NewSelectBuilder()
+	cb.Select(cb.As("COUNT(id)", "total"))
+	cb.From(webhookDeliveryTable)
+
+	cQuery, cArgs := w.filterByParams(cb, params).Build()
+	row := w.db.QueryRowxContext(ctx, cQuery, cArgs...)
+
+	var total int
+	if err := row.Scan(&total); err != nil {
+		return nil, nil, liberr.NewTracer("Error when Scan on webhookDelivery.ListByParams").Wrap(err)
+	}
+
+	return deliveries, &libpagination.OffsetPagination{
+		Total:  total,
+		Offset: params.Offset,
+		Limit:  params.Limit,
+	}, nil
+}
+
+func (w *WebhookDeliveryRepository) ListPending(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(webhookDeliveryColumns...)
+	sb.From(webhookDeliveryTable)
+	sb.Where(
+		sb.Equal("state", entity.WebhookDeliveryStatePending),
+		sb.LTE("next_attempt_at", now),
+	)
+	sb.OrderBy("id").Asc()
+	sb.Limit(limit)
+
+	query, args := sb.Build()
+
+	rows, err := w.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on webhookDelivery.ListPending").Wrap(err)
+	}
+
+	deliveries := []*entity.WebhookDelivery{}
+	for rows.Next() {
+		var obj webhookDeliveryObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on webhookDelivery.ListPending").Wrap(err)
+		}
+
+		deliveries = append(deliveries, obj.toEntity())
+	}
+
+	return deliveries, nil
+}
+
+// Claim mark the delivery as taken by increasing the attempt and postponing the next attempt,
+// the update is guarded by the current attempt so only a single worker is able to send it
+func (w *WebhookDeliveryRepository) Claim(ctx context.Context, id string, attempt int, nextAttemptAt time.Time) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(webhookDeliveryTable).
+		Set(
+			ub.Assign("attempt", attempt+1),
+			ub.Assign("next_attempt_at", nextAttemptAt),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.WebhookDeliveryStatePending),
+			ub.E("attempt", attempt),
+		)
+	query, args := ub.Build()
+
+	row, err := w.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on webhookDelivery.Claim").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+func (w *WebhookDeliveryRepository) UpdateDelivered(ctx context.Context, id string, responseStatus int, deliveredAt time.Time) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(webhookDeliveryTable).
+		Set(
+			ub.Assign("state", entity.WebhookDeliveryStateDelivered),
+			ub.Assign("response_status", responseStatus),
+			ub.Assign("last_error", sql.NullString{}),
+			ub.Assign("delivered_at", deliveredAt),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.WebhookDeliveryStatePending),
+		)
+	query, args := ub.Build()
+
+	row, err := w.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on webhookDelivery.UpdateDelivered").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// UpdateAttemptError record the error of the attempt, the delivery is failed for good when it is the last attempt
+func (w *WebhookDeliveryRepository) UpdateAttemptError(ctx context.Context, id string, toState entity.WebhookDeliveryState, responseStatus int, lastError string) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(webhookDeliveryTable).
+		Set(
+			ub.Assign("state", toState),
+			ub.Assign("response_status", responseStatus),
+			ub.Assign("last_error", lastError),
+		).
+		Where(
+			ub.E("id", id),
+			ub.E("state", entity.WebhookDeliveryStatePending),
+		)
+	query, args := ub.Build()
+
+	row, err := w.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on webhookDelivery.UpdateAttemptError").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
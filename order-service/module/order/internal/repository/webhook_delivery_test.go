@@ -0,0 +1,778 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libpagination"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	webhookDeliveryInsertAttributes = []string{
+		"subscription_id",
+		"shop_id",
+		"order_id",
+		"event",
+		"payload",
+		"state",
+		"attempt",
+		"next_attempt_at",
+	}
+	webhookDeliveryAllAttributes = []string{
+		"id",
+		"subscription_id",
+		"shop_id",
+		"order_id",
+		"event",
+		"payload",
+		"state",
+		"attempt",
+		"next_attempt_at",
+		"response_status",
+		"last_error",
+		"delivered_at",
+		"created_at",
+		"updated_at",
+	}
+
+	webhookDeliveryInsertColumnsStr = strings.Join(webhookDeliveryInsertAttributes, ", ")
+	webhookDeliveryAllColumnsStr    = strings.Join(webhookDeliveryAllAttributes, ", ")
+)
+
+func TestWebhookDeliveryRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO webhook_deliveries (%s) VALUES (?, ?, ?, ?, ?, ?, ?, ?)", webhookDeliveryInsertColumnsStr)
+
+	type input struct {
+		ctx      context.Context
+		delivery *entity.WebhookDelivery
+		tx       util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:      context.TODO(),
+				delivery: fixtures.NewWebhookDelivery(fixtures.WebhookDelivery),
+				tx:       nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.delivery.SubscriptionID, in.delivery.ShopID, in.delivery.OrderID, in.delivery.Event, in.delivery.Payload, entity.WebhookDeliveryStatePending, 0, in.delivery.NextAttemptAt).
+					WillReturnResult(sqlmock.NewResult(17, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "17", in.delivery.ID)
+				assert.Equal(t, entity.WebhookDeliveryStatePending, in.delivery.State)
+				assert.Equal(t, 0, in.delivery.Attempt)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:      context.TODO(),
+				delivery: fixtures.NewWebhookDelivery(fixtures.WebhookDelivery),
+				tx:       nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.delivery.SubscriptionID, in.delivery.ShopID, in.delivery.OrderID, in.delivery.Event, in.delivery.Payload, entity.WebhookDeliveryStatePending, 0, in.delivery.NextAttemptAt).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:      context.TODO(),
+				delivery: fixtures.NewWebhookDelivery(fixtures.WebhookDelivery),
+				tx:       nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.delivery.SubscriptionID, in.delivery.ShopID, in.delivery.OrderID, in.delivery.Event, in.delivery.Payload, entity.WebhookDeliveryStatePending, 0, in.delivery.NextAttemptAt).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:      context.TODO(),
+				delivery: fixtures.NewWebhookDelivery(fixtures.WebhookDelivery),
+				tx:       &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookDeliveryRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Create(tc.in.ctx, tc.in.delivery, tc.in.tx))
+		})
+	}
+}
+
+func TestWebhookDeliveryRepository_GetByID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM webhook_deliveries WHERE id = ?", webhookDeliveryAllColumnsStr)
+	rows := webhookDeliveryAllAttributes
+	dummyDelivery := fixtures.NewWebhookDelivery(fixtures.WebhookDelivery)
+	deliveredAt := time.Date(2025, 1, 10, 11, 13, 0, 0, time.UTC)
+	dummyDeliveredDelivery := fixtures.NewWebhookDelivery(fixtures.WebhookDelivery)
+	dummyDeliveredDelivery.State = entity.WebhookDeliveryStateDelivered
+	dummyDeliveredDelivery.ResponseStatus = 200
+	dummyDeliveredDelivery.LastError = ""
+	dummyDeliveredDelivery.DeliveredAt = &deliveredAt
+
+	type input struct {
+		ctx context.Context
+		id  string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.WebhookDelivery, error)
+	}{
+		{
+			name: "Success on GetByID",
+			in: input{
+				ctx: context.TODO(),
+				id:  "17",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWebhookDeliveryRow(dummyDelivery)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.WebhookDelivery, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyDelivery, result)
+			},
+		},
+		{
+			name: "Success on GetByID with Delivered Delivery",
+			in: input{
+				ctx: context.TODO(),
+				id:  "17",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWebhookDeliveryRow(dummyDeliveredDelivery)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.WebhookDelivery, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyDeliveredDelivery, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  "17",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.WebhookDelivery, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorWebhookDeliveryNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "17",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.WebhookDelivery, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookDeliveryRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByID(tc.in.ctx, tc.in.id))
+		})
+	}
+}
+
+func TestWebhookDeliveryRepository_ListByParams(t *testing.T) {
+	columns := webhookDeliveryAllColumnsStr
+	rows := webhookDeliveryAllAttributes
+	dummyDelivery := fixtures.NewWebhookDelivery(fixtures.WebhookDelivery)
+
+	type input struct {
+		ctx    context.Context
+		params *entity.ListWebhookDeliveryByParams
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.WebhookDelivery, *libpagination.OffsetPagination, error)
+	}{
+		{
+			name: "Success on Retrieve List By Params",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.ListWebhookDeliveryByParams{
+					Offset:         5,
+					Limit:          20,
+					ShopID:         "3",
+					SubscriptionID: "16",
+					OrderID:        "1",
+					States:         []entity.WebhookDeliveryState{entity.WebhookDeliveryStatePending, entity.WebhookDeliveryStateFailed},
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM webhook_deliveries WHERE shop_id = ? AND subscription_id = ? AND order_id = ? AND state IN (?, ?) ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.ShopID, in.params.SubscriptionID, in.params.OrderID, entity.WebhookDeliveryStatePending, entity.WebhookDeliveryStateFailed, in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWebhookDeliveryRow(dummyDelivery)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM webhook_deliveries WHERE shop_id = ? AND subscription_id = ? AND order_id = ? AND state IN (?, ?)"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WithArgs(in.params.ShopID, in.params.SubscriptionID, in.params.OrderID, entity.WebhookDeliveryStatePending, entity.WebhookDeliveryStateFailed).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(100)).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WebhookDelivery, pagination *libpagination.OffsetPagination, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.WebhookDelivery{dummyDelivery}, result)
+				assert.Equal(t, &libpagination.OffsetPagination{
+					Offset: 5,
+					Limit:  20,
+					Total:  100,
+				}, pagination)
+			},
+		},
+		{
+			name: "Success on Retrieve List By Params With Shop Only",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.ListWebhookDeliveryByParams{
+					Limit:  20,
+					ShopID: "3",
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM webhook_deliveries WHERE shop_id = ? ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.ShopID, in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWebhookDeliveryRow(dummyDelivery)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM webhook_deliveries WHERE shop_id = ?"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WithArgs(in.params.ShopID).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(1)).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WebhookDelivery, pagination *libpagination.OffsetPagination, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.WebhookDelivery{dummyDelivery}, result)
+				assert.Equal(t, &libpagination.OffsetPagination{
+					Offset: 0,
+					Limit:  20,
+					Total:  1,
+				}, pagination)
+			},
+		},
+		{
+			name: "Error on Scan Count Query",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.ListWebhookDeliveryByParams{
+					Limit:  20,
+					ShopID: "3",
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM webhook_deliveries WHERE shop_id = ? ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.ShopID, in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWebhookDeliveryRow(dummyDelivery)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM webhook_deliveries WHERE shop_id = ?"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WithArgs(in.params.ShopID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WebhookDelivery, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.ListWebhookDeliveryByParams{
+					Limit:  20,
+					ShopID: "3",
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetWebhookDeliveryRow(dummyDelivery)
+				row[len(row)-1] = "invalid"
+
+				expectedQuery := fmt.Sprintf("SELECT %s FROM webhook_deliveries WHERE shop_id = ? ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.ShopID, in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WebhookDelivery, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.ListWebhookDeliveryByParams{
+					Limit:  20,
+					ShopID: "3",
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM webhook_deliveries WHERE shop_id = ? ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.ShopID, in.params.Limit, in.params.Offset).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WebhookDelivery, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookDeliveryRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByParams(tc.in.ctx, tc.in.params))
+		})
+	}
+}
+
+func TestWebhookDeliveryRepository_ListPending(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM webhook_deliveries WHERE state = ? AND next_attempt_at <= ? ORDER BY id ASC LIMIT ?", webhookDeliveryAllColumnsStr)
+	rows := webhookDeliveryAllAttributes
+	dummyDelivery := fixtures.NewWebhookDelivery(fixtures.WebhookDelivery)
+	now := time.Date(2025, 1, 10, 11, 13, 0, 0, time.UTC)
+
+	type input struct {
+		ctx   context.Context
+		now   time.Time
+		limit int
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.WebhookDelivery, error)
+	}{
+		{
+			name: "Success on ListPending",
+			in: input{
+				ctx:   context.TODO(),
+				now:   now,
+				limit: 10,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.WebhookDeliveryStatePending, in.now, in.limit).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWebhookDeliveryRow(dummyDelivery)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WebhookDelivery, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.WebhookDelivery{dummyDelivery}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:   context.TODO(),
+				now:   now,
+				limit: 10,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetWebhookDeliveryRow(dummyDelivery)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.WebhookDeliveryStatePending, in.now, in.limit).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WebhookDelivery, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:   context.TODO(),
+				now:   now,
+				limit: 10,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.WebhookDeliveryStatePending, in.now, in.limit).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result []*entity.WebhookDelivery, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookDeliveryRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListPending(tc.in.ctx, tc.in.now, tc.in.limit))
+		})
+	}
+}
+
+func TestWebhookDeliveryRepository_Claim(t *testing.T) {
+	expectedQuery := "UPDATE webhook_deliveries SET attempt = ?, next_attempt_at = ? WHERE id = ? AND state = ? AND attempt = ?"
+	nextAttemptAt := time.Date(2025, 1, 10, 11, 13, 0, 0, time.UTC)
+
+	type input struct {
+		ctx           context.Context
+		id            string
+		attempt       int
+		nextAttemptAt time.Time
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Claim",
+			in: input{
+				ctx:           context.TODO(),
+				id:            "17",
+				attempt:       1,
+				nextAttemptAt: nextAttemptAt,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(2, in.nextAttemptAt, in.id, entity.WebhookDeliveryStatePending, 1).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:           context.TODO(),
+				id:            "17",
+				attempt:       1,
+				nextAttemptAt: nextAttemptAt,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(2, in.nextAttemptAt, in.id, entity.WebhookDeliveryStatePending, 1).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookDeliveryRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.Claim(tc.in.ctx, tc.in.id, tc.in.attempt, tc.in.nextAttemptAt))
+		})
+	}
+}
+
+func TestWebhookDeliveryRepository_UpdateDelivered(t *testing.T) {
+	expectedQuery := "UPDATE webhook_deliveries SET state = ?, response_status = ?, last_error = ?, delivered_at = ? WHERE id = ? AND state = ?"
+	deliveredAt := time.Date(2025, 1, 10, 11, 13, 0, 0, time.UTC)
+
+	type input struct {
+		ctx            context.Context
+		id             string
+		responseStatus int
+		deliveredAt    time.Time
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on UpdateDelivered",
+			in: input{
+				ctx:            context.TODO(),
+				id:             "17",
+				responseStatus: 200,
+				deliveredAt:    deliveredAt,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.WebhookDeliveryStateDelivered, in.responseStatus, sql.NullString{}, in.deliveredAt, in.id, entity.WebhookDeliveryStatePending).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:            context.TODO(),
+				id:             "17",
+				responseStatus: 200,
+				deliveredAt:    deliveredAt,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.WebhookDeliveryStateDelivered, in.responseStatus, sql.NullString{}, in.deliveredAt, in.id, entity.WebhookDeliveryStatePending).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookDeliveryRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateDelivered(tc.in.ctx, tc.in.id, tc.in.responseStatus, tc.in.deliveredAt))
+		})
+	}
+}
+
+func TestWebhookDeliveryRepository_UpdateAttemptError(t *testing.T) {
+	expectedQuery := "UPDATE webhook_deliveries SET state = ?, response_status = ?, last_error = ? WHERE id = ? AND state = ?"
+
+	type input struct {
+		ctx            context.Context
+		id             string
+		toState        entity.WebhookDeliveryState
+		responseStatus int
+		lastError      string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on UpdateAttemptError",
+			in: input{
+				ctx:            context.TODO(),
+				id:             "17",
+				toState:        entity.WebhookDeliveryStateFailed,
+				responseStatus: 503,
+				lastError:      "Error with http status 503 on Webhook.Send",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, in.responseStatus, in.lastError, in.id, entity.WebhookDeliveryStatePending).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:            context.TODO(),
+				id:             "17",
+				toState:        entity.WebhookDeliveryStatePending,
+				responseStatus: 503,
+				lastError:      "Error with http status 503 on Webhook.Send",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, in.responseStatus, in.lastError, in.id, entity.WebhookDeliveryStatePending).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookDeliveryRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateAttemptError(tc.in.ctx, tc.in.id, tc.in.toState, tc.in.responseStatus, tc.in.lastError))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"strings"
+	"time"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	webhookSubscriptionTable = "webhook_subscriptions"
+
+	webhookSubscriptionInsertColumns = []string{"shop_id", "url", "secret", "events", "active"}
+	webhookSubscriptionColumns       = []string{"id", "shop_id", "url", "secret", "events", "active", "created_at", "updated_at"}
+)
+
+type WebhookSubscriptionRepository struct {
+	db *sqlx.DB
+}
+
+type webhookSubscriptionObject struct {
+	ID        string    `db:"id"`
+	ShopID    string    `db:"shop_id"`
+	URL       string    `db:"url"`
+	Secret    string    `db:"secret"`
+	Events    string    `db:"events"`
+	Active    bool      `db:"active"`
+	CreatedAt time.Time `db:"created_at"`
+	UpdatedAt time.Time `db:"updated_at"`
+}
+
+func (w *webhookSubscriptionObject) toEntity() *entity.WebhookSubscription {
+	events := []entity.WebhookEvent{}
+	for _, e := range strings.Split(w.Events, ",") {
+		if e != "" {
+			events = append(events, entity.WebhookEvent(e))
+		}
+	}
+
+	return &entity.WebhookSubscription{
+		ID:        w.ID,
+		ShopID:    w.ShopID,
+		URL:       w.URL,
+		Secret:    w.Secret,
+		Events:    events,
+		Active:    w.Active,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: w.UpdatedAt,
+	}
+}
+
+func NewWebhookSubscriptionRepository(db *sqlx.DB) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{db: db}
+}
+
+func (w *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription, tx util.DatabaseTransaction) error {
+	events := make([]string, len(subscription.Events))
+	for i, e := range subscription.Events {
+		events[i] = string(e)
+	}
+
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(webhookSubscriptionTable)
+	ib.Cols(webhookSubscriptionInsertColumns...)
+	ib.Values(
+		subscription.ShopID,
+		subscription.URL,
+		subscription.Secret,
+		strings.Join(events, ","),
+		subscription.Active,
+	)
+
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on webhookSubscription.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on webhookSubscription.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on webhookSubscription.Create").Wrap(err)
+	}
+
+	subscription.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+func (w *WebhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(webhookSubscriptionColumns...)
+	sb.From(webhookSubscriptionTable)
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+
+	row := w.db.QueryRowxContext(ctx, query, args...)
+	obj := &webhookSubscriptionObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorWebhookNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on webhookSubscription.GetByID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+func (w *WebhookSubscriptionRepository) ListByShopID(ctx context.Context, shopID string) ([]*entity.WebhookSubscription, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(webhookSubscriptionColumns...)
+	sb.From(webhookSubscriptionTable)
+	sb.Where(sb.Equal("shop_id", shopID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := w.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on webhookSubscription.ListByShopID").Wrap(err)
+	}
+
+	subscriptions := []*entity.WebhookSubscription{}
+	for rows.Next() {
+		var obj webhookSubscriptionObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on webhookSubscription.ListByShopID").Wrap(err)
+		}
+
+		subscriptions = append(subscriptions, obj.toEntity())
+	}
+
+	return subscriptions, nil
+}
+
+// UpdateActive enable or disable the subscription, the deliveries of the disabled subscription are kept as the log
+func (w *WebhookSubscriptionRepository) UpdateActive(ctx context.Context, id string, active bool) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(webhookSubscriptionTable).
+		Set(
+			ub.Assign("active", active),
+		).
+		Where(
+			ub.E("id", id),
+		)
+	query, args := ub.Build()
+
+	row, err := w.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on webhookSubscription.UpdateActive").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
@@ -0,0 +1,392 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"order-service/internal/testutil"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"order-service/module/order/internal/repository"
+	"order-service/module/order/testutil/fixtures"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	webhookSubscriptionInsertAttributes = []string{
+		"shop_id",
+		"url",
+		"secret",
+		"events",
+		"active",
+	}
+	webhookSubscriptionAllAttributes = []string{
+		"id",
+		"shop_id",
+		"url",
+		"secret",
+		"events",
+		"active",
+		"created_at",
+		"updated_at",
+	}
+
+	webhookSubscriptionInsertColumnsStr = strings.Join(webhookSubscriptionInsertAttributes, ", ")
+	webhookSubscriptionAllColumnsStr    = strings.Join(webhookSubscriptionAllAttributes, ", ")
+)
+
+func TestWebhookSubscriptionRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO webhook_subscriptions (%s) VALUES (?, ?, ?, ?, ?)", webhookSubscriptionInsertColumnsStr)
+
+	type input struct {
+		ctx          context.Context
+		subscription *entity.WebhookSubscription
+		tx           util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:          context.TODO(),
+				subscription: fixtures.NewWebhookSubscription(fixtures.WebhookSubscription),
+				tx:           nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.subscription.ShopID, in.subscription.URL, in.subscription.Secret, "order.created,order.expired", in.subscription.Active).
+					WillReturnResult(sqlmock.NewResult(16, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "16", in.subscription.ID)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:          context.TODO(),
+				subscription: fixtures.NewWebhookSubscription(fixtures.WebhookSubscription),
+				tx:           nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.subscription.ShopID, in.subscription.URL, in.subscription.Secret, "order.created,order.expired", in.subscription.Active).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:          context.TODO(),
+				subscription: fixtures.NewWebhookSubscription(fixtures.WebhookSubscription),
+				tx:           nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.subscription.ShopID, in.subscription.URL, in.subscription.Secret, "order.created,order.expired", in.subscription.Active).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:          context.TODO(),
+				subscription: fixtures.NewWebhookSubscription(fixtures.WebhookSubscription),
+				tx:           &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookSubscriptionRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in, repo.Create(tc.in.ctx, tc.in.subscription, tc.in.tx))
+		})
+	}
+}
+
+func TestWebhookSubscriptionRepository_GetByID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM webhook_subscriptions WHERE id = ?", webhookSubscriptionAllColumnsStr)
+	rows := webhookSubscriptionAllAttributes
+	dummySubscription := fixtures.NewWebhookSubscription(fixtures.WebhookSubscription)
+
+	type input struct {
+		ctx context.Context
+		id  string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.WebhookSubscription, error)
+	}{
+		{
+			name: "Success on GetByID",
+			in: input{
+				ctx: context.TODO(),
+				id:  "16",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWebhookSubscriptionRow(dummySubscription)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.WebhookSubscription, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummySubscription, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  "16",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.WebhookSubscription, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorWebhookNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  "16",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.WebhookSubscription, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookSubscriptionRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByID(tc.in.ctx, tc.in.id))
+		})
+	}
+}
+
+func TestWebhookSubscriptionRepository_ListByShopID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM webhook_subscriptions WHERE shop_id = ? ORDER BY id ASC", webhookSubscriptionAllColumnsStr)
+	rows := webhookSubscriptionAllAttributes
+	dummySubscription := fixtures.NewWebhookSubscription(fixtures.WebhookSubscription)
+
+	type input struct {
+		ctx    context.Context
+		shopID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.WebhookSubscription, error)
+	}{
+		{
+			name: "Success on ListByShopID",
+			in: input{
+				ctx:    context.TODO(),
+				shopID: "3",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.shopID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWebhookSubscriptionRow(dummySubscription)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WebhookSubscription, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.WebhookSubscription{dummySubscription}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:    context.TODO(),
+				shopID: "3",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetWebhookSubscriptionRow(dummySubscription)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.shopID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WebhookSubscription, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:    context.TODO(),
+				shopID: "3",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.shopID).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WebhookSubscription, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookSubscriptionRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByShopID(tc.in.ctx, tc.in.shopID))
+		})
+	}
+}
+
+func TestWebhookSubscriptionRepository_UpdateActive(t *testing.T) {
+	expectedQuery := "UPDATE webhook_subscriptions SET active = ? WHERE id = ?"
+
+	type input struct {
+		ctx    context.Context
+		id     string
+		active bool
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on UpdateActive",
+			in: input{
+				ctx:    context.TODO(),
+				id:     "16",
+				active: false,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.active, in.id).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(affected int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), affected)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:    context.TODO(),
+				id:     "16",
+				active: false,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.active, in.id).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(affected int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), affected)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWebhookSubscriptionRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateActive(tc.in.ctx, tc.in.id, tc.in.active))
+		})
+	}
+}
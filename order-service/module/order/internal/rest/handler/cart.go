@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/librest"
+	"order-service/module/order/entity"
+
+	"github.com/gorilla/mux"
+)
+
+func (o *OrderHandler) GetCart(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	cart, err := o.orderUsecase.GetCart(r.Context(), &entity.GetCartRequest{User: user})
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetCartResponse{
+		Cart: cart,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) AddCartItem(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := new(entity.AddCartItemRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.User = user
+
+	cartItem, err := o.orderUsecase.AddCartItem(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.CartItemResponse{
+		Message:  "Success add cart item",
+		CartItem: cartItem,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) UpdateCartItem(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := new(entity.UpdateCartItemRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.CartItemID = mux.Vars(r)["id"]
+	params.User = user
+
+	cartItem, err := o.orderUsecase.UpdateCartItem(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.CartItemResponse{
+		Message:  "Success update cart item",
+		CartItem: cartItem,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) RemoveCartItem(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := &entity.RemoveCartItemRequest{
+		CartItemID: mux.Vars(r)["id"],
+		User:       user,
+	}
+
+	err = o.orderUsecase.RemoveCartItem(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetMessageResponse{
+		Message: "Success remove cart item",
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) CheckoutCart(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := new(entity.CheckoutCartRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.User = user
+	params.IdempotencyKey = r.Header.Get("Idempotency-Key")
+
+	order, err := o.orderUsecase.CheckoutCart(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusCreated
+	librest.WriteHTTPResponse(w, entity.CreateOrderResponse{
+		Message: "Success create order",
+		Order:   order,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/librest"
+	"order-service/module/order/entity"
+
+	"github.com/gorilla/mux"
+)
+
+func (o *OrderHandler) CreateCheckout(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := new(entity.CreateCheckoutRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.User = user
+
+	checkout, err := o.orderUsecase.CreateCheckout(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusCreated
+	librest.WriteHTTPResponse(w, entity.CreateCheckoutResponse{
+		Message:  "Success create checkout",
+		Checkout: checkout,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) GetCheckout(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := &entity.GetCheckoutRequest{
+		CheckoutID: mux.Vars(r)["id"],
+		User:       user,
+	}
+
+	checkout, err := o.orderUsecase.GetCheckout(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetCheckoutResponse{
+		Checkout: checkout,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) CreateCheckoutQuote(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := new(entity.CheckoutQuoteRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.User = user
+
+	quote, err := o.orderUsecase.CreateCheckoutQuote(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.CheckoutQuoteResponse{
+		Quote: quote,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
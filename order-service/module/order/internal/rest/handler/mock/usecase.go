@@ -0,0 +1,619 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: usecase.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	libpagination "order-service/internal/util/libpagination"
+	entity "order-service/module/order/entity"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockOrderUsecase is a mock of OrderUsecase interface.
+type MockOrderUsecase struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderUsecaseMockRecorder
+}
+
+// MockOrderUsecaseMockRecorder is the mock recorder for MockOrderUsecase.
+type MockOrderUsecaseMockRecorder struct {
+	mock *MockOrderUsecase
+}
+
+// NewMockOrderUsecase creates a new mock instance.
+func NewMockOrderUsecase(ctrl *gomock.Controller) *MockOrderUsecase {
+	mock := &MockOrderUsecase{ctrl: ctrl}
+	mock.recorder = &MockOrderUsecaseMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderUsecase) EXPECT() *MockOrderUsecaseMockRecorder {
+	return m.recorder
+}
+
+// AddCartItem mocks base method.
+func (m *MockOrderUsecase) AddCartItem(ctx context.Context, params *entity.AddCartItemRequest) (*entity.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCartItem", ctx, params)
+	ret0, _ := ret[0].(*entity.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCartItem indicates an expected call of AddCartItem.
+func (mr *MockOrderUsecaseMockRecorder) AddCartItem(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCartItem", reflect.TypeOf((*MockOrderUsecase)(nil).AddCartItem), ctx, params)
+}
+
+// ApproveOrderReturn mocks base method.
+func (m *MockOrderUsecase) ApproveOrderReturn(ctx context.Context, params *entity.ApproveOrderReturnRequest) (*entity.OrderReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveOrderReturn", ctx, params)
+	ret0, _ := ret[0].(*entity.OrderReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApproveOrderReturn indicates an expected call of ApproveOrderReturn.
+func (mr *MockOrderUsecaseMockRecorder) ApproveOrderReturn(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveOrderReturn", reflect.TypeOf((*MockOrderUsecase)(nil).ApproveOrderReturn), ctx, params)
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrderUsecase) CancelOrder(ctx context.Context, params *entity.CancelOrderRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderUsecaseMockRecorder) CancelOrder(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderUsecase)(nil).CancelOrder), ctx, params)
+}
+
+// CheckoutCart mocks base method.
+func (m *MockOrderUsecase) CheckoutCart(ctx context.Context, params *entity.CheckoutCartRequest) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckoutCart", ctx, params)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckoutCart indicates an expected call of CheckoutCart.
+func (mr *MockOrderUsecaseMockRecorder) CheckoutCart(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckoutCart", reflect.TypeOf((*MockOrderUsecase)(nil).CheckoutCart), ctx, params)
+}
+
+// CompleteOrder mocks base method.
+func (m *MockOrderUsecase) CompleteOrder(ctx context.Context, params *entity.CompleteOrderRequest) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteOrder", ctx, params)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompleteOrder indicates an expected call of CompleteOrder.
+func (mr *MockOrderUsecaseMockRecorder) CompleteOrder(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteOrder", reflect.TypeOf((*MockOrderUsecase)(nil).CompleteOrder), ctx, params)
+}
+
+// CreateCheckout mocks base method.
+func (m *MockOrderUsecase) CreateCheckout(ctx context.Context, params *entity.CreateCheckoutRequest) (*entity.Checkout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCheckout", ctx, params)
+	ret0, _ := ret[0].(*entity.Checkout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCheckout indicates an expected call of CreateCheckout.
+func (mr *MockOrderUsecaseMockRecorder) CreateCheckout(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckout", reflect.TypeOf((*MockOrderUsecase)(nil).CreateCheckout), ctx, params)
+}
+
+// CreateCheckoutQuote mocks base method.
+func (m *MockOrderUsecase) CreateCheckoutQuote(ctx context.Context, params *entity.CheckoutQuoteRequest) (*entity.CheckoutQuote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCheckoutQuote", ctx, params)
+	ret0, _ := ret[0].(*entity.CheckoutQuote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCheckoutQuote indicates an expected call of CreateCheckoutQuote.
+func (mr *MockOrderUsecaseMockRecorder) CreateCheckoutQuote(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCheckoutQuote", reflect.TypeOf((*MockOrderUsecase)(nil).CreateCheckoutQuote), ctx, params)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderUsecase) CreateOrder(ctx context.Context, params *entity.CreateOrderRequest) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, params)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderUsecaseMockRecorder) CreateOrder(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderUsecase)(nil).CreateOrder), ctx, params)
+}
+
+// CreateOrderReturn mocks base method.
+func (m *MockOrderUsecase) CreateOrderReturn(ctx context.Context, params *entity.CreateOrderReturnRequest) (*entity.OrderReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrderReturn", ctx, params)
+	ret0, _ := ret[0].(*entity.OrderReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrderReturn indicates an expected call of CreateOrderReturn.
+func (mr *MockOrderUsecaseMockRecorder) CreateOrderReturn(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrderReturn", reflect.TypeOf((*MockOrderUsecase)(nil).CreateOrderReturn), ctx, params)
+}
+
+// CreatePayment mocks base method.
+func (m *MockOrderUsecase) CreatePayment(ctx context.Context, params *entity.CreatePaymentRequest) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePayment", ctx, params)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePayment indicates an expected call of CreatePayment.
+func (mr *MockOrderUsecaseMockRecorder) CreatePayment(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePayment", reflect.TypeOf((*MockOrderUsecase)(nil).CreatePayment), ctx, params)
+}
+
+// CreateVoucher mocks base method.
+func (m *MockOrderUsecase) CreateVoucher(ctx context.Context, params *entity.CreateVoucherRequest) (*entity.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVoucher", ctx, params)
+	ret0, _ := ret[0].(*entity.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVoucher indicates an expected call of CreateVoucher.
+func (mr *MockOrderUsecaseMockRecorder) CreateVoucher(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVoucher", reflect.TypeOf((*MockOrderUsecase)(nil).CreateVoucher), ctx, params)
+}
+
+// CreateWebhookSubscription mocks base method.
+func (m *MockOrderUsecase) CreateWebhookSubscription(ctx context.Context, params *entity.CreateWebhookSubscriptionRequest) (*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", ctx, params)
+	ret0, _ := ret[0].(*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockOrderUsecaseMockRecorder) CreateWebhookSubscription(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockOrderUsecase)(nil).CreateWebhookSubscription), ctx, params)
+}
+
+// DeleteOrderExpirationPolicy mocks base method.
+func (m *MockOrderUsecase) DeleteOrderExpirationPolicy(ctx context.Context, params *entity.DeleteOrderExpirationPolicyRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrderExpirationPolicy", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrderExpirationPolicy indicates an expected call of DeleteOrderExpirationPolicy.
+func (mr *MockOrderUsecaseMockRecorder) DeleteOrderExpirationPolicy(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrderExpirationPolicy", reflect.TypeOf((*MockOrderUsecase)(nil).DeleteOrderExpirationPolicy), ctx, params)
+}
+
+// DeleteWebhookSubscription mocks base method.
+func (m *MockOrderUsecase) DeleteWebhookSubscription(ctx context.Context, params *entity.DeleteWebhookSubscriptionRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookSubscription", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhookSubscription indicates an expected call of DeleteWebhookSubscription.
+func (mr *MockOrderUsecaseMockRecorder) DeleteWebhookSubscription(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookSubscription", reflect.TypeOf((*MockOrderUsecase)(nil).DeleteWebhookSubscription), ctx, params)
+}
+
+// GetCart mocks base method.
+func (m *MockOrderUsecase) GetCart(ctx context.Context, params *entity.GetCartRequest) (*entity.Cart, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCart", ctx, params)
+	ret0, _ := ret[0].(*entity.Cart)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCart indicates an expected call of GetCart.
+func (mr *MockOrderUsecaseMockRecorder) GetCart(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCart", reflect.TypeOf((*MockOrderUsecase)(nil).GetCart), ctx, params)
+}
+
+// GetCheckout mocks base method.
+func (m *MockOrderUsecase) GetCheckout(ctx context.Context, params *entity.GetCheckoutRequest) (*entity.Checkout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCheckout", ctx, params)
+	ret0, _ := ret[0].(*entity.Checkout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCheckout indicates an expected call of GetCheckout.
+func (mr *MockOrderUsecaseMockRecorder) GetCheckout(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCheckout", reflect.TypeOf((*MockOrderUsecase)(nil).GetCheckout), ctx, params)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderUsecase) GetOrder(ctx context.Context, params *entity.GetOrderRequest) (*entity.OrderInformation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, params)
+	ret0, _ := ret[0].(*entity.OrderInformation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderUsecaseMockRecorder) GetOrder(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderUsecase)(nil).GetOrder), ctx, params)
+}
+
+// GetShopConfig mocks base method.
+func (m *MockOrderUsecase) GetShopConfig(ctx context.Context, params *entity.GetShopConfigRequest) (*entity.ShopConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShopConfig", ctx, params)
+	ret0, _ := ret[0].(*entity.ShopConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShopConfig indicates an expected call of GetShopConfig.
+func (mr *MockOrderUsecaseMockRecorder) GetShopConfig(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShopConfig", reflect.TypeOf((*MockOrderUsecase)(nil).GetShopConfig), ctx, params)
+}
+
+// GetShopOrder mocks base method.
+func (m *MockOrderUsecase) GetShopOrder(ctx context.Context, params *entity.GetShopOrderRequest) (*entity.OrderInformation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetShopOrder", ctx, params)
+	ret0, _ := ret[0].(*entity.OrderInformation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetShopOrder indicates an expected call of GetShopOrder.
+func (mr *MockOrderUsecaseMockRecorder) GetShopOrder(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetShopOrder", reflect.TypeOf((*MockOrderUsecase)(nil).GetShopOrder), ctx, params)
+}
+
+// GetVoucher mocks base method.
+func (m *MockOrderUsecase) GetVoucher(ctx context.Context, params *entity.GetVoucherRequest) (*entity.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVoucher", ctx, params)
+	ret0, _ := ret[0].(*entity.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVoucher indicates an expected call of GetVoucher.
+func (mr *MockOrderUsecaseMockRecorder) GetVoucher(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVoucher", reflect.TypeOf((*MockOrderUsecase)(nil).GetVoucher), ctx, params)
+}
+
+// HandlePaymentCallback mocks base method.
+func (m *MockOrderUsecase) HandlePaymentCallback(ctx context.Context, params *entity.PaymentCallbackRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandlePaymentCallback", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandlePaymentCallback indicates an expected call of HandlePaymentCallback.
+func (mr *MockOrderUsecaseMockRecorder) HandlePaymentCallback(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandlePaymentCallback", reflect.TypeOf((*MockOrderUsecase)(nil).HandlePaymentCallback), ctx, params)
+}
+
+// ListOrder mocks base method.
+func (m *MockOrderUsecase) ListOrder(ctx context.Context, params *entity.ListOrderByParams) ([]*entity.Order, *libpagination.OffsetPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrder", ctx, params)
+	ret0, _ := ret[0].([]*entity.Order)
+	ret1, _ := ret[1].(*libpagination.OffsetPagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListOrder indicates an expected call of ListOrder.
+func (mr *MockOrderUsecaseMockRecorder) ListOrder(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrder", reflect.TypeOf((*MockOrderUsecase)(nil).ListOrder), ctx, params)
+}
+
+// ListOrderExpirationPolicy mocks base method.
+func (m *MockOrderUsecase) ListOrderExpirationPolicy(ctx context.Context) ([]*entity.OrderExpirationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderExpirationPolicy", ctx)
+	ret0, _ := ret[0].([]*entity.OrderExpirationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderExpirationPolicy indicates an expected call of ListOrderExpirationPolicy.
+func (mr *MockOrderUsecaseMockRecorder) ListOrderExpirationPolicy(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderExpirationPolicy", reflect.TypeOf((*MockOrderUsecase)(nil).ListOrderExpirationPolicy), ctx)
+}
+
+// ListOrderReturn mocks base method.
+func (m *MockOrderUsecase) ListOrderReturn(ctx context.Context, params *entity.ListOrderReturnRequest) ([]*entity.OrderReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrderReturn", ctx, params)
+	ret0, _ := ret[0].([]*entity.OrderReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrderReturn indicates an expected call of ListOrderReturn.
+func (mr *MockOrderUsecaseMockRecorder) ListOrderReturn(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrderReturn", reflect.TypeOf((*MockOrderUsecase)(nil).ListOrderReturn), ctx, params)
+}
+
+// ListShopOrder mocks base method.
+func (m *MockOrderUsecase) ListShopOrder(ctx context.Context, params *entity.ListShopOrderRequest) ([]*entity.Order, *libpagination.OffsetPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListShopOrder", ctx, params)
+	ret0, _ := ret[0].([]*entity.Order)
+	ret1, _ := ret[1].(*libpagination.OffsetPagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListShopOrder indicates an expected call of ListShopOrder.
+func (mr *MockOrderUsecaseMockRecorder) ListShopOrder(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListShopOrder", reflect.TypeOf((*MockOrderUsecase)(nil).ListShopOrder), ctx, params)
+}
+
+// ListTaxRate mocks base method.
+func (m *MockOrderUsecase) ListTaxRate(ctx context.Context) ([]*entity.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaxRate", ctx)
+	ret0, _ := ret[0].([]*entity.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaxRate indicates an expected call of ListTaxRate.
+func (mr *MockOrderUsecaseMockRecorder) ListTaxRate(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaxRate", reflect.TypeOf((*MockOrderUsecase)(nil).ListTaxRate), ctx)
+}
+
+// ListWebhookDelivery mocks base method.
+func (m *MockOrderUsecase) ListWebhookDelivery(ctx context.Context, params *entity.ListWebhookDeliveryByParams) ([]*entity.WebhookDelivery, *libpagination.OffsetPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookDelivery", ctx, params)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(*libpagination.OffsetPagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListWebhookDelivery indicates an expected call of ListWebhookDelivery.
+func (mr *MockOrderUsecaseMockRecorder) ListWebhookDelivery(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookDelivery", reflect.TypeOf((*MockOrderUsecase)(nil).ListWebhookDelivery), ctx, params)
+}
+
+// ListWebhookSubscription mocks base method.
+func (m *MockOrderUsecase) ListWebhookSubscription(ctx context.Context, params *entity.ListWebhookSubscriptionRequest) ([]*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListWebhookSubscription", ctx, params)
+	ret0, _ := ret[0].([]*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListWebhookSubscription indicates an expected call of ListWebhookSubscription.
+func (mr *MockOrderUsecaseMockRecorder) ListWebhookSubscription(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListWebhookSubscription", reflect.TypeOf((*MockOrderUsecase)(nil).ListWebhookSubscription), ctx, params)
+}
+
+// ProcessShopOrder mocks base method.
+func (m *MockOrderUsecase) ProcessShopOrder(ctx context.Context, params *entity.ShopOrderActionRequest) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ProcessShopOrder", ctx, params)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ProcessShopOrder indicates an expected call of ProcessShopOrder.
+func (mr *MockOrderUsecaseMockRecorder) ProcessShopOrder(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessShopOrder", reflect.TypeOf((*MockOrderUsecase)(nil).ProcessShopOrder), ctx, params)
+}
+
+// RedeliverWebhook mocks base method.
+func (m *MockOrderUsecase) RedeliverWebhook(ctx context.Context, params *entity.RedeliverWebhookRequest) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverWebhook", ctx, params)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedeliverWebhook indicates an expected call of RedeliverWebhook.
+func (mr *MockOrderUsecaseMockRecorder) RedeliverWebhook(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverWebhook", reflect.TypeOf((*MockOrderUsecase)(nil).RedeliverWebhook), ctx, params)
+}
+
+// RejectOrderReturn mocks base method.
+func (m *MockOrderUsecase) RejectOrderReturn(ctx context.Context, params *entity.RejectOrderReturnRequest) (*entity.OrderReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectOrderReturn", ctx, params)
+	ret0, _ := ret[0].(*entity.OrderReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectOrderReturn indicates an expected call of RejectOrderReturn.
+func (mr *MockOrderUsecaseMockRecorder) RejectOrderReturn(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectOrderReturn", reflect.TypeOf((*MockOrderUsecase)(nil).RejectOrderReturn), ctx, params)
+}
+
+// RejectShopOrder mocks base method.
+func (m *MockOrderUsecase) RejectShopOrder(ctx context.Context, params *entity.RejectShopOrderRequest) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RejectShopOrder", ctx, params)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RejectShopOrder indicates an expected call of RejectShopOrder.
+func (mr *MockOrderUsecaseMockRecorder) RejectShopOrder(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RejectShopOrder", reflect.TypeOf((*MockOrderUsecase)(nil).RejectShopOrder), ctx, params)
+}
+
+// RemoveCartItem mocks base method.
+func (m *MockOrderUsecase) RemoveCartItem(ctx context.Context, params *entity.RemoveCartItemRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveCartItem", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveCartItem indicates an expected call of RemoveCartItem.
+func (mr *MockOrderUsecaseMockRecorder) RemoveCartItem(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCartItem", reflect.TypeOf((*MockOrderUsecase)(nil).RemoveCartItem), ctx, params)
+}
+
+// ShipShopOrder mocks base method.
+func (m *MockOrderUsecase) ShipShopOrder(ctx context.Context, params *entity.ShopOrderActionRequest) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ShipShopOrder", ctx, params)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ShipShopOrder indicates an expected call of ShipShopOrder.
+func (mr *MockOrderUsecaseMockRecorder) ShipShopOrder(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ShipShopOrder", reflect.TypeOf((*MockOrderUsecase)(nil).ShipShopOrder), ctx, params)
+}
+
+// StreamSalesReport mocks base method.
+func (m *MockOrderUsecase) StreamSalesReport(ctx context.Context, params *entity.SalesReportByParams, handle func(*entity.SalesReport) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamSalesReport", ctx, params, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamSalesReport indicates an expected call of StreamSalesReport.
+func (mr *MockOrderUsecaseMockRecorder) StreamSalesReport(ctx, params, handle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamSalesReport", reflect.TypeOf((*MockOrderUsecase)(nil).StreamSalesReport), ctx, params, handle)
+}
+
+// UpdateCartItem mocks base method.
+func (m *MockOrderUsecase) UpdateCartItem(ctx context.Context, params *entity.UpdateCartItemRequest) (*entity.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCartItem", ctx, params)
+	ret0, _ := ret[0].(*entity.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCartItem indicates an expected call of UpdateCartItem.
+func (mr *MockOrderUsecaseMockRecorder) UpdateCartItem(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCartItem", reflect.TypeOf((*MockOrderUsecase)(nil).UpdateCartItem), ctx, params)
+}
+
+// UpsertOrderExpirationPolicy mocks base method.
+func (m *MockOrderUsecase) UpsertOrderExpirationPolicy(ctx context.Context, params *entity.UpsertOrderExpirationPolicyRequest) (*entity.OrderExpirationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertOrderExpirationPolicy", ctx, params)
+	ret0, _ := ret[0].(*entity.OrderExpirationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertOrderExpirationPolicy indicates an expected call of UpsertOrderExpirationPolicy.
+func (mr *MockOrderUsecaseMockRecorder) UpsertOrderExpirationPolicy(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertOrderExpirationPolicy", reflect.TypeOf((*MockOrderUsecase)(nil).UpsertOrderExpirationPolicy), ctx, params)
+}
+
+// UpsertShopConfig mocks base method.
+func (m *MockOrderUsecase) UpsertShopConfig(ctx context.Context, params *entity.UpsertShopConfigRequest) (*entity.ShopConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertShopConfig", ctx, params)
+	ret0, _ := ret[0].(*entity.ShopConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertShopConfig indicates an expected call of UpsertShopConfig.
+func (mr *MockOrderUsecaseMockRecorder) UpsertShopConfig(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertShopConfig", reflect.TypeOf((*MockOrderUsecase)(nil).UpsertShopConfig), ctx, params)
+}
+
+// UpsertTaxRate mocks base method.
+func (m *MockOrderUsecase) UpsertTaxRate(ctx context.Context, params *entity.UpsertTaxRateRequest) (*entity.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertTaxRate", ctx, params)
+	ret0, _ := ret[0].(*entity.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertTaxRate indicates an expected call of UpsertTaxRate.
+func (mr *MockOrderUsecaseMockRecorder) UpsertTaxRate(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertTaxRate", reflect.TypeOf((*MockOrderUsecase)(nil).UpsertTaxRate), ctx, params)
+}
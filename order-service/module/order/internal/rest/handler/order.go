@@ -3,9 +3,23 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"order-service/internal/util"
 	"order-service/internal/util/liberr"
+	"order-service/internal/util/libpagination"
 	"order-service/internal/util/librest"
 	"order-service/module/order/entity"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	MinimalPageNum  = 1
+	MinimalPageSize = 1
+
+	DefaultValueOrderListPageNum  = 1
+	DefaultValueOrderListPageSize = 10
 )
 
 type OrderHandlerConfig struct {
@@ -35,18 +49,171 @@ func (o *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) error
 		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
 	}
 	params.User = user
+	params.IdempotencyKey = r.Header.Get("Idempotency-Key")
 
-	err = o.orderUsecase.CreateOrder(r.Context(), params)
+	order, err := o.orderUsecase.CreateOrder(r.Context(), params)
 	if err != nil {
 		return err
 	}
 
 	code := http.StatusCreated
-	librest.WriteHTTPResponse(w, entity.GetMessageResponse{
+	librest.WriteHTTPResponse(w, entity.CreateOrderResponse{
 		Message: "Success create order",
+		Order:   order,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) GetOrder(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := &entity.GetOrderRequest{
+		OrderID: mux.Vars(r)["id"],
+		User:    user,
+	}
+
+	order, err := o.orderUsecase.GetOrder(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetOrderResponse{
+		Order: order,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := &entity.CancelOrderRequest{
+		OrderID: mux.Vars(r)["id"],
+		User:    user,
+	}
+
+	err = o.orderUsecase.CancelOrder(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetMessageResponse{
+		Message: "Success cancel order",
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) CompleteOrder(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := &entity.CompleteOrderRequest{
+		OrderID: mux.Vars(r)["id"],
+		User:    user,
+	}
+
+	order, err := o.orderUsecase.CompleteOrder(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.CompleteOrderResponse{
+		Message: "Success complete order",
+		Order:   order,
 		Meta: &entity.Meta{
 			HttpStatusCode: code,
 		},
 	}, code)
 	return nil
 }
+
+func (o *OrderHandler) ListOrder(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	// Query parameters
+	qparams := r.URL.Query()
+
+	params := &entity.ListOrderByParams{
+		UserID: user.ID,
+		Page:   util.ConvertStringToIntWithDefault(qparams.Get("page_num"), DefaultValueOrderListPageNum),
+		Limit:  util.ConvertStringToIntWithDefault(qparams.Get("page_size"), DefaultValueOrderListPageSize),
+	}
+	if params.Page < MinimalPageNum {
+		params.Page = DefaultValueOrderListPageNum
+	}
+	if params.Limit < MinimalPageSize {
+		params.Limit = DefaultValueOrderListPageSize
+	}
+
+	for _, s := range qparams["state"] {
+		state, err := strconv.Atoi(s)
+		if err != nil {
+			return liberr.NewBaseError(entity.ErrorInvalidParameter)
+		}
+		params.States = append(params.States, entity.OrderState(state))
+	}
+
+	if params.CreatedAtFrom, err = parseTimeParameter(qparams.Get("created_at_from")); err != nil {
+		return err
+	}
+	if params.CreatedAtTo, err = parseTimeParameter(qparams.Get("created_at_to")); err != nil {
+		return err
+	}
+
+	params.Offset = libpagination.Offset(params.Page, params.Limit)
+
+	orders, pagination, err := o.orderUsecase.ListOrder(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ListOrderResponse{
+		Orders: orders,
+		Meta: &entity.ListMeta{
+			Meta: &entity.Meta{
+				HttpStatusCode: code,
+			},
+			PageNum:   pagination.PageNum(),
+			PageSize:  pagination.PageSize(),
+			PageTotal: pagination.PageTotal(),
+		},
+	}, code)
+	return nil
+}
+
+// parseTimeParameter parse optional RFC3339 query parameter, empty value will return nil time
+func parseTimeParameter(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, liberr.NewBaseError(entity.ErrorInvalidParameter)
+	}
+
+	return &t, nil
+}
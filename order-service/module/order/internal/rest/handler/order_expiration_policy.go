@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/librest"
+	"order-service/module/order/entity"
+
+	"github.com/gorilla/mux"
+)
+
+func (o *OrderHandler) UpsertShopOrderExpirationPolicy(w http.ResponseWriter, r *http.Request) error {
+	return o.upsertOrderExpirationPolicy(w, r, entity.OrderExpirationPolicyScopeShop, mux.Vars(r)["shop_id"])
+}
+
+func (o *OrderHandler) UpsertProductOrderExpirationPolicy(w http.ResponseWriter, r *http.Request) error {
+	return o.upsertOrderExpirationPolicy(w, r, entity.OrderExpirationPolicyScopeProduct, mux.Vars(r)["product_id"])
+}
+
+func (o *OrderHandler) DeleteShopOrderExpirationPolicy(w http.ResponseWriter, r *http.Request) error {
+	return o.deleteOrderExpirationPolicy(w, r, entity.OrderExpirationPolicyScopeShop, mux.Vars(r)["shop_id"])
+}
+
+func (o *OrderHandler) DeleteProductOrderExpirationPolicy(w http.ResponseWriter, r *http.Request) error {
+	return o.deleteOrderExpirationPolicy(w, r, entity.OrderExpirationPolicyScopeProduct, mux.Vars(r)["product_id"])
+}
+
+func (o *OrderHandler) ListOrderExpirationPolicy(w http.ResponseWriter, r *http.Request) error {
+	policies, err := o.orderUsecase.ListOrderExpirationPolicy(r.Context())
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ListOrderExpirationPolicyResponse{
+		OrderExpirationPolicies: policies,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) upsertOrderExpirationPolicy(w http.ResponseWriter, r *http.Request, scope entity.OrderExpirationPolicyScope, scopeID string) error {
+	params := new(entity.UpsertOrderExpirationPolicyRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.Scope = scope
+	params.ScopeID = scopeID
+
+	policy, err := o.orderUsecase.UpsertOrderExpirationPolicy(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.UpsertOrderExpirationPolicyResponse{
+		Message:               "Success save order expiration policy",
+		OrderExpirationPolicy: policy,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) deleteOrderExpirationPolicy(w http.ResponseWriter, r *http.Request, scope entity.OrderExpirationPolicyScope, scopeID string) error {
+	params := &entity.DeleteOrderExpirationPolicyRequest{
+		Scope:   scope,
+		ScopeID: scopeID,
+	}
+
+	err := o.orderUsecase.DeleteOrderExpirationPolicy(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetMessageResponse{
+		Message: "Success delete order expiration policy",
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/librest"
+	"order-service/module/order/entity"
+
+	"github.com/gorilla/mux"
+)
+
+func (o *OrderHandler) CreateOrderReturn(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := new(entity.CreateOrderReturnRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.OrderID = mux.Vars(r)["id"]
+	params.User = user
+
+	orderReturn, err := o.orderUsecase.CreateOrderReturn(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusCreated
+	librest.WriteHTTPResponse(w, entity.CreateOrderReturnResponse{
+		Message:     "Success create order return",
+		OrderReturn: orderReturn,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) ListOrderReturn(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := &entity.ListOrderReturnRequest{
+		OrderID: mux.Vars(r)["id"],
+		User:    user,
+	}
+
+	orderReturns, err := o.orderUsecase.ListOrderReturn(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ListOrderReturnResponse{
+		OrderReturns: orderReturns,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) ApproveOrderReturn(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.ApproveOrderReturnRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.ReturnID = mux.Vars(r)["id"]
+
+	orderReturn, err := o.orderUsecase.ApproveOrderReturn(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ReviewOrderReturnResponse{
+		Message:     "Success approve order return",
+		OrderReturn: orderReturn,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) RejectOrderReturn(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.RejectOrderReturnRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.ReturnID = mux.Vars(r)["id"]
+
+	orderReturn, err := o.orderUsecase.RejectOrderReturn(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ReviewOrderReturnResponse{
+		Message:     "Success reject order return",
+		OrderReturn: orderReturn,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/librest"
+	"order-service/module/order/entity"
+
+	"github.com/gorilla/mux"
+)
+
+// PaymentSignatureHeader is the header of the callback signature, the hex encoded HMAC-SHA256 of the raw body
+const PaymentSignatureHeader = "X-Payment-Signature"
+
+func (o *OrderHandler) CreatePayment(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := &entity.CreatePaymentRequest{
+		OrderID: mux.Vars(r)["id"],
+		User:    user,
+	}
+
+	payment, err := o.orderUsecase.CreatePayment(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusCreated
+	librest.WriteHTTPResponse(w, entity.CreatePaymentResponse{
+		Message: "Success create payment",
+		Payment: payment,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) PaymentCallback(w http.ResponseWriter, r *http.Request) error {
+	// The signature is computed over the raw body, so the body is kept as it is sent
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		return liberr.NewBaseError(entity.ErrorPaymentCallbackInvalid)
+	}
+
+	params := &entity.PaymentCallbackRequest{
+		Payload:   payload,
+		Signature: r.Header.Get(PaymentSignatureHeader),
+	}
+
+	err = o.orderUsecase.HandlePaymentCallback(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetMessageResponse{
+		Message: "Success handle payment callback",
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"strconv"
+	"time"
+)
+
+var salesReportCSVHeader = []string{"period", "shop_id", "product_id", "order_count", "units", "revenue"}
+
+func (o *OrderHandler) ListSalesReport(w http.ResponseWriter, r *http.Request) error {
+	// Query parameters
+	qparams := r.URL.Query()
+
+	params := &entity.SalesReportByParams{
+		Period:    entity.SalesReportPeriod(qparams.Get("period")),
+		GroupBy:   entity.SalesReportGroup(qparams.Get("group_by")),
+		Format:    entity.SalesReportFormat(qparams.Get("format")),
+		ShopID:    qparams.Get("shop_id"),
+		ProductID: qparams.Get("product_id"),
+	}
+	if params.Format == "" {
+		params.Format = entity.SalesReportFormatJSON
+	}
+
+	var err error
+	if params.DateFrom, err = parseDateParameter(qparams.Get("date_from")); err != nil {
+		return err
+	}
+	if params.DateTo, err = parseDateParameter(qparams.Get("date_to")); err != nil {
+		return err
+	}
+
+	for _, s := range qparams["state"] {
+		state, err := strconv.Atoi(s)
+		if err != nil {
+			return liberr.NewBaseError(entity.ErrorInvalidParameter)
+		}
+		params.States = append(params.States, entity.OrderState(state))
+	}
+
+	if params.Format == entity.SalesReportFormatCSV {
+		return o.streamSalesReportCSV(w, r, params)
+	}
+
+	return o.streamSalesReportJSON(w, r, params)
+}
+
+// streamSalesReportJSON encode every report once it is read into the sales_reports array of the response.
+// The header is written with the first report, so the error before any report is still answered as the JSON
+// error response while the error afterward is only logged and ends the response
+func (o *OrderHandler) streamSalesReportJSON(w http.ResponseWriter, r *http.Request, params *entity.SalesReportByParams) error {
+	enc := json.NewEncoder(w)
+
+	wroteHeader := false
+	writeHeader := func() error {
+		if wroteHeader {
+			return nil
+		}
+		wroteHeader = true
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := io.WriteString(w, `{"sales_reports":[`)
+		return err
+	}
+
+	first := true
+	err := o.orderUsecase.StreamSalesReport(r.Context(), params, func(report *entity.SalesReport) error {
+		if err := writeHeader(); err != nil {
+			return err
+		}
+
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+
+		return enc.Encode(report)
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writeHeader(); err != nil {
+		return err
+	}
+	if _, err := io.WriteString(w, `],"meta":`); err != nil {
+		return err
+	}
+	if err := enc.Encode(&entity.Meta{HttpStatusCode: http.StatusOK}); err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "}")
+	return err
+}
+
+// streamSalesReportCSV write every report as a CSV row once it is read. The header is written with the first row,
+// so the error before any row is still answered as the JSON error response while the error afterward is only
+// logged and ends the CSV
+func (o *OrderHandler) streamSalesReportCSV(w http.ResponseWriter, r *http.Request, params *entity.SalesReportByParams) error {
+	cw := csv.NewWriter(w)
+	rc := http.NewResponseController(w)
+
+	wroteHeader := false
+	writeHeader := func() error {
+		if wroteHeader {
+			return nil
+		}
+		wroteHeader = true
+
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", `attachment; filename="sales-report.csv"`)
+		w.WriteHeader(http.StatusOK)
+		return cw.Write(salesReportCSVHeader)
+	}
+
+	err := o.orderUsecase.StreamSalesReport(r.Context(), params, func(report *entity.SalesReport) error {
+		if err := writeHeader(); err != nil {
+			return err
+		}
+
+		err := cw.Write([]string{
+			report.Period.Format(time.DateOnly),
+			report.ShopID,
+			report.ProductID,
+			strconv.Itoa(report.OrderCount),
+			strconv.Itoa(report.Units),
+			report.Revenue.String(),
+		})
+		if err != nil {
+			return err
+		}
+
+		cw.Flush()
+		if err := cw.Error(); err != nil {
+			return err
+		}
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := writeHeader(); err != nil {
+		return err
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// parseDateParameter parse the YYYY-MM-DD query parameter, empty value will return zero time
+func parseDateParameter(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, liberr.NewBaseError(entity.ErrorInvalidParameter)
+	}
+
+	return t, nil
+}
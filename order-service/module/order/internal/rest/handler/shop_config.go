@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/librest"
+	"order-service/module/order/entity"
+
+	"github.com/gorilla/mux"
+)
+
+func (o *OrderHandler) UpsertShopConfig(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.UpsertShopConfigRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.ShopID = mux.Vars(r)["shop_id"]
+
+	shopConfig, err := o.orderUsecase.UpsertShopConfig(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.UpsertShopConfigResponse{
+		Message:    "Success save shop config",
+		ShopConfig: shopConfig,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) GetShopConfig(w http.ResponseWriter, r *http.Request) error {
+	params := &entity.GetShopConfigRequest{
+		ShopID: mux.Vars(r)["shop_id"],
+	}
+
+	shopConfig, err := o.orderUsecase.GetShopConfig(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetShopConfigResponse{
+		ShopConfig: shopConfig,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) UpsertTaxRate(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.UpsertTaxRateRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.Region = mux.Vars(r)["region"]
+
+	taxRate, err := o.orderUsecase.UpsertTaxRate(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.UpsertTaxRateResponse{
+		Message: "Success save tax rate",
+		TaxRate: taxRate,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) ListTaxRate(w http.ResponseWriter, r *http.Request) error {
+	taxRates, err := o.orderUsecase.ListTaxRate(r.Context())
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ListTaxRateResponse{
+		TaxRates: taxRates,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
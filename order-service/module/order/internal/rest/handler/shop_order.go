@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/librest"
+	"order-service/module/order/entity"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func (o *OrderHandler) ListShopOrder(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	// Query parameters
+	qparams := r.URL.Query()
+
+	params := &entity.ListShopOrderRequest{
+		ShopID: mux.Vars(r)["shop_id"],
+		User:   user,
+		Page:   util.ConvertStringToIntWithDefault(qparams.Get("page_num"), DefaultValueOrderListPageNum),
+		Limit:  util.ConvertStringToIntWithDefault(qparams.Get("page_size"), DefaultValueOrderListPageSize),
+	}
+	if params.Page < MinimalPageNum {
+		params.Page = DefaultValueOrderListPageNum
+	}
+	if params.Limit < MinimalPageSize {
+		params.Limit = DefaultValueOrderListPageSize
+	}
+
+	for _, s := range qparams["state"] {
+		state, err := strconv.Atoi(s)
+		if err != nil {
+			return liberr.NewBaseError(entity.ErrorInvalidParameter)
+		}
+		params.States = append(params.States, entity.OrderState(state))
+	}
+
+	if params.CreatedAtFrom, err = parseTimeParameter(qparams.Get("created_at_from")); err != nil {
+		return err
+	}
+	if params.CreatedAtTo, err = parseTimeParameter(qparams.Get("created_at_to")); err != nil {
+		return err
+	}
+
+	orders, pagination, err := o.orderUsecase.ListShopOrder(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ListOrderResponse{
+		Orders: orders,
+		Meta: &entity.ListMeta{
+			Meta: &entity.Meta{
+				HttpStatusCode: code,
+			},
+			PageNum:   pagination.PageNum(),
+			PageSize:  pagination.PageSize(),
+			PageTotal: pagination.PageTotal(),
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) GetShopOrder(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := &entity.GetShopOrderRequest{
+		ShopID:  mux.Vars(r)["shop_id"],
+		OrderID: mux.Vars(r)["id"],
+		User:    user,
+	}
+
+	order, err := o.orderUsecase.GetShopOrder(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetOrderResponse{
+		Order: order,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) ProcessShopOrder(w http.ResponseWriter, r *http.Request) error {
+	return o.shopOrderAction(w, r, o.orderUsecase.ProcessShopOrder, "Success process order")
+}
+
+func (o *OrderHandler) ShipShopOrder(w http.ResponseWriter, r *http.Request) error {
+	return o.shopOrderAction(w, r, o.orderUsecase.ShipShopOrder, "Success ship order")
+}
+
+func (o *OrderHandler) RejectShopOrder(w http.ResponseWriter, r *http.Request) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := new(entity.RejectShopOrderRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.ShopID = mux.Vars(r)["shop_id"]
+	params.OrderID = mux.Vars(r)["id"]
+	params.User = user
+
+	order, err := o.orderUsecase.RejectShopOrder(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ShopOrderActionResponse{
+		Message: "Success reject order",
+		Order:   order,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+// shopOrderAction run the state action of the seller on the order of the shop
+func (o *OrderHandler) shopOrderAction(w http.ResponseWriter, r *http.Request, action func(context.Context, *entity.ShopOrderActionRequest) (*entity.Order, error), message string) error {
+	user, err := UserAuth(r, o.configs.AuthServiceJWTSecret)
+	if err != nil {
+		return err
+	}
+
+	params := &entity.ShopOrderActionRequest{
+		ShopID:  mux.Vars(r)["shop_id"],
+		OrderID: mux.Vars(r)["id"],
+		User:    user,
+	}
+
+	order, err := action(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ShopOrderActionResponse{
+		Message: message,
+		Order:   order,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
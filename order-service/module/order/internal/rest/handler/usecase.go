@@ -2,11 +2,50 @@ package handler
 
 import (
 	"context"
+	"order-service/internal/util/libpagination"
 	"order-service/module/order/entity"
 )
 
 //go:generate mockgen -destination=mock/usecase.go -package=mock -source=usecase.go
 
 type OrderUsecase interface {
-	CreateOrder(ctx context.Context, params *entity.CreateOrderRequest) error
+	CreateOrder(ctx context.Context, params *entity.CreateOrderRequest) (*entity.Order, error)
+	GetOrder(ctx context.Context, params *entity.GetOrderRequest) (*entity.OrderInformation, error)
+	CancelOrder(ctx context.Context, params *entity.CancelOrderRequest) error
+	CompleteOrder(ctx context.Context, params *entity.CompleteOrderRequest) (*entity.Order, error)
+	ListOrder(ctx context.Context, params *entity.ListOrderByParams) ([]*entity.Order, *libpagination.OffsetPagination, error)
+	CreateCheckout(ctx context.Context, params *entity.CreateCheckoutRequest) (*entity.Checkout, error)
+	GetCheckout(ctx context.Context, params *entity.GetCheckoutRequest) (*entity.Checkout, error)
+	GetCart(ctx context.Context, params *entity.GetCartRequest) (*entity.Cart, error)
+	AddCartItem(ctx context.Context, params *entity.AddCartItemRequest) (*entity.CartItem, error)
+	UpdateCartItem(ctx context.Context, params *entity.UpdateCartItemRequest) (*entity.CartItem, error)
+	RemoveCartItem(ctx context.Context, params *entity.RemoveCartItemRequest) error
+	CheckoutCart(ctx context.Context, params *entity.CheckoutCartRequest) (*entity.Order, error)
+	CreateCheckoutQuote(ctx context.Context, params *entity.CheckoutQuoteRequest) (*entity.CheckoutQuote, error)
+	CreateVoucher(ctx context.Context, params *entity.CreateVoucherRequest) (*entity.Voucher, error)
+	GetVoucher(ctx context.Context, params *entity.GetVoucherRequest) (*entity.Voucher, error)
+	UpsertShopConfig(ctx context.Context, params *entity.UpsertShopConfigRequest) (*entity.ShopConfig, error)
+	GetShopConfig(ctx context.Context, params *entity.GetShopConfigRequest) (*entity.ShopConfig, error)
+	UpsertTaxRate(ctx context.Context, params *entity.UpsertTaxRateRequest) (*entity.TaxRate, error)
+	ListTaxRate(ctx context.Context) ([]*entity.TaxRate, error)
+	CreatePayment(ctx context.Context, params *entity.CreatePaymentRequest) (*entity.Payment, error)
+	HandlePaymentCallback(ctx context.Context, params *entity.PaymentCallbackRequest) error
+	CreateOrderReturn(ctx context.Context, params *entity.CreateOrderReturnRequest) (*entity.OrderReturn, error)
+	ListOrderReturn(ctx context.Context, params *entity.ListOrderReturnRequest) ([]*entity.OrderReturn, error)
+	ApproveOrderReturn(ctx context.Context, params *entity.ApproveOrderReturnRequest) (*entity.OrderReturn, error)
+	RejectOrderReturn(ctx context.Context, params *entity.RejectOrderReturnRequest) (*entity.OrderReturn, error)
+	ListShopOrder(ctx context.Context, params *entity.ListShopOrderRequest) ([]*entity.Order, *libpagination.OffsetPagination, error)
+	GetShopOrder(ctx context.Context, params *entity.GetShopOrderRequest) (*entity.OrderInformation, error)
+	ProcessShopOrder(ctx context.Context, params *entity.ShopOrderActionRequest) (*entity.Order, error)
+	ShipShopOrder(ctx context.Context, params *entity.ShopOrderActionRequest) (*entity.Order, error)
+	RejectShopOrder(ctx context.Context, params *entity.RejectShopOrderRequest) (*entity.Order, error)
+	CreateWebhookSubscription(ctx context.Context, params *entity.CreateWebhookSubscriptionRequest) (*entity.WebhookSubscription, error)
+	ListWebhookSubscription(ctx context.Context, params *entity.ListWebhookSubscriptionRequest) ([]*entity.WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, params *entity.DeleteWebhookSubscriptionRequest) error
+	ListWebhookDelivery(ctx context.Context, params *entity.ListWebhookDeliveryByParams) ([]*entity.WebhookDelivery, *libpagination.OffsetPagination, error)
+	RedeliverWebhook(ctx context.Context, params *entity.RedeliverWebhookRequest) (*entity.WebhookDelivery, error)
+	UpsertOrderExpirationPolicy(ctx context.Context, params *entity.UpsertOrderExpirationPolicyRequest) (*entity.OrderExpirationPolicy, error)
+	ListOrderExpirationPolicy(ctx context.Context) ([]*entity.OrderExpirationPolicy, error)
+	DeleteOrderExpirationPolicy(ctx context.Context, params *entity.DeleteOrderExpirationPolicyRequest) error
+	StreamSalesReport(ctx context.Context, params *entity.SalesReportByParams, handle func(*entity.SalesReport) error) error
 }
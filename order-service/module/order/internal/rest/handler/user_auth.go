@@ -39,6 +39,23 @@ func UserAuth(r *http.Request, authServiceJWTSecret string) (*entity.User, error
 
 	userID := claims["sub"].(string)
 	return &entity.User{
-		ID: userID,
+		ID:      userID,
+		ShopIDs: shopIDsClaim(claims),
 	}, nil
 }
+
+// shopIDsClaim read the shops the user operates from the shop_ids claim, the claim is absent for buyers
+func shopIDsClaim(claims jwt.MapClaims) []string {
+	values, ok := claims["shop_ids"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	shopIDs := []string{}
+	for _, v := range values {
+		if shopID, ok := v.(string); ok {
+			shopIDs = append(shopIDs, shopID)
+		}
+	}
+	return shopIDs
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/librest"
+	"order-service/module/order/entity"
+
+	"github.com/gorilla/mux"
+)
+
+func (o *OrderHandler) CreateVoucher(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.CreateVoucherRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+
+	voucher, err := o.orderUsecase.CreateVoucher(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusCreated
+	librest.WriteHTTPResponse(w, entity.CreateVoucherResponse{
+		Message: "Success create voucher",
+		Voucher: voucher,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) GetVoucher(w http.ResponseWriter, r *http.Request) error {
+	params := &entity.GetVoucherRequest{
+		VoucherID: mux.Vars(r)["id"],
+	}
+
+	voucher, err := o.orderUsecase.GetVoucher(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetVoucherResponse{
+		Voucher: voucher,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libpagination"
+	"order-service/internal/util/librest"
+	"order-service/module/order/entity"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+func (o *OrderHandler) CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.CreateWebhookSubscriptionRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.ShopID = mux.Vars(r)["shop_id"]
+
+	subscription, err := o.orderUsecase.CreateWebhookSubscription(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusCreated
+	librest.WriteHTTPResponse(w, entity.CreateWebhookSubscriptionResponse{
+		Message:             "Success create webhook subscription",
+		WebhookSubscription: subscription,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) ListWebhookSubscription(w http.ResponseWriter, r *http.Request) error {
+	params := &entity.ListWebhookSubscriptionRequest{
+		ShopID: mux.Vars(r)["shop_id"],
+	}
+
+	subscriptions, err := o.orderUsecase.ListWebhookSubscription(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ListWebhookSubscriptionResponse{
+		WebhookSubscriptions: subscriptions,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request) error {
+	params := &entity.DeleteWebhookSubscriptionRequest{
+		ShopID:         mux.Vars(r)["shop_id"],
+		SubscriptionID: mux.Vars(r)["id"],
+	}
+
+	err := o.orderUsecase.DeleteWebhookSubscription(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetMessageResponse{
+		Message: "Success delete webhook subscription",
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) ListWebhookDelivery(w http.ResponseWriter, r *http.Request) error {
+	// Query parameters
+	qparams := r.URL.Query()
+
+	params := &entity.ListWebhookDeliveryByParams{
+		ShopID:         mux.Vars(r)["shop_id"],
+		SubscriptionID: qparams.Get("subscription_id"),
+		OrderID:        qparams.Get("order_id"),
+		Page:           util.ConvertStringToIntWithDefault(qparams.Get("page_num"), DefaultValueOrderListPageNum),
+		Limit:          util.ConvertStringToIntWithDefault(qparams.Get("page_size"), DefaultValueOrderListPageSize),
+	}
+	if params.Page < MinimalPageNum {
+		params.Page = DefaultValueOrderListPageNum
+	}
+	if params.Limit < MinimalPageSize {
+		params.Limit = DefaultValueOrderListPageSize
+	}
+
+	for _, s := range qparams["state"] {
+		state, err := strconv.Atoi(s)
+		if err != nil {
+			return liberr.NewBaseError(entity.ErrorInvalidParameter)
+		}
+		params.States = append(params.States, entity.WebhookDeliveryState(state))
+	}
+
+	params.Offset = libpagination.Offset(params.Page, params.Limit)
+
+	deliveries, pagination, err := o.orderUsecase.ListWebhookDelivery(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ListWebhookDeliveryResponse{
+		WebhookDeliveries: deliveries,
+		Meta: &entity.ListMeta{
+			Meta: &entity.Meta{
+				HttpStatusCode: code,
+			},
+			PageNum:   pagination.PageNum(),
+			PageSize:  pagination.PageSize(),
+			PageTotal: pagination.PageTotal(),
+		},
+	}, code)
+	return nil
+}
+
+func (o *OrderHandler) RedeliverWebhook(w http.ResponseWriter, r *http.Request) error {
+	params := &entity.RedeliverWebhookRequest{
+		ShopID:     mux.Vars(r)["shop_id"],
+		DeliveryID: mux.Vars(r)["id"],
+	}
+
+	delivery, err := o.orderUsecase.RedeliverWebhook(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusCreated
+	librest.WriteHTTPResponse(w, entity.RedeliverWebhookResponse{
+		Message:         "Success redeliver webhook",
+		WebhookDelivery: delivery,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
@@ -9,12 +9,35 @@ import (
 
 var (
 	errorCodeMapper = map[string]int{
-		entity.ErrorCodeForbidden:         http.StatusForbidden,
-		entity.ErrorCodeTokenNotFound:     http.StatusForbidden,
-		entity.ErrorCodeTokenExpired:      http.StatusForbidden,
-		entity.ErrorCodeTokenInvalid:      http.StatusForbidden,
-		entity.ErrorCodeTokenInvalidBarer: http.StatusForbidden,
-		entity.ErrorCodeOrderNotFound:     http.StatusNotFound,
+		entity.ErrorCodeForbidden:                http.StatusForbidden,
+		entity.ErrorCodeTokenNotFound:            http.StatusForbidden,
+		entity.ErrorCodeTokenExpired:             http.StatusForbidden,
+		entity.ErrorCodeTokenInvalid:             http.StatusForbidden,
+		entity.ErrorCodeTokenInvalidBarer:        http.StatusForbidden,
+		entity.ErrorCodeOrderNotFound:            http.StatusNotFound,
+		entity.ErrorCodeCheckoutNotFound:         http.StatusNotFound,
+		entity.ErrorCodeCartItemNotFound:         http.StatusNotFound,
+		entity.ErrorCodeCartItemDuplicated:       http.StatusConflict,
+		entity.ErrorCodeOrderInvalidTransition:   http.StatusConflict,
+		entity.ErrorCodeIdempotencyKeyConflicted: http.StatusConflict,
+		entity.ErrorCodeIdempotencyKeyInProgress: http.StatusConflict,
+		entity.ErrorCodeVoucherNotFound:          http.StatusNotFound,
+		entity.ErrorCodeVoucherDuplicated:        http.StatusConflict,
+		entity.ErrorCodeVoucherUsageLimitReached: http.StatusConflict,
+		entity.ErrorCodeVoucherUserLimitReached:  http.StatusConflict,
+		entity.ErrorCodeShopConfigNotFound:       http.StatusNotFound,
+		entity.ErrorCodeOrderNotPayable:          http.StatusConflict,
+		entity.ErrorCodePaymentNotFound:          http.StatusNotFound,
+		entity.ErrorCodePaymentSignatureInvalid:  http.StatusUnauthorized,
+		entity.ErrorCodeWebhookNotFound:          http.StatusNotFound,
+		entity.ErrorCodeWebhookDeliveryNotFound:  http.StatusNotFound,
+		entity.ErrorCodeOrderNotReturnable:       http.StatusConflict,
+		entity.ErrorCodeOrderDetailNotFound:      http.StatusNotFound,
+		entity.ErrorCodeOrderReturnNotFound:      http.StatusNotFound,
+		entity.ErrorCodeOrderReturnExceeded:      http.StatusConflict,
+		entity.ErrorCodeOrderReturnReviewed:      http.StatusConflict,
+		entity.ErrorCodeExpirationPolicyNotFound: http.StatusNotFound,
+		entity.ErrorCodeOrderNotCancellable:      http.StatusConflict,
 	}
 )
 
@@ -73,7 +96,8 @@ func (em *errorMiddleware) bodyFromAnyError() entity.ErrorResponse {
 func (em *errorMiddleware) handle(w http.ResponseWriter, r *http.Request) error {
 	rw := librest.WrapResponseWriter(w)
 	err := em.handler(rw, r)
-	if err != nil {
+	// The streamed response already sent its header and part of its body, the error is only logged
+	if err != nil && !rw.WroteHeader() {
 		var body entity.ErrorResponse
 
 		if berr, ok := err.(*liberr.BaseError); ok {
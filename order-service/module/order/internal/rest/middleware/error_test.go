@@ -129,6 +129,29 @@ func TestWithErrorMiddleware(t *testing.T) {
 				assert.Equal(t, expected, actual)
 			},
 		},
+		{
+			name: "Error Handler With Error After Header Written",
+			buildInputFn: func(i *input) {
+				handler := librest.GatewayHandlerFunc(
+					librest.ApplyGatewayMiddlewares(
+						func(w http.ResponseWriter, r *http.Request) error {
+							w.WriteHeader(http.StatusOK)
+							_, _ = w.Write([]byte("period\n"))
+							return errors.New("Error Happened")
+						}, middlewares...,
+					),
+				)
+
+				i.w = httptest.NewRecorder()
+				i.r = httptest.NewRequest(http.MethodGet, "/handlers", nil)
+
+				handler.ServeHTTP(i.w, i.r)
+			},
+			assertFn: func(i *input) {
+				assert.Equal(t, http.StatusOK, i.w.Code)
+				assert.Equal(t, "period\n", i.w.Body.String())
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
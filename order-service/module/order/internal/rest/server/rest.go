@@ -15,6 +15,8 @@ type ServerConfig struct {
 	Logger   *zap.Logger
 
 	AuthServiceJWTSecret string
+	BasicAuthUsername    string
+	BasicAuthPassword    string
 }
 
 type ServerUsecase struct {
@@ -27,6 +29,47 @@ func RegisterRESTHandler(serverMux *mux.Router, cfg *ServerConfig) error {
 	})
 
 	registerHandler(serverMux, cfg, http.MethodPost, "/checkout-orders", order.CreateOrder)
+	registerHandler(serverMux, cfg, http.MethodPost, "/checkout-quotes", order.CreateCheckoutQuote)
+	registerHandler(serverMux, cfg, http.MethodGet, "/orders", order.ListOrder)
+	registerHandler(serverMux, cfg, http.MethodGet, "/orders/{id}", order.GetOrder)
+	registerHandler(serverMux, cfg, http.MethodPost, "/orders/{id}/cancel", order.CancelOrder)
+	registerHandler(serverMux, cfg, http.MethodPost, "/orders/{id}/complete", order.CompleteOrder)
+	registerHandler(serverMux, cfg, http.MethodPost, "/orders/{id}/payments", order.CreatePayment)
+	registerHandler(serverMux, cfg, http.MethodPost, "/payments/callback", order.PaymentCallback)
+	registerHandler(serverMux, cfg, http.MethodPost, "/orders/{id}/returns", order.CreateOrderReturn)
+	registerHandler(serverMux, cfg, http.MethodGet, "/orders/{id}/returns", order.ListOrderReturn)
+	registerHandler(serverMux, cfg, http.MethodGet, "/shops/{shop_id}/orders", order.ListShopOrder)
+	registerHandler(serverMux, cfg, http.MethodGet, "/shops/{shop_id}/orders/{id}", order.GetShopOrder)
+	registerHandler(serverMux, cfg, http.MethodPost, "/shops/{shop_id}/orders/{id}/process", order.ProcessShopOrder)
+	registerHandler(serverMux, cfg, http.MethodPost, "/shops/{shop_id}/orders/{id}/ship", order.ShipShopOrder)
+	registerHandler(serverMux, cfg, http.MethodPost, "/shops/{shop_id}/orders/{id}/reject", order.RejectShopOrder)
+	registerHandler(serverMux, cfg, http.MethodPost, "/checkouts", order.CreateCheckout)
+	registerHandler(serverMux, cfg, http.MethodGet, "/checkouts/{id}", order.GetCheckout)
+	registerHandler(serverMux, cfg, http.MethodGet, "/cart", order.GetCart)
+	registerHandler(serverMux, cfg, http.MethodPost, "/cart/items", order.AddCartItem)
+	registerHandler(serverMux, cfg, http.MethodPut, "/cart/items/{id}", order.UpdateCartItem)
+	registerHandler(serverMux, cfg, http.MethodDelete, "/cart/items/{id}", order.RemoveCartItem)
+	registerHandler(serverMux, cfg, http.MethodPost, "/cart/checkout", order.CheckoutCart)
+
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/vouchers", order.CreateVoucher)
+	registerInternalHandler(serverMux, cfg, http.MethodGet, "/vouchers/{id}", order.GetVoucher)
+	registerInternalHandler(serverMux, cfg, http.MethodPut, "/shop-configs/{shop_id}", order.UpsertShopConfig)
+	registerInternalHandler(serverMux, cfg, http.MethodGet, "/shop-configs/{shop_id}", order.GetShopConfig)
+	registerInternalHandler(serverMux, cfg, http.MethodPut, "/tax-rates/{region}", order.UpsertTaxRate)
+	registerInternalHandler(serverMux, cfg, http.MethodGet, "/tax-rates", order.ListTaxRate)
+	registerInternalHandler(serverMux, cfg, http.MethodGet, "/order-expiration-policies", order.ListOrderExpirationPolicy)
+	registerInternalHandler(serverMux, cfg, http.MethodPut, "/shops/{shop_id}/order-expiration-policy", order.UpsertShopOrderExpirationPolicy)
+	registerInternalHandler(serverMux, cfg, http.MethodDelete, "/shops/{shop_id}/order-expiration-policy", order.DeleteShopOrderExpirationPolicy)
+	registerInternalHandler(serverMux, cfg, http.MethodPut, "/products/{product_id}/order-expiration-policy", order.UpsertProductOrderExpirationPolicy)
+	registerInternalHandler(serverMux, cfg, http.MethodDelete, "/products/{product_id}/order-expiration-policy", order.DeleteProductOrderExpirationPolicy)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/shops/{shop_id}/webhooks", order.CreateWebhookSubscription)
+	registerInternalHandler(serverMux, cfg, http.MethodGet, "/shops/{shop_id}/webhooks", order.ListWebhookSubscription)
+	registerInternalHandler(serverMux, cfg, http.MethodDelete, "/shops/{shop_id}/webhooks/{id}", order.DeleteWebhookSubscription)
+	registerInternalHandler(serverMux, cfg, http.MethodGet, "/shops/{shop_id}/webhook-deliveries", order.ListWebhookDelivery)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/shops/{shop_id}/webhook-deliveries/{id}/redeliver", order.RedeliverWebhook)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/order-returns/{id}/approve", order.ApproveOrderReturn)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/order-returns/{id}/reject", order.RejectOrderReturn)
+	registerInternalHandler(serverMux, cfg, http.MethodGet, "/sales-reports", order.ListSalesReport)
 
 	return nil
 }
@@ -40,3 +83,14 @@ func registerHandler(serverMux *mux.Router, cfg *ServerConfig, method, path stri
 
 	serverMux.HandleFunc(path, gatewayHandler).Methods(method)
 }
+
+func registerInternalHandler(serverMux *mux.Router, cfg *ServerConfig, method, path string, handle librest.GatewayHandler) {
+	basicAuthMiddlewares := []librest.GatewayMiddleware{
+		middleware.WithBasicAuthMiddleware(cfg.BasicAuthUsername, cfg.BasicAuthPassword),
+		middleware.WithErrorMiddleware(),
+		librest.WithLoggingMiddleware(path, cfg.Logger),
+	}
+	gatewayHandler := librest.GatewayHandlerFunc(librest.ApplyGatewayMiddlewares(handle, basicAuthMiddlewares...))
+
+	serverMux.HandleFunc(path, gatewayHandler).Methods(method)
+}
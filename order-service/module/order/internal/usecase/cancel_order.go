@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+
+	"go.uber.org/zap"
+)
+
+func (o *OrderUsecase) CancelOrder(ctx context.Context, params *entity.CancelOrderRequest) error {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	order, err := o.repos.OrderRepo.GetByID(ctx, params.OrderID)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	// Validate order ownership
+	if order.UserID != params.User.ID {
+		return liberr.ResolveError(entity.ErrorForbidden)
+	}
+
+	// Paid order is cancelled by the seller rejection, which refunds the captured payment
+	if order.State != entity.OrderStateCreated {
+		return liberr.ResolveError(entity.ErrorOrderNotCancellable)
+	}
+
+	orderDetails, err := o.repos.OrderDetailRepo.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	err = o.transitionOrderState(ctx, order, entity.OrderStateCancelled, &entity.OrderStateChange{
+		ActorType: entity.OrderStateActorTypeUser,
+		ActorID:   params.User.ID,
+		Reason:    "Cancelled by user",
+	}, tx)
+	if err != nil {
+		return err
+	}
+
+	outbox, err := o.createOrderOutbox(ctx, order.ID, entity.OrderOutboxCommandReleaseStock, releaseStockPayload(order.ID, orderDetails), tx)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	// Cancellation is already recorded, failed release is retried by the outbox relay
+	if derr := o.dispatchOrderOutbox(ctx, outbox); derr != nil {
+		o.logger.Warn("Failed on dispatch order release", zap.String("order_id", order.ID), zap.Error(derr))
+	}
+
+	return nil
+}
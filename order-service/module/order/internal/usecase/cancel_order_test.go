@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"order-service/module/order/entity"
+	"order-service/module/order/testutil/fixtures"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderUsecase_CancelOrder(t *testing.T) {
+	type input struct {
+		params *entity.CancelOrderRequest
+	}
+
+	params := &entity.CancelOrderRequest{
+		OrderID: fixtures.Order.ID,
+		User:    &entity.User{ID: fixtures.Order.UserID},
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(error)
+	}{
+		{
+			name: "Success Cancel Order Waiting For Payment",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+				dependency.orderDetailRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderDetail{fixtures.NewOrderDetail(fixtures.OrderDetail)}, nil)
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				expectOrderCancelled(dependency, order)
+				dependency.orderOutboxRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, outbox *entity.OrderOutbox, _ interface{}) error {
+						assert.Equal(t, entity.OrderOutboxCommandReleaseStock, outbox.Command)
+						outbox.ID = "6"
+						return nil
+					})
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+
+				// The release waits for the reservation still pending
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderOutbox{fixtures.NewOrderOutbox(fixtures.OrderOutbox)}, nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error Paid Order",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStatePaid
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+			},
+			assertFn: func(err error) {
+				assertErrorDetails(t, entity.ErrorOrderNotCancellable, err)
+			},
+		},
+		{
+			name: "Error Processing Order",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStateProcessing
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+			},
+			assertFn: func(err error) {
+				assertErrorDetails(t, entity.ErrorOrderNotCancellable, err)
+			},
+		},
+		{
+			name: "Error Order Of Another User",
+			in: input{params: &entity.CancelOrderRequest{
+				OrderID: fixtures.Order.ID,
+				User:    &entity.User{ID: "99"},
+			}},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(fixtures.NewOrder(fixtures.Order), nil)
+			},
+			assertFn: func(err error) {
+				assertErrorDetails(t, entity.ErrorForbidden, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(uc.CancelOrder(ctx, tc.in.params))
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"context"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+// GetCart retrieve the cart of the user revalidated against the current product price and warehouse availability,
+// the items whose price changed or which are no longer available are flagged with the problems
+func (o *OrderUsecase) GetCart(ctx context.Context, params *entity.GetCartRequest) (*entity.Cart, error) {
+	cartItems, err := o.repos.CartItemRepo.ListByUserID(ctx, params.User.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	cart := &entity.Cart{
+		UserID:     params.User.ID,
+		TotalPrice: decimal.NewFromInt(0),
+		Items:      []*entity.CartItemInformation{},
+	}
+	if len(cartItems) == 0 {
+		return cart, nil
+	}
+
+	productIDsMap := make(map[string]struct{})
+	productIDs := []string{}
+	for _, ci := range cartItems {
+		if _, exists := productIDsMap[ci.ProductID]; !exists {
+			productIDsMap[ci.ProductID] = struct{}{}
+			productIDs = append(productIDs, ci.ProductID)
+		}
+	}
+
+	// Products which no longer exist are not returned by product service
+	products, err := o.repos.ProductRepo.ListByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// map[product_id]product
+	productMap := map[string]*entity.Product{}
+	for _, p := range products {
+		productMap[p.ID] = p
+	}
+
+	warehouseStocks, err := o.repos.WarehouseRepo.ActiveStock(ctx, productIDs)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	shopAvailable := shopAvailableStocks(warehouseStocks)
+
+	for _, ci := range cartItems {
+		item := &entity.CartItemInformation{
+			CartItem:       ci,
+			CurrentPrice:   decimal.NewFromInt(0),
+			AvailableStock: shopAvailable[ci.ShopID][ci.ProductID],
+			TotalPrice:     decimal.NewFromInt(0),
+			Problems:       []entity.LineProblem{},
+		}
+
+		if product, ok := productMap[ci.ProductID]; ok {
+			item.ProductName = product.Name
+			item.CurrentPrice = product.Price
+			item.TotalPrice = product.Price.Mul(decimal.NewFromInt(int64(ci.Stock)))
+
+			if !product.Price.Equal(ci.Price) {
+				item.Problems = append(item.Problems, entity.LineProblemPriceChanged)
+			}
+		} else {
+			item.Problems = append(item.Problems, entity.LineProblemProductNotFound)
+		}
+
+		if problem, ok := stockProblem(item.AvailableStock, ci.Stock); ok {
+			item.Problems = append(item.Problems, problem)
+		}
+
+		cart.TotalStock += ci.Stock
+		cart.TotalPrice = cart.TotalPrice.Add(item.TotalPrice)
+		cart.Items = append(cart.Items, item)
+	}
+
+	return cart, nil
+}
+
+// AddCartItem put the product of the shop into the cart, adding the product already in the cart increase the stock.
+// The price of the item is refreshed to the current product price
+func (o *OrderUsecase) AddCartItem(ctx context.Context, params *entity.AddCartItemRequest) (*entity.CartItem, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	products, err := o.repos.ProductRepo.ListByProductIDs(ctx, []string{params.ProductID})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	if len(products) == 0 {
+		return nil, liberr.ResolveError(entity.ErrorProductNotFound)
+	}
+	product := products[0]
+
+	cartItem, err := o.repos.CartItemRepo.GetByUserIDAndProductID(ctx, params.User.ID, params.ShopID, params.ProductID)
+	if err != nil {
+		if berr, ok := err.(*liberr.BaseError); !ok || !berr.IsAnyCodeEqual(entity.ErrorCodeCartItemNotFound) {
+			return nil, liberr.ResolveError(err)
+		}
+		cartItem = nil
+	}
+
+	stock := params.Stock
+	if cartItem != nil {
+		stock += cartItem.Stock
+	}
+
+	if err := o.validateCartItemStock(ctx, params.ShopID, params.ProductID, stock); err != nil {
+		return nil, err
+	}
+
+	if cartItem == nil {
+		cartItem = &entity.CartItem{
+			UserID:    params.User.ID,
+			ShopID:    params.ShopID,
+			ProductID: params.ProductID,
+			Stock:     stock,
+			Price:     product.Price,
+		}
+
+		// Concurrent add of the same product is rejected by the unique index
+		if err := o.repos.CartItemRepo.Create(ctx, cartItem, nil); err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+
+		return cartItem, nil
+	}
+
+	if _, err := o.repos.CartItemRepo.UpdateStock(ctx, cartItem.ID, stock, product.Price, nil); err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	cartItem.Stock = stock
+	cartItem.Price = product.Price
+	return cartItem, nil
+}
+
+// UpdateCartItem replace the stock of the cart item, the price is kept so the price change is still flagged
+func (o *OrderUsecase) UpdateCartItem(ctx context.Context, params *entity.UpdateCartItemRequest) (*entity.CartItem, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	cartItem, err := o.repos.CartItemRepo.GetByID(ctx, params.CartItemID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Validate cart item ownership
+	if cartItem.UserID != params.User.ID {
+		return nil, liberr.ResolveError(entity.ErrorForbidden)
+	}
+
+	if err := o.validateCartItemStock(ctx, cartItem.ShopID, cartItem.ProductID, params.Stock); err != nil {
+		return nil, err
+	}
+
+	if _, err := o.repos.CartItemRepo.UpdateStock(ctx, cartItem.ID, params.Stock, cartItem.Price, nil); err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	cartItem.Stock = params.Stock
+	return cartItem, nil
+}
+
+func (o *OrderUsecase) RemoveCartItem(ctx context.Context, params *entity.RemoveCartItemRequest) error {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	cartItem, err := o.repos.CartItemRepo.GetByID(ctx, params.CartItemID)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	// Validate cart item ownership
+	if cartItem.UserID != params.User.ID {
+		return liberr.ResolveError(entity.ErrorForbidden)
+	}
+
+	if _, err := o.repos.CartItemRepo.DeleteByIDs(ctx, params.User.ID, []string{cartItem.ID}, nil); err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	return nil
+}
+
+// CheckoutCart create the order of the cart items of the shop through the order checkout,
+// the checked out items are removed from the cart within the transaction of the order
+func (o *OrderUsecase) CheckoutCart(ctx context.Context, params *entity.CheckoutCartRequest) (*entity.Order, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	cartItems, err := o.repos.CartItemRepo.ListByUserID(ctx, params.User.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	cartItemIDs := []string{}
+	orderProducts := []*entity.CreateOrderProduct{}
+	for _, ci := range cartItems {
+		if ci.ShopID != params.ShopID {
+			continue
+		}
+
+		cartItemIDs = append(cartItemIDs, ci.ID)
+		orderProducts = append(orderProducts, &entity.CreateOrderProduct{
+			ProductID: ci.ProductID,
+			Stock:     ci.Stock,
+		})
+	}
+
+	if len(orderProducts) == 0 {
+		return o.replayCheckoutCart(ctx, params)
+	}
+
+	return o.createIdempotentOrder(ctx, &entity.CreateOrderRequest{
+		ShopID:         params.ShopID,
+		Products:       orderProducts,
+		VoucherCodes:   params.VoucherCodes,
+		IdempotencyKey: params.IdempotencyKey,
+		User:           params.User,
+	}, cartItemIDs)
+}
+
+// replayCheckoutCart return the order of the cart checkout retried with the same idempotency key,
+// the items of the shop have been removed from the cart by the first checkout
+func (o *OrderUsecase) replayCheckoutCart(ctx context.Context, params *entity.CheckoutCartRequest) (*entity.Order, error) {
+	if params.IdempotencyKey == "" {
+		return nil, liberr.ResolveError(entity.ErrorCartEmpty)
+	}
+
+	idempotencyKey, err := o.repos.OrderIdempotencyKeyRepo.GetByUserIDAndKey(ctx, params.User.ID, params.IdempotencyKey)
+	if err != nil {
+		if berr, ok := err.(*liberr.BaseError); ok && berr.IsAnyCodeEqual(entity.ErrorCodeIdempotencyKeyNotFound) {
+			return nil, liberr.ResolveError(entity.ErrorCartEmpty)
+		}
+		return nil, liberr.ResolveError(err)
+	}
+
+	if idempotencyKey.State != entity.OrderIdempotencyKeyStateCompleted {
+		return nil, liberr.ResolveError(entity.ErrorIdempotencyKeyInProgress)
+	}
+
+	order, err := o.repos.OrderRepo.GetByID(ctx, idempotencyKey.OrderID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	if order.ShopID != params.ShopID {
+		return nil, liberr.ResolveError(entity.ErrorIdempotencyKeyConflicted)
+	}
+
+	return order, nil
+}
+
+// validateCartItemStock validate the product is sold by the shop and the shop warehouses are able to fulfill the stock
+func (o *OrderUsecase) validateCartItemStock(ctx context.Context, shopID, productID string, stock int) error {
+	warehouseStocks, err := o.repos.WarehouseRepo.ActiveStock(ctx, []string{productID})
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	available, ok := shopAvailableStocks(warehouseStocks)[shopID][productID]
+	if !ok {
+		return liberr.ResolveError(entity.ErrorProductStockNotFound)
+	}
+	if available < stock {
+		return liberr.ResolveError(entity.ErrorProductInsufficientStock)
+	}
+
+	return nil
+}
+
+// shopAvailableStocks sum the available stock of the product across the active warehouses of the shop,
+// map[shop_id][product_id]available
+func shopAvailableStocks(warehouseStocks []*entity.WarehouseStock) map[string]map[string]int {
+	available := map[string]map[string]int{}
+	for _, ws := range warehouseStocks {
+		if _, ok := available[ws.ShopID]; !ok {
+			available[ws.ShopID] = map[string]int{}
+		}
+		available[ws.ShopID][ws.ProductID] += ws.Available
+	}
+
+	return available
+}
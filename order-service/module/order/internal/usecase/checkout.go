@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+	"slices"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// CreateCheckout split the products of multiple shops into an order per shop grouped by the checkout.
+// The checkout is all-or-nothing, every shop has to pass the validation before anything is recorded,
+// and a rejected reservation of any order cancels the whole checkout
+func (o *OrderUsecase) CreateCheckout(ctx context.Context, params *entity.CreateCheckoutRequest) (*entity.Checkout, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	drafts, err := o.draftOrders(ctx, params.User, mergeCheckoutShops(params.Shops))
+	if err != nil {
+		return nil, err
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	checkout := &entity.Checkout{
+		UserID:     params.User.ID,
+		TotalPrice: decimal.NewFromInt(0),
+		CreatedAt:  now,
+		UpdatedAt:  now,
+		Orders:     []*entity.Order{},
+	}
+	for _, d := range drafts {
+		checkout.TotalStock += d.order.TotalStock
+		checkout.TotalPrice = checkout.TotalPrice.Add(d.order.TotalPrice)
+	}
+
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	err = o.repos.CheckoutRepo.Create(ctx, checkout, tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	err = o.countVoucherUsages(ctx, drafts, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	outboxes := []*entity.OrderOutbox{}
+	for _, d := range drafts {
+		d.order.CheckoutID = checkout.ID
+
+		var outbox *entity.OrderOutbox
+		outbox, err = o.saveOrderDraft(ctx, d, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		outboxes = append(outboxes, outbox)
+		checkout.Orders = append(checkout.Orders, d.order)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	for _, ob := range outboxes {
+		if derr := o.dispatchOrderOutbox(ctx, ob); derr != nil {
+			// Rejected reservation has been compensated by cancelling every order of the checkout,
+			// the reservations not dispatched yet have been skipped along with the cancellation
+			if isStockAdjustmentRejected(derr) {
+				return nil, liberr.ResolveError(derr)
+			}
+
+			// Otherwise the reservation is retried by the outbox relay
+			o.logger.Warn("Failed on dispatch order reservation", zap.String("order_id", ob.OrderID), zap.Error(derr))
+		}
+	}
+
+	return checkout, nil
+}
+
+func (o *OrderUsecase) GetCheckout(ctx context.Context, params *entity.GetCheckoutRequest) (*entity.Checkout, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	checkout, err := o.repos.CheckoutRepo.GetByID(ctx, params.CheckoutID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Validate checkout ownership
+	if checkout.UserID != params.User.ID {
+		return nil, liberr.ResolveError(entity.ErrorForbidden)
+	}
+
+	checkout.Orders, err = o.repos.OrderRepo.ListByCheckoutID(ctx, checkout.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return checkout, nil
+}
+
+// mergeCheckoutShops combine the products and the vouchers of the same shop sent more than once,
+// so a shop is ordered only once and a voucher is applied only once per shop
+func mergeCheckoutShops(shops []*entity.CreateCheckoutShop) []*entity.CreateCheckoutShop {
+	merged := []*entity.CreateCheckoutShop{}
+	// map[shop_id]merged shop
+	shopMap := map[string]*entity.CreateCheckoutShop{}
+
+	for _, shop := range shops {
+		if ms, ok := shopMap[shop.ShopID]; ok {
+			ms.Products = append(ms.Products, shop.Products...)
+			for _, code := range shop.VoucherCodes {
+				if !slices.Contains(ms.VoucherCodes, code) {
+					ms.VoucherCodes = append(ms.VoucherCodes, code)
+				}
+			}
+			continue
+		}
+
+		ms := &entity.CreateCheckoutShop{
+			ShopID:       shop.ShopID,
+			Products:     append([]*entity.CreateOrderProduct{}, shop.Products...),
+			VoucherCodes: append([]string{}, shop.VoucherCodes...),
+		}
+		shopMap[shop.ShopID] = ms
+		merged = append(merged, ms)
+	}
+
+	return merged
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"order-service/internal/util"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+// CreateCheckoutQuote run the validation and the pricing of the order checkout without recording the order or
+// reserving the stock. Instead of rejecting the first invalid line, every line is quoted along with its problems
+func (o *OrderUsecase) CreateCheckoutQuote(ctx context.Context, params *entity.CheckoutQuoteRequest) (*entity.CheckoutQuote, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	shop := &entity.CreateCheckoutShop{
+		ShopID:       params.ShopID,
+		Products:     params.Products,
+		VoucherCodes: params.VoucherCodes,
+	}
+
+	// The same catalog, allocation and pricing as the order checkout, so the quote never drifts from the order
+	catalog, err := o.listOrderCatalog(ctx, []*entity.CreateCheckoutShop{shop})
+	if err != nil {
+		return nil, err
+	}
+
+	lines, orderProducts, err := o.allocateOrderLines(catalog, shop)
+	if err != nil {
+		return nil, err
+	}
+
+	draft, err := priceOrderDraft(catalog, shop, lines, orderProducts, util.NowUTCWithoutNanoSecond())
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &entity.CheckoutQuote{
+		ShopID:        params.ShopID,
+		TotalStock:    draft.order.TotalStock,
+		SubtotalPrice: draft.order.SubtotalPrice,
+		DiscountPrice: draft.order.DiscountPrice,
+		ShippingPrice: draft.order.ShippingPrice,
+		TaxPrice:      draft.order.TaxPrice,
+		TotalPrice:    draft.order.TotalPrice,
+		Orderable:     true,
+		Lines:         []*entity.CheckoutQuoteLine{},
+	}
+
+	for _, line := range draft.lines {
+		if len(line.problems) > 0 {
+			quote.Orderable = false
+		}
+		quote.Lines = append(quote.Lines, newCheckoutQuoteLine(line))
+	}
+
+	return quote, nil
+}
+
+func newCheckoutQuoteLine(line *orderLine) *entity.CheckoutQuoteLine {
+	op := line.orderProduct
+	quoteLine := &entity.CheckoutQuoteLine{
+		ProductID:      op.ProductID,
+		WarehouseID:    op.WarehouseID,
+		Stock:          op.Stock,
+		Price:          decimal.NewFromInt(0),
+		TotalPrice:     decimal.NewFromInt(0),
+		AvailableStock: line.availableStock,
+		Allocations:    []*entity.CheckoutQuoteAllocation{},
+		Problems:       line.problems,
+	}
+
+	for _, a := range line.allocations {
+		quoteLine.Allocations = append(quoteLine.Allocations, &entity.CheckoutQuoteAllocation{
+			WarehouseID: a.WarehouseID,
+			Stock:       a.Stock,
+		})
+	}
+
+	if line.product != nil {
+		quoteLine.ProductName = line.product.Name
+		quoteLine.Price = line.product.Price
+		quoteLine.TotalPrice = line.product.Price.Mul(decimal.NewFromInt(int64(op.Stock)))
+	}
+
+	return quoteLine
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+)
+
+// CompleteOrder move the shipped order into completed once the user confirms the order is received,
+// the completed order is still returnable
+func (o *OrderUsecase) CompleteOrder(ctx context.Context, params *entity.CompleteOrderRequest) (*entity.Order, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	order, err := o.repos.OrderRepo.GetByID(ctx, params.OrderID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Validate order ownership
+	if order.UserID != params.User.ID {
+		return nil, liberr.ResolveError(entity.ErrorForbidden)
+	}
+
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	err = o.transitionOrderState(ctx, order, entity.OrderStateCompleted, &entity.OrderStateChange{
+		ActorType: entity.OrderStateActorTypeUser,
+		ActorID:   params.User.ID,
+		Reason:    "Received by user",
+	}, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return order, nil
+}
@@ -4,55 +4,158 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"sync"
 
 	"go.uber.org/zap"
 )
 
+type expiredOrderOutcome int
+
+const (
+	expiredOrderProcessed expiredOrderOutcome = iota
+	expiredOrderSkipped
+	expiredOrderFailed
+)
+
+// ExecuteExpiredOrder expire the created orders past the expiry. The orders are retrieved in batches
+// and each batch is processed by the worker pool, every order is claimed with a row lock
+// so the order processed by another worker or cron instance is skipped
 func (o *OrderUsecase) ExecuteExpiredOrder(ctx context.Context) error {
 	logFields := []zap.Field{
 		zap.String("function", "ExecuteExpiredOrder"),
 	}
 
-	expiredOrders, err := o.repos.OrderRepo.ListByOrderExpired(ctx)
-	if err != nil {
-		return err
-	}
-
-	for _, eo := range expiredOrders {
-		o.logger.Info(fmt.Sprintf("Expired Order ID : %s", eo.ID), logFields...)
+	batchSize := max(o.configs.OrderExpiredBatchSize, 1)
 
-		orderDetails, err := o.repos.OrderDetailRepo.ListByOrderID(ctx, eo.ID)
+	processed, skipped, failed := 0, 0, 0
+	lastID := ""
+	for ctx.Err() == nil {
+		expiredOrders, err := o.repos.OrderRepo.ListByOrderExpired(ctx, lastID, batchSize)
 		if err != nil {
-			o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on Retrieve Order Detail due %v", eo.ID, err), logFields...)
-			continue
+			return err
 		}
 
-		tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
-		if err != nil {
-			o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on Begin Transaction due %v", eo.ID, err), logFields...)
-			continue
+		for _, outcome := range o.processExpiredOrderBatch(ctx, expiredOrders, logFields) {
+			switch outcome {
+			case expiredOrderProcessed:
+				processed++
+			case expiredOrderSkipped:
+				skipped++
+			default:
+				failed++
+			}
 		}
 
-		_, err = o.repos.OrderRepo.UpdateExpired(ctx, eo.ID, tx)
-		if err != nil {
-			tx.Rollback() //nolint
-			o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on UpdateExpired due %v", eo.ID, err), logFields...)
-			continue
+		if len(expiredOrders) < batchSize {
+			break
 		}
+		lastID = expiredOrders[len(expiredOrders)-1].ID
+	}
 
-		err = o.releaseStocks(ctx, orderDetails)
-		if err != nil {
-			tx.Rollback() //nolint
-			o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on ReleaseStock due %v", eo.ID, err), logFields...)
-			continue
-		}
+	o.logger.Info(fmt.Sprintf("Expired Order processed %d, skipped %d, failed %d", processed, skipped, failed), logFields...)
 
-		err = tx.Commit()
-		if err != nil {
-			tx.Rollback() //nolint
-			o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on Commit due %v", eo.ID, err), logFields...)
+	return nil
+}
+
+// processExpiredOrderBatch expire the orders of the batch concurrently by the configured number of workers
+func (o *OrderUsecase) processExpiredOrderBatch(ctx context.Context, expiredOrders []*entity.Order, logFields []zap.Field) []expiredOrderOutcome {
+	outcomes := make([]expiredOrderOutcome, len(expiredOrders))
+	workerSize := min(max(o.configs.OrderExpiredWorkerSize, 1), len(expiredOrders))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workerSize; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				outcomes[i] = o.expireOrder(ctx, expiredOrders[i], logFields)
+			}
+		}()
+	}
+
+	for i := range expiredOrders {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return outcomes
+}
+
+func (o *OrderUsecase) expireOrder(ctx context.Context, expiredOrder *entity.Order, logFields []zap.Field) expiredOrderOutcome {
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on Begin Transaction due %v", expiredOrder.ID, err), logFields...)
+		return expiredOrderFailed
+	}
+
+	eo, err := o.repos.OrderRepo.ClaimExpiredByID(ctx, expiredOrder.ID, tx)
+	if err != nil {
+		tx.Rollback() //nolint
+		if berr, ok := err.(*liberr.BaseError); ok && berr.IsAnyCodeEqual(entity.ErrorCodeOrderNotFound) {
+			// Claimed by another worker, or no longer created
+			return expiredOrderSkipped
 		}
+		o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on Claim due %v", expiredOrder.ID, err), logFields...)
+		return expiredOrderFailed
 	}
 
-	return nil
+	// The captured payment moves the order into paid, the order is never expired once its payment is captured
+	payments, err := o.repos.PaymentRepo.ListByOrderID(ctx, eo.ID, tx)
+	if err != nil {
+		tx.Rollback() //nolint
+		o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on Retrieve Payment due %v", eo.ID, err), logFields...)
+		return expiredOrderFailed
+	}
+
+	if isPaymentCaptured(payments) {
+		tx.Rollback() //nolint
+		o.logger.Warn(fmt.Sprintf("Expired Order ID : %s Skipped due captured payment", eo.ID), logFields...)
+		return expiredOrderSkipped
+	}
+
+	o.logger.Info(fmt.Sprintf("Expired Order ID : %s", eo.ID), logFields...)
+
+	orderDetails, err := o.repos.OrderDetailRepo.ListByOrderID(ctx, eo.ID)
+	if err != nil {
+		tx.Rollback() //nolint
+		o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on Retrieve Order Detail due %v", eo.ID, err), logFields...)
+		return expiredOrderFailed
+	}
+
+	err = o.transitionOrderState(ctx, eo, entity.OrderStateExpired, &entity.OrderStateChange{
+		ActorType: entity.OrderStateActorTypeSystem,
+		ActorID:   orderStateActorExpiredOrderCron,
+		Reason:    "Payment deadline passed",
+	}, tx)
+	if err != nil {
+		tx.Rollback() //nolint
+		o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on Transition State due %v", eo.ID, err), logFields...)
+		return expiredOrderFailed
+	}
+
+	outbox, err := o.createOrderOutbox(ctx, eo.ID, entity.OrderOutboxCommandReleaseStock, releaseStockPayload(eo.ID, orderDetails), tx)
+	if err != nil {
+		tx.Rollback() //nolint
+		o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on Create Outbox due %v", eo.ID, err), logFields...)
+		return expiredOrderFailed
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback() //nolint
+		o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on Commit due %v", eo.ID, err), logFields...)
+		return expiredOrderFailed
+	}
+
+	// Expiration is already recorded, failed release is retried by the outbox relay
+	err = o.dispatchOrderOutbox(ctx, outbox)
+	if err != nil {
+		o.logger.Error(fmt.Sprintf("Expired Order ID : %s Failed on ReleaseStock due %v", eo.ID, err), logFields...)
+	}
+
+	return expiredOrderProcessed
 }
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+func (o *OrderUsecase) GetOrder(ctx context.Context, params *entity.GetOrderRequest) (*entity.OrderInformation, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	order, err := o.repos.OrderRepo.GetByID(ctx, params.OrderID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Validate order ownership
+	if order.UserID != params.User.ID {
+		return nil, liberr.ResolveError(entity.ErrorForbidden)
+	}
+
+	return o.getOrderInformation(ctx, order)
+}
+
+// getOrderInformation retrieve the ordered items with the warehouse breakdown along with the discounts,
+// the payments, the returns and the state histories of the order
+func (o *OrderUsecase) getOrderInformation(ctx context.Context, order *entity.Order) (*entity.OrderInformation, error) {
+	orderDetails, err := o.repos.OrderDetailRepo.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Retrieve product names
+	productIDsMap := make(map[string]struct{})
+	productIDs := []string{}
+	for _, od := range orderDetails {
+		if _, exists := productIDsMap[od.ProductID]; !exists {
+			productIDsMap[od.ProductID] = struct{}{}
+			productIDs = append(productIDs, od.ProductID)
+		}
+	}
+
+	// map[product_id]product_name
+	productNameMap := map[string]string{}
+	if len(productIDs) > 0 {
+		products, err := o.repos.ProductRepo.ListByProductIDs(ctx, productIDs)
+		if err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+
+		for _, p := range products {
+			productNameMap[p.ID] = p.Name
+		}
+	}
+
+	// Build order items with warehouse breakdown
+	items := []*entity.OrderItem{}
+	warehouses := []*entity.OrderWarehouse{}
+	warehouseMap := map[string]*entity.OrderWarehouse{}
+
+	for _, od := range orderDetails {
+		item := &entity.OrderItem{
+			ID:          od.ID,
+			ProductID:   od.ProductID,
+			ProductName: productNameMap[od.ProductID],
+			WarehouseID: od.WarehouseID,
+			Stock:       od.Stock,
+			Price:       od.Price,
+			TotalPrice:  od.Price.Mul(decimal.NewFromInt(int64(od.Stock))),
+		}
+		items = append(items, item)
+
+		warehouse, ok := warehouseMap[od.WarehouseID]
+		if !ok {
+			warehouse = &entity.OrderWarehouse{
+				WarehouseID: od.WarehouseID,
+				TotalPrice:  decimal.NewFromInt(0),
+				Items:       []*entity.OrderItem{},
+			}
+			warehouseMap[od.WarehouseID] = warehouse
+			warehouses = append(warehouses, warehouse)
+		}
+		warehouse.TotalStock += item.Stock
+		warehouse.TotalPrice = warehouse.TotalPrice.Add(item.TotalPrice)
+		warehouse.Items = append(warehouse.Items, item)
+	}
+
+	discounts, err := o.repos.OrderDiscountRepo.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	payments, err := o.repos.PaymentRepo.ListByOrderID(ctx, order.ID, nil)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	orderReturns, err := o.repos.OrderReturnRepo.ListByOrderID(ctx, order.ID, nil)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	stateHistories, err := o.repos.OrderStateHistoryRepo.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Remaining time is only relevant while the order is waiting for payment
+	expiresInSecond := 0
+	if order.State == entity.OrderStateCreated {
+		if remaining := order.ExpiredAt.Sub(util.NowUTCWithoutNanoSecond()); remaining > 0 {
+			expiresInSecond = int(remaining.Seconds())
+		}
+	}
+
+	return &entity.OrderInformation{
+		Order:           order,
+		ExpiresInSecond: expiresInSecond,
+		Items:           items,
+		Warehouses:      warehouses,
+		Discounts:       discounts,
+		Payments:        payments,
+		Returns:         orderReturns,
+		RefundPrice:     refundPrice(orderReturns),
+		StateHistories:  stateHistories,
+	}, nil
+}
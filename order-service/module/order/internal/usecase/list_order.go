@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libpagination"
+	"order-service/module/order/entity"
+)
+
+func (o *OrderUsecase) ListOrder(ctx context.Context, params *entity.ListOrderByParams) ([]*entity.Order, *libpagination.OffsetPagination, error) {
+	params.Offset = libpagination.Offset(params.Page, params.Limit)
+
+	orders, pagination, err := o.repos.OrderRepo.ListByParams(ctx, params)
+	if err != nil {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	return orders, pagination, nil
+}
@@ -0,0 +1,1906 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	util "order-service/internal/util"
+	libpagination "order-service/internal/util/libpagination"
+	entity "order-service/module/order/entity"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	decimal "github.com/shopspring/decimal"
+)
+
+// MockOrderRepository is a mock of OrderRepository interface.
+type MockOrderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderRepositoryMockRecorder
+}
+
+// MockOrderRepositoryMockRecorder is the mock recorder for MockOrderRepository.
+type MockOrderRepositoryMockRecorder struct {
+	mock *MockOrderRepository
+}
+
+// NewMockOrderRepository creates a new mock instance.
+func NewMockOrderRepository(ctrl *gomock.Controller) *MockOrderRepository {
+	mock := &MockOrderRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderRepository) EXPECT() *MockOrderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ClaimExpiredByID mocks base method.
+func (m *MockOrderRepository) ClaimExpiredByID(ctx context.Context, id string, tx util.DatabaseTransaction) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimExpiredByID", ctx, id, tx)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimExpiredByID indicates an expected call of ClaimExpiredByID.
+func (mr *MockOrderRepositoryMockRecorder) ClaimExpiredByID(ctx, id, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimExpiredByID", reflect.TypeOf((*MockOrderRepository)(nil).ClaimExpiredByID), ctx, id, tx)
+}
+
+// Create mocks base method.
+func (m *MockOrderRepository) Create(ctx context.Context, order *entity.Order, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, order, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderRepositoryMockRecorder) Create(ctx, order, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderRepository)(nil).Create), ctx, order, tx)
+}
+
+// GetByID mocks base method.
+func (m *MockOrderRepository) GetByID(ctx context.Context, id string) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOrderRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrderRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByIDForUpdate mocks base method.
+func (m *MockOrderRepository) GetByIDForUpdate(ctx context.Context, id string, tx util.DatabaseTransaction) (*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIDForUpdate", ctx, id, tx)
+	ret0, _ := ret[0].(*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIDForUpdate indicates an expected call of GetByIDForUpdate.
+func (mr *MockOrderRepositoryMockRecorder) GetByIDForUpdate(ctx, id, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIDForUpdate", reflect.TypeOf((*MockOrderRepository)(nil).GetByIDForUpdate), ctx, id, tx)
+}
+
+// ListByCheckoutID mocks base method.
+func (m *MockOrderRepository) ListByCheckoutID(ctx context.Context, checkoutID string) ([]*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCheckoutID", ctx, checkoutID)
+	ret0, _ := ret[0].([]*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCheckoutID indicates an expected call of ListByCheckoutID.
+func (mr *MockOrderRepositoryMockRecorder) ListByCheckoutID(ctx, checkoutID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCheckoutID", reflect.TypeOf((*MockOrderRepository)(nil).ListByCheckoutID), ctx, checkoutID)
+}
+
+// ListByOrderExpired mocks base method.
+func (m *MockOrderRepository) ListByOrderExpired(ctx context.Context, lastID string, limit int) ([]*entity.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOrderExpired", ctx, lastID, limit)
+	ret0, _ := ret[0].([]*entity.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOrderExpired indicates an expected call of ListByOrderExpired.
+func (mr *MockOrderRepositoryMockRecorder) ListByOrderExpired(ctx, lastID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOrderExpired", reflect.TypeOf((*MockOrderRepository)(nil).ListByOrderExpired), ctx, lastID, limit)
+}
+
+// ListByParams mocks base method.
+func (m *MockOrderRepository) ListByParams(ctx context.Context, params *entity.ListOrderByParams) ([]*entity.Order, *libpagination.OffsetPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParams", ctx, params)
+	ret0, _ := ret[0].([]*entity.Order)
+	ret1, _ := ret[1].(*libpagination.OffsetPagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByParams indicates an expected call of ListByParams.
+func (mr *MockOrderRepositoryMockRecorder) ListByParams(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParams", reflect.TypeOf((*MockOrderRepository)(nil).ListByParams), ctx, params)
+}
+
+// UpdateManualHandlingReason mocks base method.
+func (m *MockOrderRepository) UpdateManualHandlingReason(ctx context.Context, id, reason string, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateManualHandlingReason", ctx, id, reason, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateManualHandlingReason indicates an expected call of UpdateManualHandlingReason.
+func (mr *MockOrderRepositoryMockRecorder) UpdateManualHandlingReason(ctx, id, reason, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateManualHandlingReason", reflect.TypeOf((*MockOrderRepository)(nil).UpdateManualHandlingReason), ctx, id, reason, tx)
+}
+
+// UpdateState mocks base method.
+func (m *MockOrderRepository) UpdateState(ctx context.Context, id string, fromState, toState entity.OrderState, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateState", ctx, id, fromState, toState, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateState indicates an expected call of UpdateState.
+func (mr *MockOrderRepositoryMockRecorder) UpdateState(ctx, id, fromState, toState, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateState", reflect.TypeOf((*MockOrderRepository)(nil).UpdateState), ctx, id, fromState, toState, tx)
+}
+
+// MockCheckoutRepository is a mock of CheckoutRepository interface.
+type MockCheckoutRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCheckoutRepositoryMockRecorder
+}
+
+// MockCheckoutRepositoryMockRecorder is the mock recorder for MockCheckoutRepository.
+type MockCheckoutRepositoryMockRecorder struct {
+	mock *MockCheckoutRepository
+}
+
+// NewMockCheckoutRepository creates a new mock instance.
+func NewMockCheckoutRepository(ctrl *gomock.Controller) *MockCheckoutRepository {
+	mock := &MockCheckoutRepository{ctrl: ctrl}
+	mock.recorder = &MockCheckoutRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCheckoutRepository) EXPECT() *MockCheckoutRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCheckoutRepository) Create(ctx context.Context, checkout *entity.Checkout, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, checkout, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCheckoutRepositoryMockRecorder) Create(ctx, checkout, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCheckoutRepository)(nil).Create), ctx, checkout, tx)
+}
+
+// GetByID mocks base method.
+func (m *MockCheckoutRepository) GetByID(ctx context.Context, id string) (*entity.Checkout, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Checkout)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCheckoutRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCheckoutRepository)(nil).GetByID), ctx, id)
+}
+
+// MockCartItemRepository is a mock of CartItemRepository interface.
+type MockCartItemRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCartItemRepositoryMockRecorder
+}
+
+// MockCartItemRepositoryMockRecorder is the mock recorder for MockCartItemRepository.
+type MockCartItemRepositoryMockRecorder struct {
+	mock *MockCartItemRepository
+}
+
+// NewMockCartItemRepository creates a new mock instance.
+func NewMockCartItemRepository(ctrl *gomock.Controller) *MockCartItemRepository {
+	mock := &MockCartItemRepository{ctrl: ctrl}
+	mock.recorder = &MockCartItemRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCartItemRepository) EXPECT() *MockCartItemRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCartItemRepository) Create(ctx context.Context, cartItem *entity.CartItem, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, cartItem, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCartItemRepositoryMockRecorder) Create(ctx, cartItem, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCartItemRepository)(nil).Create), ctx, cartItem, tx)
+}
+
+// DeleteByIDs mocks base method.
+func (m *MockCartItemRepository) DeleteByIDs(ctx context.Context, userID string, ids []string, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByIDs", ctx, userID, ids, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByIDs indicates an expected call of DeleteByIDs.
+func (mr *MockCartItemRepositoryMockRecorder) DeleteByIDs(ctx, userID, ids, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByIDs", reflect.TypeOf((*MockCartItemRepository)(nil).DeleteByIDs), ctx, userID, ids, tx)
+}
+
+// GetByID mocks base method.
+func (m *MockCartItemRepository) GetByID(ctx context.Context, id string) (*entity.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockCartItemRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockCartItemRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByUserIDAndProductID mocks base method.
+func (m *MockCartItemRepository) GetByUserIDAndProductID(ctx context.Context, userID, shopID, productID string) (*entity.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndProductID", ctx, userID, shopID, productID)
+	ret0, _ := ret[0].(*entity.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndProductID indicates an expected call of GetByUserIDAndProductID.
+func (mr *MockCartItemRepositoryMockRecorder) GetByUserIDAndProductID(ctx, userID, shopID, productID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndProductID", reflect.TypeOf((*MockCartItemRepository)(nil).GetByUserIDAndProductID), ctx, userID, shopID, productID)
+}
+
+// ListByUserID mocks base method.
+func (m *MockCartItemRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.CartItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.CartItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockCartItemRepositoryMockRecorder) ListByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockCartItemRepository)(nil).ListByUserID), ctx, userID)
+}
+
+// UpdateStock mocks base method.
+func (m *MockCartItemRepository) UpdateStock(ctx context.Context, id string, stock int, price decimal.Decimal, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStock", ctx, id, stock, price, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStock indicates an expected call of UpdateStock.
+func (mr *MockCartItemRepositoryMockRecorder) UpdateStock(ctx, id, stock, price, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStock", reflect.TypeOf((*MockCartItemRepository)(nil).UpdateStock), ctx, id, stock, price, tx)
+}
+
+// MockOrderDetailRepository is a mock of OrderDetailRepository interface.
+type MockOrderDetailRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderDetailRepositoryMockRecorder
+}
+
+// MockOrderDetailRepositoryMockRecorder is the mock recorder for MockOrderDetailRepository.
+type MockOrderDetailRepositoryMockRecorder struct {
+	mock *MockOrderDetailRepository
+}
+
+// NewMockOrderDetailRepository creates a new mock instance.
+func NewMockOrderDetailRepository(ctrl *gomock.Controller) *MockOrderDetailRepository {
+	mock := &MockOrderDetailRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderDetailRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderDetailRepository) EXPECT() *MockOrderDetailRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrderDetailRepository) Create(ctx context.Context, orderDetail *entity.OrderDetail, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, orderDetail, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderDetailRepositoryMockRecorder) Create(ctx, orderDetail, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderDetailRepository)(nil).Create), ctx, orderDetail, tx)
+}
+
+// ListByOrderID mocks base method.
+func (m *MockOrderDetailRepository) ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderDetail, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOrderID", ctx, orderID)
+	ret0, _ := ret[0].([]*entity.OrderDetail)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOrderID indicates an expected call of ListByOrderID.
+func (mr *MockOrderDetailRepositoryMockRecorder) ListByOrderID(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOrderID", reflect.TypeOf((*MockOrderDetailRepository)(nil).ListByOrderID), ctx, orderID)
+}
+
+// MockOrderIdempotencyKeyRepository is a mock of OrderIdempotencyKeyRepository interface.
+type MockOrderIdempotencyKeyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderIdempotencyKeyRepositoryMockRecorder
+}
+
+// MockOrderIdempotencyKeyRepositoryMockRecorder is the mock recorder for MockOrderIdempotencyKeyRepository.
+type MockOrderIdempotencyKeyRepositoryMockRecorder struct {
+	mock *MockOrderIdempotencyKeyRepository
+}
+
+// NewMockOrderIdempotencyKeyRepository creates a new mock instance.
+func NewMockOrderIdempotencyKeyRepository(ctrl *gomock.Controller) *MockOrderIdempotencyKeyRepository {
+	mock := &MockOrderIdempotencyKeyRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderIdempotencyKeyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderIdempotencyKeyRepository) EXPECT() *MockOrderIdempotencyKeyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrderIdempotencyKeyRepository) Create(ctx context.Context, idempotencyKey *entity.OrderIdempotencyKey, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, idempotencyKey, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderIdempotencyKeyRepositoryMockRecorder) Create(ctx, idempotencyKey, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderIdempotencyKeyRepository)(nil).Create), ctx, idempotencyKey, tx)
+}
+
+// DeleteProcessing mocks base method.
+func (m *MockOrderIdempotencyKeyRepository) DeleteProcessing(ctx context.Context, id string, lockedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteProcessing", ctx, id, lockedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteProcessing indicates an expected call of DeleteProcessing.
+func (mr *MockOrderIdempotencyKeyRepositoryMockRecorder) DeleteProcessing(ctx, id, lockedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteProcessing", reflect.TypeOf((*MockOrderIdempotencyKeyRepository)(nil).DeleteProcessing), ctx, id, lockedUntil)
+}
+
+// GetByUserIDAndKey mocks base method.
+func (m *MockOrderIdempotencyKeyRepository) GetByUserIDAndKey(ctx context.Context, userID, key string) (*entity.OrderIdempotencyKey, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndKey", ctx, userID, key)
+	ret0, _ := ret[0].(*entity.OrderIdempotencyKey)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndKey indicates an expected call of GetByUserIDAndKey.
+func (mr *MockOrderIdempotencyKeyRepositoryMockRecorder) GetByUserIDAndKey(ctx, userID, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndKey", reflect.TypeOf((*MockOrderIdempotencyKeyRepository)(nil).GetByUserIDAndKey), ctx, userID, key)
+}
+
+// TakeOver mocks base method.
+func (m *MockOrderIdempotencyKeyRepository) TakeOver(ctx context.Context, id string, lockedUntil, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeOver", ctx, id, lockedUntil, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeOver indicates an expected call of TakeOver.
+func (mr *MockOrderIdempotencyKeyRepositoryMockRecorder) TakeOver(ctx, id, lockedUntil, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeOver", reflect.TypeOf((*MockOrderIdempotencyKeyRepository)(nil).TakeOver), ctx, id, lockedUntil, now)
+}
+
+// UpdateCompleted mocks base method.
+func (m *MockOrderIdempotencyKeyRepository) UpdateCompleted(ctx context.Context, id string, lockedUntil time.Time, orderID string, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCompleted", ctx, id, lockedUntil, orderID, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCompleted indicates an expected call of UpdateCompleted.
+func (mr *MockOrderIdempotencyKeyRepositoryMockRecorder) UpdateCompleted(ctx, id, lockedUntil, orderID, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompleted", reflect.TypeOf((*MockOrderIdempotencyKeyRepository)(nil).UpdateCompleted), ctx, id, lockedUntil, orderID, tx)
+}
+
+// MockOrderOutboxRepository is a mock of OrderOutboxRepository interface.
+type MockOrderOutboxRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderOutboxRepositoryMockRecorder
+}
+
+// MockOrderOutboxRepositoryMockRecorder is the mock recorder for MockOrderOutboxRepository.
+type MockOrderOutboxRepositoryMockRecorder struct {
+	mock *MockOrderOutboxRepository
+}
+
+// NewMockOrderOutboxRepository creates a new mock instance.
+func NewMockOrderOutboxRepository(ctrl *gomock.Controller) *MockOrderOutboxRepository {
+	mock := &MockOrderOutboxRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderOutboxRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderOutboxRepository) EXPECT() *MockOrderOutboxRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockOrderOutboxRepository) Claim(ctx context.Context, id string, attempt int, nextAttemptAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, id, attempt, nextAttemptAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockOrderOutboxRepositoryMockRecorder) Claim(ctx, id, attempt, nextAttemptAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockOrderOutboxRepository)(nil).Claim), ctx, id, attempt, nextAttemptAt)
+}
+
+// Create mocks base method.
+func (m *MockOrderOutboxRepository) Create(ctx context.Context, outbox *entity.OrderOutbox, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, outbox, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderOutboxRepositoryMockRecorder) Create(ctx, outbox, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderOutboxRepository)(nil).Create), ctx, outbox, tx)
+}
+
+// ListByOrderID mocks base method.
+func (m *MockOrderOutboxRepository) ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOrderID", ctx, orderID)
+	ret0, _ := ret[0].([]*entity.OrderOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOrderID indicates an expected call of ListByOrderID.
+func (mr *MockOrderOutboxRepositoryMockRecorder) ListByOrderID(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOrderID", reflect.TypeOf((*MockOrderOutboxRepository)(nil).ListByOrderID), ctx, orderID)
+}
+
+// ListByOrderIDForUpdate mocks base method.
+func (m *MockOrderOutboxRepository) ListByOrderIDForUpdate(ctx context.Context, orderID string, tx util.DatabaseTransaction) ([]*entity.OrderOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOrderIDForUpdate", ctx, orderID, tx)
+	ret0, _ := ret[0].([]*entity.OrderOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOrderIDForUpdate indicates an expected call of ListByOrderIDForUpdate.
+func (mr *MockOrderOutboxRepositoryMockRecorder) ListByOrderIDForUpdate(ctx, orderID, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOrderIDForUpdate", reflect.TypeOf((*MockOrderOutboxRepository)(nil).ListByOrderIDForUpdate), ctx, orderID, tx)
+}
+
+// ListPending mocks base method.
+func (m *MockOrderOutboxRepository) ListPending(ctx context.Context, now time.Time, limit int) ([]*entity.OrderOutbox, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.OrderOutbox)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockOrderOutboxRepositoryMockRecorder) ListPending(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockOrderOutboxRepository)(nil).ListPending), ctx, now, limit)
+}
+
+// UpdateLastError mocks base method.
+func (m *MockOrderOutboxRepository) UpdateLastError(ctx context.Context, id, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastError", ctx, id, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastError indicates an expected call of UpdateLastError.
+func (mr *MockOrderOutboxRepositoryMockRecorder) UpdateLastError(ctx, id, lastError interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastError", reflect.TypeOf((*MockOrderOutboxRepository)(nil).UpdateLastError), ctx, id, lastError)
+}
+
+// UpdateState mocks base method.
+func (m *MockOrderOutboxRepository) UpdateState(ctx context.Context, id string, toState entity.OrderOutboxState, lastError string, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateState", ctx, id, toState, lastError, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateState indicates an expected call of UpdateState.
+func (mr *MockOrderOutboxRepositoryMockRecorder) UpdateState(ctx, id, toState, lastError, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateState", reflect.TypeOf((*MockOrderOutboxRepository)(nil).UpdateState), ctx, id, toState, lastError, tx)
+}
+
+// MockVoucherRepository is a mock of VoucherRepository interface.
+type MockVoucherRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoucherRepositoryMockRecorder
+}
+
+// MockVoucherRepositoryMockRecorder is the mock recorder for MockVoucherRepository.
+type MockVoucherRepositoryMockRecorder struct {
+	mock *MockVoucherRepository
+}
+
+// NewMockVoucherRepository creates a new mock instance.
+func NewMockVoucherRepository(ctrl *gomock.Controller) *MockVoucherRepository {
+	mock := &MockVoucherRepository{ctrl: ctrl}
+	mock.recorder = &MockVoucherRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVoucherRepository) EXPECT() *MockVoucherRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockVoucherRepository) Create(ctx context.Context, voucher *entity.Voucher, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, voucher, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVoucherRepositoryMockRecorder) Create(ctx, voucher, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVoucherRepository)(nil).Create), ctx, voucher, tx)
+}
+
+// DecrementUsedCount mocks base method.
+func (m *MockVoucherRepository) DecrementUsedCount(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrementUsedCount", ctx, id, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DecrementUsedCount indicates an expected call of DecrementUsedCount.
+func (mr *MockVoucherRepositoryMockRecorder) DecrementUsedCount(ctx, id, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrementUsedCount", reflect.TypeOf((*MockVoucherRepository)(nil).DecrementUsedCount), ctx, id, tx)
+}
+
+// GetByID mocks base method.
+func (m *MockVoucherRepository) GetByID(ctx context.Context, id string) (*entity.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockVoucherRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockVoucherRepository)(nil).GetByID), ctx, id)
+}
+
+// IncrementUsedCount mocks base method.
+func (m *MockVoucherRepository) IncrementUsedCount(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementUsedCount", ctx, id, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementUsedCount indicates an expected call of IncrementUsedCount.
+func (mr *MockVoucherRepositoryMockRecorder) IncrementUsedCount(ctx, id, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementUsedCount", reflect.TypeOf((*MockVoucherRepository)(nil).IncrementUsedCount), ctx, id, tx)
+}
+
+// ListByCodes mocks base method.
+func (m *MockVoucherRepository) ListByCodes(ctx context.Context, codes []string) ([]*entity.Voucher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByCodes", ctx, codes)
+	ret0, _ := ret[0].([]*entity.Voucher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByCodes indicates an expected call of ListByCodes.
+func (mr *MockVoucherRepositoryMockRecorder) ListByCodes(ctx, codes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByCodes", reflect.TypeOf((*MockVoucherRepository)(nil).ListByCodes), ctx, codes)
+}
+
+// MockVoucherUsageRepository is a mock of VoucherUsageRepository interface.
+type MockVoucherUsageRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockVoucherUsageRepositoryMockRecorder
+}
+
+// MockVoucherUsageRepositoryMockRecorder is the mock recorder for MockVoucherUsageRepository.
+type MockVoucherUsageRepositoryMockRecorder struct {
+	mock *MockVoucherUsageRepository
+}
+
+// NewMockVoucherUsageRepository creates a new mock instance.
+func NewMockVoucherUsageRepository(ctrl *gomock.Controller) *MockVoucherUsageRepository {
+	mock := &MockVoucherUsageRepository{ctrl: ctrl}
+	mock.recorder = &MockVoucherUsageRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVoucherUsageRepository) EXPECT() *MockVoucherUsageRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CountByVoucherIDAndUserID mocks base method.
+func (m *MockVoucherUsageRepository) CountByVoucherIDAndUserID(ctx context.Context, voucherID, userID string, tx util.DatabaseTransaction) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountByVoucherIDAndUserID", ctx, voucherID, userID, tx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountByVoucherIDAndUserID indicates an expected call of CountByVoucherIDAndUserID.
+func (mr *MockVoucherUsageRepositoryMockRecorder) CountByVoucherIDAndUserID(ctx, voucherID, userID, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountByVoucherIDAndUserID", reflect.TypeOf((*MockVoucherUsageRepository)(nil).CountByVoucherIDAndUserID), ctx, voucherID, userID, tx)
+}
+
+// Create mocks base method.
+func (m *MockVoucherUsageRepository) Create(ctx context.Context, voucherUsage *entity.VoucherUsage, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, voucherUsage, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockVoucherUsageRepositoryMockRecorder) Create(ctx, voucherUsage, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVoucherUsageRepository)(nil).Create), ctx, voucherUsage, tx)
+}
+
+// DeleteByOrderID mocks base method.
+func (m *MockVoucherUsageRepository) DeleteByOrderID(ctx context.Context, orderID string, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByOrderID", ctx, orderID, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByOrderID indicates an expected call of DeleteByOrderID.
+func (mr *MockVoucherUsageRepositoryMockRecorder) DeleteByOrderID(ctx, orderID, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByOrderID", reflect.TypeOf((*MockVoucherUsageRepository)(nil).DeleteByOrderID), ctx, orderID, tx)
+}
+
+// ListByOrderID mocks base method.
+func (m *MockVoucherUsageRepository) ListByOrderID(ctx context.Context, orderID string) ([]*entity.VoucherUsage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOrderID", ctx, orderID)
+	ret0, _ := ret[0].([]*entity.VoucherUsage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOrderID indicates an expected call of ListByOrderID.
+func (mr *MockVoucherUsageRepositoryMockRecorder) ListByOrderID(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOrderID", reflect.TypeOf((*MockVoucherUsageRepository)(nil).ListByOrderID), ctx, orderID)
+}
+
+// MockOrderDiscountRepository is a mock of OrderDiscountRepository interface.
+type MockOrderDiscountRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderDiscountRepositoryMockRecorder
+}
+
+// MockOrderDiscountRepositoryMockRecorder is the mock recorder for MockOrderDiscountRepository.
+type MockOrderDiscountRepositoryMockRecorder struct {
+	mock *MockOrderDiscountRepository
+}
+
+// NewMockOrderDiscountRepository creates a new mock instance.
+func NewMockOrderDiscountRepository(ctrl *gomock.Controller) *MockOrderDiscountRepository {
+	mock := &MockOrderDiscountRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderDiscountRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderDiscountRepository) EXPECT() *MockOrderDiscountRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrderDiscountRepository) Create(ctx context.Context, orderDiscount *entity.OrderDiscount, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, orderDiscount, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderDiscountRepositoryMockRecorder) Create(ctx, orderDiscount, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderDiscountRepository)(nil).Create), ctx, orderDiscount, tx)
+}
+
+// ListByOrderID mocks base method.
+func (m *MockOrderDiscountRepository) ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderDiscount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOrderID", ctx, orderID)
+	ret0, _ := ret[0].([]*entity.OrderDiscount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOrderID indicates an expected call of ListByOrderID.
+func (mr *MockOrderDiscountRepositoryMockRecorder) ListByOrderID(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOrderID", reflect.TypeOf((*MockOrderDiscountRepository)(nil).ListByOrderID), ctx, orderID)
+}
+
+// MockShopConfigRepository is a mock of ShopConfigRepository interface.
+type MockShopConfigRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShopConfigRepositoryMockRecorder
+}
+
+// MockShopConfigRepositoryMockRecorder is the mock recorder for MockShopConfigRepository.
+type MockShopConfigRepositoryMockRecorder struct {
+	mock *MockShopConfigRepository
+}
+
+// NewMockShopConfigRepository creates a new mock instance.
+func NewMockShopConfigRepository(ctrl *gomock.Controller) *MockShopConfigRepository {
+	mock := &MockShopConfigRepository{ctrl: ctrl}
+	mock.recorder = &MockShopConfigRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShopConfigRepository) EXPECT() *MockShopConfigRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetByShopID mocks base method.
+func (m *MockShopConfigRepository) GetByShopID(ctx context.Context, shopID string) (*entity.ShopConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByShopID", ctx, shopID)
+	ret0, _ := ret[0].(*entity.ShopConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByShopID indicates an expected call of GetByShopID.
+func (mr *MockShopConfigRepositoryMockRecorder) GetByShopID(ctx, shopID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByShopID", reflect.TypeOf((*MockShopConfigRepository)(nil).GetByShopID), ctx, shopID)
+}
+
+// ListByShopIDs mocks base method.
+func (m *MockShopConfigRepository) ListByShopIDs(ctx context.Context, shopIDs []string) ([]*entity.ShopConfig, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByShopIDs", ctx, shopIDs)
+	ret0, _ := ret[0].([]*entity.ShopConfig)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByShopIDs indicates an expected call of ListByShopIDs.
+func (mr *MockShopConfigRepositoryMockRecorder) ListByShopIDs(ctx, shopIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByShopIDs", reflect.TypeOf((*MockShopConfigRepository)(nil).ListByShopIDs), ctx, shopIDs)
+}
+
+// Upsert mocks base method.
+func (m *MockShopConfigRepository) Upsert(ctx context.Context, shopConfig *entity.ShopConfig, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, shopConfig, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockShopConfigRepositoryMockRecorder) Upsert(ctx, shopConfig, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockShopConfigRepository)(nil).Upsert), ctx, shopConfig, tx)
+}
+
+// MockTaxRateRepository is a mock of TaxRateRepository interface.
+type MockTaxRateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaxRateRepositoryMockRecorder
+}
+
+// MockTaxRateRepositoryMockRecorder is the mock recorder for MockTaxRateRepository.
+type MockTaxRateRepositoryMockRecorder struct {
+	mock *MockTaxRateRepository
+}
+
+// NewMockTaxRateRepository creates a new mock instance.
+func NewMockTaxRateRepository(ctrl *gomock.Controller) *MockTaxRateRepository {
+	mock := &MockTaxRateRepository{ctrl: ctrl}
+	mock.recorder = &MockTaxRateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaxRateRepository) EXPECT() *MockTaxRateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockTaxRateRepository) List(ctx context.Context) ([]*entity.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*entity.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTaxRateRepositoryMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTaxRateRepository)(nil).List), ctx)
+}
+
+// ListByRegions mocks base method.
+func (m *MockTaxRateRepository) ListByRegions(ctx context.Context, regions []string) ([]*entity.TaxRate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByRegions", ctx, regions)
+	ret0, _ := ret[0].([]*entity.TaxRate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByRegions indicates an expected call of ListByRegions.
+func (mr *MockTaxRateRepositoryMockRecorder) ListByRegions(ctx, regions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByRegions", reflect.TypeOf((*MockTaxRateRepository)(nil).ListByRegions), ctx, regions)
+}
+
+// Upsert mocks base method.
+func (m *MockTaxRateRepository) Upsert(ctx context.Context, taxRate *entity.TaxRate, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, taxRate, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockTaxRateRepositoryMockRecorder) Upsert(ctx, taxRate, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockTaxRateRepository)(nil).Upsert), ctx, taxRate, tx)
+}
+
+// MockOrderReturnRepository is a mock of OrderReturnRepository interface.
+type MockOrderReturnRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderReturnRepositoryMockRecorder
+}
+
+// MockOrderReturnRepositoryMockRecorder is the mock recorder for MockOrderReturnRepository.
+type MockOrderReturnRepositoryMockRecorder struct {
+	mock *MockOrderReturnRepository
+}
+
+// NewMockOrderReturnRepository creates a new mock instance.
+func NewMockOrderReturnRepository(ctrl *gomock.Controller) *MockOrderReturnRepository {
+	mock := &MockOrderReturnRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderReturnRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderReturnRepository) EXPECT() *MockOrderReturnRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrderReturnRepository) Create(ctx context.Context, orderReturn *entity.OrderReturn, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, orderReturn, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderReturnRepositoryMockRecorder) Create(ctx, orderReturn, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderReturnRepository)(nil).Create), ctx, orderReturn, tx)
+}
+
+// GetByID mocks base method.
+func (m *MockOrderReturnRepository) GetByID(ctx context.Context, id string) (*entity.OrderReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.OrderReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockOrderReturnRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockOrderReturnRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByOrderID mocks base method.
+func (m *MockOrderReturnRepository) ListByOrderID(ctx context.Context, orderID string, tx util.DatabaseTransaction) ([]*entity.OrderReturn, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOrderID", ctx, orderID, tx)
+	ret0, _ := ret[0].([]*entity.OrderReturn)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOrderID indicates an expected call of ListByOrderID.
+func (mr *MockOrderReturnRepositoryMockRecorder) ListByOrderID(ctx, orderID, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOrderID", reflect.TypeOf((*MockOrderReturnRepository)(nil).ListByOrderID), ctx, orderID, tx)
+}
+
+// UpdateReviewed mocks base method.
+func (m *MockOrderReturnRepository) UpdateReviewed(ctx context.Context, id string, toState entity.OrderReturnState, reviewNote string, reviewedAt time.Time, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateReviewed", ctx, id, toState, reviewNote, reviewedAt, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateReviewed indicates an expected call of UpdateReviewed.
+func (mr *MockOrderReturnRepositoryMockRecorder) UpdateReviewed(ctx, id, toState, reviewNote, reviewedAt, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateReviewed", reflect.TypeOf((*MockOrderReturnRepository)(nil).UpdateReviewed), ctx, id, toState, reviewNote, reviewedAt, tx)
+}
+
+// MockOrderStateHistoryRepository is a mock of OrderStateHistoryRepository interface.
+type MockOrderStateHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderStateHistoryRepositoryMockRecorder
+}
+
+// MockOrderStateHistoryRepositoryMockRecorder is the mock recorder for MockOrderStateHistoryRepository.
+type MockOrderStateHistoryRepositoryMockRecorder struct {
+	mock *MockOrderStateHistoryRepository
+}
+
+// NewMockOrderStateHistoryRepository creates a new mock instance.
+func NewMockOrderStateHistoryRepository(ctrl *gomock.Controller) *MockOrderStateHistoryRepository {
+	mock := &MockOrderStateHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderStateHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderStateHistoryRepository) EXPECT() *MockOrderStateHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockOrderStateHistoryRepository) Create(ctx context.Context, history *entity.OrderStateHistory, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, history, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockOrderStateHistoryRepositoryMockRecorder) Create(ctx, history, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockOrderStateHistoryRepository)(nil).Create), ctx, history, tx)
+}
+
+// ListByOrderID mocks base method.
+func (m *MockOrderStateHistoryRepository) ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderStateHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOrderID", ctx, orderID)
+	ret0, _ := ret[0].([]*entity.OrderStateHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOrderID indicates an expected call of ListByOrderID.
+func (mr *MockOrderStateHistoryRepositoryMockRecorder) ListByOrderID(ctx, orderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOrderID", reflect.TypeOf((*MockOrderStateHistoryRepository)(nil).ListByOrderID), ctx, orderID)
+}
+
+// MockOrderExpirationPolicyRepository is a mock of OrderExpirationPolicyRepository interface.
+type MockOrderExpirationPolicyRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderExpirationPolicyRepositoryMockRecorder
+}
+
+// MockOrderExpirationPolicyRepositoryMockRecorder is the mock recorder for MockOrderExpirationPolicyRepository.
+type MockOrderExpirationPolicyRepositoryMockRecorder struct {
+	mock *MockOrderExpirationPolicyRepository
+}
+
+// NewMockOrderExpirationPolicyRepository creates a new mock instance.
+func NewMockOrderExpirationPolicyRepository(ctrl *gomock.Controller) *MockOrderExpirationPolicyRepository {
+	mock := &MockOrderExpirationPolicyRepository{ctrl: ctrl}
+	mock.recorder = &MockOrderExpirationPolicyRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderExpirationPolicyRepository) EXPECT() *MockOrderExpirationPolicyRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteByScope mocks base method.
+func (m *MockOrderExpirationPolicyRepository) DeleteByScope(ctx context.Context, scope entity.OrderExpirationPolicyScope, scopeID string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteByScope", ctx, scope, scopeID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteByScope indicates an expected call of DeleteByScope.
+func (mr *MockOrderExpirationPolicyRepositoryMockRecorder) DeleteByScope(ctx, scope, scopeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteByScope", reflect.TypeOf((*MockOrderExpirationPolicyRepository)(nil).DeleteByScope), ctx, scope, scopeID)
+}
+
+// GetByScope mocks base method.
+func (m *MockOrderExpirationPolicyRepository) GetByScope(ctx context.Context, scope entity.OrderExpirationPolicyScope, scopeID string) (*entity.OrderExpirationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByScope", ctx, scope, scopeID)
+	ret0, _ := ret[0].(*entity.OrderExpirationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByScope indicates an expected call of GetByScope.
+func (mr *MockOrderExpirationPolicyRepositoryMockRecorder) GetByScope(ctx, scope, scopeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByScope", reflect.TypeOf((*MockOrderExpirationPolicyRepository)(nil).GetByScope), ctx, scope, scopeID)
+}
+
+// List mocks base method.
+func (m *MockOrderExpirationPolicyRepository) List(ctx context.Context) ([]*entity.OrderExpirationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx)
+	ret0, _ := ret[0].([]*entity.OrderExpirationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockOrderExpirationPolicyRepositoryMockRecorder) List(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockOrderExpirationPolicyRepository)(nil).List), ctx)
+}
+
+// ListByScopeIDs mocks base method.
+func (m *MockOrderExpirationPolicyRepository) ListByScopeIDs(ctx context.Context, shopIDs, productIDs []string) ([]*entity.OrderExpirationPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByScopeIDs", ctx, shopIDs, productIDs)
+	ret0, _ := ret[0].([]*entity.OrderExpirationPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByScopeIDs indicates an expected call of ListByScopeIDs.
+func (mr *MockOrderExpirationPolicyRepositoryMockRecorder) ListByScopeIDs(ctx, shopIDs, productIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByScopeIDs", reflect.TypeOf((*MockOrderExpirationPolicyRepository)(nil).ListByScopeIDs), ctx, shopIDs, productIDs)
+}
+
+// Upsert mocks base method.
+func (m *MockOrderExpirationPolicyRepository) Upsert(ctx context.Context, policy *entity.OrderExpirationPolicy, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Upsert", ctx, policy, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Upsert indicates an expected call of Upsert.
+func (mr *MockOrderExpirationPolicyRepositoryMockRecorder) Upsert(ctx, policy, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upsert", reflect.TypeOf((*MockOrderExpirationPolicyRepository)(nil).Upsert), ctx, policy, tx)
+}
+
+// MockSalesRollupRepository is a mock of SalesRollupRepository interface.
+type MockSalesRollupRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSalesRollupRepositoryMockRecorder
+}
+
+// MockSalesRollupRepositoryMockRecorder is the mock recorder for MockSalesRollupRepository.
+type MockSalesRollupRepositoryMockRecorder struct {
+	mock *MockSalesRollupRepository
+}
+
+// NewMockSalesRollupRepository creates a new mock instance.
+func NewMockSalesRollupRepository(ctrl *gomock.Controller) *MockSalesRollupRepository {
+	mock := &MockSalesRollupRepository{ctrl: ctrl}
+	mock.recorder = &MockSalesRollupRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSalesRollupRepository) EXPECT() *MockSalesRollupRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CreateProductRollups mocks base method.
+func (m *MockSalesRollupRepository) CreateProductRollups(ctx context.Context, salesDate time.Time, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateProductRollups", ctx, salesDate, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateProductRollups indicates an expected call of CreateProductRollups.
+func (mr *MockSalesRollupRepositoryMockRecorder) CreateProductRollups(ctx, salesDate, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateProductRollups", reflect.TypeOf((*MockSalesRollupRepository)(nil).CreateProductRollups), ctx, salesDate, tx)
+}
+
+// CreateShopRollups mocks base method.
+func (m *MockSalesRollupRepository) CreateShopRollups(ctx context.Context, salesDate time.Time, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateShopRollups", ctx, salesDate, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateShopRollups indicates an expected call of CreateShopRollups.
+func (mr *MockSalesRollupRepositoryMockRecorder) CreateShopRollups(ctx, salesDate, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateShopRollups", reflect.TypeOf((*MockSalesRollupRepository)(nil).CreateShopRollups), ctx, salesDate, tx)
+}
+
+// DeleteBySalesDate mocks base method.
+func (m *MockSalesRollupRepository) DeleteBySalesDate(ctx context.Context, salesDate time.Time, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBySalesDate", ctx, salesDate, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBySalesDate indicates an expected call of DeleteBySalesDate.
+func (mr *MockSalesRollupRepositoryMockRecorder) DeleteBySalesDate(ctx, salesDate, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBySalesDate", reflect.TypeOf((*MockSalesRollupRepository)(nil).DeleteBySalesDate), ctx, salesDate, tx)
+}
+
+// StreamReport mocks base method.
+func (m *MockSalesRollupRepository) StreamReport(ctx context.Context, params *entity.SalesReportByParams, handle func(*entity.SalesReport) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamReport", ctx, params, handle)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamReport indicates an expected call of StreamReport.
+func (mr *MockSalesRollupRepositoryMockRecorder) StreamReport(ctx, params, handle interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamReport", reflect.TypeOf((*MockSalesRollupRepository)(nil).StreamReport), ctx, params, handle)
+}
+
+// MockPaymentRepository is a mock of PaymentRepository interface.
+type MockPaymentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentRepositoryMockRecorder
+}
+
+// MockPaymentRepositoryMockRecorder is the mock recorder for MockPaymentRepository.
+type MockPaymentRepositoryMockRecorder struct {
+	mock *MockPaymentRepository
+}
+
+// NewMockPaymentRepository creates a new mock instance.
+func NewMockPaymentRepository(ctrl *gomock.Controller) *MockPaymentRepository {
+	mock := &MockPaymentRepository{ctrl: ctrl}
+	mock.recorder = &MockPaymentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentRepository) EXPECT() *MockPaymentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPaymentRepository) Create(ctx context.Context, payment *entity.Payment, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, payment, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPaymentRepositoryMockRecorder) Create(ctx, payment, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPaymentRepository)(nil).Create), ctx, payment, tx)
+}
+
+// GetByID mocks base method.
+func (m *MockPaymentRepository) GetByID(ctx context.Context, id string) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockPaymentRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockPaymentRepository)(nil).GetByID), ctx, id)
+}
+
+// GetByProviderReference mocks base method.
+func (m *MockPaymentRepository) GetByProviderReference(ctx context.Context, provider, reference string) (*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByProviderReference", ctx, provider, reference)
+	ret0, _ := ret[0].(*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByProviderReference indicates an expected call of GetByProviderReference.
+func (mr *MockPaymentRepositoryMockRecorder) GetByProviderReference(ctx, provider, reference interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByProviderReference", reflect.TypeOf((*MockPaymentRepository)(nil).GetByProviderReference), ctx, provider, reference)
+}
+
+// ListByOrderID mocks base method.
+func (m *MockPaymentRepository) ListByOrderID(ctx context.Context, orderID string, tx util.DatabaseTransaction) ([]*entity.Payment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByOrderID", ctx, orderID, tx)
+	ret0, _ := ret[0].([]*entity.Payment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByOrderID indicates an expected call of ListByOrderID.
+func (mr *MockPaymentRepositoryMockRecorder) ListByOrderID(ctx, orderID, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByOrderID", reflect.TypeOf((*MockPaymentRepository)(nil).ListByOrderID), ctx, orderID, tx)
+}
+
+// UpdateCaptured mocks base method.
+func (m *MockPaymentRepository) UpdateCaptured(ctx context.Context, id string, capturedAt time.Time, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCaptured", ctx, id, capturedAt, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCaptured indicates an expected call of UpdateCaptured.
+func (mr *MockPaymentRepositoryMockRecorder) UpdateCaptured(ctx, id, capturedAt, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCaptured", reflect.TypeOf((*MockPaymentRepository)(nil).UpdateCaptured), ctx, id, capturedAt, tx)
+}
+
+// UpdateFailed mocks base method.
+func (m *MockPaymentRepository) UpdateFailed(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFailed", ctx, id, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFailed indicates an expected call of UpdateFailed.
+func (mr *MockPaymentRepositoryMockRecorder) UpdateFailed(ctx, id, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFailed", reflect.TypeOf((*MockPaymentRepository)(nil).UpdateFailed), ctx, id, tx)
+}
+
+// UpdateRefundPending mocks base method.
+func (m *MockPaymentRepository) UpdateRefundPending(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRefundPending", ctx, id, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRefundPending indicates an expected call of UpdateRefundPending.
+func (mr *MockPaymentRepositoryMockRecorder) UpdateRefundPending(ctx, id, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefundPending", reflect.TypeOf((*MockPaymentRepository)(nil).UpdateRefundPending), ctx, id, tx)
+}
+
+// UpdateRefunded mocks base method.
+func (m *MockPaymentRepository) UpdateRefunded(ctx context.Context, id string, refundedAt time.Time, tx util.DatabaseTransaction) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRefunded", ctx, id, refundedAt, tx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRefunded indicates an expected call of UpdateRefunded.
+func (mr *MockPaymentRepositoryMockRecorder) UpdateRefunded(ctx, id, refundedAt, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRefunded", reflect.TypeOf((*MockPaymentRepository)(nil).UpdateRefunded), ctx, id, refundedAt, tx)
+}
+
+// MockPaymentProvider is a mock of PaymentProvider interface.
+type MockPaymentProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaymentProviderMockRecorder
+}
+
+// MockPaymentProviderMockRecorder is the mock recorder for MockPaymentProvider.
+type MockPaymentProviderMockRecorder struct {
+	mock *MockPaymentProvider
+}
+
+// NewMockPaymentProvider creates a new mock instance.
+func NewMockPaymentProvider(ctrl *gomock.Controller) *MockPaymentProvider {
+	mock := &MockPaymentProvider{ctrl: ctrl}
+	mock.recorder = &MockPaymentProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaymentProvider) EXPECT() *MockPaymentProviderMockRecorder {
+	return m.recorder
+}
+
+// CreateIntent mocks base method.
+func (m *MockPaymentProvider) CreateIntent(ctx context.Context, payment *entity.Payment) (*entity.PaymentIntent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIntent", ctx, payment)
+	ret0, _ := ret[0].(*entity.PaymentIntent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIntent indicates an expected call of CreateIntent.
+func (mr *MockPaymentProviderMockRecorder) CreateIntent(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIntent", reflect.TypeOf((*MockPaymentProvider)(nil).CreateIntent), ctx, payment)
+}
+
+// Name mocks base method.
+func (m *MockPaymentProvider) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockPaymentProviderMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockPaymentProvider)(nil).Name))
+}
+
+// Refund mocks base method.
+func (m *MockPaymentProvider) Refund(ctx context.Context, payment *entity.Payment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refund", ctx, payment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Refund indicates an expected call of Refund.
+func (mr *MockPaymentProviderMockRecorder) Refund(ctx, payment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refund", reflect.TypeOf((*MockPaymentProvider)(nil).Refund), ctx, payment)
+}
+
+// VerifyCallback mocks base method.
+func (m *MockPaymentProvider) VerifyCallback(payload []byte, signature string) (*entity.PaymentCallback, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyCallback", payload, signature)
+	ret0, _ := ret[0].(*entity.PaymentCallback)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyCallback indicates an expected call of VerifyCallback.
+func (mr *MockPaymentProviderMockRecorder) VerifyCallback(payload, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyCallback", reflect.TypeOf((*MockPaymentProvider)(nil).VerifyCallback), payload, signature)
+}
+
+// MockWebhookSubscriptionRepository is a mock of WebhookSubscriptionRepository interface.
+type MockWebhookSubscriptionRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookSubscriptionRepositoryMockRecorder
+}
+
+// MockWebhookSubscriptionRepositoryMockRecorder is the mock recorder for MockWebhookSubscriptionRepository.
+type MockWebhookSubscriptionRepositoryMockRecorder struct {
+	mock *MockWebhookSubscriptionRepository
+}
+
+// NewMockWebhookSubscriptionRepository creates a new mock instance.
+func NewMockWebhookSubscriptionRepository(ctrl *gomock.Controller) *MockWebhookSubscriptionRepository {
+	mock := &MockWebhookSubscriptionRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookSubscriptionRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookSubscriptionRepository) EXPECT() *MockWebhookSubscriptionRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockWebhookSubscriptionRepository) Create(ctx context.Context, subscription *entity.WebhookSubscription, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, subscription, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookSubscriptionRepositoryMockRecorder) Create(ctx, subscription, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookSubscriptionRepository)(nil).Create), ctx, subscription, tx)
+}
+
+// GetByID mocks base method.
+func (m *MockWebhookSubscriptionRepository) GetByID(ctx context.Context, id string) (*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhookSubscriptionRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhookSubscriptionRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByShopID mocks base method.
+func (m *MockWebhookSubscriptionRepository) ListByShopID(ctx context.Context, shopID string) ([]*entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByShopID", ctx, shopID)
+	ret0, _ := ret[0].([]*entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByShopID indicates an expected call of ListByShopID.
+func (mr *MockWebhookSubscriptionRepositoryMockRecorder) ListByShopID(ctx, shopID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByShopID", reflect.TypeOf((*MockWebhookSubscriptionRepository)(nil).ListByShopID), ctx, shopID)
+}
+
+// UpdateActive mocks base method.
+func (m *MockWebhookSubscriptionRepository) UpdateActive(ctx context.Context, id string, active bool) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateActive", ctx, id, active)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateActive indicates an expected call of UpdateActive.
+func (mr *MockWebhookSubscriptionRepositoryMockRecorder) UpdateActive(ctx, id, active interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateActive", reflect.TypeOf((*MockWebhookSubscriptionRepository)(nil).UpdateActive), ctx, id, active)
+}
+
+// MockWebhookDeliveryRepository is a mock of WebhookDeliveryRepository interface.
+type MockWebhookDeliveryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookDeliveryRepositoryMockRecorder
+}
+
+// MockWebhookDeliveryRepositoryMockRecorder is the mock recorder for MockWebhookDeliveryRepository.
+type MockWebhookDeliveryRepositoryMockRecorder struct {
+	mock *MockWebhookDeliveryRepository
+}
+
+// NewMockWebhookDeliveryRepository creates a new mock instance.
+func NewMockWebhookDeliveryRepository(ctrl *gomock.Controller) *MockWebhookDeliveryRepository {
+	mock := &MockWebhookDeliveryRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookDeliveryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookDeliveryRepository) EXPECT() *MockWebhookDeliveryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Claim mocks base method.
+func (m *MockWebhookDeliveryRepository) Claim(ctx context.Context, id string, attempt int, nextAttemptAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Claim", ctx, id, attempt, nextAttemptAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Claim indicates an expected call of Claim.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Claim(ctx, id, attempt, nextAttemptAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Claim", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Claim), ctx, id, attempt, nextAttemptAt)
+}
+
+// Create mocks base method.
+func (m *MockWebhookDeliveryRepository) Create(ctx context.Context, delivery *entity.WebhookDelivery, tx util.DatabaseTransaction) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, delivery, tx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) Create(ctx, delivery, tx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).Create), ctx, delivery, tx)
+}
+
+// GetByID mocks base method.
+func (m *MockWebhookDeliveryRepository) GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", ctx, id)
+	ret0, _ := ret[0].(*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) GetByID(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).GetByID), ctx, id)
+}
+
+// ListByParams mocks base method.
+func (m *MockWebhookDeliveryRepository) ListByParams(ctx context.Context, params *entity.ListWebhookDeliveryByParams) ([]*entity.WebhookDelivery, *libpagination.OffsetPagination, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByParams", ctx, params)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(*libpagination.OffsetPagination)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListByParams indicates an expected call of ListByParams.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) ListByParams(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByParams", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).ListByParams), ctx, params)
+}
+
+// ListPending mocks base method.
+func (m *MockWebhookDeliveryRepository) ListPending(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPending", ctx, now, limit)
+	ret0, _ := ret[0].([]*entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPending indicates an expected call of ListPending.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) ListPending(ctx, now, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPending", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).ListPending), ctx, now, limit)
+}
+
+// UpdateAttemptError mocks base method.
+func (m *MockWebhookDeliveryRepository) UpdateAttemptError(ctx context.Context, id string, toState entity.WebhookDeliveryState, responseStatus int, lastError string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAttemptError", ctx, id, toState, responseStatus, lastError)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAttemptError indicates an expected call of UpdateAttemptError.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) UpdateAttemptError(ctx, id, toState, responseStatus, lastError interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAttemptError", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).UpdateAttemptError), ctx, id, toState, responseStatus, lastError)
+}
+
+// UpdateDelivered mocks base method.
+func (m *MockWebhookDeliveryRepository) UpdateDelivered(ctx context.Context, id string, responseStatus int, deliveredAt time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivered", ctx, id, responseStatus, deliveredAt)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateDelivered indicates an expected call of UpdateDelivered.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) UpdateDelivered(ctx, id, responseStatus, deliveredAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivered", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).UpdateDelivered), ctx, id, responseStatus, deliveredAt)
+}
+
+// MockWebhookRepository is a mock of WebhookRepository interface.
+type MockWebhookRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookRepositoryMockRecorder
+}
+
+// MockWebhookRepositoryMockRecorder is the mock recorder for MockWebhookRepository.
+type MockWebhookRepositoryMockRecorder struct {
+	mock *MockWebhookRepository
+}
+
+// NewMockWebhookRepository creates a new mock instance.
+func NewMockWebhookRepository(ctrl *gomock.Controller) *MockWebhookRepository {
+	mock := &MockWebhookRepository{ctrl: ctrl}
+	mock.recorder = &MockWebhookRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhookRepository) EXPECT() *MockWebhookRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockWebhookRepository) Send(ctx context.Context, subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, subscription, delivery)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockWebhookRepositoryMockRecorder) Send(ctx, subscription, delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockWebhookRepository)(nil).Send), ctx, subscription, delivery)
+}
+
+// MockProductRepository is a mock of ProductRepository interface.
+type MockProductRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProductRepositoryMockRecorder
+}
+
+// MockProductRepositoryMockRecorder is the mock recorder for MockProductRepository.
+type MockProductRepositoryMockRecorder struct {
+	mock *MockProductRepository
+}
+
+// NewMockProductRepository creates a new mock instance.
+func NewMockProductRepository(ctrl *gomock.Controller) *MockProductRepository {
+	mock := &MockProductRepository{ctrl: ctrl}
+	mock.recorder = &MockProductRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProductRepository) EXPECT() *MockProductRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListByProductIDs mocks base method.
+func (m *MockProductRepository) ListByProductIDs(ctx context.Context, productIDs []string) ([]*entity.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByProductIDs", ctx, productIDs)
+	ret0, _ := ret[0].([]*entity.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByProductIDs indicates an expected call of ListByProductIDs.
+func (mr *MockProductRepositoryMockRecorder) ListByProductIDs(ctx, productIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByProductIDs", reflect.TypeOf((*MockProductRepository)(nil).ListByProductIDs), ctx, productIDs)
+}
+
+// MockWarehouseRepository is a mock of WarehouseRepository interface.
+type MockWarehouseRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarehouseRepositoryMockRecorder
+}
+
+// MockWarehouseRepositoryMockRecorder is the mock recorder for MockWarehouseRepository.
+type MockWarehouseRepositoryMockRecorder struct {
+	mock *MockWarehouseRepository
+}
+
+// NewMockWarehouseRepository creates a new mock instance.
+func NewMockWarehouseRepository(ctrl *gomock.Controller) *MockWarehouseRepository {
+	mock := &MockWarehouseRepository{ctrl: ctrl}
+	mock.recorder = &MockWarehouseRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarehouseRepository) EXPECT() *MockWarehouseRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ActiveStock mocks base method.
+func (m *MockWarehouseRepository) ActiveStock(ctx context.Context, productIDs []string) ([]*entity.WarehouseStock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActiveStock", ctx, productIDs)
+	ret0, _ := ret[0].([]*entity.WarehouseStock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActiveStock indicates an expected call of ActiveStock.
+func (mr *MockWarehouseRepositoryMockRecorder) ActiveStock(ctx, productIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActiveStock", reflect.TypeOf((*MockWarehouseRepository)(nil).ActiveStock), ctx, productIDs)
+}
+
+// AdjustmentStock mocks base method.
+func (m *MockWarehouseRepository) AdjustmentStock(ctx context.Context, params *entity.WarehouseStockAdjustmentParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdjustmentStock", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AdjustmentStock indicates an expected call of AdjustmentStock.
+func (mr *MockWarehouseRepositoryMockRecorder) AdjustmentStock(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustmentStock", reflect.TypeOf((*MockWarehouseRepository)(nil).AdjustmentStock), ctx, params)
+}
+
+// CommitStock mocks base method.
+func (m *MockWarehouseRepository) CommitStock(ctx context.Context, reservationID, actor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitStock", ctx, reservationID, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CommitStock indicates an expected call of CommitStock.
+func (mr *MockWarehouseRepositoryMockRecorder) CommitStock(ctx, reservationID, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitStock", reflect.TypeOf((*MockWarehouseRepository)(nil).CommitStock), ctx, reservationID, actor)
+}
+
+// ExtendStock mocks base method.
+func (m *MockWarehouseRepository) ExtendStock(ctx context.Context, reservationID string, expiredAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendStock", ctx, reservationID, expiredAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendStock indicates an expected call of ExtendStock.
+func (mr *MockWarehouseRepositoryMockRecorder) ExtendStock(ctx, reservationID, expiredAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendStock", reflect.TypeOf((*MockWarehouseRepository)(nil).ExtendStock), ctx, reservationID, expiredAt)
+}
+
+// ReleaseStock mocks base method.
+func (m *MockWarehouseRepository) ReleaseStock(ctx context.Context, reservationID, actor string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseStock", ctx, reservationID, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseStock indicates an expected call of ReleaseStock.
+func (mr *MockWarehouseRepositoryMockRecorder) ReleaseStock(ctx, reservationID, actor interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseStock", reflect.TypeOf((*MockWarehouseRepository)(nil).ReleaseStock), ctx, reservationID, actor)
+}
+
+// ReserveStock mocks base method.
+func (m *MockWarehouseRepository) ReserveStock(ctx context.Context, params *entity.WarehouseStockReservationParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReserveStock", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReserveStock indicates an expected call of ReserveStock.
+func (mr *MockWarehouseRepositoryMockRecorder) ReserveStock(ctx, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReserveStock", reflect.TypeOf((*MockWarehouseRepository)(nil).ReserveStock), ctx, params)
+}
@@ -16,13 +16,43 @@ import (
 type OrderUsecaseRepos struct {
 	DatabaseTransactionHandler util.DatabaseTransactionHandler
 	OrderRepo                  OrderRepository
+	CheckoutRepo               CheckoutRepository
+	CartItemRepo               CartItemRepository
 	OrderDetailRepo            OrderDetailRepository
+	OrderIdempotencyKeyRepo    OrderIdempotencyKeyRepository
+	OrderOutboxRepo            OrderOutboxRepository
+	VoucherRepo                VoucherRepository
+	VoucherUsageRepo           VoucherUsageRepository
+	OrderDiscountRepo          OrderDiscountRepository
+	ShopConfigRepo             ShopConfigRepository
+	TaxRateRepo                TaxRateRepository
+	ExpirationPolicyRepo       OrderExpirationPolicyRepository
+	OrderReturnRepo            OrderReturnRepository
+	OrderStateHistoryRepo      OrderStateHistoryRepository
+	SalesRollupRepo            SalesRollupRepository
+	PaymentRepo                PaymentRepository
+	PaymentProvider            PaymentProvider
+	WebhookSubscriptionRepo    WebhookSubscriptionRepository
+	WebhookDeliveryRepo        WebhookDeliveryRepository
+	WebhookRepo                WebhookRepository
 	ProductRepo                ProductRepository
 	WarehouseRepo              WarehouseRepository
 }
 
 type OrderUsecaseConfig struct {
-	OrderExpirationTimeSecond int
+	OrderExpirationTimeSecond      int
+	OrderOutboxRetryIntervalSecond int
+	OrderOutboxRelayBatchSize      int
+	OrderReservationGraceSecond    int
+	OrderExpiredBatchSize          int
+	OrderExpiredWorkerSize         int
+	OrderPaidReservationSecond     int
+	OrderIdempotencyKeyLeaseSecond int
+	WebhookRetryIntervalSecond     int
+	WebhookRelayBatchSize          int
+	WebhookMaxAttempt              int
+	SalesRollupLookbackDay         int
+	WarehouseAllocationStrategy    WarehouseAllocationStrategy
 }
 
 type OrderUsecase struct {
@@ -39,153 +69,191 @@ func NewOrderUsecase(repos *OrderUsecaseRepos, configs *OrderUsecaseConfig, logg
 	}
 }
 
-func (o *OrderUsecase) CreateOrder(ctx context.Context, params *entity.CreateOrderRequest) error {
+func (o *OrderUsecase) CreateOrder(ctx context.Context, params *entity.CreateOrderRequest) (*entity.Order, error) {
+	return o.createIdempotentOrder(ctx, params, nil)
+}
+
+// createIdempotentOrder create the order once per idempotency key, the cart items are removed
+// together with the order insert
+func (o *OrderUsecase) createIdempotentOrder(ctx context.Context, params *entity.CreateOrderRequest, cartItemIDs []string) (*entity.Order, error) {
 	// Validation struct
 	if err := libvalidate.Validator().Struct(params); err != nil {
-		return libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
 	}
 
-	// Retrieve products
-	productIDsMap := make(map[string]struct{})
-	productIDs := []string{}
-
-	for _, op := range params.Products {
-		if _, exists := productIDsMap[op.ProductID]; !exists {
-			productIDsMap[op.ProductID] = struct{}{}
-			productIDs = append(productIDs, op.ProductID)
-		}
+	if params.IdempotencyKey == "" {
+		return o.createOrder(ctx, params, nil, cartItemIDs)
 	}
 
-	products, err := o.repos.ProductRepo.ListByProductIDs(ctx, productIDs)
+	idempotencyKey, order, err := o.acquireIdempotencyKey(ctx, params)
 	if err != nil {
-		return liberr.ResolveError(err)
+		return nil, err
 	}
 
-	// Validate existance products
-	if len(productIDs) != len(products) {
-		return liberr.ResolveError(entity.ErrorProductNotFound)
+	// Replay of completed request return the original order
+	if order != nil {
+		return order, nil
 	}
 
-	// map[product_id]product
-	productMap := map[string]*entity.Product{}
-	for _, p := range products {
-		productMap[p.ID] = p
+	order, err = o.createOrder(ctx, params, idempotencyKey, cartItemIDs)
+	if err != nil {
+		// Release the idempotency key so the client is able to retry the request
+		if rerr := o.repos.OrderIdempotencyKeyRepo.DeleteProcessing(ctx, idempotencyKey.ID, idempotencyKey.LockedUntil); rerr != nil {
+			o.logger.Error("Failed on release idempotency key", zap.String("idempotency_key_id", idempotencyKey.ID), zap.Error(rerr))
+		}
+		return nil, err
 	}
 
-	// Retrieve warehouse stocks
-	warehouseStocks, err := o.repos.WarehouseRepo.ActiveStock(ctx, productIDs)
+	return order, nil
+}
+
+func (o *OrderUsecase) createOrder(ctx context.Context, params *entity.CreateOrderRequest, idempotencyKey *entity.OrderIdempotencyKey, cartItemIDs []string) (*entity.Order, error) {
+	drafts, err := o.draftOrders(ctx, params.User, []*entity.CreateCheckoutShop{
+		{ShopID: params.ShopID, Products: params.Products, VoucherCodes: params.VoucherCodes},
+	})
 	if err != nil {
-		return liberr.ResolveError(err)
+		return nil, err
 	}
+	draft := drafts[0]
 
-	// map[product_id][warehouse_id]warehouse_stock
-	productWarehouseStockMap := map[string]map[string]*entity.WarehouseStock{}
-	for _, ws := range warehouseStocks {
-		if _, ok := productWarehouseStockMap[ws.ProductID]; !ok {
-			productWarehouseStockMap[ws.ProductID] = map[string]*entity.WarehouseStock{}
-		}
-		productWarehouseStockMap[ws.ProductID][ws.WarehouseID] = ws
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
 	}
-
-	// Validate warehouse stock
-	for _, op := range params.Products {
-		if warehouseStock, ok := productWarehouseStockMap[op.ProductID][op.WarehouseID]; !ok {
-			return liberr.ResolveError(entity.ErrorProductStockNotFound)
-		} else if warehouseStock.ShopID != params.ShopID {
-			return liberr.ResolveError(entity.ErrorProductMultiShop)
-		} else if warehouseStock.Stock < op.Stock {
-			return liberr.ResolveError(entity.ErrorProductInsufficientStock)
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
 		}
+	}()
+
+	err = o.countVoucherUsages(ctx, drafts, tx)
+	if err != nil {
+		return nil, err
 	}
 
-	totalStock := 0
-	totalPrice := decimal.NewFromInt(0)
+	outbox, err := o.saveOrderDraft(ctx, draft, tx)
+	if err != nil {
+		return nil, err
+	}
 
-	for _, op := range params.Products {
-		totalStock += op.Stock
+	// Checked out cart items are removed with the order, so the cart is never ordered twice
+	if len(cartItemIDs) > 0 {
+		_, err = o.repos.CartItemRepo.DeleteByIDs(ctx, params.User.ID, cartItemIDs, tx)
+		if err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+	}
 
-		if product, ok := productMap[op.ProductID]; ok {
-			totalPrice = totalPrice.Add(product.Price.Mul(decimal.NewFromInt(int64(op.Stock))))
+	if idempotencyKey != nil {
+		var affected int64
+		affected, err = o.repos.OrderIdempotencyKeyRepo.UpdateCompleted(ctx, idempotencyKey.ID, idempotencyKey.LockedUntil, draft.order.ID, tx)
+		if err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+		if affected <= 0 {
+			err = liberr.ResolveError(entity.ErrorIdempotencyKeyInProgress)
+			return nil, err
 		}
 	}
 
-	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	err = tx.Commit()
 	if err != nil {
-		return liberr.ResolveError(err)
+		return nil, liberr.ResolveError(err)
 	}
-	defer func() {
-		if err != nil {
-			tx.Rollback() //nolint
+
+	if derr := o.dispatchOrderOutbox(ctx, outbox); derr != nil {
+		// Rejected reservation has been compensated by cancelling the order. The idempotency key is already
+		// completed, so a retry with the same key returns the cancelled order and ordering again needs a new key
+		if isStockAdjustmentRejected(derr) {
+			return nil, liberr.ResolveError(derr)
 		}
-	}()
 
-	now := util.NowUTCWithoutNanoSecond()
-	order := &entity.Order{
-		UserID:     params.User.ID,
-		ShopID:     params.ShopID,
-		TotalStock: totalStock,
-		TotalPrice: totalPrice,
-		ExpiredAt:  now.Add(time.Duration(o.configs.OrderExpirationTimeSecond) * time.Second),
+		// Otherwise the reservation is retried by the outbox relay
+		o.logger.Warn("Failed on dispatch order reservation", zap.String("order_id", draft.order.ID), zap.Error(derr))
+	}
+
+	return draft.order, nil
+}
+
+// saveOrderDraft record the drafted order along with the details, the discounts, the webhook deliveries and
+// the reservation outbox, both are delivered once the transaction is committed. The voucher usages have
+// to be counted by countVoucherUsages within the same transaction beforehand
+func (o *OrderUsecase) saveOrderDraft(ctx context.Context, draft *orderDraft, tx util.DatabaseTransaction) (*entity.OrderOutbox, error) {
+	order := draft.order
+
+	err := o.repos.OrderRepo.Create(ctx, order, tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
 	}
 
-	err = o.repos.OrderRepo.Create(ctx, order, tx)
+	// The history starts with the creation of the order by the user
+	err = o.repos.OrderStateHistoryRepo.Create(ctx, &entity.OrderStateHistory{
+		OrderID:   order.ID,
+		FromState: entity.OrderStateUnspecified,
+		ToState:   order.State,
+		ActorType: entity.OrderStateActorTypeUser,
+		ActorID:   order.UserID,
+	}, tx)
 	if err != nil {
-		return liberr.ResolveError(err)
+		return nil, liberr.ResolveError(err)
 	}
 
-	orderDetail := []*entity.OrderDetail{}
-	for _, op := range params.Products {
+	for _, op := range draft.orderProducts {
 		price := decimal.NewFromInt(0)
-		if product, ok := productMap[op.ProductID]; ok {
+		if product, ok := draft.productMap[op.ProductID]; ok {
 			price = product.Price
 		}
 
-		orderDetail = append(orderDetail, &entity.OrderDetail{
+		err = o.repos.OrderDetailRepo.Create(ctx, &entity.OrderDetail{
 			OrderID:     order.ID,
 			ProductID:   op.ProductID,
 			WarehouseID: op.WarehouseID,
 			Stock:       op.Stock,
 			Price:       price,
-		})
-	}
-
-	for _, od := range orderDetail {
-		err = o.repos.OrderDetailRepo.Create(ctx, od, tx)
+		}, tx)
 		if err != nil {
-			return liberr.ResolveError(err)
+			return nil, liberr.ResolveError(err)
 		}
 	}
 
-	err = o.reserveStocks(ctx, params.Products)
+	err = o.saveOrderDiscounts(ctx, draft, tx)
 	if err != nil {
-		return liberr.ResolveError(err)
+		return nil, err
 	}
 
-	err = tx.Commit()
+	err = o.createOrderWebhookDeliveries(ctx, order, entity.WebhookEventOrderCreated, tx)
 	if err != nil {
-		return liberr.ResolveError(err)
+		return nil, err
 	}
 
-	return nil
+	// Reservation is recorded together with the order and delivered after commit
+	return o.createOrderOutbox(ctx, order.ID, entity.OrderOutboxCommandReserveStock, o.reserveStockPayload(order, draft.orderProducts), tx)
 }
 
-func (o *OrderUsecase) reserveStocks(ctx context.Context, orderProducts []*entity.CreateOrderProduct) error {
-	adjustmentStock := []*entity.WarehouseStockAdjustment{}
+// reserveStockPayload reserve the ordered stocks by the order ID. The reservation outlives the order expiration
+// by a grace period, so the order expiration releases it first while warehouse service only sweeps the orphans
+func (o *OrderUsecase) reserveStockPayload(order *entity.Order, orderProducts []*entity.CreateOrderProduct) *entity.OrderOutboxPayload {
+	expiredAt := order.ExpiredAt.Add(time.Duration(o.configs.OrderReservationGraceSecond) * time.Second)
 
+	reservationStock := []*entity.WarehouseStockAdjustment{}
 	for _, p := range orderProducts {
-		adjustmentStock = append(adjustmentStock, &entity.WarehouseStockAdjustment{
+		reservationStock = append(reservationStock, &entity.WarehouseStockAdjustment{
 			WarehouseID: p.WarehouseID,
 			ProductID:   p.ProductID,
-			Stock:       -1 * p.Stock,
+			Stock:       p.Stock,
 		})
 	}
 
-	return o.repos.WarehouseRepo.AdjustmentStock(ctx, &entity.WarehouseStockAdjustmentParams{
-		WarehouseStocks: adjustmentStock,
-	})
+	return &entity.OrderOutboxPayload{
+		ReservationID:   order.ID,
+		ExpiredAt:       &expiredAt,
+		WarehouseStocks: reservationStock,
+	}
 }
 
-func (o *OrderUsecase) releaseStocks(ctx context.Context, orderDetails []*entity.OrderDetail) error {
+// releaseStockPayload release the reservation of the order, the stocks are kept to release
+// orders reserved by stock adjustment before the stock reservation was introduced
+func releaseStockPayload(orderID string, orderDetails []*entity.OrderDetail) *entity.OrderOutboxPayload {
 	adjustmentStock := []*entity.WarehouseStockAdjustment{}
 
 	for _, p := range orderDetails {
@@ -196,7 +264,8 @@ func (o *OrderUsecase) releaseStocks(ctx context.Context, orderDetails []*entity
 		})
 	}
 
-	return o.repos.WarehouseRepo.AdjustmentStock(ctx, &entity.WarehouseStockAdjustmentParams{
+	return &entity.OrderOutboxPayload{
+		ReservationID:   orderID,
 		WarehouseStocks: adjustmentStock,
-	})
+	}
 }
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+// ShippingFeeCalculator compute the shipping fee of the order lines allocated to the shop warehouses
+type ShippingFeeCalculator interface {
+	Calculate(config *entity.ShopConfig, orderProducts []*entity.CreateOrderProduct) decimal.Decimal
+}
+
+// NewShippingFeeCalculator build the calculator of the shipping method, the shipping is free
+// for the shop without shipping method
+func NewShippingFeeCalculator(method entity.ShippingMethod) ShippingFeeCalculator {
+	switch method {
+	case entity.ShippingMethodFlat:
+		return &flatShippingFee{}
+	case entity.ShippingMethodWeight:
+		return &weightShippingFee{}
+	case entity.ShippingMethodPerWarehouse:
+		return &perWarehouseShippingFee{}
+	}
+
+	return &freeShippingFee{}
+}
+
+type freeShippingFee struct{}
+
+func (f *freeShippingFee) Calculate(config *entity.ShopConfig, orderProducts []*entity.CreateOrderProduct) decimal.Decimal {
+	return decimal.NewFromInt(0)
+}
+
+// flatShippingFee charge the shipping fee once, whatever the order is
+type flatShippingFee struct{}
+
+func (f *flatShippingFee) Calculate(config *entity.ShopConfig, orderProducts []*entity.CreateOrderProduct) decimal.Decimal {
+	return config.ShippingFee
+}
+
+// weightShippingFee charge the shipping fee plus the fee per started kilogram of the ordered items
+type weightShippingFee struct{}
+
+func (w *weightShippingFee) Calculate(config *entity.ShopConfig, orderProducts []*entity.CreateOrderProduct) decimal.Decimal {
+	totalStock := 0
+	for _, op := range orderProducts {
+		totalStock += op.Stock
+	}
+
+	weightGram := totalStock * config.ItemWeightGram
+	kilograms := (weightGram + 999) / 1000
+
+	return config.ShippingFee.Add(config.ShippingFeePerKg.Mul(decimal.NewFromInt(int64(kilograms))))
+}
+
+// perWarehouseShippingFee charge the shipping fee once per warehouse the order is shipped from,
+// a line split across warehouses is shipped by every warehouse of the split
+type perWarehouseShippingFee struct{}
+
+func (p *perWarehouseShippingFee) Calculate(config *entity.ShopConfig, orderProducts []*entity.CreateOrderProduct) decimal.Decimal {
+	warehouseIDs := map[string]struct{}{}
+	for _, op := range orderProducts {
+		warehouseIDs[op.WarehouseID] = struct{}{}
+	}
+
+	return config.ShippingFee.Mul(decimal.NewFromInt(int64(len(warehouseIDs))))
+}
+
+// TaxCalculator compute the tax of the taxable price of the shop order
+type TaxCalculator interface {
+	Calculate(config *entity.ShopConfig, taxablePrice decimal.Decimal) decimal.Decimal
+}
+
+// regionTaxCalculator tax the order by the rate of the shop tax region, the order of the shop
+// without tax region or with a region without rate is not taxed
+type regionTaxCalculator struct {
+	// map[region]rate
+	rates map[string]decimal.Decimal
+}
+
+func NewRegionTaxCalculator(taxRates []*entity.TaxRate) TaxCalculator {
+	rates := make(map[string]decimal.Decimal, len(taxRates))
+	for _, tr := range taxRates {
+		rates[tr.Region] = tr.Rate
+	}
+
+	return &regionTaxCalculator{rates: rates}
+}
+
+func (r *regionTaxCalculator) Calculate(config *entity.ShopConfig, taxablePrice decimal.Decimal) decimal.Decimal {
+	rate, ok := r.rates[config.TaxRegion]
+	if !ok || !taxablePrice.IsPositive() {
+		return decimal.NewFromInt(0)
+	}
+
+	return taxablePrice.Mul(rate).Div(decimal.NewFromInt(100)).Round(3)
+}
+
+// orderCharges compute the shipping and the tax of the shop orders by the configuration of the shops,
+// the shop without configuration has neither shipping nor tax
+type orderCharges struct {
+	// map[shop_id]shop_config
+	shopConfigMap map[string]*entity.ShopConfig
+	tax           TaxCalculator
+}
+
+// listOrderCharges retrieve the configuration of the shops and the tax rates of their regions once for all shops
+func (o *OrderUsecase) listOrderCharges(ctx context.Context, shopIDs []string) (*orderCharges, error) {
+	shopConfigs, err := o.repos.ShopConfigRepo.ListByShopIDs(ctx, shopIDs)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	charges := &orderCharges{
+		shopConfigMap: map[string]*entity.ShopConfig{},
+	}
+
+	regionsMap := make(map[string]struct{})
+	regions := []string{}
+	for _, sc := range shopConfigs {
+		charges.shopConfigMap[sc.ShopID] = sc
+		if _, exists := regionsMap[sc.TaxRegion]; !exists && sc.TaxRegion != "" {
+			regionsMap[sc.TaxRegion] = struct{}{}
+			regions = append(regions, sc.TaxRegion)
+		}
+	}
+
+	taxRates := []*entity.TaxRate{}
+	if len(regions) > 0 {
+		taxRates, err = o.repos.TaxRateRepo.ListByRegions(ctx, regions)
+		if err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+	}
+	charges.tax = NewRegionTaxCalculator(taxRates)
+
+	return charges, nil
+}
+
+func (c *orderCharges) shopConfig(shopID string) *entity.ShopConfig {
+	if config, ok := c.shopConfigMap[shopID]; ok {
+		return config
+	}
+
+	return &entity.ShopConfig{ShopID: shopID}
+}
+
+// shippingPrice compute the shipping fee of the lines allocated to the shop warehouses
+func (c *orderCharges) shippingPrice(shopID string, orderProducts []*entity.CreateOrderProduct) decimal.Decimal {
+	config := c.shopConfig(shopID)
+	return NewShippingFeeCalculator(config.ShippingMethod).Calculate(config, orderProducts)
+}
+
+// taxPrice compute the tax of the price left once the discount is taken
+func (c *orderCharges) taxPrice(shopID string, taxablePrice decimal.Decimal) decimal.Decimal {
+	return c.tax.Calculate(c.shopConfig(shopID), taxablePrice)
+}
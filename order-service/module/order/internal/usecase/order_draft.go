@@ -0,0 +1,345 @@
+package usecase
+
+import (
+	"context"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// orderDraft is the validated order of a shop along with the lines allocated to the shop warehouses
+// and the vouchers applied to the order
+type orderDraft struct {
+	order         *entity.Order
+	orderProducts []*entity.CreateOrderProduct
+	productMap    map[string]*entity.Product
+	vouchers      []*appliedVoucher
+	lines         []*orderLine
+}
+
+// orderLine is the ordered product evaluated against the catalog, the allocations are the stock taken
+// from the shop warehouses once the line has no problem
+type orderLine struct {
+	orderProduct   *entity.CreateOrderProduct
+	product        *entity.Product
+	availableStock int
+	allocations    []*entity.CreateOrderProduct
+	problems       []entity.LineProblem
+}
+
+// orderCatalog is what the orders are drafted against: the products, the active warehouse stocks, the vouchers
+// and the charges and expiration policies of the shops
+type orderCatalog struct {
+	// map[product_id]product
+	productMap      map[string]*entity.Product
+	warehouseStocks []*entity.WarehouseStock
+	// map[product_id][warehouse_id]warehouse_stock
+	productWarehouseStockMap map[string]map[string]*entity.WarehouseStock
+	// map[code]voucher
+	voucherMap  map[string]*entity.Voucher
+	charges     *orderCharges
+	expirations *orderExpirations
+}
+
+// lineProblemErrors is the error rejecting the order checkout by the problem of the line,
+// the problems are checked in this order
+var lineProblemErrors = []struct {
+	problem entity.LineProblem
+	err     *liberr.ErrorDetails
+}{
+	{entity.LineProblemProductNotFound, entity.ErrorProductNotFound},
+	{entity.LineProblemStockNotFound, entity.ErrorProductStockNotFound},
+	{entity.LineProblemMultiShop, entity.ErrorProductMultiShop},
+	{entity.LineProblemOutOfStock, entity.ErrorProductInsufficientStock},
+	{entity.LineProblemInsufficientStock, entity.ErrorProductInsufficientStock},
+}
+
+// draftOrders validate the ordered products of every shop against the products and the active warehouse stocks
+// without writing anything, an order is drafted per shop. Products and stocks are retrieved once for all shops
+func (o *OrderUsecase) draftOrders(ctx context.Context, user *entity.User, shops []*entity.CreateCheckoutShop) ([]*orderDraft, error) {
+	catalog, err := o.listOrderCatalog(ctx, shops)
+	if err != nil {
+		return nil, err
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	drafts := []*orderDraft{}
+	for _, shop := range shops {
+		lines, orderProducts, err := o.allocateOrderLines(catalog, shop)
+		if err != nil {
+			return nil, err
+		}
+
+		// The first problem of the lines rejects the order checkout
+		if err := lineProblemError(lines); err != nil {
+			return nil, err
+		}
+
+		draft, err := priceOrderDraft(catalog, shop, lines, orderProducts, now)
+		if err != nil {
+			return nil, err
+		}
+
+		draft.order.UserID = user.ID
+		drafts = append(drafts, draft)
+	}
+
+	return drafts, nil
+}
+
+// listOrderCatalog retrieve the catalog the ordered products of every shop are drafted against. Products which
+// no longer exist are left out of the catalog, the lines are flagged by allocateOrderLines
+func (o *OrderUsecase) listOrderCatalog(ctx context.Context, shops []*entity.CreateCheckoutShop) (*orderCatalog, error) {
+	// Retrieve products
+	productIDsMap := make(map[string]struct{})
+	productIDs := []string{}
+
+	for _, shop := range shops {
+		for _, op := range shop.Products {
+			if _, exists := productIDsMap[op.ProductID]; !exists {
+				productIDsMap[op.ProductID] = struct{}{}
+				productIDs = append(productIDs, op.ProductID)
+			}
+		}
+	}
+
+	products, err := o.repos.ProductRepo.ListByProductIDs(ctx, productIDs)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	catalog := &orderCatalog{
+		productMap:               map[string]*entity.Product{},
+		productWarehouseStockMap: map[string]map[string]*entity.WarehouseStock{},
+	}
+	for _, p := range products {
+		catalog.productMap[p.ID] = p
+	}
+
+	// Retrieve warehouse stocks
+	catalog.warehouseStocks, err = o.repos.WarehouseRepo.ActiveStock(ctx, productIDs)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	for _, ws := range catalog.warehouseStocks {
+		if _, ok := catalog.productWarehouseStockMap[ws.ProductID]; !ok {
+			catalog.productWarehouseStockMap[ws.ProductID] = map[string]*entity.WarehouseStock{}
+		}
+		catalog.productWarehouseStockMap[ws.ProductID][ws.WarehouseID] = ws
+	}
+
+	catalog.voucherMap, err = o.listVouchersByCode(ctx, shops)
+	if err != nil {
+		return nil, err
+	}
+
+	shopIDs := []string{}
+	for _, shop := range shops {
+		shopIDs = append(shopIDs, shop.ShopID)
+	}
+
+	// Retrieve the shipping and the tax configuration of the shops
+	catalog.charges, err = o.listOrderCharges(ctx, shopIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// Retrieve the expiration policies of the shops and the products
+	catalog.expirations, err = o.listOrderExpirations(ctx, shopIDs, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return catalog, nil
+}
+
+// allocateOrderLines evaluate every line of the shop against the catalog and allocate the stock of the lines
+// to the shop warehouses. A line with a problem is left unallocated and the rest of the lines are still evaluated,
+// so the caller decides whether the problems reject the order
+func (o *OrderUsecase) allocateOrderLines(catalog *orderCatalog, shop *entity.CreateCheckoutShop) ([]*orderLine, []*entity.CreateOrderProduct, error) {
+	lines := make([]*orderLine, len(shop.Products))
+	for i, op := range shop.Products {
+		lines[i] = &orderLine{
+			orderProduct: op,
+			product:      catalog.productMap[op.ProductID],
+			allocations:  []*entity.CreateOrderProduct{},
+			problems:     []entity.LineProblem{},
+		}
+		if lines[i].product == nil {
+			lines[i].problems = append(lines[i].problems, entity.LineProblemProductNotFound)
+		}
+	}
+
+	// Lines with warehouse take the stock first, the rest is allocated afterward from the remaining stock
+	available := NewWarehouseAvailability(shop.ShopID, catalog.warehouseStocks)
+	for _, line := range lines {
+		op := line.orderProduct
+		if op.WarehouseID == "" || len(line.problems) > 0 {
+			continue
+		}
+
+		warehouseStock, ok := catalog.productWarehouseStockMap[op.ProductID][op.WarehouseID]
+		switch {
+		case !ok:
+			line.problems = append(line.problems, entity.LineProblemStockNotFound)
+		case warehouseStock.ShopID != shop.ShopID:
+			line.problems = append(line.problems, entity.LineProblemMultiShop)
+		default:
+			line.availableStock = available.Available(op.ProductID, op.WarehouseID)
+			if problem, ok := stockProblem(line.availableStock, op.Stock); ok {
+				line.problems = append(line.problems, problem)
+				continue
+			}
+
+			available.Take(op.ProductID, op.WarehouseID, op.Stock)
+			line.allocations = append(line.allocations, op)
+		}
+	}
+
+	// map[product_id]available stock left on the shop warehouses for the lines without warehouse
+	remainingStocks := map[string]int{}
+	for productID, warehouseStocks := range available.warehouseStocks {
+		for _, ws := range warehouseStocks {
+			remainingStocks[productID] += available.Available(productID, ws.WarehouseID)
+		}
+	}
+
+	unallocatedLines := []*orderLine{}
+	unallocatedProducts := []*entity.CreateOrderProduct{}
+	for _, line := range lines {
+		op := line.orderProduct
+		if op.WarehouseID != "" || len(line.problems) > 0 {
+			continue
+		}
+
+		if len(available.warehouseStocks[op.ProductID]) == 0 {
+			line.problems = append(line.problems, entity.LineProblemStockNotFound)
+			continue
+		}
+
+		line.availableStock = remainingStocks[op.ProductID]
+		if problem, ok := stockProblem(line.availableStock, op.Stock); ok {
+			line.problems = append(line.problems, problem)
+			continue
+		}
+
+		remainingStocks[op.ProductID] -= op.Stock
+		unallocatedLines = append(unallocatedLines, line)
+		unallocatedProducts = append(unallocatedProducts, op)
+	}
+
+	if len(unallocatedProducts) > 0 {
+		// Every line is fulfillable by the remaining stock, so the allocation never fails
+		allocatedProducts, err := o.configs.WarehouseAllocationStrategy.Allocate(unallocatedProducts, available)
+		if err != nil {
+			return nil, nil, liberr.ResolveError(err)
+		}
+
+		// The allocations are returned by the line order, each line is split until its stock is fulfilled
+		for _, line := range unallocatedLines {
+			stock := 0
+			for stock < line.orderProduct.Stock && len(allocatedProducts) > 0 {
+				stock += allocatedProducts[0].Stock
+				line.allocations = append(line.allocations, allocatedProducts[0])
+				allocatedProducts = allocatedProducts[1:]
+			}
+		}
+	}
+
+	orderProducts := []*entity.CreateOrderProduct{}
+	for _, line := range lines {
+		if line.orderProduct.WarehouseID != "" {
+			orderProducts = append(orderProducts, line.allocations...)
+		}
+	}
+	for _, line := range unallocatedLines {
+		orderProducts = append(orderProducts, line.allocations...)
+	}
+
+	return lines, orderProducts, nil
+}
+
+// priceOrderDraft price the order of the shop on the evaluated lines, the same for the order checkout and the quote.
+// The subtotal counts the lines of the products which still exist, the vouchers reject the draft once not applicable
+func priceOrderDraft(catalog *orderCatalog, shop *entity.CreateCheckoutShop, lines []*orderLine, orderProducts []*entity.CreateOrderProduct, now time.Time) (*orderDraft, error) {
+	totalStock := 0
+	subtotalPrice := decimal.NewFromInt(0)
+	// The vouchers are computed on the products which still exist
+	voucherShop := &entity.CreateCheckoutShop{
+		ShopID:       shop.ShopID,
+		VoucherCodes: shop.VoucherCodes,
+	}
+
+	for _, line := range lines {
+		totalStock += line.orderProduct.Stock
+
+		if line.product != nil {
+			subtotalPrice = subtotalPrice.Add(line.product.Price.Mul(decimal.NewFromInt(int64(line.orderProduct.Stock))))
+			voucherShop.Products = append(voucherShop.Products, line.orderProduct)
+		}
+	}
+
+	vouchers, discountPrice, err := applyVouchers(voucherShop, catalog.voucherMap, catalog.productMap, subtotalPrice, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// The shipping is charged on the allocated lines, the tax on the price left once discounted
+	shippingPrice := catalog.charges.shippingPrice(shop.ShopID, orderProducts)
+	taxPrice := catalog.charges.taxPrice(shop.ShopID, subtotalPrice.Sub(discountPrice))
+
+	// The shortest of the shop and the product policies wins
+	expirationPolicyID, expirationSecond := catalog.expirations.resolve(shop.ShopID, shop.Products)
+
+	return &orderDraft{
+		order: &entity.Order{
+			ShopID:             shop.ShopID,
+			State:              entity.OrderStateCreated,
+			TotalStock:         totalStock,
+			SubtotalPrice:      subtotalPrice,
+			DiscountPrice:      discountPrice,
+			ShippingPrice:      shippingPrice,
+			TaxPrice:           taxPrice,
+			TotalPrice:         subtotalPrice.Sub(discountPrice).Add(shippingPrice).Add(taxPrice),
+			ExpirationPolicyID: expirationPolicyID,
+			ExpirationSecond:   expirationSecond,
+			ExpiredAt:          now.Add(time.Duration(expirationSecond) * time.Second),
+			CreatedAt:          now,
+			UpdatedAt:          now,
+		},
+		orderProducts: orderProducts,
+		productMap:    catalog.productMap,
+		vouchers:      vouchers,
+		lines:         lines,
+	}, nil
+}
+
+// lineProblemError return the error of the first problem found on the lines by the lineProblemErrors order
+func lineProblemError(lines []*orderLine) error {
+	for _, pe := range lineProblemErrors {
+		for _, line := range lines {
+			for _, problem := range line.problems {
+				if problem == pe.problem {
+					return liberr.ResolveError(pe.err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// stockProblem report the problem of the line when the available stock is not able to fulfill it
+func stockProblem(available, stock int) (entity.LineProblem, bool) {
+	if available <= 0 {
+		return entity.LineProblemOutOfStock, true
+	}
+	if available < stock {
+		return entity.LineProblemInsufficientStock, true
+	}
+	return "", false
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+)
+
+func (o *OrderUsecase) UpsertOrderExpirationPolicy(ctx context.Context, params *entity.UpsertOrderExpirationPolicyRequest) (*entity.OrderExpirationPolicy, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	err := o.repos.ExpirationPolicyRepo.Upsert(ctx, &entity.OrderExpirationPolicy{
+		Scope:            params.Scope,
+		ScopeID:          params.ScopeID,
+		ExpirationSecond: params.ExpirationSecond,
+	}, nil)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	policy, err := o.repos.ExpirationPolicyRepo.GetByScope(ctx, params.Scope, params.ScopeID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return policy, nil
+}
+
+func (o *OrderUsecase) ListOrderExpirationPolicy(ctx context.Context) ([]*entity.OrderExpirationPolicy, error) {
+	policies, err := o.repos.ExpirationPolicyRepo.List(ctx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return policies, nil
+}
+
+// DeleteOrderExpirationPolicy remove the override, the orders placed afterward fall back to the other policies.
+// The placed orders keep the expiration resolved at their checkout
+func (o *OrderUsecase) DeleteOrderExpirationPolicy(ctx context.Context, params *entity.DeleteOrderExpirationPolicyRequest) error {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	affected, err := o.repos.ExpirationPolicyRepo.DeleteByScope(ctx, params.Scope, params.ScopeID)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+	if affected <= 0 {
+		return liberr.ResolveError(entity.ErrorExpirationPolicyNotFound)
+	}
+
+	return nil
+}
+
+// orderExpirations resolve the expiration of the shop orders by the policies of the shops and of the ordered products,
+// the shortest of the matching policies wins and the order without any is expired by the global expiration
+type orderExpirations struct {
+	// map[scope]map[scope_id]policy
+	policyMap     map[entity.OrderExpirationPolicyScope]map[string]*entity.OrderExpirationPolicy
+	defaultSecond int
+}
+
+// listOrderExpirations retrieve the policies of the shops and of the ordered products once for all shops
+func (o *OrderUsecase) listOrderExpirations(ctx context.Context, shopIDs, productIDs []string) (*orderExpirations, error) {
+	policies, err := o.repos.ExpirationPolicyRepo.ListByScopeIDs(ctx, shopIDs, productIDs)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	expirations := &orderExpirations{
+		policyMap:     map[entity.OrderExpirationPolicyScope]map[string]*entity.OrderExpirationPolicy{},
+		defaultSecond: o.configs.OrderExpirationTimeSecond,
+	}
+	for _, p := range policies {
+		if _, ok := expirations.policyMap[p.Scope]; !ok {
+			expirations.policyMap[p.Scope] = map[string]*entity.OrderExpirationPolicy{}
+		}
+		expirations.policyMap[p.Scope][p.ScopeID] = p
+	}
+
+	return expirations, nil
+}
+
+// resolve return the policy ID and the expiration of the shop order, the policy ID is empty
+// when the global expiration applies
+func (e *orderExpirations) resolve(shopID string, orderProducts []*entity.CreateOrderProduct) (string, int) {
+	var resolved *entity.OrderExpirationPolicy
+	pick := func(p *entity.OrderExpirationPolicy) {
+		if p != nil && (resolved == nil || p.ExpirationSecond < resolved.ExpirationSecond) {
+			resolved = p
+		}
+	}
+
+	pick(e.policyMap[entity.OrderExpirationPolicyScopeShop][shopID])
+	for _, op := range orderProducts {
+		pick(e.policyMap[entity.OrderExpirationPolicyScopeProduct][op.ProductID])
+	}
+
+	if resolved == nil {
+		return "", e.defaultSecond
+	}
+
+	return resolved.ID, resolved.ExpirationSecond
+}
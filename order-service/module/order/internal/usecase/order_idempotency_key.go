@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+)
+
+// acquireIdempotencyKey reserve the idempotency key for the user before processing the checkout,
+// the order will be returned when the same request is already completed before. The key is leased,
+// the processing key of a crashed request is taken over once the lease is over
+func (o *OrderUsecase) acquireIdempotencyKey(ctx context.Context, params *entity.CreateOrderRequest) (*entity.OrderIdempotencyKey, *entity.Order, error) {
+	requestHash, err := hashCreateOrderRequest(params)
+	if err != nil {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	lockedUntil := now.Add(time.Duration(o.configs.OrderIdempotencyKeyLeaseSecond) * time.Second)
+
+	idempotencyKey := &entity.OrderIdempotencyKey{
+		UserID:         params.User.ID,
+		IdempotencyKey: params.IdempotencyKey,
+		RequestHash:    requestHash,
+		LockedUntil:    lockedUntil,
+	}
+
+	err = o.repos.OrderIdempotencyKeyRepo.Create(ctx, idempotencyKey, nil)
+	if err == nil {
+		return idempotencyKey, nil, nil
+	}
+	if !liberr.ErrorCodeEquals(err, entity.ErrorCodeIdempotencyKeyDuplicated) {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	// Idempotency key already used, compare with the existing request
+	existingKey, err := o.repos.OrderIdempotencyKeyRepo.GetByUserIDAndKey(ctx, params.User.ID, params.IdempotencyKey)
+	if err != nil {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	if existingKey.RequestHash != requestHash {
+		return nil, nil, liberr.ResolveError(entity.ErrorIdempotencyKeyConflicted)
+	}
+
+	if existingKey.State != entity.OrderIdempotencyKeyStateCompleted {
+		if existingKey.LockedUntil.After(now) {
+			return nil, nil, liberr.ResolveError(entity.ErrorIdempotencyKeyInProgress)
+		}
+
+		// Lease is over, the request holding the key crashed or failed to release it
+		affected, err := o.repos.OrderIdempotencyKeyRepo.TakeOver(ctx, existingKey.ID, lockedUntil, now)
+		if err != nil {
+			return nil, nil, liberr.ResolveError(err)
+		}
+		if affected <= 0 {
+			return nil, nil, liberr.ResolveError(entity.ErrorIdempotencyKeyInProgress)
+		}
+
+		existingKey.LockedUntil = lockedUntil
+		return existingKey, nil, nil
+	}
+
+	order, err := o.repos.OrderRepo.GetByID(ctx, existingKey.OrderID)
+	if err != nil {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	return existingKey, order, nil
+}
+
+func hashCreateOrderRequest(params *entity.CreateOrderRequest) (string, error) {
+	body, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:]), nil
+}
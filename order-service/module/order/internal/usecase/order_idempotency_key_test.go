@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"order-service/module/order/entity"
+	"order-service/module/order/testutil/fixtures"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderUsecase_AcquireIdempotencyKey(t *testing.T) {
+	type input struct {
+		params *entity.CreateOrderRequest
+	}
+
+	newParams := func() *entity.CreateOrderRequest {
+		return &entity.CreateOrderRequest{
+			ShopID:         "3",
+			IdempotencyKey: "0f8fad5b-d9cb-469f-a165-70867728950e",
+			Products: []*entity.CreateOrderProduct{
+				{ProductID: "1", Stock: 2},
+			},
+			User: fixtures.NewUser(fixtures.User),
+		}
+	}
+
+	requestHash := func(params *entity.CreateOrderRequest) string {
+		hash, _ := hashCreateOrderRequest(params)
+		return hash
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(*entity.OrderIdempotencyKey, *entity.Order, error)
+	}{
+		{
+			name: "Success Acquire New Key",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), nil).
+					DoAndReturn(func(ctx context.Context, key *entity.OrderIdempotencyKey, _ interface{}) error {
+						key.ID = "4"
+						return nil
+					})
+			},
+			assertFn: func(key *entity.OrderIdempotencyKey, order *entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Nil(t, order)
+				assert.Equal(t, "4", key.ID)
+				assert.False(t, key.LockedUntil.IsZero())
+			},
+		},
+		{
+			name: "Replay Of Completed Key Return The Order",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				existingKey := fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey)
+				existingKey.RequestHash = requestHash(in.params)
+
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), nil).
+					Return(entity.ErrorIdempotencyKeyDuplicated)
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					GetByUserIDAndKey(gomock.Any(), in.params.User.ID, in.params.IdempotencyKey).
+					Return(existingKey, nil)
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), existingKey.OrderID).
+					Return(fixtures.NewOrder(fixtures.Order), nil)
+			},
+			assertFn: func(key *entity.OrderIdempotencyKey, order *entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.NotNil(t, key)
+				assert.Equal(t, fixtures.Order.ID, order.ID)
+			},
+		},
+		{
+			name: "Error Key Used By Another Request",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), nil).
+					Return(entity.ErrorIdempotencyKeyDuplicated)
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					GetByUserIDAndKey(gomock.Any(), in.params.User.ID, in.params.IdempotencyKey).
+					Return(fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey), nil)
+			},
+			assertFn: func(key *entity.OrderIdempotencyKey, order *entity.Order, err error) {
+				assertErrorDetails(t, entity.ErrorIdempotencyKeyConflicted, err)
+				assert.Nil(t, key)
+				assert.Nil(t, order)
+			},
+		},
+		{
+			name: "Error Key Processing Within The Lease",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				existingKey := fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey)
+				existingKey.RequestHash = requestHash(in.params)
+				existingKey.State = entity.OrderIdempotencyKeyStateProcessing
+				existingKey.OrderID = ""
+				existingKey.LockedUntil = fixtures.OrderIdempotencyKey.LockedUntil.AddDate(100, 0, 0)
+
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), nil).
+					Return(entity.ErrorIdempotencyKeyDuplicated)
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					GetByUserIDAndKey(gomock.Any(), in.params.User.ID, in.params.IdempotencyKey).
+					Return(existingKey, nil)
+			},
+			assertFn: func(key *entity.OrderIdempotencyKey, order *entity.Order, err error) {
+				assertErrorDetails(t, entity.ErrorIdempotencyKeyInProgress, err)
+				assert.Nil(t, key)
+				assert.Nil(t, order)
+			},
+		},
+		{
+			name: "Success Take Over Key Once The Lease Is Over",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				existingKey := fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey)
+				existingKey.RequestHash = requestHash(in.params)
+				existingKey.State = entity.OrderIdempotencyKeyStateProcessing
+				existingKey.OrderID = ""
+
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), nil).
+					Return(entity.ErrorIdempotencyKeyDuplicated)
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					GetByUserIDAndKey(gomock.Any(), in.params.User.ID, in.params.IdempotencyKey).
+					Return(existingKey, nil)
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					TakeOver(gomock.Any(), existingKey.ID, gomock.Any(), gomock.Any()).
+					Return(int64(1), nil)
+			},
+			assertFn: func(key *entity.OrderIdempotencyKey, order *entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Nil(t, order)
+				assert.Equal(t, fixtures.OrderIdempotencyKey.ID, key.ID)
+				assert.True(t, key.LockedUntil.After(fixtures.OrderIdempotencyKey.LockedUntil))
+			},
+		},
+		{
+			name: "Error Key Taken Over By Another Request",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				existingKey := fixtures.NewOrderIdempotencyKey(fixtures.OrderIdempotencyKey)
+				existingKey.RequestHash = requestHash(in.params)
+				existingKey.State = entity.OrderIdempotencyKeyStateProcessing
+				existingKey.OrderID = ""
+
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), nil).
+					Return(entity.ErrorIdempotencyKeyDuplicated)
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					GetByUserIDAndKey(gomock.Any(), in.params.User.ID, in.params.IdempotencyKey).
+					Return(existingKey, nil)
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					TakeOver(gomock.Any(), existingKey.ID, gomock.Any(), gomock.Any()).
+					Return(int64(0), nil)
+			},
+			assertFn: func(key *entity.OrderIdempotencyKey, order *entity.Order, err error) {
+				assertErrorDetails(t, entity.ErrorIdempotencyKeyInProgress, err)
+				assert.Nil(t, key)
+				assert.Nil(t, order)
+			},
+		},
+		{
+			name: "Error On Create Key",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderIdempotencyKeyRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), nil).
+					Return(errors.New("error"))
+			},
+			assertFn: func(key *entity.OrderIdempotencyKey, order *entity.Order, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, key)
+				assert.Nil(t, order)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(uc.acquireIdempotencyKey(ctx, tc.in.params))
+		})
+	}
+}
@@ -0,0 +1,391 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	maxOrderOutboxRetryInterval = time.Hour
+
+	orderOutboxActor = "order-service"
+)
+
+// createOrderOutbox record the stock or refund command of the order, it has to be called within
+// the same transaction of the order changes so both are committed atomically
+func (o *OrderUsecase) createOrderOutbox(ctx context.Context, orderID string, command entity.OrderOutboxCommand, outboxPayload *entity.OrderOutboxPayload, tx util.DatabaseTransaction) (*entity.OrderOutbox, error) {
+	payload, err := json.Marshal(outboxPayload)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	outbox := &entity.OrderOutbox{
+		OrderID:       orderID,
+		Command:       command,
+		Payload:       string(payload),
+		NextAttemptAt: util.NowUTCWithoutNanoSecond(),
+	}
+
+	if err := o.repos.OrderOutboxRepo.Create(ctx, outbox, tx); err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return outbox, nil
+}
+
+func (o *OrderUsecase) ExecuteOutboxRelay(ctx context.Context) error {
+	logFields := []zap.Field{
+		zap.String("function", "ExecuteOutboxRelay"),
+	}
+
+	outboxes, err := o.repos.OrderOutboxRepo.ListPending(ctx, util.NowUTCWithoutNanoSecond(), o.configs.OrderOutboxRelayBatchSize)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, ob := range outboxes {
+		if err := o.dispatchOrderOutbox(ctx, ob); err != nil {
+			failed++
+			o.logger.Error(fmt.Sprintf("Order Outbox ID : %s Failed on Dispatch due %v", ob.ID, err), logFields...)
+		}
+	}
+
+	o.logger.Info(fmt.Sprintf("Order Outbox Relay dispatched %d outboxes with %d failed", len(outboxes), failed), logFields...)
+
+	return nil
+}
+
+// dispatchOrderOutbox deliver the stock command to warehouse service or the refund to the payment provider. Transport
+// failures keep the outbox pending to be retried by the relay, while rejected commands are failed and compensated
+func (o *OrderUsecase) dispatchOrderOutbox(ctx context.Context, outbox *entity.OrderOutbox) error {
+	if outbox.Command == entity.OrderOutboxCommandReleaseStock || outbox.Command == entity.OrderOutboxCommandExtendReservation ||
+		outbox.Command == entity.OrderOutboxCommandCommitReservation {
+		settled, reserved, err := o.orderReservationSettled(ctx, outbox.OrderID)
+		if err != nil {
+			return err
+		}
+
+		// Wait until the reservation is delivered, otherwise the release, the extension or the commit might come before it
+		if !settled {
+			return nil
+		}
+
+		// Nothing to release, extend or commit when the reservation was never applied
+		if !reserved {
+			_, err = o.repos.OrderOutboxRepo.UpdateState(ctx, outbox.ID, entity.OrderOutboxStateProcessed, "", nil)
+			return liberr.ResolveError(err)
+		}
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	affected, err := o.repos.OrderOutboxRepo.Claim(ctx, outbox.ID, outbox.Attempt, now.Add(o.orderOutboxRetryInterval(outbox.Attempt+1)))
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	// Outbox already claimed by another worker
+	if affected <= 0 {
+		return nil
+	}
+	outbox.Attempt++
+
+	// Malformed payload is never deliverable
+	payload := &entity.OrderOutboxPayload{}
+	if err := json.Unmarshal([]byte(outbox.Payload), payload); err != nil {
+		if cerr := o.compensateOrderOutbox(ctx, outbox, err); cerr != nil {
+			return cerr
+		}
+		return liberr.ResolveError(err)
+	}
+
+	err = o.deliverOrderOutbox(ctx, outbox, payload)
+	if err != nil {
+		if isStockAdjustmentRejected(err) {
+			if cerr := o.compensateOrderOutbox(ctx, outbox, err); cerr != nil {
+				return cerr
+			}
+			return err
+		}
+
+		if uerr := o.repos.OrderOutboxRepo.UpdateLastError(ctx, outbox.ID, err.Error()); uerr != nil {
+			return liberr.ResolveError(uerr)
+		}
+		return liberr.ResolveError(err)
+	}
+
+	_, err = o.repos.OrderOutboxRepo.UpdateState(ctx, outbox.ID, entity.OrderOutboxStateProcessed, "", nil)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	outbox.State = entity.OrderOutboxStateProcessed
+	return nil
+}
+
+// deliverOrderOutbox send the stock command as stock reservation keyed by the order ID, the paid order
+// extends its reservation until it is shipped, which commits it, or cancelled. Outboxes recorded before the stock
+// reservation was introduced are sent as stock adjustments
+func (o *OrderUsecase) deliverOrderOutbox(ctx context.Context, outbox *entity.OrderOutbox, payload *entity.OrderOutboxPayload) error {
+	if outbox.Command == entity.OrderOutboxCommandRefundPayment {
+		return o.refundPayment(ctx, payload.PaymentID)
+	}
+
+	if outbox.Command == entity.OrderOutboxCommandCommitReservation {
+		err := o.repos.WarehouseRepo.CommitStock(ctx, payload.ReservationID, orderOutboxActor)
+		if liberr.ErrorCodeEquals(err, entity.ErrorCodeReservationNotFound) {
+			// Stock adjusted before the stock reservation was introduced is already taken out of the warehouse
+			return nil
+		}
+
+		// Warehouse service commits once per reservation, so redelivery never takes the stock out twice
+		return err
+	}
+
+	if outbox.Command == entity.OrderOutboxCommandExtendReservation {
+		// Stock adjusted before the stock reservation was introduced never expires
+		if payload.ReservationID == "" || payload.ExpiredAt == nil {
+			return nil
+		}
+
+		// Extension set the expiration rather than adding to it, so redelivery never extends it twice
+		return o.repos.WarehouseRepo.ExtendStock(ctx, payload.ReservationID, *payload.ExpiredAt)
+	}
+
+	if payload.ReservationID == "" {
+		return o.adjustOrderOutboxStock(ctx, outbox, payload.WarehouseStocks)
+	}
+
+	if outbox.Command == entity.OrderOutboxCommandReserveStock {
+		params := &entity.WarehouseStockReservationParams{
+			ReservationID:   payload.ReservationID,
+			Actor:           orderOutboxActor,
+			WarehouseStocks: payload.WarehouseStocks,
+		}
+		if payload.ExpiredAt != nil {
+			params.ExpiredAt = *payload.ExpiredAt
+		}
+
+		// Warehouse service reserves once per reservation ID, so redelivery never reserves the stock twice
+		return o.repos.WarehouseRepo.ReserveStock(ctx, params)
+	}
+
+	err := o.repos.WarehouseRepo.ReleaseStock(ctx, payload.ReservationID, orderOutboxActor)
+	if liberr.ErrorCodeEquals(err, entity.ErrorCodeReservationNotFound) {
+		// Order reserved by stock adjustment before the stock reservation was introduced
+		return o.adjustOrderOutboxStock(ctx, outbox, payload.WarehouseStocks)
+	}
+
+	return err
+}
+
+func (o *OrderUsecase) adjustOrderOutboxStock(ctx context.Context, outbox *entity.OrderOutbox, stockAdjustments []*entity.WarehouseStockAdjustment) error {
+	// Warehouse service applies the adjustment once per ID, so redelivery never adjusts the stock twice
+	params := &entity.WarehouseStockAdjustmentParams{
+		AdjustmentID:    orderOutboxAdjustmentID(outbox),
+		ReferenceID:     outbox.OrderID,
+		Actor:           orderOutboxActor,
+		Reason:          entity.WarehouseStockMovementReasonOrderReservation,
+		WarehouseStocks: stockAdjustments,
+	}
+
+	switch outbox.Command {
+	case entity.OrderOutboxCommandReleaseStock:
+		params.Reason = entity.WarehouseStockMovementReasonOrderRelease
+	case entity.OrderOutboxCommandRestockReturn:
+		params.Reason = entity.WarehouseStockMovementReasonOrderReturn
+	}
+
+	return o.repos.WarehouseRepo.AdjustmentStock(ctx, params)
+}
+
+// compensateOrderOutbox fail the outbox, a failed reservation cancel the order when it is still waiting for payment
+// along with the other orders of the same checkout. The order of any other failed command, e.g. the shipped order
+// whose reservation was already released, is flagged to be handled manually
+func (o *OrderUsecase) compensateOrderOutbox(ctx context.Context, outbox *entity.OrderOutbox, cause error) error {
+	var releaseOutboxes []*entity.OrderOutbox
+
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	_, err = o.repos.OrderOutboxRepo.UpdateState(ctx, outbox.ID, entity.OrderOutboxStateFailed, cause.Error(), tx)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	if outbox.Command != entity.OrderOutboxCommandReserveStock {
+		reason := fmt.Sprintf("Order outbox %s failed, see the last error of the outbox", outbox.ID)
+		_, err = o.repos.OrderRepo.UpdateManualHandlingReason(ctx, outbox.OrderID, reason, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+
+		o.logger.Error("Order flagged for manual handling", zap.String("order_id", outbox.OrderID), zap.String("outbox_id", outbox.ID), zap.Error(cause))
+	}
+
+	if outbox.Command == entity.OrderOutboxCommandReserveStock {
+		var order *entity.Order
+		order, err = o.repos.OrderRepo.GetByID(ctx, outbox.OrderID)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+
+		if order.State == entity.OrderStateCreated {
+			err = o.transitionOrderState(ctx, order, entity.OrderStateCancelled, &entity.OrderStateChange{
+				ActorType: entity.OrderStateActorTypeSystem,
+				ActorID:   orderStateActorOrderOutbox,
+				Reason:    "Stock reservation failed",
+			}, tx)
+			if err != nil {
+				return err
+			}
+		}
+
+		// Checkout is all-or-nothing, the other orders of the checkout are cancelled along with the order
+		if order.CheckoutID != "" {
+			releaseOutboxes, err = o.cancelCheckoutOrders(ctx, order, tx)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	outbox.State = entity.OrderOutboxStateFailed
+
+	// Cancellation is already recorded, failed release is retried by the outbox relay
+	for _, ob := range releaseOutboxes {
+		if derr := o.dispatchOrderOutbox(ctx, ob); derr != nil {
+			o.logger.Warn("Failed on dispatch checkout order release", zap.String("order_id", ob.OrderID), zap.Error(derr))
+		}
+	}
+
+	return nil
+}
+
+// cancelCheckoutOrders cancel the other created orders of the checkout, skip their reservations never claimed and record
+// the release of the others, the order moved concurrently by another process is left as it is
+func (o *OrderUsecase) cancelCheckoutOrders(ctx context.Context, order *entity.Order, tx util.DatabaseTransaction) ([]*entity.OrderOutbox, error) {
+	orders, err := o.repos.OrderRepo.ListByCheckoutID(ctx, order.CheckoutID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	outboxes := []*entity.OrderOutbox{}
+	for _, co := range orders {
+		if co.ID == order.ID || co.State != entity.OrderStateCreated {
+			continue
+		}
+
+		orderDetails, err := o.repos.OrderDetailRepo.ListByOrderID(ctx, co.ID)
+		if err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+
+		err = o.transitionOrderState(ctx, co, entity.OrderStateCancelled, &entity.OrderStateChange{
+			ActorType: entity.OrderStateActorTypeSystem,
+			ActorID:   orderStateActorOrderOutbox,
+			Reason:    fmt.Sprintf("Stock reservation of checkout order %s failed", order.ID),
+		}, tx)
+		if err != nil {
+			if berr, ok := err.(*liberr.BaseError); ok && berr.IsAnyCodeEqual(entity.ErrorCodeOrderInvalidTransition) {
+				continue
+			}
+			return nil, err
+		}
+
+		// Reservation never claimed is never sent, so the stock is not locked for the cancelled order. The outboxes are
+		// locked so the relay cannot claim it meanwhile. The reservation claimed before might be delivered already
+		// or in flight, it is left pending and the release outbox waits for it
+		orderOutboxes, err := o.repos.OrderOutboxRepo.ListByOrderIDForUpdate(ctx, co.ID, tx)
+		if err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+		for _, ob := range orderOutboxes {
+			if ob.Command != entity.OrderOutboxCommandReserveStock || ob.State != entity.OrderOutboxStatePending || ob.Attempt > 0 {
+				continue
+			}
+
+			_, err = o.repos.OrderOutboxRepo.UpdateState(ctx, ob.ID, entity.OrderOutboxStateFailed, "Checkout cancelled", tx)
+			if err != nil {
+				return nil, liberr.ResolveError(err)
+			}
+		}
+
+		outbox, err := o.createOrderOutbox(ctx, co.ID, entity.OrderOutboxCommandReleaseStock, releaseStockPayload(co.ID, orderDetails), tx)
+		if err != nil {
+			return nil, err
+		}
+		outboxes = append(outboxes, outbox)
+	}
+
+	return outboxes, nil
+}
+
+// orderReservationSettled report whether the reservation of the order is no longer pending and whether it was applied,
+// orders created before the outbox was introduced have no reservation outbox and are considered reserved
+func (o *OrderUsecase) orderReservationSettled(ctx context.Context, orderID string) (settled bool, reserved bool, err error) {
+	outboxes, err := o.repos.OrderOutboxRepo.ListByOrderID(ctx, orderID)
+	if err != nil {
+		return false, false, liberr.ResolveError(err)
+	}
+
+	for _, ob := range outboxes {
+		if ob.Command != entity.OrderOutboxCommandReserveStock {
+			continue
+		}
+
+		switch ob.State {
+		case entity.OrderOutboxStatePending:
+			return false, false, nil
+		case entity.OrderOutboxStateFailed:
+			return true, false, nil
+		}
+	}
+
+	return true, true, nil
+}
+
+func orderOutboxAdjustmentID(outbox *entity.OrderOutbox) string {
+	return fmt.Sprintf("order-outbox-%s", outbox.ID)
+}
+
+func (o *OrderUsecase) orderOutboxRetryInterval(attempt int) time.Duration {
+	interval := time.Duration(o.configs.OrderOutboxRetryIntervalSecond) * time.Second
+	for i := 1; i < attempt && interval < maxOrderOutboxRetryInterval; i++ {
+		interval *= 2
+	}
+
+	if interval > maxOrderOutboxRetryInterval {
+		return maxOrderOutboxRetryInterval
+	}
+	return interval
+}
+
+// isStockAdjustmentRejected report whether warehouse service refused the adjustment, retrying it will never succeed.
+// The reservation already committed or released never moves again
+func isStockAdjustmentRejected(err error) bool {
+	return liberr.ErrorCodeEquals(err, entity.ErrorCodeProductStockNotFound) ||
+		liberr.ErrorCodeEquals(err, entity.ErrorCodeProductOutOfStock) ||
+		liberr.ErrorCodeEquals(err, entity.ErrorCodeProductConflicted) ||
+		liberr.ErrorCodeEquals(err, entity.ErrorCodeReservationInvalidState)
+}
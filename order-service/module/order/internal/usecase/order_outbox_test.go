@@ -0,0 +1,611 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"order-service/module/order/entity"
+	"order-service/module/order/testutil/fixtures"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectOrderCancelled expect the created order to be cancelled without voucher usages and webhook subscriptions
+func expectOrderCancelled(dependency *orderUsecaseDependency, order *entity.Order) {
+	dependency.orderRepository.EXPECT().
+		UpdateState(gomock.Any(), order.ID, entity.OrderStateCreated, entity.OrderStateCancelled, dependency.databaseTransaction).
+		Return(int64(1), nil)
+	dependency.orderStateHistoryRepo.EXPECT().
+		Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+		Return(nil)
+	dependency.voucherUsageRepository.EXPECT().
+		ListByOrderID(gomock.Any(), order.ID).
+		Return([]*entity.VoucherUsage{}, nil)
+	dependency.webhookSubscriptionRepo.EXPECT().
+		ListByShopID(gomock.Any(), order.ShopID).
+		Return([]*entity.WebhookSubscription{}, nil)
+}
+
+func TestOrderUsecase_DispatchOrderOutbox(t *testing.T) {
+	type input struct {
+		outbox *entity.OrderOutbox
+	}
+
+	newReserveOutbox := func() *entity.OrderOutbox {
+		outbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+		outbox.Payload = `{"reservation_id":"1","expired_at":"2025-01-10T11:27:13Z","warehouse_stocks":[{"warehouse_id":"1","product_id":"1","stock":2}]}`
+		return outbox
+	}
+
+	newReleaseOutbox := func() *entity.OrderOutbox {
+		outbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+		outbox.ID = "6"
+		outbox.Command = entity.OrderOutboxCommandReleaseStock
+		outbox.Payload = `{"reservation_id":"1","warehouse_stocks":[{"warehouse_id":"1","product_id":"1","stock":2}]}`
+		return outbox
+	}
+
+	newRefundOutbox := func() *entity.OrderOutbox {
+		outbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+		outbox.ID = "7"
+		outbox.Command = entity.OrderOutboxCommandRefundPayment
+		outbox.Payload = `{"payment_id":"15","warehouse_stocks":[]}`
+		return outbox
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success Reserve Stock",
+			in:   input{outbox: newReserveOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.warehouseRepository.EXPECT().
+					ReserveStock(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, params *entity.WarehouseStockReservationParams) error {
+						assert.Equal(t, "1", params.ReservationID)
+						assert.Equal(t, 2, params.WarehouseStocks[0].Stock)
+						assert.False(t, params.ExpiredAt.IsZero())
+						assert.Equal(t, orderOutboxActor, params.Actor)
+						return nil
+					})
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderOutboxStateProcessed, in.outbox.State)
+				assert.Equal(t, fixtures.OrderOutbox.Attempt+1, in.outbox.Attempt)
+			},
+		},
+		{
+			name: "Success Outbox Claimed By Another Worker",
+			in:   input{outbox: newReserveOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(0), nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderOutboxStatePending, in.outbox.State)
+			},
+		},
+		{
+			name: "Error Transport Failure Keep The Outbox Pending",
+			in:   input{outbox: newReserveOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.warehouseRepository.EXPECT().
+					ReserveStock(gomock.Any(), gomock.Any()).
+					Return(errors.New("connection refused"))
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateLastError(gomock.Any(), in.outbox.ID, "connection refused").
+					Return(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, entity.OrderOutboxStatePending, in.outbox.State)
+			},
+		},
+		{
+			name: "Error Rejected Reservation Cancel The Order",
+			in:   input{outbox: newReserveOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.warehouseRepository.EXPECT().
+					ReserveStock(gomock.Any(), gomock.Any()).
+					Return(entity.ErrorProductOutOfStock)
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateFailed, entity.ErrorProductOutOfStock.Error(), dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.outbox.OrderID).
+					Return(order, nil)
+				expectOrderCancelled(dependency, order)
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Equal(t, entity.ErrorProductOutOfStock, err)
+				assert.Equal(t, entity.OrderOutboxStateFailed, in.outbox.State)
+			},
+		},
+		{
+			name: "Error Malformed Payload Fail The Outbox And Flag The Order",
+			in: input{outbox: func() *entity.OrderOutbox {
+				outbox := newReserveOutbox()
+				outbox.Command = entity.OrderOutboxCommandRestockReturn
+				outbox.Payload = "{"
+				return outbox
+			}()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateFailed, gomock.Any(), dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderRepository.EXPECT().
+					UpdateManualHandlingReason(gomock.Any(), in.outbox.OrderID, gomock.Any(), dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, entity.OrderOutboxStateFailed, in.outbox.State)
+			},
+		},
+		{
+			name: "Error Commit Of The Released Reservation Fail The Outbox And Flag The Shipped Order",
+			in: input{outbox: func() *entity.OrderOutbox {
+				outbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+				outbox.ID = "8"
+				outbox.Command = entity.OrderOutboxCommandCommitReservation
+				outbox.Payload = `{"reservation_id":"1","warehouse_stocks":[]}`
+				return outbox
+			}()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				reserveOutbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+				reserveOutbox.State = entity.OrderOutboxStateProcessed
+
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), in.outbox.OrderID).
+					Return([]*entity.OrderOutbox{reserveOutbox, in.outbox}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.warehouseRepository.EXPECT().
+					CommitStock(gomock.Any(), "1", orderOutboxActor).
+					Return(entity.ErrorReservationInvalidState)
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateFailed, gomock.Any(), dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderRepository.EXPECT().
+					UpdateManualHandlingReason(gomock.Any(), in.outbox.OrderID, gomock.Any(), dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Equal(t, entity.ErrorReservationInvalidState, err)
+				assert.Equal(t, entity.OrderOutboxStateFailed, in.outbox.State)
+			},
+		},
+		{
+			name: "Success Release Wait For The Pending Reservation",
+			in:   input{outbox: newReleaseOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), in.outbox.OrderID).
+					Return([]*entity.OrderOutbox{newReserveOutbox(), in.outbox}, nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderOutboxStatePending, in.outbox.State)
+			},
+		},
+		{
+			name: "Success Release Skipped When The Reservation Failed",
+			in:   input{outbox: newReleaseOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				reserveOutbox := newReserveOutbox()
+				reserveOutbox.State = entity.OrderOutboxStateFailed
+
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), in.outbox.OrderID).
+					Return([]*entity.OrderOutbox{reserveOutbox, in.outbox}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Success Release The Applied Reservation",
+			in:   input{outbox: newReleaseOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				reserveOutbox := newReserveOutbox()
+				reserveOutbox.State = entity.OrderOutboxStateProcessed
+
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), in.outbox.OrderID).
+					Return([]*entity.OrderOutbox{reserveOutbox, in.outbox}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.warehouseRepository.EXPECT().
+					ReleaseStock(gomock.Any(), "1", orderOutboxActor).
+					Return(nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderOutboxStateProcessed, in.outbox.State)
+			},
+		},
+		{
+			name: "Success Release Reservation Adjusted Before The Stock Reservation",
+			in:   input{outbox: newReleaseOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), in.outbox.OrderID).
+					Return([]*entity.OrderOutbox{in.outbox}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.warehouseRepository.EXPECT().
+					ReleaseStock(gomock.Any(), "1", orderOutboxActor).
+					Return(entity.ErrorReservationNotFound)
+				dependency.warehouseRepository.EXPECT().
+					AdjustmentStock(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, params *entity.WarehouseStockAdjustmentParams) error {
+						assert.Equal(t, orderOutboxAdjustmentID(in.outbox), params.AdjustmentID)
+						assert.Equal(t, entity.WarehouseStockMovementReasonOrderRelease, params.Reason)
+						return nil
+					})
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderOutboxStateProcessed, in.outbox.State)
+			},
+		},
+		{
+			name: "Success Refund Payment Without Waiting For The Reservation",
+			in:   input{outbox: newRefundOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				payment := fixtures.NewPayment(fixtures.Payment)
+				payment.State = entity.PaymentStateRefundPending
+
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.paymentRepository.EXPECT().
+					GetByID(gomock.Any(), payment.ID).
+					Return(payment, nil)
+				dependency.paymentProvider.EXPECT().
+					Refund(gomock.Any(), payment).
+					Return(nil)
+				dependency.paymentRepository.EXPECT().
+					UpdateRefunded(gomock.Any(), payment.ID, gomock.Any(), nil).
+					Return(int64(1), nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderOutboxStateProcessed, in.outbox.State)
+			},
+		},
+		{
+			name: "Success Refund Redelivered After The Payment Is Refunded",
+			in:   input{outbox: newRefundOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				payment := fixtures.NewPayment(fixtures.Payment)
+				payment.State = entity.PaymentStateRefunded
+
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.paymentRepository.EXPECT().
+					GetByID(gomock.Any(), payment.ID).
+					Return(payment, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderOutboxStateProcessed, in.outbox.State)
+			},
+		},
+		{
+			name: "Error Refund Failure Keep The Outbox Pending",
+			in:   input{outbox: newRefundOutbox()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				payment := fixtures.NewPayment(fixtures.Payment)
+				payment.State = entity.PaymentStateRefundPending
+
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), in.outbox.ID, in.outbox.Attempt, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.paymentRepository.EXPECT().
+					GetByID(gomock.Any(), payment.ID).
+					Return(payment, nil)
+				dependency.paymentProvider.EXPECT().
+					Refund(gomock.Any(), payment).
+					Return(errors.New("connection refused"))
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateLastError(gomock.Any(), in.outbox.ID, gomock.Any()).
+					Return(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, entity.OrderOutboxStatePending, in.outbox.State)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(tc.in, uc.dispatchOrderOutbox(ctx, tc.in.outbox))
+		})
+	}
+}
+
+func TestOrderUsecase_CompensateOrderOutbox(t *testing.T) {
+	type input struct {
+		outbox *entity.OrderOutbox
+		cause  error
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success Fail Reservation Of Paid Order Without Cancellation",
+			in: input{
+				outbox: fixtures.NewOrderOutbox(fixtures.OrderOutbox),
+				cause:  entity.ErrorProductConflicted,
+			},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStatePaid
+
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateFailed, in.cause.Error(), dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.outbox.OrderID).
+					Return(order, nil)
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderOutboxStateFailed, in.outbox.State)
+			},
+		},
+		{
+			name: "Success Cancel The Other Orders Of The Checkout",
+			in: input{
+				outbox: fixtures.NewOrderOutbox(fixtures.OrderOutbox),
+				cause:  entity.ErrorProductOutOfStock,
+			},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.CheckoutID = "9"
+
+				// Sibling order whose reservation is not delivered yet
+				siblingOrder := fixtures.NewOrder(fixtures.Order)
+				siblingOrder.ID = "2"
+				siblingOrder.CheckoutID = "9"
+				siblingReserveOutbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+				siblingReserveOutbox.ID = "7"
+				siblingReserveOutbox.OrderID = siblingOrder.ID
+				siblingReserveOutbox.Attempt = 0
+
+				// Sibling order already paid is left as it is
+				paidOrder := fixtures.NewOrder(fixtures.Order)
+				paidOrder.ID = "3"
+				paidOrder.CheckoutID = "9"
+				paidOrder.State = entity.OrderStatePaid
+
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateFailed, in.cause.Error(), dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.outbox.OrderID).
+					Return(order, nil)
+				expectOrderCancelled(dependency, order)
+
+				dependency.orderRepository.EXPECT().
+					ListByCheckoutID(gomock.Any(), order.CheckoutID).
+					Return([]*entity.Order{order, siblingOrder, paidOrder}, nil)
+				dependency.orderDetailRepository.EXPECT().
+					ListByOrderID(gomock.Any(), siblingOrder.ID).
+					Return([]*entity.OrderDetail{fixtures.NewOrderDetail(fixtures.OrderDetail)}, nil)
+				expectOrderCancelled(dependency, siblingOrder)
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderIDForUpdate(gomock.Any(), siblingOrder.ID, dependency.databaseTransaction).
+					Return([]*entity.OrderOutbox{siblingReserveOutbox}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), siblingReserveOutbox.ID, entity.OrderOutboxStateFailed, "Checkout cancelled", dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, outbox *entity.OrderOutbox, _ interface{}) error {
+						assert.Equal(t, siblingOrder.ID, outbox.OrderID)
+						assert.Equal(t, entity.OrderOutboxCommandReleaseStock, outbox.Command)
+						outbox.ID = "8"
+						return nil
+					})
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+
+				// The release is skipped once dispatched as the sibling reservation was never sent
+				failedReserveOutbox := fixtures.NewOrderOutbox(siblingReserveOutbox)
+				failedReserveOutbox.State = entity.OrderOutboxStateFailed
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), siblingOrder.ID).
+					Return([]*entity.OrderOutbox{failedReserveOutbox}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), "8", entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderOutboxStateFailed, in.outbox.State)
+			},
+		},
+		{
+			name: "Success Keep The Claimed Reservation Of The Other Order Pending",
+			in: input{
+				outbox: fixtures.NewOrderOutbox(fixtures.OrderOutbox),
+				cause:  entity.ErrorProductOutOfStock,
+			},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.CheckoutID = "9"
+
+				// Sibling order whose reservation is claimed by another worker, it might be delivered already
+				siblingOrder := fixtures.NewOrder(fixtures.Order)
+				siblingOrder.ID = "2"
+				siblingOrder.CheckoutID = "9"
+				siblingReserveOutbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+				siblingReserveOutbox.ID = "7"
+				siblingReserveOutbox.OrderID = siblingOrder.ID
+
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateFailed, in.cause.Error(), dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.outbox.OrderID).
+					Return(order, nil)
+				expectOrderCancelled(dependency, order)
+
+				dependency.orderRepository.EXPECT().
+					ListByCheckoutID(gomock.Any(), order.CheckoutID).
+					Return([]*entity.Order{order, siblingOrder}, nil)
+				dependency.orderDetailRepository.EXPECT().
+					ListByOrderID(gomock.Any(), siblingOrder.ID).
+					Return([]*entity.OrderDetail{fixtures.NewOrderDetail(fixtures.OrderDetail)}, nil)
+				expectOrderCancelled(dependency, siblingOrder)
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderIDForUpdate(gomock.Any(), siblingOrder.ID, dependency.databaseTransaction).
+					Return([]*entity.OrderOutbox{siblingReserveOutbox}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, outbox *entity.OrderOutbox, _ interface{}) error {
+						outbox.ID = "8"
+						return nil
+					})
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+
+				// The release waits for the claimed reservation to be delivered
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), siblingOrder.ID).
+					Return([]*entity.OrderOutbox{siblingReserveOutbox}, nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderOutboxStateFailed, in.outbox.State)
+			},
+		},
+		{
+			name: "Error On Begin Transaction",
+			in: input{
+				outbox: fixtures.NewOrderOutbox(fixtures.OrderOutbox),
+				cause:  entity.ErrorProductOutOfStock,
+			},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, entity.OrderOutboxStatePending, in.outbox.State)
+			},
+		},
+		{
+			name: "Error On Fail The Outbox Rollback The Transaction",
+			in: input{
+				outbox: fixtures.NewOrderOutbox(fixtures.OrderOutbox),
+				cause:  entity.ErrorProductOutOfStock,
+			},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), in.outbox.ID, entity.OrderOutboxStateFailed, in.cause.Error(), dependency.databaseTransaction).
+					Return(int64(0), errors.New("error"))
+				dependency.databaseTransaction.EXPECT().Rollback().Return(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, entity.OrderOutboxStatePending, in.outbox.State)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(tc.in, uc.compensateOrderOutbox(ctx, tc.in.outbox, tc.in.cause))
+		})
+	}
+}
@@ -0,0 +1,320 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+)
+
+// returnableOrderStates is the states of the order which are delivered to the user
+var returnableOrderStates = map[entity.OrderState]struct{}{
+	entity.OrderStateShipped:           {},
+	entity.OrderStateCompleted:         {},
+	entity.OrderStatePartiallyReturned: {},
+}
+
+// CreateOrderReturn request the return of a part of the order detail line, the order row is locked
+// so concurrent requests never return more than the ordered quantity of the line
+func (o *OrderUsecase) CreateOrderReturn(ctx context.Context, params *entity.CreateOrderReturnRequest) (*entity.OrderReturn, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	order, err := o.repos.OrderRepo.GetByIDForUpdate(ctx, params.OrderID, tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Validate order ownership
+	if order.UserID != params.User.ID {
+		err = entity.ErrorForbidden
+		return nil, liberr.ResolveError(err)
+	}
+
+	if _, ok := returnableOrderStates[order.State]; !ok {
+		err = entity.ErrorOrderNotReturnable
+		return nil, liberr.ResolveError(err)
+	}
+
+	orderDetails, err := o.repos.OrderDetailRepo.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	var orderDetail *entity.OrderDetail
+	for _, od := range orderDetails {
+		if od.ID == params.OrderDetailID {
+			orderDetail = od
+			break
+		}
+	}
+	if orderDetail == nil {
+		err = entity.ErrorOrderDetailNotFound
+		return nil, liberr.ResolveError(err)
+	}
+
+	orderReturns, err := o.repos.OrderReturnRepo.ListByOrderID(ctx, order.ID, tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Requested returns hold the quantity until they are reviewed
+	if returnedQuantity(orderReturns, entity.OrderReturnStateRequested, entity.OrderReturnStateApproved)[orderDetail.ID]+params.Quantity > orderDetail.Stock {
+		err = entity.ErrorOrderReturnExceeded
+		return nil, liberr.ResolveError(err)
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	orderReturn := &entity.OrderReturn{
+		OrderID:       order.ID,
+		OrderDetailID: orderDetail.ID,
+		UserID:        order.UserID,
+		ProductID:     orderDetail.ProductID,
+		WarehouseID:   orderDetail.WarehouseID,
+		Quantity:      params.Quantity,
+		RefundAmount:  returnRefundAmount(order, orderDetail, params.Quantity, orderReturns),
+		Reason:        params.Reason,
+		State:         entity.OrderReturnStateRequested,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	err = o.repos.OrderReturnRepo.Create(ctx, orderReturn, tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return orderReturn, nil
+}
+
+func (o *OrderUsecase) ListOrderReturn(ctx context.Context, params *entity.ListOrderReturnRequest) ([]*entity.OrderReturn, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	order, err := o.repos.OrderRepo.GetByID(ctx, params.OrderID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Validate order ownership
+	if order.UserID != params.User.ID {
+		return nil, liberr.ResolveError(entity.ErrorForbidden)
+	}
+
+	orderReturns, err := o.repos.OrderReturnRepo.ListByOrderID(ctx, order.ID, nil)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return orderReturns, nil
+}
+
+// ApproveOrderReturn approve the requested return, the order becomes returned once every ordered quantity
+// is returned and partially returned otherwise. The returned stock is given back to the warehouse of the line
+// by the outbox, recorded together with the approval and delivered after commit
+func (o *OrderUsecase) ApproveOrderReturn(ctx context.Context, params *entity.ApproveOrderReturnRequest) (*entity.OrderReturn, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	orderReturn, err := o.repos.OrderReturnRepo.GetByID(ctx, params.ReturnID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	orderDetails, err := o.repos.OrderDetailRepo.ListByOrderID(ctx, orderReturn.OrderID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	order, err := o.repos.OrderRepo.GetByIDForUpdate(ctx, orderReturn.OrderID, tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	affected, err := o.repos.OrderReturnRepo.UpdateReviewed(ctx, orderReturn.ID, entity.OrderReturnStateApproved, params.ReviewNote, util.NowUTCWithoutNanoSecond(), tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	if affected <= 0 {
+		err = entity.ErrorOrderReturnReviewed
+		return nil, liberr.ResolveError(err)
+	}
+
+	orderReturns, err := o.repos.OrderReturnRepo.ListByOrderID(ctx, order.ID, tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	toState := entity.OrderStateReturned
+	approvedQuantity := returnedQuantity(orderReturns, entity.OrderReturnStateApproved)
+	for _, od := range orderDetails {
+		if approvedQuantity[od.ID] < od.Stock {
+			toState = entity.OrderStatePartiallyReturned
+			break
+		}
+	}
+
+	if order.State != toState {
+		err = o.transitionOrderState(ctx, order, toState, &entity.OrderStateChange{
+			ActorType: entity.OrderStateActorTypeSystem,
+			ActorID:   orderStateActorOrderReturn,
+			Reason:    fmt.Sprintf("Order return %s approved", orderReturn.ID),
+		}, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	outbox, err := o.createOrderOutbox(ctx, order.ID, entity.OrderOutboxCommandRestockReturn, restockReturnPayload(orderReturn), tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Approval is already recorded, failed restock is retried by the outbox relay
+	if derr := o.dispatchOrderOutbox(ctx, outbox); derr != nil {
+		o.logger.Warn("Failed on dispatch order return restock", zap.String("order_return_id", orderReturn.ID), zap.Error(derr))
+	}
+
+	orderReturn, err = o.repos.OrderReturnRepo.GetByID(ctx, orderReturn.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return orderReturn, nil
+}
+
+// RejectOrderReturn reject the requested return, the rejected quantity can be requested again
+func (o *OrderUsecase) RejectOrderReturn(ctx context.Context, params *entity.RejectOrderReturnRequest) (*entity.OrderReturn, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	orderReturn, err := o.repos.OrderReturnRepo.GetByID(ctx, params.ReturnID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	affected, err := o.repos.OrderReturnRepo.UpdateReviewed(ctx, orderReturn.ID, entity.OrderReturnStateRejected, params.ReviewNote, util.NowUTCWithoutNanoSecond(), nil)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	if affected <= 0 {
+		return nil, liberr.ResolveError(entity.ErrorOrderReturnReviewed)
+	}
+
+	orderReturn, err = o.repos.OrderReturnRepo.GetByID(ctx, orderReturn.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return orderReturn, nil
+}
+
+// restockReturnPayload give the returned quantity back to the warehouse the line was shipped from. The payload has
+// no reservation ID so it is delivered as stock adjustment keyed by the outbox ID
+func restockReturnPayload(orderReturn *entity.OrderReturn) *entity.OrderOutboxPayload {
+	return &entity.OrderOutboxPayload{
+		WarehouseStocks: []*entity.WarehouseStockAdjustment{
+			{
+				WarehouseID: orderReturn.WarehouseID,
+				ProductID:   orderReturn.ProductID,
+				Stock:       orderReturn.Quantity,
+			},
+		},
+	}
+}
+
+// returnedQuantity sum the quantity of the returns in the given states by the order detail ID
+func returnedQuantity(orderReturns []*entity.OrderReturn, states ...entity.OrderReturnState) map[string]int {
+	quantity := map[string]int{}
+	for _, r := range orderReturns {
+		for _, s := range states {
+			if r.State == s {
+				quantity[r.OrderDetailID] += r.Quantity
+				break
+			}
+		}
+	}
+	return quantity
+}
+
+// returnRefundAmount price the returned quantity of the line after its share of the order discount, pro-rated by the
+// line price over the order subtotal. The refunds held by the requested and approved returns never exceed the paid price
+func returnRefundAmount(order *entity.Order, orderDetail *entity.OrderDetail, quantity int, orderReturns []*entity.OrderReturn) decimal.Decimal {
+	amount := orderDetail.Price.Mul(decimal.NewFromInt(int64(quantity)))
+	if order.DiscountPrice.IsPositive() && order.SubtotalPrice.IsPositive() {
+		discount := order.DiscountPrice.Mul(amount).Div(order.SubtotalPrice).Round(3)
+		amount = amount.Sub(discount)
+	}
+
+	held := decimal.NewFromInt(0)
+	for _, r := range orderReturns {
+		if r.State == entity.OrderReturnStateRequested || r.State == entity.OrderReturnStateApproved {
+			held = held.Add(r.RefundAmount)
+		}
+	}
+
+	refundable := order.TotalPrice.Sub(held)
+	if amount.GreaterThan(refundable) {
+		amount = refundable
+	}
+	if amount.IsNegative() {
+		return decimal.NewFromInt(0)
+	}
+
+	return amount
+}
+
+// refundPrice sum the refund amount of the approved returns
+func refundPrice(orderReturns []*entity.OrderReturn) decimal.Decimal {
+	price := decimal.NewFromInt(0)
+	for _, r := range orderReturns {
+		if r.State == entity.OrderReturnStateApproved {
+			price = price.Add(r.RefundAmount)
+		}
+	}
+	return price
+}
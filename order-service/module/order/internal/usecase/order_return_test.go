@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"order-service/module/order/entity"
+	"order-service/module/order/testutil/fixtures"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderUsecase_CreateOrderReturn(t *testing.T) {
+	type input struct {
+		params *entity.CreateOrderReturnRequest
+	}
+
+	newParams := func(quantity int) *entity.CreateOrderReturnRequest {
+		return &entity.CreateOrderReturnRequest{
+			OrderID:       fixtures.Order.ID,
+			OrderDetailID: fixtures.OrderDetail.ID,
+			Quantity:      quantity,
+			Reason:        "Damaged on arrival",
+			User:          &entity.User{ID: fixtures.Order.UserID},
+		}
+	}
+
+	newShippedOrder := func() *entity.Order {
+		order := fixtures.NewOrder(fixtures.Order)
+		order.State = entity.OrderStateShipped
+		return order
+	}
+
+	// expectReturnableOrder expect the locked order along with its lines and returns
+	expectReturnableOrder := func(dependency *orderUsecaseDependency, order *entity.Order, orderReturns []*entity.OrderReturn) {
+		dependency.databaseTransactionHandler.EXPECT().
+			Begin(gomock.Any(), gomock.Any()).
+			Return(dependency.databaseTransaction, nil)
+		dependency.orderRepository.EXPECT().
+			GetByIDForUpdate(gomock.Any(), order.ID, dependency.databaseTransaction).
+			Return(order, nil)
+		dependency.orderDetailRepository.EXPECT().
+			ListByOrderID(gomock.Any(), order.ID).
+			Return([]*entity.OrderDetail{fixtures.NewOrderDetail(fixtures.OrderDetail)}, nil)
+		dependency.orderReturnRepository.EXPECT().
+			ListByOrderID(gomock.Any(), order.ID, dependency.databaseTransaction).
+			Return(orderReturns, nil)
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(*entity.OrderReturn, error)
+	}{
+		{
+			name: "Success Request Return With The Discount Pro-Rated",
+			in:   input{params: newParams(2)},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				expectReturnableOrder(dependency, newShippedOrder(), []*entity.OrderReturn{})
+
+				dependency.orderReturnRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					Return(nil)
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+			},
+			assertFn: func(result *entity.OrderReturn, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderReturnStateRequested, result.State)
+				assert.Equal(t, fixtures.OrderDetail.WarehouseID, result.WarehouseID)
+				assert.True(t, decimal.NewFromInt(18000).Equal(result.RefundAmount), result.RefundAmount.String())
+			},
+		},
+		{
+			name: "Error Quantity Held By Requested Returns",
+			in:   input{params: newParams(2)},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				requestedReturn := fixtures.NewOrderReturn(fixtures.OrderReturn)
+				requestedReturn.OrderDetailID = fixtures.OrderDetail.ID
+				requestedReturn.Quantity = 4
+
+				expectReturnableOrder(dependency, newShippedOrder(), []*entity.OrderReturn{requestedReturn})
+
+				dependency.databaseTransaction.EXPECT().Rollback().Return(nil)
+			},
+			assertFn: func(result *entity.OrderReturn, err error) {
+				assertErrorDetails(t, entity.ErrorOrderReturnExceeded, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error Order Not Delivered",
+			in:   input{params: newParams(1)},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), in.params.OrderID, dependency.databaseTransaction).
+					Return(fixtures.NewOrder(fixtures.Order), nil)
+				dependency.databaseTransaction.EXPECT().Rollback().Return(nil)
+			},
+			assertFn: func(result *entity.OrderReturn, err error) {
+				assertErrorDetails(t, entity.ErrorOrderNotReturnable, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error Order Of Another User",
+			in: input{params: func() *entity.CreateOrderReturnRequest {
+				params := newParams(1)
+				params.User = &entity.User{ID: "99"}
+				return params
+			}()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderRepository.EXPECT().
+					GetByIDForUpdate(gomock.Any(), in.params.OrderID, dependency.databaseTransaction).
+					Return(newShippedOrder(), nil)
+				dependency.databaseTransaction.EXPECT().Rollback().Return(nil)
+			},
+			assertFn: func(result *entity.OrderReturn, err error) {
+				assertErrorDetails(t, entity.ErrorForbidden, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error On Create Return",
+			in:   input{params: newParams(1)},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				expectReturnableOrder(dependency, newShippedOrder(), []*entity.OrderReturn{})
+
+				dependency.orderReturnRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					Return(errors.New("error"))
+				dependency.databaseTransaction.EXPECT().Rollback().Return(nil)
+			},
+			assertFn: func(result *entity.OrderReturn, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(uc.CreateOrderReturn(ctx, tc.in.params))
+		})
+	}
+}
+
+func TestReturnRefundAmount(t *testing.T) {
+	type input struct {
+		order        *entity.Order
+		quantity     int
+		orderReturns []*entity.OrderReturn
+	}
+
+	newOrder := func(discountPrice, totalPrice int64) *entity.Order {
+		order := fixtures.NewOrder(fixtures.Order)
+		order.DiscountPrice = decimal.NewFromInt(discountPrice)
+		order.TotalPrice = decimal.NewFromInt(totalPrice)
+		return order
+	}
+
+	newReturn := func(state entity.OrderReturnState, refundAmount int64) *entity.OrderReturn {
+		orderReturn := fixtures.NewOrderReturn(fixtures.OrderReturn)
+		orderReturn.State = state
+		orderReturn.RefundAmount = decimal.NewFromInt(refundAmount)
+		return orderReturn
+	}
+
+	testCases := []struct {
+		name     string
+		in       input
+		expected decimal.Decimal
+	}{
+		{
+			name: "Line Price Without Discount",
+			in: input{
+				order:    newOrder(0, 60000),
+				quantity: 2,
+			},
+			expected: decimal.NewFromInt(20000),
+		},
+		{
+			name: "Discount Pro-Rated By The Line Price",
+			in: input{
+				order:    newOrder(5000, 59950),
+				quantity: 5,
+			},
+			expected: decimal.NewFromInt(45000),
+		},
+		{
+			name: "Capped By The Price Left After The Held Refunds",
+			in: input{
+				order:    newOrder(5000, 59950),
+				quantity: 5,
+				orderReturns: []*entity.OrderReturn{
+					newReturn(entity.OrderReturnStateApproved, 40000),
+					newReturn(entity.OrderReturnStateRequested, 10000),
+					newReturn(entity.OrderReturnStateRejected, 30000),
+				},
+			},
+			expected: decimal.NewFromInt(9950),
+		},
+		{
+			name: "Never Negative",
+			in: input{
+				order:    newOrder(5000, 10000),
+				quantity: 1,
+				orderReturns: []*entity.OrderReturn{
+					newReturn(entity.OrderReturnStateApproved, 20000),
+				},
+			},
+			expected: decimal.NewFromInt(0),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := returnRefundAmount(tc.in.order, fixtures.NewOrderDetail(fixtures.OrderDetail), tc.in.quantity, tc.in.orderReturns)
+			assert.True(t, tc.expected.Equal(result), result.String())
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+)
+
+// System actors of the order state changes
+const (
+	orderStateActorExpiredOrderCron = "expired-order-cron"
+	orderStateActorOrderOutbox      = "order-outbox"
+	orderStateActorPaymentCallback  = "payment-callback"
+	orderStateActorOrderReturn      = "order-return"
+)
+
+// orderStateWebhookEvents is the webhook event emitted when the order moves into the state
+var orderStateWebhookEvents = map[entity.OrderState]entity.WebhookEvent{
+	entity.OrderStateCancelled: entity.WebhookEventOrderCancelled,
+	entity.OrderStateExpired:   entity.WebhookEventOrderExpired,
+}
+
+// transitionOrderState move the order into the next state when the transition is allowed,
+// the update is guarded by the current state so concurrent transitions only succeed once.
+// The change is recorded in the state history by the same transaction. The voucher usages are given back
+// along with the cancellation or the expiration, which are notified to the webhook subscriptions of the shop
+func (o *OrderUsecase) transitionOrderState(ctx context.Context, order *entity.Order, toState entity.OrderState, change *entity.OrderStateChange, tx util.DatabaseTransaction) error {
+	if !order.State.CanTransitionTo(toState) {
+		return liberr.ResolveError(entity.ErrorOrderInvalidTransition)
+	}
+
+	affected, err := o.repos.OrderRepo.UpdateState(ctx, order.ID, order.State, toState, tx)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+	if affected <= 0 {
+		return liberr.ResolveError(entity.ErrorOrderInvalidTransition)
+	}
+
+	err = o.repos.OrderStateHistoryRepo.Create(ctx, &entity.OrderStateHistory{
+		OrderID:   order.ID,
+		FromState: order.State,
+		ToState:   toState,
+		ActorType: change.ActorType,
+		ActorID:   change.ActorID,
+		Reason:    change.Reason,
+	}, tx)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	if toState == entity.OrderStateCancelled || toState == entity.OrderStateExpired {
+		if err := o.releaseVoucherUsages(ctx, order.ID, tx); err != nil {
+			return err
+		}
+	}
+
+	order.State = toState
+
+	if event, ok := orderStateWebhookEvents[toState]; ok {
+		if err := o.createOrderWebhookDeliveries(ctx, order, event, tx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
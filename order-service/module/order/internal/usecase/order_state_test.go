@@ -0,0 +1,166 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"order-service/module/order/entity"
+	"order-service/module/order/testutil/fixtures"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderUsecase_TransitionOrderState(t *testing.T) {
+	type input struct {
+		order   *entity.Order
+		toState entity.OrderState
+		change  *entity.OrderStateChange
+	}
+
+	change := &entity.OrderStateChange{
+		ActorType: entity.OrderStateActorTypeSystem,
+		ActorID:   orderStateActorOrderOutbox,
+		Reason:    "Stock reservation failed",
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(input, error)
+	}{
+		{
+			name: "Success Transition Into Paid",
+			in: input{
+				order:   fixtures.NewOrder(fixtures.Order),
+				toState: entity.OrderStatePaid,
+				change:  change,
+			},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderRepository.EXPECT().
+					UpdateState(gomock.Any(), in.order.ID, entity.OrderStateCreated, entity.OrderStatePaid, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderStateHistoryRepo.EXPECT().
+					Create(gomock.Any(), &entity.OrderStateHistory{
+						OrderID:   in.order.ID,
+						FromState: entity.OrderStateCreated,
+						ToState:   entity.OrderStatePaid,
+						ActorType: change.ActorType,
+						ActorID:   change.ActorID,
+						Reason:    change.Reason,
+					}, dependency.databaseTransaction).
+					Return(nil)
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderStatePaid, in.order.State)
+			},
+		},
+		{
+			name: "Success Cancellation Release Vouchers And Notify Webhooks",
+			in: input{
+				order:   fixtures.NewOrder(fixtures.Order),
+				toState: entity.OrderStateCancelled,
+				change:  change,
+			},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				subscription := fixtures.NewWebhookSubscription(fixtures.WebhookSubscription)
+				subscription.Events = []entity.WebhookEvent{entity.WebhookEventOrderCancelled}
+
+				dependency.orderRepository.EXPECT().
+					UpdateState(gomock.Any(), in.order.ID, entity.OrderStateCreated, entity.OrderStateCancelled, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderStateHistoryRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					Return(nil)
+				dependency.voucherUsageRepository.EXPECT().
+					ListByOrderID(gomock.Any(), in.order.ID).
+					Return([]*entity.VoucherUsage{fixtures.NewVoucherUsage(fixtures.VoucherUsage)}, nil)
+				dependency.voucherRepository.EXPECT().
+					DecrementUsedCount(gomock.Any(), fixtures.VoucherUsage.VoucherID, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.voucherUsageRepository.EXPECT().
+					DeleteByOrderID(gomock.Any(), in.order.ID, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.webhookSubscriptionRepo.EXPECT().
+					ListByShopID(gomock.Any(), in.order.ShopID).
+					Return([]*entity.WebhookSubscription{subscription}, nil)
+				dependency.webhookDeliveryRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, delivery *entity.WebhookDelivery, _ interface{}) error {
+						assert.Equal(t, entity.WebhookEventOrderCancelled, delivery.Event)
+						assert.Equal(t, subscription.ID, delivery.SubscriptionID)
+						return nil
+					})
+			},
+			assertFn: func(in input, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderStateCancelled, in.order.State)
+			},
+		},
+		{
+			name: "Error Transition Not Allowed",
+			in: input{
+				order:   fixtures.NewOrder(fixtures.Order),
+				toState: entity.OrderStateShipped,
+				change:  change,
+			},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {},
+			assertFn: func(in input, err error) {
+				assertErrorDetails(t, entity.ErrorOrderInvalidTransition, err)
+				assert.Equal(t, entity.OrderStateCreated, in.order.State)
+			},
+		},
+		{
+			name: "Error Order Moved By Concurrent Transition",
+			in: input{
+				order:   fixtures.NewOrder(fixtures.Order),
+				toState: entity.OrderStatePaid,
+				change:  change,
+			},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderRepository.EXPECT().
+					UpdateState(gomock.Any(), in.order.ID, entity.OrderStateCreated, entity.OrderStatePaid, dependency.databaseTransaction).
+					Return(int64(0), nil)
+			},
+			assertFn: func(in input, err error) {
+				assertErrorDetails(t, entity.ErrorOrderInvalidTransition, err)
+				assert.Equal(t, entity.OrderStateCreated, in.order.State)
+			},
+		},
+		{
+			name: "Error On Create State History",
+			in: input{
+				order:   fixtures.NewOrder(fixtures.Order),
+				toState: entity.OrderStatePaid,
+				change:  change,
+			},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderRepository.EXPECT().
+					UpdateState(gomock.Any(), in.order.ID, entity.OrderStateCreated, entity.OrderStatePaid, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderStateHistoryRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					Return(errors.New("error"))
+			},
+			assertFn: func(in input, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, entity.OrderStateCreated, in.order.State)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(tc.in, uc.transitionOrderState(ctx, tc.in.order, tc.in.toState, tc.in.change, ucDependency.databaseTransaction))
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"order-service/internal/util/liberr"
+	utilmock "order-service/internal/util/mock"
+	"order-service/module/order/internal/usecase/mock"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type orderUsecaseDependency struct {
+	databaseTransactionHandler *utilmock.MockDatabaseTransactionHandler
+	databaseTransaction        *utilmock.MockDatabaseTransaction
+	orderRepository            *mock.MockOrderRepository
+	orderDetailRepository      *mock.MockOrderDetailRepository
+	orderDiscountRepository    *mock.MockOrderDiscountRepository
+	orderIdempotencyKeyRepo    *mock.MockOrderIdempotencyKeyRepository
+	orderOutboxRepository      *mock.MockOrderOutboxRepository
+	voucherRepository          *mock.MockVoucherRepository
+	voucherUsageRepository     *mock.MockVoucherUsageRepository
+	orderReturnRepository      *mock.MockOrderReturnRepository
+	orderStateHistoryRepo      *mock.MockOrderStateHistoryRepository
+	paymentRepository          *mock.MockPaymentRepository
+	paymentProvider            *mock.MockPaymentProvider
+	webhookSubscriptionRepo    *mock.MockWebhookSubscriptionRepository
+	webhookDeliveryRepository  *mock.MockWebhookDeliveryRepository
+	warehouseRepository        *mock.MockWarehouseRepository
+	productRepository          *mock.MockProductRepository
+}
+
+func newTestOrderUsecase(ctrl *gomock.Controller) (*OrderUsecase, orderUsecaseDependency) {
+	dependency := orderUsecaseDependency{
+		databaseTransactionHandler: utilmock.NewMockDatabaseTransactionHandler(ctrl),
+		databaseTransaction:        utilmock.NewMockDatabaseTransaction(ctrl),
+		orderRepository:            mock.NewMockOrderRepository(ctrl),
+		orderDetailRepository:      mock.NewMockOrderDetailRepository(ctrl),
+		orderDiscountRepository:    mock.NewMockOrderDiscountRepository(ctrl),
+		orderIdempotencyKeyRepo:    mock.NewMockOrderIdempotencyKeyRepository(ctrl),
+		orderOutboxRepository:      mock.NewMockOrderOutboxRepository(ctrl),
+		voucherRepository:          mock.NewMockVoucherRepository(ctrl),
+		voucherUsageRepository:     mock.NewMockVoucherUsageRepository(ctrl),
+		orderReturnRepository:      mock.NewMockOrderReturnRepository(ctrl),
+		orderStateHistoryRepo:      mock.NewMockOrderStateHistoryRepository(ctrl),
+		paymentRepository:          mock.NewMockPaymentRepository(ctrl),
+		paymentProvider:            mock.NewMockPaymentProvider(ctrl),
+		webhookSubscriptionRepo:    mock.NewMockWebhookSubscriptionRepository(ctrl),
+		webhookDeliveryRepository:  mock.NewMockWebhookDeliveryRepository(ctrl),
+		warehouseRepository:        mock.NewMockWarehouseRepository(ctrl),
+		productRepository:          mock.NewMockProductRepository(ctrl),
+	}
+
+	return NewOrderUsecase(&OrderUsecaseRepos{
+		DatabaseTransactionHandler: dependency.databaseTransactionHandler,
+		OrderRepo:                  dependency.orderRepository,
+		OrderDetailRepo:            dependency.orderDetailRepository,
+		OrderDiscountRepo:          dependency.orderDiscountRepository,
+		OrderIdempotencyKeyRepo:    dependency.orderIdempotencyKeyRepo,
+		OrderOutboxRepo:            dependency.orderOutboxRepository,
+		VoucherRepo:                dependency.voucherRepository,
+		VoucherUsageRepo:           dependency.voucherUsageRepository,
+		OrderReturnRepo:            dependency.orderReturnRepository,
+		OrderStateHistoryRepo:      dependency.orderStateHistoryRepo,
+		PaymentRepo:                dependency.paymentRepository,
+		PaymentProvider:            dependency.paymentProvider,
+		WebhookSubscriptionRepo:    dependency.webhookSubscriptionRepo,
+		WebhookDeliveryRepo:        dependency.webhookDeliveryRepository,
+		WarehouseRepo:              dependency.warehouseRepository,
+		ProductRepo:                dependency.productRepository,
+	}, &OrderUsecaseConfig{
+		OrderExpirationTimeSecond:      900,
+		OrderOutboxRetryIntervalSecond: 30,
+		OrderOutboxRelayBatchSize:      100,
+		OrderPaidReservationSecond:     604800,
+		OrderIdempotencyKeyLeaseSecond: 60,
+		WarehouseAllocationStrategy:    &largestStockFirstAllocation{},
+	}, zap.NewNop()), dependency
+}
+
+// assertErrorDetails assert the error is the base error of the error details
+func assertErrorDetails(t *testing.T, expected *liberr.ErrorDetails, err error) {
+	assert.NotNil(t, err)
+
+	berr, ok := err.(*liberr.BaseError)
+	assert.True(t, ok)
+	if ok {
+		assert.Equal(t, expected, berr.GetDetails()[0])
+	}
+}
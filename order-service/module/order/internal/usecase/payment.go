@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// CreatePayment register the payment intent of the created order at the payment provider. The pending payment
+// of the same amount is returned as it is, so the user paying again keeps paying the same intent
+func (o *OrderUsecase) CreatePayment(ctx context.Context, params *entity.CreatePaymentRequest) (*entity.Payment, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	order, err := o.repos.OrderRepo.GetByID(ctx, params.OrderID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Validate order ownership
+	if order.UserID != params.User.ID {
+		return nil, liberr.ResolveError(entity.ErrorForbidden)
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	if order.State != entity.OrderStateCreated || !now.Before(order.ExpiredAt) {
+		return nil, liberr.ResolveError(entity.ErrorOrderNotPayable)
+	}
+
+	payments, err := o.repos.PaymentRepo.ListByOrderID(ctx, order.ID, nil)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	for _, p := range payments {
+		if p.State == entity.PaymentStateCaptured {
+			return nil, liberr.ResolveError(entity.ErrorOrderNotPayable)
+		}
+		if p.State == entity.PaymentStatePending && p.Provider == o.repos.PaymentProvider.Name() && p.Amount.Equal(order.TotalPrice) {
+			return p, nil
+		}
+	}
+
+	payment := &entity.Payment{
+		OrderID:   order.ID,
+		UserID:    order.UserID,
+		Provider:  o.repos.PaymentProvider.Name(),
+		Amount:    order.TotalPrice,
+		State:     entity.PaymentStatePending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	intent, err := o.repos.PaymentProvider.CreateIntent(ctx, payment)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	payment.ProviderReference = intent.Reference
+	payment.PaymentURL = intent.PaymentURL
+
+	if err := o.repos.PaymentRepo.Create(ctx, payment, nil); err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return payment, nil
+}
+
+// HandlePaymentCallback settle the payment notified by the payment provider. The captured payment moves the order
+// into paid and extends the stock reservation, the order is locked before the payment is captured so the order
+// expiration never runs in between. Redelivered callbacks of the settled payment are ignored
+func (o *OrderUsecase) HandlePaymentCallback(ctx context.Context, params *entity.PaymentCallbackRequest) error {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return libvalidate.ResolveError(err, entity.ErrorCodePaymentCallbackInvalid)
+	}
+
+	callback, err := o.repos.PaymentProvider.VerifyCallback(params.Payload, params.Signature)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	if err := libvalidate.Validator().Struct(callback); err != nil {
+		return libvalidate.ResolveError(err, entity.ErrorCodePaymentCallbackInvalid)
+	}
+
+	payment, err := o.repos.PaymentRepo.GetByProviderReference(ctx, o.repos.PaymentProvider.Name(), callback.Reference)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	if callback.Event == entity.PaymentEventFailed {
+		if _, err := o.repos.PaymentRepo.UpdateFailed(ctx, payment.ID, nil); err != nil {
+			return liberr.ResolveError(err)
+		}
+		return nil
+	}
+
+	if !callback.Amount.Equal(payment.Amount) {
+		return liberr.ResolveError(entity.ErrorPaymentAmountMismatch)
+	}
+
+	return o.capturePayment(ctx, payment)
+}
+
+func (o *OrderUsecase) capturePayment(ctx context.Context, payment *entity.Payment) error {
+	var outbox *entity.OrderOutbox
+
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	order, err := o.repos.OrderRepo.GetByIDForUpdate(ctx, payment.OrderID, tx)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	affected, err := o.repos.PaymentRepo.UpdateCaptured(ctx, payment.ID, now, tx)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	// Payment already settled by the previous delivery of the callback
+	if affected <= 0 {
+		err = tx.Commit()
+		return liberr.ResolveError(err)
+	}
+
+	if order.State == entity.OrderStateCreated {
+		err = o.transitionOrderState(ctx, order, entity.OrderStatePaid, &entity.OrderStateChange{
+			ActorType: entity.OrderStateActorTypeSystem,
+			ActorID:   orderStateActorPaymentCallback,
+			Reason:    fmt.Sprintf("Payment %s captured", payment.ID),
+		}, tx)
+		if err != nil {
+			return err
+		}
+
+		outbox, err = o.createOrderOutbox(ctx, order.ID, entity.OrderOutboxCommandExtendReservation, o.extendReservationPayload(order.ID, now), tx)
+		if err != nil {
+			return err
+		}
+	} else {
+		// The money is captured anyway, the order cancelled or expired meanwhile is refunded
+		outbox, err = o.recordPaymentRefund(ctx, order.ID, payment.ID, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	// Payment is already recorded, failed extension or refund is retried by the outbox relay
+	if outbox != nil {
+		if derr := o.dispatchOrderOutbox(ctx, outbox); derr != nil {
+			o.logger.Warn("Failed on dispatch order payment outbox", zap.String("order_id", order.ID), zap.Error(derr))
+		}
+	}
+
+	return nil
+}
+
+// extendReservationPayload keep the reservation of the paid order until it is shipped or cancelled
+func (o *OrderUsecase) extendReservationPayload(orderID string, now time.Time) *entity.OrderOutboxPayload {
+	expiredAt := now.Add(time.Duration(o.configs.OrderPaidReservationSecond) * time.Second)
+
+	return &entity.OrderOutboxPayload{
+		ReservationID:   orderID,
+		ExpiredAt:       &expiredAt,
+		WarehouseStocks: []*entity.WarehouseStockAdjustment{},
+	}
+}
+
+// recordPaymentRefund move the captured payment into refund pending along with the refund outbox, it has to be called
+// within the same transaction of the order changes so the refund is committed atomically with them
+func (o *OrderUsecase) recordPaymentRefund(ctx context.Context, orderID string, paymentID string, tx util.DatabaseTransaction) (*entity.OrderOutbox, error) {
+	affected, err := o.repos.PaymentRepo.UpdateRefundPending(ctx, paymentID, tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Refund already recorded
+	if affected <= 0 {
+		return nil, nil
+	}
+
+	return o.createOrderOutbox(ctx, orderID, entity.OrderOutboxCommandRefundPayment, &entity.OrderOutboxPayload{
+		PaymentID:       paymentID,
+		WarehouseStocks: []*entity.WarehouseStockAdjustment{},
+	}, tx)
+}
+
+// refundPayment give the refund pending payment back at the payment provider, the payment refunded by the previous
+// delivery of the outbox is not refunded again
+func (o *OrderUsecase) refundPayment(ctx context.Context, paymentID string) error {
+	payment, err := o.repos.PaymentRepo.GetByID(ctx, paymentID)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	if payment.State != entity.PaymentStateRefundPending {
+		return nil
+	}
+
+	if err := o.repos.PaymentProvider.Refund(ctx, payment); err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	_, err = o.repos.PaymentRepo.UpdateRefunded(ctx, payment.ID, util.NowUTCWithoutNanoSecond(), nil)
+	return liberr.ResolveError(err)
+}
+
+// isPaymentCaptured report whether any payment of the order is captured
+func isPaymentCaptured(payments []*entity.Payment) bool {
+	for _, p := range payments {
+		if p.State == entity.PaymentStateCaptured {
+			return true
+		}
+	}
+
+	return false
+}
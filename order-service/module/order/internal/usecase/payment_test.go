@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"context"
+	"order-service/module/order/entity"
+	"order-service/module/order/testutil/fixtures"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderUsecase_HandlePaymentCallback(t *testing.T) {
+	type input struct {
+		params *entity.PaymentCallbackRequest
+	}
+
+	params := &entity.PaymentCallbackRequest{
+		Payload:   []byte(`{"reference":"fake-4f1c2a9b7d3e5f60","event":"payment.captured","amount":"59950"}`),
+		Signature: "signature",
+	}
+
+	newCallback := func(event entity.PaymentEvent, amount decimal.Decimal) *entity.PaymentCallback {
+		return &entity.PaymentCallback{
+			Reference: fixtures.Payment.ProviderReference,
+			Event:     event,
+			Amount:    amount,
+		}
+	}
+
+	// expectPaymentCaptured expect the payment to be captured while the order is locked
+	expectPaymentCaptured := func(dependency *orderUsecaseDependency, order *entity.Order, affected int64) {
+		dependency.paymentProvider.EXPECT().Name().Return(fixtures.Payment.Provider).AnyTimes()
+		dependency.paymentProvider.EXPECT().
+			VerifyCallback(params.Payload, params.Signature).
+			Return(newCallback(entity.PaymentEventCaptured, fixtures.Payment.Amount), nil)
+		dependency.paymentRepository.EXPECT().
+			GetByProviderReference(gomock.Any(), fixtures.Payment.Provider, fixtures.Payment.ProviderReference).
+			Return(fixtures.NewPayment(fixtures.Payment), nil)
+		dependency.databaseTransactionHandler.EXPECT().
+			Begin(gomock.Any(), gomock.Any()).
+			Return(dependency.databaseTransaction, nil)
+		dependency.orderRepository.EXPECT().
+			GetByIDForUpdate(gomock.Any(), fixtures.Payment.OrderID, dependency.databaseTransaction).
+			Return(order, nil)
+		dependency.paymentRepository.EXPECT().
+			UpdateCaptured(gomock.Any(), fixtures.Payment.ID, gomock.Any(), dependency.databaseTransaction).
+			Return(affected, nil)
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(error)
+	}{
+		{
+			name: "Success Capture Move The Order Into Paid",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				expectPaymentCaptured(dependency, order, 1)
+
+				dependency.orderRepository.EXPECT().
+					UpdateState(gomock.Any(), order.ID, entity.OrderStateCreated, entity.OrderStatePaid, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderStateHistoryRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, history *entity.OrderStateHistory, _ interface{}) error {
+						assert.Equal(t, orderStateActorPaymentCallback, history.ActorID)
+						return nil
+					})
+				dependency.orderOutboxRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, outbox *entity.OrderOutbox, _ interface{}) error {
+						assert.Equal(t, entity.OrderOutboxCommandExtendReservation, outbox.Command)
+						outbox.ID = "6"
+						return nil
+					})
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+
+				// The extension waits for the reservation still pending
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderOutbox{fixtures.NewOrderOutbox(fixtures.OrderOutbox)}, nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Success Redelivered Callback Of The Captured Payment",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStatePaid
+				expectPaymentCaptured(dependency, order, 0)
+
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Success Capture Of The Order No Longer Payable",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStateExpired
+				expectPaymentCaptured(dependency, order, 1)
+
+				// The captured payment is refunded along with the capture
+				dependency.paymentRepository.EXPECT().
+					UpdateRefundPending(gomock.Any(), fixtures.Payment.ID, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, outbox *entity.OrderOutbox, _ interface{}) error {
+						assert.Equal(t, entity.OrderOutboxCommandRefundPayment, outbox.Command)
+						assert.JSONEq(t, `{"payment_id":"15","warehouse_stocks":[]}`, outbox.Payload)
+						outbox.ID = "7"
+						return nil
+					})
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+
+				refundPendingPayment := fixtures.NewPayment(fixtures.Payment)
+				refundPendingPayment.State = entity.PaymentStateRefundPending
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), "7", 0, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.paymentRepository.EXPECT().
+					GetByID(gomock.Any(), fixtures.Payment.ID).
+					Return(refundPendingPayment, nil)
+				dependency.paymentProvider.EXPECT().
+					Refund(gomock.Any(), refundPendingPayment).
+					Return(nil)
+				dependency.paymentRepository.EXPECT().
+					UpdateRefunded(gomock.Any(), fixtures.Payment.ID, gomock.Any(), nil).
+					Return(int64(1), nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), "7", entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Success Failed Payment",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.paymentProvider.EXPECT().Name().Return(fixtures.Payment.Provider).AnyTimes()
+				dependency.paymentProvider.EXPECT().
+					VerifyCallback(in.params.Payload, in.params.Signature).
+					Return(newCallback(entity.PaymentEventFailed, decimal.NewFromInt(0)), nil)
+				dependency.paymentRepository.EXPECT().
+					GetByProviderReference(gomock.Any(), fixtures.Payment.Provider, fixtures.Payment.ProviderReference).
+					Return(fixtures.NewPayment(fixtures.Payment), nil)
+				dependency.paymentRepository.EXPECT().
+					UpdateFailed(gomock.Any(), fixtures.Payment.ID, nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error Amount Mismatch",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.paymentProvider.EXPECT().Name().Return(fixtures.Payment.Provider).AnyTimes()
+				dependency.paymentProvider.EXPECT().
+					VerifyCallback(in.params.Payload, in.params.Signature).
+					Return(newCallback(entity.PaymentEventCaptured, decimal.NewFromInt(100)), nil)
+				dependency.paymentRepository.EXPECT().
+					GetByProviderReference(gomock.Any(), fixtures.Payment.Provider, fixtures.Payment.ProviderReference).
+					Return(fixtures.NewPayment(fixtures.Payment), nil)
+			},
+			assertFn: func(err error) {
+				assertErrorDetails(t, entity.ErrorPaymentAmountMismatch, err)
+			},
+		},
+		{
+			name: "Error Invalid Signature",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.paymentProvider.EXPECT().
+					VerifyCallback(in.params.Payload, in.params.Signature).
+					Return(nil, entity.ErrorPaymentSignatureInvalid)
+			},
+			assertFn: func(err error) {
+				assertErrorDetails(t, entity.ErrorPaymentSignatureInvalid, err)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(uc.HandlePaymentCallback(ctx, tc.in.params))
+		})
+	}
+}
@@ -3,15 +3,39 @@ package usecase
 import (
 	"context"
 	"order-service/internal/util"
+	"order-service/internal/util/libpagination"
 	"order-service/module/order/entity"
+	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 //go:generate mockgen -destination=mock/repository.go -package=mock -source=repository.go
 
 type OrderRepository interface {
 	Create(ctx context.Context, order *entity.Order, tx util.DatabaseTransaction) error
-	UpdateExpired(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error)
-	ListByOrderExpired(ctx context.Context) ([]*entity.Order, error)
+	GetByID(ctx context.Context, id string) (*entity.Order, error)
+	GetByIDForUpdate(ctx context.Context, id string, tx util.DatabaseTransaction) (*entity.Order, error)
+	UpdateState(ctx context.Context, id string, fromState, toState entity.OrderState, tx util.DatabaseTransaction) (int64, error)
+	UpdateManualHandlingReason(ctx context.Context, id string, reason string, tx util.DatabaseTransaction) (int64, error)
+	ListByOrderExpired(ctx context.Context, lastID string, limit int) ([]*entity.Order, error)
+	ClaimExpiredByID(ctx context.Context, id string, tx util.DatabaseTransaction) (*entity.Order, error)
+	ListByParams(ctx context.Context, params *entity.ListOrderByParams) ([]*entity.Order, *libpagination.OffsetPagination, error)
+	ListByCheckoutID(ctx context.Context, checkoutID string) ([]*entity.Order, error)
+}
+
+type CheckoutRepository interface {
+	Create(ctx context.Context, checkout *entity.Checkout, tx util.DatabaseTransaction) error
+	GetByID(ctx context.Context, id string) (*entity.Checkout, error)
+}
+
+type CartItemRepository interface {
+	Create(ctx context.Context, cartItem *entity.CartItem, tx util.DatabaseTransaction) error
+	GetByID(ctx context.Context, id string) (*entity.CartItem, error)
+	GetByUserIDAndProductID(ctx context.Context, userID, shopID, productID string) (*entity.CartItem, error)
+	ListByUserID(ctx context.Context, userID string) ([]*entity.CartItem, error)
+	UpdateStock(ctx context.Context, id string, stock int, price decimal.Decimal, tx util.DatabaseTransaction) (int64, error)
+	DeleteByIDs(ctx context.Context, userID string, ids []string, tx util.DatabaseTransaction) (int64, error)
 }
 
 type OrderDetailRepository interface {
@@ -19,11 +43,133 @@ type OrderDetailRepository interface {
 	ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderDetail, error)
 }
 
+type OrderIdempotencyKeyRepository interface {
+	Create(ctx context.Context, idempotencyKey *entity.OrderIdempotencyKey, tx util.DatabaseTransaction) error
+	GetByUserIDAndKey(ctx context.Context, userID string, key string) (*entity.OrderIdempotencyKey, error)
+	TakeOver(ctx context.Context, id string, lockedUntil time.Time, now time.Time) (int64, error)
+	UpdateCompleted(ctx context.Context, id string, lockedUntil time.Time, orderID string, tx util.DatabaseTransaction) (int64, error)
+	DeleteProcessing(ctx context.Context, id string, lockedUntil time.Time) error
+}
+
+type OrderOutboxRepository interface {
+	Create(ctx context.Context, outbox *entity.OrderOutbox, tx util.DatabaseTransaction) error
+	ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderOutbox, error)
+	ListByOrderIDForUpdate(ctx context.Context, orderID string, tx util.DatabaseTransaction) ([]*entity.OrderOutbox, error)
+	ListPending(ctx context.Context, now time.Time, limit int) ([]*entity.OrderOutbox, error)
+	Claim(ctx context.Context, id string, attempt int, nextAttemptAt time.Time) (int64, error)
+	UpdateState(ctx context.Context, id string, toState entity.OrderOutboxState, lastError string, tx util.DatabaseTransaction) (int64, error)
+	UpdateLastError(ctx context.Context, id string, lastError string) error
+}
+
+type VoucherRepository interface {
+	Create(ctx context.Context, voucher *entity.Voucher, tx util.DatabaseTransaction) error
+	GetByID(ctx context.Context, id string) (*entity.Voucher, error)
+	ListByCodes(ctx context.Context, codes []string) ([]*entity.Voucher, error)
+	IncrementUsedCount(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error)
+	DecrementUsedCount(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error)
+}
+
+type VoucherUsageRepository interface {
+	Create(ctx context.Context, voucherUsage *entity.VoucherUsage, tx util.DatabaseTransaction) error
+	CountByVoucherIDAndUserID(ctx context.Context, voucherID, userID string, tx util.DatabaseTransaction) (int, error)
+	ListByOrderID(ctx context.Context, orderID string) ([]*entity.VoucherUsage, error)
+	DeleteByOrderID(ctx context.Context, orderID string, tx util.DatabaseTransaction) (int64, error)
+}
+
+type OrderDiscountRepository interface {
+	Create(ctx context.Context, orderDiscount *entity.OrderDiscount, tx util.DatabaseTransaction) error
+	ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderDiscount, error)
+}
+
+type ShopConfigRepository interface {
+	Upsert(ctx context.Context, shopConfig *entity.ShopConfig, tx util.DatabaseTransaction) error
+	GetByShopID(ctx context.Context, shopID string) (*entity.ShopConfig, error)
+	ListByShopIDs(ctx context.Context, shopIDs []string) ([]*entity.ShopConfig, error)
+}
+
+type TaxRateRepository interface {
+	Upsert(ctx context.Context, taxRate *entity.TaxRate, tx util.DatabaseTransaction) error
+	List(ctx context.Context) ([]*entity.TaxRate, error)
+	ListByRegions(ctx context.Context, regions []string) ([]*entity.TaxRate, error)
+}
+
+type OrderReturnRepository interface {
+	Create(ctx context.Context, orderReturn *entity.OrderReturn, tx util.DatabaseTransaction) error
+	GetByID(ctx context.Context, id string) (*entity.OrderReturn, error)
+	ListByOrderID(ctx context.Context, orderID string, tx util.DatabaseTransaction) ([]*entity.OrderReturn, error)
+	UpdateReviewed(ctx context.Context, id string, toState entity.OrderReturnState, reviewNote string, reviewedAt time.Time, tx util.DatabaseTransaction) (int64, error)
+}
+
+type OrderStateHistoryRepository interface {
+	Create(ctx context.Context, history *entity.OrderStateHistory, tx util.DatabaseTransaction) error
+	ListByOrderID(ctx context.Context, orderID string) ([]*entity.OrderStateHistory, error)
+}
+
+type OrderExpirationPolicyRepository interface {
+	Upsert(ctx context.Context, policy *entity.OrderExpirationPolicy, tx util.DatabaseTransaction) error
+	GetByScope(ctx context.Context, scope entity.OrderExpirationPolicyScope, scopeID string) (*entity.OrderExpirationPolicy, error)
+	List(ctx context.Context) ([]*entity.OrderExpirationPolicy, error)
+	ListByScopeIDs(ctx context.Context, shopIDs, productIDs []string) ([]*entity.OrderExpirationPolicy, error)
+	DeleteByScope(ctx context.Context, scope entity.OrderExpirationPolicyScope, scopeID string) (int64, error)
+}
+
+type SalesRollupRepository interface {
+	DeleteBySalesDate(ctx context.Context, salesDate time.Time, tx util.DatabaseTransaction) (int64, error)
+	CreateProductRollups(ctx context.Context, salesDate time.Time, tx util.DatabaseTransaction) error
+	CreateShopRollups(ctx context.Context, salesDate time.Time, tx util.DatabaseTransaction) error
+	StreamReport(ctx context.Context, params *entity.SalesReportByParams, handle func(*entity.SalesReport) error) error
+}
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment *entity.Payment, tx util.DatabaseTransaction) error
+	GetByID(ctx context.Context, id string) (*entity.Payment, error)
+	GetByProviderReference(ctx context.Context, provider, reference string) (*entity.Payment, error)
+	ListByOrderID(ctx context.Context, orderID string, tx util.DatabaseTransaction) ([]*entity.Payment, error)
+	UpdateCaptured(ctx context.Context, id string, capturedAt time.Time, tx util.DatabaseTransaction) (int64, error)
+	UpdateFailed(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error)
+	UpdateRefundPending(ctx context.Context, id string, tx util.DatabaseTransaction) (int64, error)
+	UpdateRefunded(ctx context.Context, id string, refundedAt time.Time, tx util.DatabaseTransaction) (int64, error)
+}
+
+// PaymentProvider create the payment intent on the payment gateway, refund the captured payment and verify
+// the callback the gateway send once the payment is settled. The refund is given once per payment reference
+type PaymentProvider interface {
+	Name() string
+	CreateIntent(ctx context.Context, payment *entity.Payment) (*entity.PaymentIntent, error)
+	Refund(ctx context.Context, payment *entity.Payment) error
+	VerifyCallback(payload []byte, signature string) (*entity.PaymentCallback, error)
+}
+
+type WebhookSubscriptionRepository interface {
+	Create(ctx context.Context, subscription *entity.WebhookSubscription, tx util.DatabaseTransaction) error
+	GetByID(ctx context.Context, id string) (*entity.WebhookSubscription, error)
+	ListByShopID(ctx context.Context, shopID string) ([]*entity.WebhookSubscription, error)
+	UpdateActive(ctx context.Context, id string, active bool) (int64, error)
+}
+
+type WebhookDeliveryRepository interface {
+	Create(ctx context.Context, delivery *entity.WebhookDelivery, tx util.DatabaseTransaction) error
+	GetByID(ctx context.Context, id string) (*entity.WebhookDelivery, error)
+	ListByParams(ctx context.Context, params *entity.ListWebhookDeliveryByParams) ([]*entity.WebhookDelivery, *libpagination.OffsetPagination, error)
+	ListPending(ctx context.Context, now time.Time, limit int) ([]*entity.WebhookDelivery, error)
+	Claim(ctx context.Context, id string, attempt int, nextAttemptAt time.Time) (int64, error)
+	UpdateDelivered(ctx context.Context, id string, responseStatus int, deliveredAt time.Time) (int64, error)
+	UpdateAttemptError(ctx context.Context, id string, toState entity.WebhookDeliveryState, responseStatus int, lastError string) (int64, error)
+}
+
+type WebhookRepository interface {
+	Send(ctx context.Context, subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery) (int, error)
+}
+
 type ProductRepository interface {
 	ListByProductIDs(ctx context.Context, productIDs []string) ([]*entity.Product, error)
 }
 
 type WarehouseRepository interface {
 	ActiveStock(ctx context.Context, productIDs []string) ([]*entity.WarehouseStock, error)
+	ReserveStock(ctx context.Context, params *entity.WarehouseStockReservationParams) error
+	ReleaseStock(ctx context.Context, reservationID string, actor string) error
+	CommitStock(ctx context.Context, reservationID string, actor string) error
 	AdjustmentStock(ctx context.Context, params *entity.WarehouseStockAdjustmentParams) error
+	ExtendStock(ctx context.Context, reservationID string, expiredAt time.Time) error
 }
@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ExecuteSalesRollup roll up the orders of the last days into the daily sales. The days within the lookback are
+// rolled up again on every run so the state changes of the orders after their creation day are kept.
+// A failed day does not stop the other days, but the run is reported as failed
+func (o *OrderUsecase) ExecuteSalesRollup(ctx context.Context) error {
+	logFields := []zap.Field{
+		zap.String("function", "ExecuteSalesRollup"),
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	lookbackDay := max(o.configs.SalesRollupLookbackDay, 1)
+
+	failed := 0
+	for i := 1; i <= lookbackDay && ctx.Err() == nil; i++ {
+		salesDate := today.AddDate(0, 0, -i)
+		if err := o.rollupSalesDate(ctx, salesDate); err != nil {
+			o.logger.Error(fmt.Sprintf("Sales Date : %s Failed on Rollup due %v", salesDate.Format(time.DateOnly), err), logFields...)
+			failed++
+		}
+	}
+
+	o.logger.Info(fmt.Sprintf("Sales Rollup rolled up %d days with %d failed", lookbackDay, failed), logFields...)
+
+	if failed > 0 {
+		return fmt.Errorf("sales rollup failed on %d of %d days", failed, lookbackDay)
+	}
+
+	return nil
+}
+
+// rollupSalesDate replace the rollups of the day within a transaction, so the report never reads a partial day
+func (o *OrderUsecase) rollupSalesDate(ctx context.Context, salesDate time.Time) error {
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	_, err = o.repos.SalesRollupRepo.DeleteBySalesDate(ctx, salesDate, tx)
+	if err != nil {
+		return err
+	}
+
+	err = o.repos.SalesRollupRepo.CreateProductRollups(ctx, salesDate, tx)
+	if err != nil {
+		return err
+	}
+
+	err = o.repos.SalesRollupRepo.CreateShopRollups(ctx, salesDate, tx)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// StreamSalesReport pass the sales reports one by one to the handle function in the order of the period, shop and product
+func (o *OrderUsecase) StreamSalesReport(ctx context.Context, params *entity.SalesReportByParams, handle func(*entity.SalesReport) error) error {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	// Cancelled, expired and returned orders are not revenue unless requested
+	if len(params.States) == 0 {
+		params.States = entity.SalesReportRevenueStates
+	}
+
+	if err := o.repos.SalesRollupRepo.StreamReport(ctx, params, handle); err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	return nil
+}
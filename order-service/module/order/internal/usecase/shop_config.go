@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"context"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+func (o *OrderUsecase) UpsertShopConfig(ctx context.Context, params *entity.UpsertShopConfigRequest) (*entity.ShopConfig, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	if !isValidShopConfigRule(params) {
+		return nil, liberr.ResolveError(entity.ErrorShopConfigInvalidRule)
+	}
+
+	err := o.repos.ShopConfigRepo.Upsert(ctx, &entity.ShopConfig{
+		ShopID:           params.ShopID,
+		TaxRegion:        params.TaxRegion,
+		ShippingMethod:   params.ShippingMethod,
+		ShippingFee:      params.ShippingFee,
+		ShippingFeePerKg: params.ShippingFeePerKg,
+		ItemWeightGram:   params.ItemWeightGram,
+	}, nil)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	shopConfig, err := o.repos.ShopConfigRepo.GetByShopID(ctx, params.ShopID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return shopConfig, nil
+}
+
+func (o *OrderUsecase) GetShopConfig(ctx context.Context, params *entity.GetShopConfigRequest) (*entity.ShopConfig, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	shopConfig, err := o.repos.ShopConfigRepo.GetByShopID(ctx, params.ShopID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return shopConfig, nil
+}
+
+func (o *OrderUsecase) UpsertTaxRate(ctx context.Context, params *entity.UpsertTaxRateRequest) (*entity.TaxRate, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	if params.Rate.IsNegative() || params.Rate.GreaterThan(decimal.NewFromInt(100)) {
+		return nil, liberr.ResolveError(entity.ErrorTaxRateInvalid)
+	}
+
+	taxRate := &entity.TaxRate{
+		Region: params.Region,
+		Rate:   params.Rate,
+	}
+
+	if err := o.repos.TaxRateRepo.Upsert(ctx, taxRate, nil); err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	taxRates, err := o.repos.TaxRateRepo.ListByRegions(ctx, []string{params.Region})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	if len(taxRates) > 0 {
+		taxRate = taxRates[0]
+	}
+
+	return taxRate, nil
+}
+
+func (o *OrderUsecase) ListTaxRate(ctx context.Context) ([]*entity.TaxRate, error) {
+	taxRates, err := o.repos.TaxRateRepo.List(ctx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return taxRates, nil
+}
+
+// isValidShopConfigRule validate the fees required by the shipping method, the fees are never negative
+func isValidShopConfigRule(params *entity.UpsertShopConfigRequest) bool {
+	if params.ShippingFee.IsNegative() || params.ShippingFeePerKg.IsNegative() {
+		return false
+	}
+
+	switch params.ShippingMethod {
+	case entity.ShippingMethodFlat, entity.ShippingMethodPerWarehouse:
+		return params.ShippingFee.IsPositive()
+	case entity.ShippingMethodWeight:
+		return params.ItemWeightGram > 0 && params.ShippingFeePerKg.IsPositive()
+	}
+
+	return true
+}
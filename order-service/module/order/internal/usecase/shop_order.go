@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libpagination"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+
+	"go.uber.org/zap"
+)
+
+func (o *OrderUsecase) ListShopOrder(ctx context.Context, params *entity.ListShopOrderRequest) ([]*entity.Order, *libpagination.OffsetPagination, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	// Validate shop binding of the seller
+	if !params.User.CanManageShop(params.ShopID) {
+		return nil, nil, liberr.ResolveError(entity.ErrorForbidden)
+	}
+
+	orders, pagination, err := o.repos.OrderRepo.ListByParams(ctx, &entity.ListOrderByParams{
+		Page:          params.Page,
+		Offset:        libpagination.Offset(params.Page, params.Limit),
+		Limit:         params.Limit,
+		ShopID:        params.ShopID,
+		States:        params.States,
+		CreatedAtFrom: params.CreatedAtFrom,
+		CreatedAtTo:   params.CreatedAtTo,
+	})
+	if err != nil {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	return orders, pagination, nil
+}
+
+func (o *OrderUsecase) GetShopOrder(ctx context.Context, params *entity.GetShopOrderRequest) (*entity.OrderInformation, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	order, err := o.getShopOrder(ctx, params.User, params.ShopID, params.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return o.getOrderInformation(ctx, order)
+}
+
+// ProcessShopOrder move the paid order into processing once the seller starts preparing it
+func (o *OrderUsecase) ProcessShopOrder(ctx context.Context, params *entity.ShopOrderActionRequest) (*entity.Order, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	order, err := o.getShopOrder(ctx, params.User, params.ShopID, params.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	err = o.transitionOrderState(ctx, order, entity.OrderStateProcessing, &entity.OrderStateChange{
+		ActorType: entity.OrderStateActorTypeSeller,
+		ActorID:   params.User.ID,
+	}, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return order, nil
+}
+
+// ShipShopOrder move the processing order into shipped, the stock reservation of the order is committed so the
+// stock is taken out of the warehouse by the outbox, recorded together with the shipment and delivered after commit
+func (o *OrderUsecase) ShipShopOrder(ctx context.Context, params *entity.ShopOrderActionRequest) (*entity.Order, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	order, err := o.getShopOrder(ctx, params.User, params.ShopID, params.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	err = o.transitionOrderState(ctx, order, entity.OrderStateShipped, &entity.OrderStateChange{
+		ActorType: entity.OrderStateActorTypeSeller,
+		ActorID:   params.User.ID,
+	}, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	outbox, err := o.createOrderOutbox(ctx, order.ID, entity.OrderOutboxCommandCommitReservation, &entity.OrderOutboxPayload{
+		ReservationID:   order.ID,
+		WarehouseStocks: []*entity.WarehouseStockAdjustment{},
+	}, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Shipment is already recorded, failed commit is retried by the outbox relay
+	if derr := o.dispatchOrderOutbox(ctx, outbox); derr != nil {
+		o.logger.Warn("Failed on dispatch order reservation commit", zap.String("order_id", order.ID), zap.Error(derr))
+	}
+
+	return order, nil
+}
+
+// RejectShopOrder cancel the order which is not shipped yet and release the reserved stock back to the warehouse.
+// The captured payment of the rejected order is refunded, the refund is recorded together with the rejection
+func (o *OrderUsecase) RejectShopOrder(ctx context.Context, params *entity.RejectShopOrderRequest) (*entity.Order, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	order, err := o.getShopOrder(ctx, params.User, params.ShopID, params.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	orderDetails, err := o.repos.OrderDetailRepo.ListByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	tx, err := o.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	err = o.transitionOrderState(ctx, order, entity.OrderStateCancelled, &entity.OrderStateChange{
+		ActorType: entity.OrderStateActorTypeSeller,
+		ActorID:   params.User.ID,
+		Reason:    params.Reason,
+	}, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	outbox, err := o.createOrderOutbox(ctx, order.ID, entity.OrderOutboxCommandReleaseStock, releaseStockPayload(order.ID, orderDetails), tx)
+	if err != nil {
+		return nil, err
+	}
+	outboxes := []*entity.OrderOutbox{outbox}
+
+	// Payments are read after the order row is locked by the transition, so the payment captured meanwhile is refunded
+	// here or by the payment callback which finds the order cancelled
+	payments, err := o.repos.PaymentRepo.ListByOrderID(ctx, order.ID, tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+	for _, p := range payments {
+		if p.State != entity.PaymentStateCaptured {
+			continue
+		}
+
+		var refundOutbox *entity.OrderOutbox
+		refundOutbox, err = o.recordPaymentRefund(ctx, order.ID, p.ID, tx)
+		if err != nil {
+			return nil, err
+		}
+		if refundOutbox != nil {
+			outboxes = append(outboxes, refundOutbox)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Rejection is already recorded, failed release or refund is retried by the outbox relay
+	for _, ob := range outboxes {
+		if derr := o.dispatchOrderOutbox(ctx, ob); derr != nil {
+			o.logger.Warn("Failed on dispatch order rejection outbox", zap.String("order_id", order.ID), zap.Error(derr))
+		}
+	}
+
+	return order, nil
+}
+
+// getShopOrder retrieve the order of the shop the seller is bound to
+func (o *OrderUsecase) getShopOrder(ctx context.Context, user *entity.User, shopID, orderID string) (*entity.Order, error) {
+	// Validate shop binding of the seller
+	if !user.CanManageShop(shopID) {
+		return nil, liberr.ResolveError(entity.ErrorForbidden)
+	}
+
+	order, err := o.repos.OrderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Order of another shop is never exposed
+	if order.ShopID != shopID {
+		return nil, liberr.ResolveError(entity.ErrorOrderNotFound)
+	}
+
+	return order, nil
+}
@@ -0,0 +1,584 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"order-service/internal/util/libpagination"
+	"order-service/module/order/entity"
+	"order-service/module/order/testutil/fixtures"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestSeller() *entity.User {
+	return &entity.User{ID: "7", ShopIDs: []string{fixtures.Order.ShopID}}
+}
+
+func TestOrderUsecase_ListShopOrder(t *testing.T) {
+	type input struct {
+		params *entity.ListShopOrderRequest
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func([]*entity.Order, *libpagination.OffsetPagination, error)
+	}{
+		{
+			name: "Success List Only The Orders Of The Seller Shop",
+			in: input{params: &entity.ListShopOrderRequest{
+				ShopID: fixtures.Order.ShopID,
+				User:   newTestSeller(),
+				Page:   1,
+				Limit:  10,
+			}},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderRepository.EXPECT().
+					ListByParams(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, params *entity.ListOrderByParams) ([]*entity.Order, *libpagination.OffsetPagination, error) {
+						assert.Equal(t, in.params.ShopID, params.ShopID)
+						assert.Empty(t, params.UserID)
+						return []*entity.Order{fixtures.NewOrder(fixtures.Order)}, &libpagination.OffsetPagination{Limit: 10, Total: 1}, nil
+					})
+			},
+			assertFn: func(result []*entity.Order, pagination *libpagination.OffsetPagination, err error) {
+				assert.Nil(t, err)
+				assert.Len(t, result, 1)
+				assert.Equal(t, 1, pagination.Total)
+			},
+		},
+		{
+			name: "Error Shop Of Another Seller",
+			in: input{params: &entity.ListShopOrderRequest{
+				ShopID: "4",
+				User:   newTestSeller(),
+			}},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {},
+			assertFn: func(result []*entity.Order, pagination *libpagination.OffsetPagination, err error) {
+				assertErrorDetails(t, entity.ErrorForbidden, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+		{
+			name: "Error Buyer Without Shop",
+			in: input{params: &entity.ListShopOrderRequest{
+				ShopID: fixtures.Order.ShopID,
+				User:   &entity.User{ID: fixtures.Order.UserID},
+			}},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {},
+			assertFn: func(result []*entity.Order, pagination *libpagination.OffsetPagination, err error) {
+				assertErrorDetails(t, entity.ErrorForbidden, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(uc.ListShopOrder(ctx, tc.in.params))
+		})
+	}
+}
+
+func TestOrderUsecase_GetShopOrder(t *testing.T) {
+	type input struct {
+		params *entity.GetShopOrderRequest
+	}
+
+	newParams := func() *entity.GetShopOrderRequest {
+		return &entity.GetShopOrderRequest{
+			ShopID:  fixtures.Order.ShopID,
+			OrderID: fixtures.Order.ID,
+			User:    newTestSeller(),
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(*entity.OrderInformation, error)
+	}{
+		{
+			name: "Success Get Order Of The Seller Shop",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStatePaid
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+				dependency.orderDetailRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderDetail{fixtures.NewOrderDetail(fixtures.OrderDetail)}, nil)
+				dependency.productRepository.EXPECT().
+					ListByProductIDs(gomock.Any(), []string{fixtures.OrderDetail.ProductID}).
+					Return([]*entity.Product{{ID: fixtures.OrderDetail.ProductID, Name: "Lorem Ipsum Product"}}, nil)
+				dependency.orderDiscountRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderDiscount{}, nil)
+				dependency.paymentRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID, nil).
+					Return([]*entity.Payment{}, nil)
+				dependency.orderReturnRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID, nil).
+					Return([]*entity.OrderReturn{}, nil)
+				dependency.orderStateHistoryRepo.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderStateHistory{}, nil)
+			},
+			assertFn: func(result *entity.OrderInformation, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, fixtures.Order.ID, result.Order.ID)
+				assert.Len(t, result.Items, 1)
+				assert.Equal(t, "Lorem Ipsum Product", result.Items[0].ProductName)
+			},
+		},
+		{
+			name: "Error Order Of Another Shop",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.ShopID = "4"
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+			},
+			assertFn: func(result *entity.OrderInformation, err error) {
+				assertErrorDetails(t, entity.ErrorOrderNotFound, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error Shop Of Another Seller",
+			in: input{params: func() *entity.GetShopOrderRequest {
+				params := newParams()
+				params.ShopID = "4"
+				return params
+			}()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {},
+			assertFn: func(result *entity.OrderInformation, err error) {
+				assertErrorDetails(t, entity.ErrorForbidden, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(uc.GetShopOrder(ctx, tc.in.params))
+		})
+	}
+}
+
+func TestOrderUsecase_ProcessShopOrder(t *testing.T) {
+	type input struct {
+		params *entity.ShopOrderActionRequest
+	}
+
+	newParams := func() *entity.ShopOrderActionRequest {
+		return &entity.ShopOrderActionRequest{
+			ShopID:  fixtures.Order.ShopID,
+			OrderID: fixtures.Order.ID,
+			User:    newTestSeller(),
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(*entity.Order, error)
+	}{
+		{
+			name: "Success Process Paid Order",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStatePaid
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderRepository.EXPECT().
+					UpdateState(gomock.Any(), order.ID, entity.OrderStatePaid, entity.OrderStateProcessing, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderStateHistoryRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, history *entity.OrderStateHistory, _ interface{}) error {
+						assert.Equal(t, entity.OrderStateActorTypeSeller, history.ActorType)
+						assert.Equal(t, in.params.User.ID, history.ActorID)
+						return nil
+					})
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderStateProcessing, result.State)
+			},
+		},
+		{
+			name: "Error Order Waiting For Payment",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(fixtures.NewOrder(fixtures.Order), nil)
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.databaseTransaction.EXPECT().Rollback().Return(nil)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assertErrorDetails(t, entity.ErrorOrderInvalidTransition, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error Seller Not Bound To The Shop",
+			in: input{params: func() *entity.ShopOrderActionRequest {
+				params := newParams()
+				params.User = &entity.User{ID: "7", ShopIDs: []string{"4"}}
+				return params
+			}()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {},
+			assertFn: func(result *entity.Order, err error) {
+				assertErrorDetails(t, entity.ErrorForbidden, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error Order Of Another Shop",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.ShopID = "4"
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assertErrorDetails(t, entity.ErrorOrderNotFound, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(uc.ProcessShopOrder(ctx, tc.in.params))
+		})
+	}
+}
+
+func TestOrderUsecase_ShipShopOrder(t *testing.T) {
+	type input struct {
+		params *entity.ShopOrderActionRequest
+	}
+
+	params := &entity.ShopOrderActionRequest{
+		ShopID:  fixtures.Order.ShopID,
+		OrderID: fixtures.Order.ID,
+		User:    newTestSeller(),
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(*entity.Order, error)
+	}{
+		{
+			name: "Success Ship Processing Order And Commit The Reservation",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStateProcessing
+				reserveOutbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+				reserveOutbox.State = entity.OrderOutboxStateProcessed
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderRepository.EXPECT().
+					UpdateState(gomock.Any(), order.ID, entity.OrderStateProcessing, entity.OrderStateShipped, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderStateHistoryRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					Return(nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, outbox *entity.OrderOutbox, _ interface{}) error {
+						assert.Equal(t, entity.OrderOutboxCommandCommitReservation, outbox.Command)
+						outbox.ID = "6"
+						return nil
+					})
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderOutbox{reserveOutbox}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), "6", 0, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.warehouseRepository.EXPECT().
+					CommitStock(gomock.Any(), order.ID, orderOutboxActor).
+					Return(nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), "6", entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderStateShipped, result.State)
+			},
+		},
+		{
+			name: "Success Shipment Kept When The Commit Is Not Delivered",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStateProcessing
+				reserveOutbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+				reserveOutbox.State = entity.OrderOutboxStateProcessed
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderRepository.EXPECT().
+					UpdateState(gomock.Any(), order.ID, entity.OrderStateProcessing, entity.OrderStateShipped, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderStateHistoryRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					Return(nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, outbox *entity.OrderOutbox, _ interface{}) error {
+						outbox.ID = "6"
+						return nil
+					})
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderOutbox{reserveOutbox}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), "6", 0, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.warehouseRepository.EXPECT().
+					CommitStock(gomock.Any(), order.ID, orderOutboxActor).
+					Return(errors.New("connection refused"))
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateLastError(gomock.Any(), "6", "connection refused").
+					Return(nil)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderStateShipped, result.State)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(uc.ShipShopOrder(ctx, tc.in.params))
+		})
+	}
+}
+
+func TestOrderUsecase_RejectShopOrder(t *testing.T) {
+	type input struct {
+		params *entity.RejectShopOrderRequest
+	}
+
+	params := &entity.RejectShopOrderRequest{
+		ShopID:  fixtures.Order.ShopID,
+		OrderID: fixtures.Order.ID,
+		Reason:  "Out of stock at the warehouse",
+		User:    newTestSeller(),
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(*entity.Order, error)
+	}{
+		{
+			name: "Success Reject Paid Order Release The Reservation And Refund The Payment",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStatePaid
+				reserveOutbox := fixtures.NewOrderOutbox(fixtures.OrderOutbox)
+				reserveOutbox.State = entity.OrderOutboxStateProcessed
+				capturedPayment := fixtures.NewPayment(fixtures.Payment)
+				capturedPayment.State = entity.PaymentStateCaptured
+				refundPendingPayment := fixtures.NewPayment(fixtures.Payment)
+				refundPendingPayment.State = entity.PaymentStateRefundPending
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+				dependency.orderDetailRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderDetail{fixtures.NewOrderDetail(fixtures.OrderDetail)}, nil)
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.orderRepository.EXPECT().
+					UpdateState(gomock.Any(), order.ID, entity.OrderStatePaid, entity.OrderStateCancelled, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderStateHistoryRepo.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, history *entity.OrderStateHistory, _ interface{}) error {
+						assert.Equal(t, in.params.Reason, history.Reason)
+						return nil
+					})
+				dependency.voucherUsageRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.VoucherUsage{}, nil)
+				dependency.webhookSubscriptionRepo.EXPECT().
+					ListByShopID(gomock.Any(), order.ShopID).
+					Return([]*entity.WebhookSubscription{}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, outbox *entity.OrderOutbox, _ interface{}) error {
+						assert.Equal(t, entity.OrderOutboxCommandReleaseStock, outbox.Command)
+						outbox.ID = "6"
+						return nil
+					})
+
+				// The refund is recorded together with the rejection
+				dependency.paymentRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID, dependency.databaseTransaction).
+					Return([]*entity.Payment{capturedPayment}, nil)
+				dependency.paymentRepository.EXPECT().
+					UpdateRefundPending(gomock.Any(), capturedPayment.ID, dependency.databaseTransaction).
+					Return(int64(1), nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), dependency.databaseTransaction).
+					DoAndReturn(func(ctx context.Context, outbox *entity.OrderOutbox, _ interface{}) error {
+						assert.Equal(t, entity.OrderOutboxCommandRefundPayment, outbox.Command)
+						outbox.ID = "7"
+						return nil
+					})
+				dependency.databaseTransaction.EXPECT().Commit().Return(nil)
+
+				dependency.orderOutboxRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderOutbox{reserveOutbox}, nil)
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), "6", 0, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.warehouseRepository.EXPECT().
+					ReleaseStock(gomock.Any(), order.ID, orderOutboxActor).
+					Return(nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), "6", entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+
+				dependency.orderOutboxRepository.EXPECT().
+					Claim(gomock.Any(), "7", 0, gomock.Any()).
+					Return(int64(1), nil)
+				dependency.paymentRepository.EXPECT().
+					GetByID(gomock.Any(), capturedPayment.ID).
+					Return(refundPendingPayment, nil)
+				dependency.paymentProvider.EXPECT().
+					Refund(gomock.Any(), refundPendingPayment).
+					Return(nil)
+				dependency.paymentRepository.EXPECT().
+					UpdateRefunded(gomock.Any(), capturedPayment.ID, gomock.Any(), nil).
+					Return(int64(1), nil)
+				dependency.orderOutboxRepository.EXPECT().
+					UpdateState(gomock.Any(), "7", entity.OrderOutboxStateProcessed, "", nil).
+					Return(int64(1), nil)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, entity.OrderStateCancelled, result.State)
+			},
+		},
+		{
+			name: "Error Shipped Order",
+			in:   input{params: params},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				order := fixtures.NewOrder(fixtures.Order)
+				order.State = entity.OrderStateShipped
+
+				dependency.orderRepository.EXPECT().
+					GetByID(gomock.Any(), in.params.OrderID).
+					Return(order, nil)
+				dependency.orderDetailRepository.EXPECT().
+					ListByOrderID(gomock.Any(), order.ID).
+					Return([]*entity.OrderDetail{fixtures.NewOrderDetail(fixtures.OrderDetail)}, nil)
+				dependency.databaseTransactionHandler.EXPECT().
+					Begin(gomock.Any(), gomock.Any()).
+					Return(dependency.databaseTransaction, nil)
+				dependency.databaseTransaction.EXPECT().Rollback().Return(nil)
+			},
+			assertFn: func(result *entity.Order, err error) {
+				assertErrorDetails(t, entity.ErrorOrderInvalidTransition, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(uc.RejectShopOrder(ctx, tc.in.params))
+		})
+	}
+}
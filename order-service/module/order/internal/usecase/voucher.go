@@ -0,0 +1,314 @@
+package usecase
+
+import (
+	"context"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+	"sort"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func (o *OrderUsecase) CreateVoucher(ctx context.Context, params *entity.CreateVoucherRequest) (*entity.Voucher, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	if !isValidVoucherRule(params) {
+		return nil, liberr.ResolveError(entity.ErrorVoucherInvalidRule)
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	voucher := &entity.Voucher{
+		Code:              params.Code,
+		ShopID:            params.ShopID,
+		ProductID:         params.ProductID,
+		Type:              params.Type,
+		Value:             params.Value,
+		MaxDiscount:       params.MaxDiscount,
+		BuyQuantity:       params.BuyQuantity,
+		GetQuantity:       params.GetQuantity,
+		MinSpend:          params.MinSpend,
+		UsageLimit:        params.UsageLimit,
+		UsageLimitPerUser: params.UsageLimitPerUser,
+		StartsAt:          params.StartsAt.UTC(),
+		EndsAt:            params.EndsAt.UTC(),
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if err := o.repos.VoucherRepo.Create(ctx, voucher, nil); err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return voucher, nil
+}
+
+func (o *OrderUsecase) GetVoucher(ctx context.Context, params *entity.GetVoucherRequest) (*entity.Voucher, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	voucher, err := o.repos.VoucherRepo.GetByID(ctx, params.VoucherID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return voucher, nil
+}
+
+// isValidVoucherRule validate the value required by the voucher type, the amounts are never negative
+func isValidVoucherRule(params *entity.CreateVoucherRequest) bool {
+	if params.Value.IsNegative() || params.MaxDiscount.IsNegative() || params.MinSpend.IsNegative() {
+		return false
+	}
+
+	switch params.Type {
+	case entity.VoucherTypePercentage:
+		return params.Value.IsPositive() && params.Value.LessThanOrEqual(decimal.NewFromInt(100))
+	case entity.VoucherTypeFixed:
+		return params.Value.IsPositive()
+	case entity.VoucherTypeBuyXGetY:
+		return params.BuyQuantity > 0 && params.GetQuantity > 0
+	}
+
+	return false
+}
+
+// appliedVoucher is the voucher applied to the drafted order along with the discount amount
+type appliedVoucher struct {
+	voucher *entity.Voucher
+	amount  decimal.Decimal
+}
+
+// listVouchersByCode retrieve the vouchers of the codes used by every shop, the codes have to exist
+func (o *OrderUsecase) listVouchersByCode(ctx context.Context, shops []*entity.CreateCheckoutShop) (map[string]*entity.Voucher, error) {
+	// map[code]voucher
+	voucherMap := map[string]*entity.Voucher{}
+
+	codesMap := make(map[string]struct{})
+	codes := []string{}
+	for _, shop := range shops {
+		for _, code := range shop.VoucherCodes {
+			if _, exists := codesMap[code]; !exists {
+				codesMap[code] = struct{}{}
+				codes = append(codes, code)
+			}
+		}
+	}
+
+	if len(codes) == 0 {
+		return voucherMap, nil
+	}
+
+	vouchers, err := o.repos.VoucherRepo.ListByCodes(ctx, codes)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	if len(vouchers) != len(codes) {
+		return nil, liberr.ResolveError(entity.ErrorVoucherNotFound)
+	}
+
+	for _, v := range vouchers {
+		voucherMap[v.Code] = v
+	}
+
+	return voucherMap, nil
+}
+
+// applyVouchers compute the discount of the vouchers used by the shop. Every voucher is computed against
+// the price before any discount, and the total discount never exceeds the order price
+func applyVouchers(shop *entity.CreateCheckoutShop, voucherMap map[string]*entity.Voucher, productMap map[string]*entity.Product, totalPrice decimal.Decimal, now time.Time) ([]*appliedVoucher, decimal.Decimal, error) {
+	applied := []*appliedVoucher{}
+	discountPrice := decimal.NewFromInt(0)
+
+	for _, code := range shop.VoucherCodes {
+		voucher := voucherMap[code]
+
+		amount, err := voucherDiscount(voucher, shop, productMap, now)
+		if err != nil {
+			return nil, discountPrice, err
+		}
+
+		// The voucher only takes the price left by the vouchers applied before
+		amount = decimal.Min(amount, totalPrice.Sub(discountPrice))
+		if !amount.IsPositive() {
+			return nil, discountPrice, liberr.ResolveError(entity.ErrorVoucherNotApplicable)
+		}
+
+		discountPrice = discountPrice.Add(amount)
+		applied = append(applied, &appliedVoucher{
+			voucher: voucher,
+			amount:  amount,
+		})
+	}
+
+	return applied, discountPrice, nil
+}
+
+// voucherDiscount compute the discount of the voucher on the ordered products of the shop it is scoped to,
+// the usage limit is checked again while the usage is counted
+func voucherDiscount(voucher *entity.Voucher, shop *entity.CreateCheckoutShop, productMap map[string]*entity.Product, now time.Time) (decimal.Decimal, error) {
+	zero := decimal.NewFromInt(0)
+
+	if !voucher.IsActive(now) {
+		return zero, liberr.ResolveError(entity.ErrorVoucherInactive)
+	}
+
+	if voucher.UsageLimit > 0 && voucher.UsedCount >= voucher.UsageLimit {
+		return zero, liberr.ResolveError(entity.ErrorVoucherUsageLimitReached)
+	}
+
+	if voucher.ShopID != "" && voucher.ShopID != shop.ShopID {
+		return zero, liberr.ResolveError(entity.ErrorVoucherNotApplicable)
+	}
+
+	// map[product_id]stock of the products the voucher applies to, a product split across warehouses is counted once
+	eligibleStocks := map[string]int{}
+	eligibleProductIDs := []string{}
+	for _, op := range shop.Products {
+		if voucher.ProductID != "" && voucher.ProductID != op.ProductID {
+			continue
+		}
+		if _, exists := eligibleStocks[op.ProductID]; !exists {
+			eligibleProductIDs = append(eligibleProductIDs, op.ProductID)
+		}
+		eligibleStocks[op.ProductID] += op.Stock
+	}
+
+	if len(eligibleProductIDs) == 0 {
+		return zero, liberr.ResolveError(entity.ErrorVoucherNotApplicable)
+	}
+
+	eligiblePrice := decimal.NewFromInt(0)
+	for _, productID := range eligibleProductIDs {
+		eligiblePrice = eligiblePrice.Add(productMap[productID].Price.Mul(decimal.NewFromInt(int64(eligibleStocks[productID]))))
+	}
+
+	if eligiblePrice.LessThan(voucher.MinSpend) {
+		return zero, liberr.ResolveError(entity.ErrorVoucherMinSpendNotReached)
+	}
+
+	amount := decimal.NewFromInt(0)
+	switch voucher.Type {
+	case entity.VoucherTypePercentage:
+		amount = eligiblePrice.Mul(voucher.Value).Div(decimal.NewFromInt(100)).Round(3)
+		if voucher.MaxDiscount.IsPositive() {
+			amount = decimal.Min(amount, voucher.MaxDiscount)
+		}
+	case entity.VoucherTypeFixed:
+		amount = decimal.Min(voucher.Value, eligiblePrice)
+	case entity.VoucherTypeBuyXGetY:
+		for _, productID := range eligibleProductIDs {
+			free := eligibleStocks[productID] / (voucher.BuyQuantity + voucher.GetQuantity) * voucher.GetQuantity
+			amount = amount.Add(productMap[productID].Price.Mul(decimal.NewFromInt(int64(free))))
+		}
+	}
+
+	if !amount.IsPositive() {
+		return zero, liberr.ResolveError(entity.ErrorVoucherNotApplicable)
+	}
+
+	return amount, nil
+}
+
+// countVoucherUsages count the usage of the vouchers applied to the drafts against the global usage limit.
+// The voucher rows stay locked until the transaction ends, they are locked by the voucher ID order
+// so concurrent orders using the same vouchers never deadlock
+func (o *OrderUsecase) countVoucherUsages(ctx context.Context, drafts []*orderDraft, tx util.DatabaseTransaction) error {
+	voucherIDs := []string{}
+	for _, d := range drafts {
+		for _, av := range d.vouchers {
+			voucherIDs = append(voucherIDs, av.voucher.ID)
+		}
+	}
+	sort.Strings(voucherIDs)
+
+	for _, voucherID := range voucherIDs {
+		affected, err := o.repos.VoucherRepo.IncrementUsedCount(ctx, voucherID, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+		if affected <= 0 {
+			return liberr.ResolveError(entity.ErrorVoucherUsageLimitReached)
+		}
+	}
+
+	return nil
+}
+
+// saveOrderDiscounts record the voucher usages and the discount lines of the order. The usages per user
+// are counted while the voucher rows are locked by countVoucherUsages, so the usage committed by
+// a concurrent order of the same user is always counted
+func (o *OrderUsecase) saveOrderDiscounts(ctx context.Context, draft *orderDraft, tx util.DatabaseTransaction) error {
+	order := draft.order
+
+	for _, av := range draft.vouchers {
+		if av.voucher.UsageLimitPerUser > 0 {
+			count, err := o.repos.VoucherUsageRepo.CountByVoucherIDAndUserID(ctx, av.voucher.ID, order.UserID, tx)
+			if err != nil {
+				return liberr.ResolveError(err)
+			}
+			if count >= av.voucher.UsageLimitPerUser {
+				return liberr.ResolveError(entity.ErrorVoucherUserLimitReached)
+			}
+		}
+
+		err := o.repos.VoucherUsageRepo.Create(ctx, &entity.VoucherUsage{
+			VoucherID: av.voucher.ID,
+			UserID:    order.UserID,
+			OrderID:   order.ID,
+		}, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+
+		err = o.repos.OrderDiscountRepo.Create(ctx, &entity.OrderDiscount{
+			OrderID:   order.ID,
+			VoucherID: av.voucher.ID,
+			Code:      av.voucher.Code,
+			Amount:    av.amount,
+		}, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+	}
+
+	return nil
+}
+
+// releaseVoucherUsages give the voucher usages of the order back once the order is cancelled or expired,
+// the discount lines are kept on the order as they were applied
+func (o *OrderUsecase) releaseVoucherUsages(ctx context.Context, orderID string, tx util.DatabaseTransaction) error {
+	usages, err := o.repos.VoucherUsageRepo.ListByOrderID(ctx, orderID)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	if len(usages) == 0 {
+		return nil
+	}
+
+	// Locked by the voucher ID order as the usages are counted
+	sort.Slice(usages, func(i, j int) bool {
+		return usages[i].VoucherID < usages[j].VoucherID
+	})
+
+	for _, u := range usages {
+		if _, err := o.repos.VoucherRepo.DecrementUsedCount(ctx, u.VoucherID, tx); err != nil {
+			return liberr.ResolveError(err)
+		}
+	}
+
+	if _, err := o.repos.VoucherUsageRepo.DeleteByOrderID(ctx, orderID, tx); err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"order-service/module/order/entity"
+	"order-service/module/order/testutil/fixtures"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderUsecase_CreateVoucher(t *testing.T) {
+	type input struct {
+		params *entity.CreateVoucherRequest
+	}
+
+	newParams := func() *entity.CreateVoucherRequest {
+		return &entity.CreateVoucherRequest{
+			Code:        fixtures.Voucher.Code,
+			ShopID:      fixtures.Voucher.ShopID,
+			Type:        entity.VoucherTypePercentage,
+			Value:       decimal.NewFromInt(10),
+			MaxDiscount: decimal.NewFromInt(20000),
+			MinSpend:    decimal.NewFromInt(50000),
+			StartsAt:    fixtures.Voucher.StartsAt,
+			EndsAt:      fixtures.Voucher.EndsAt,
+		}
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*orderUsecaseDependency, input)
+		assertFn       func(*entity.Voucher, error)
+	}{
+		{
+			name: "Success Create Voucher",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.voucherRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), nil).
+					Return(nil)
+			},
+			assertFn: func(result *entity.Voucher, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, fixtures.Voucher.Code, result.Code)
+				assert.True(t, decimal.NewFromInt(10).Equal(result.Value))
+			},
+		},
+		{
+			name: "Error Percentage Over A Hundred",
+			in: input{params: func() *entity.CreateVoucherRequest {
+				params := newParams()
+				params.Value = decimal.NewFromInt(150)
+				return params
+			}()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {},
+			assertFn: func(result *entity.Voucher, err error) {
+				assertErrorDetails(t, entity.ErrorVoucherInvalidRule, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error Buy X Get Y Without Quantity",
+			in: input{params: func() *entity.CreateVoucherRequest {
+				params := newParams()
+				params.Type = entity.VoucherTypeBuyXGetY
+				return params
+			}()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {},
+			assertFn: func(result *entity.Voucher, err error) {
+				assertErrorDetails(t, entity.ErrorVoucherInvalidRule, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error On Create Voucher",
+			in:   input{params: newParams()},
+			mockDependency: func(dependency *orderUsecaseDependency, in input) {
+				dependency.voucherRepository.EXPECT().
+					Create(gomock.Any(), gomock.Any(), nil).
+					Return(errors.New("error"))
+			},
+			assertFn: func(result *entity.Voucher, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.TODO()
+
+			ctrl := gomock.NewController(t)
+			uc, ucDependency := newTestOrderUsecase(ctrl)
+			defer ctrl.Finish()
+
+			tc.mockDependency(&ucDependency, tc.in)
+			tc.assertFn(uc.CreateVoucher(ctx, tc.in.params))
+		})
+	}
+}
+
+func TestApplyVouchers(t *testing.T) {
+	type input struct {
+		vouchers []*entity.Voucher
+	}
+
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	productMap := map[string]*entity.Product{
+		"1": {ID: "1", Name: "Lorem Ipsum Product", Price: decimal.NewFromInt(10000)},
+		"2": {ID: "2", Name: "Dolor Sit Product", Price: decimal.NewFromInt(20000)},
+	}
+	subtotalPrice := decimal.NewFromInt(50000)
+
+	newVoucher := func(modify func(*entity.Voucher)) *entity.Voucher {
+		voucher := fixtures.NewVoucher(fixtures.Voucher)
+		voucher.Value = fixtures.Voucher.Value
+		voucher.MaxDiscount = fixtures.Voucher.MaxDiscount
+		voucher.MinSpend = fixtures.Voucher.MinSpend
+		modify(voucher)
+		return voucher
+	}
+
+	testCases := []struct {
+		name     string
+		in       input
+		assertFn func([]*appliedVoucher, decimal.Decimal, error)
+	}{
+		{
+			name: "Percentage On The Shop Products",
+			in: input{vouchers: []*entity.Voucher{
+				newVoucher(func(v *entity.Voucher) {}),
+			}},
+			assertFn: func(applied []*appliedVoucher, discount decimal.Decimal, err error) {
+				assert.Nil(t, err)
+				assert.Len(t, applied, 1)
+				assert.True(t, decimal.NewFromInt(5000).Equal(discount), discount.String())
+			},
+		},
+		{
+			name: "Percentage Capped By The Max Discount",
+			in: input{vouchers: []*entity.Voucher{
+				newVoucher(func(v *entity.Voucher) {
+					v.Value = decimal.NewFromInt(50)
+					v.MaxDiscount = decimal.NewFromInt(15000)
+				}),
+			}},
+			assertFn: func(applied []*appliedVoucher, discount decimal.Decimal, err error) {
+				assert.Nil(t, err)
+				assert.True(t, decimal.NewFromInt(15000).Equal(discount), discount.String())
+			},
+		},
+		{
+			name: "Fixed Capped By The Eligible Price",
+			in: input{vouchers: []*entity.Voucher{
+				newVoucher(func(v *entity.Voucher) {
+					v.Type = entity.VoucherTypeFixed
+					v.Value = decimal.NewFromInt(60000)
+				}),
+			}},
+			assertFn: func(applied []*appliedVoucher, discount decimal.Decimal, err error) {
+				assert.Nil(t, err)
+				assert.True(t, subtotalPrice.Equal(discount), discount.String())
+			},
+		},
+		{
+			name: "Buy X Get Y On The Scoped Product",
+			in: input{vouchers: []*entity.Voucher{
+				newVoucher(func(v *entity.Voucher) {
+					v.Type = entity.VoucherTypeBuyXGetY
+					v.ProductID = "1"
+					v.BuyQuantity = 2
+					v.GetQuantity = 1
+					v.MinSpend = decimal.NewFromInt(0)
+				}),
+			}},
+			assertFn: func(applied []*appliedVoucher, discount decimal.Decimal, err error) {
+				assert.Nil(t, err)
+				assert.True(t, decimal.NewFromInt(10000).Equal(discount), discount.String())
+			},
+		},
+		{
+			name: "Second Voucher Only Take The Price Left",
+			in: input{vouchers: []*entity.Voucher{
+				newVoucher(func(v *entity.Voucher) {
+					v.Code = "FIXED48"
+					v.Type = entity.VoucherTypeFixed
+					v.Value = decimal.NewFromInt(48000)
+				}),
+				newVoucher(func(v *entity.Voucher) {}),
+			}},
+			assertFn: func(applied []*appliedVoucher, discount decimal.Decimal, err error) {
+				assert.Nil(t, err)
+				assert.Len(t, applied, 2)
+				assert.True(t, decimal.NewFromInt(2000).Equal(applied[1].amount), applied[1].amount.String())
+				assert.True(t, subtotalPrice.Equal(discount), discount.String())
+			},
+		},
+		{
+			name: "Error Voucher Inactive",
+			in: input{vouchers: []*entity.Voucher{
+				newVoucher(func(v *entity.Voucher) {
+					v.EndsAt = now
+				}),
+			}},
+			assertFn: func(applied []*appliedVoucher, discount decimal.Decimal, err error) {
+				assertErrorDetails(t, entity.ErrorVoucherInactive, err)
+				assert.Nil(t, applied)
+			},
+		},
+		{
+			name: "Error Usage Limit Reached",
+			in: input{vouchers: []*entity.Voucher{
+				newVoucher(func(v *entity.Voucher) {
+					v.UsedCount = v.UsageLimit
+				}),
+			}},
+			assertFn: func(applied []*appliedVoucher, discount decimal.Decimal, err error) {
+				assertErrorDetails(t, entity.ErrorVoucherUsageLimitReached, err)
+				assert.Nil(t, applied)
+			},
+		},
+		{
+			name: "Error Voucher Of Another Shop",
+			in: input{vouchers: []*entity.Voucher{
+				newVoucher(func(v *entity.Voucher) {
+					v.ShopID = "4"
+				}),
+			}},
+			assertFn: func(applied []*appliedVoucher, discount decimal.Decimal, err error) {
+				assertErrorDetails(t, entity.ErrorVoucherNotApplicable, err)
+				assert.Nil(t, applied)
+			},
+		},
+		{
+			name: "Error Product Not Ordered",
+			in: input{vouchers: []*entity.Voucher{
+				newVoucher(func(v *entity.Voucher) {
+					v.ProductID = "9"
+				}),
+			}},
+			assertFn: func(applied []*appliedVoucher, discount decimal.Decimal, err error) {
+				assertErrorDetails(t, entity.ErrorVoucherNotApplicable, err)
+				assert.Nil(t, applied)
+			},
+		},
+		{
+			name: "Error Min Spend Not Reached",
+			in: input{vouchers: []*entity.Voucher{
+				newVoucher(func(v *entity.Voucher) {
+					v.MinSpend = decimal.NewFromInt(60000)
+				}),
+			}},
+			assertFn: func(applied []*appliedVoucher, discount decimal.Decimal, err error) {
+				assertErrorDetails(t, entity.ErrorVoucherMinSpendNotReached, err)
+				assert.Nil(t, applied)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			shop := &entity.CreateCheckoutShop{
+				ShopID: "3",
+				Products: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 3},
+					{ProductID: "2", Stock: 1},
+				},
+			}
+
+			voucherMap := map[string]*entity.Voucher{}
+			for _, v := range tc.in.vouchers {
+				shop.VoucherCodes = append(shop.VoucherCodes, v.Code)
+				voucherMap[v.Code] = v
+			}
+
+			tc.assertFn(applyVouchers(shop, voucherMap, productMap, subtotalPrice, now))
+		})
+	}
+}
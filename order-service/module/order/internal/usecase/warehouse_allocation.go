@@ -0,0 +1,233 @@
+package usecase
+
+import (
+	"fmt"
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"sort"
+)
+
+const (
+	WarehouseAllocationLargestStockFirst = "largest-stock-first"
+	WarehouseAllocationFewestSplits      = "fewest-splits"
+	WarehouseAllocationPriority          = "priority"
+)
+
+// WarehouseAllocationStrategy allocate the ordered stock of the lines without warehouse across the shop warehouses,
+// a line is split into a line per warehouse when a single warehouse is not able to fulfill it
+type WarehouseAllocationStrategy interface {
+	Allocate(orderProducts []*entity.CreateOrderProduct, available *WarehouseAvailability) ([]*entity.CreateOrderProduct, error)
+}
+
+// NewWarehouseAllocationStrategy build the strategy by the name, the priority is the warehouse IDs
+// ordered by preference which is only used by the priority strategy
+func NewWarehouseAllocationStrategy(name string, priority []string) (WarehouseAllocationStrategy, error) {
+	switch name {
+	case WarehouseAllocationLargestStockFirst:
+		return &largestStockFirstAllocation{}, nil
+	case WarehouseAllocationFewestSplits:
+		return &fewestSplitsAllocation{}, nil
+	case WarehouseAllocationPriority:
+		priorityMap := make(map[string]int, len(priority))
+		for i, warehouseID := range priority {
+			priorityMap[warehouseID] = i
+		}
+		return &priorityAllocation{priority: priorityMap}, nil
+	}
+
+	return nil, fmt.Errorf("unknown warehouse allocation strategy %q", name)
+}
+
+// WarehouseAvailability track the available stock of the shop warehouses while the order lines are allocated
+type WarehouseAvailability struct {
+	// map[product_id][]warehouse_stock
+	warehouseStocks map[string][]*entity.WarehouseStock
+	// map[product_id][warehouse_id]available
+	available map[string]map[string]int
+}
+
+func NewWarehouseAvailability(shopID string, warehouseStocks []*entity.WarehouseStock) *WarehouseAvailability {
+	wa := &WarehouseAvailability{
+		warehouseStocks: map[string][]*entity.WarehouseStock{},
+		available:       map[string]map[string]int{},
+	}
+
+	for _, ws := range warehouseStocks {
+		if ws.ShopID != shopID {
+			continue
+		}
+		if _, ok := wa.available[ws.ProductID]; !ok {
+			wa.available[ws.ProductID] = map[string]int{}
+		}
+		wa.warehouseStocks[ws.ProductID] = append(wa.warehouseStocks[ws.ProductID], ws)
+		wa.available[ws.ProductID][ws.WarehouseID] = ws.Available
+	}
+
+	return wa
+}
+
+// Available return the remaining available stock of the product on the warehouse
+func (wa *WarehouseAvailability) Available(productID, warehouseID string) int {
+	return wa.available[productID][warehouseID]
+}
+
+// Take reduce the remaining available stock once it is allocated
+func (wa *WarehouseAvailability) Take(productID, warehouseID string, stock int) {
+	wa.available[productID][warehouseID] -= stock
+}
+
+// Candidates return the shop warehouses of the product which still have available stock
+func (wa *WarehouseAvailability) Candidates(productID string) []*entity.WarehouseStock {
+	candidates := []*entity.WarehouseStock{}
+	for _, ws := range wa.warehouseStocks[productID] {
+		if wa.Available(productID, ws.WarehouseID) > 0 {
+			candidates = append(candidates, ws)
+		}
+	}
+	return candidates
+}
+
+// allocateInOrder fill the line from the candidates in the given order until the ordered stock is fulfilled
+func allocateInOrder(orderProduct *entity.CreateOrderProduct, candidates []*entity.WarehouseStock, available *WarehouseAvailability) ([]*entity.CreateOrderProduct, error) {
+	if len(available.warehouseStocks[orderProduct.ProductID]) == 0 {
+		return nil, liberr.ResolveError(entity.ErrorProductStockNotFound)
+	}
+
+	allocated := []*entity.CreateOrderProduct{}
+	remaining := orderProduct.Stock
+	for _, ws := range candidates {
+		if remaining <= 0 {
+			break
+		}
+
+		stock := min(remaining, available.Available(ws.ProductID, ws.WarehouseID))
+		if stock <= 0 {
+			continue
+		}
+
+		available.Take(ws.ProductID, ws.WarehouseID, stock)
+		remaining -= stock
+		allocated = append(allocated, &entity.CreateOrderProduct{
+			ProductID:   orderProduct.ProductID,
+			WarehouseID: ws.WarehouseID,
+			Stock:       stock,
+		})
+	}
+
+	if remaining > 0 {
+		return nil, liberr.ResolveError(entity.ErrorProductInsufficientStock)
+	}
+
+	return allocated, nil
+}
+
+// sortByAvailable sort the candidates by the largest available stock, the warehouse ID keeps the order stable
+func sortByAvailable(candidates []*entity.WarehouseStock, available *WarehouseAvailability, less func(a, b *entity.WarehouseStock) (bool, bool)) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if less != nil {
+			if result, decided := less(candidates[i], candidates[j]); decided {
+				return result
+			}
+		}
+
+		ai := available.Available(candidates[i].ProductID, candidates[i].WarehouseID)
+		aj := available.Available(candidates[j].ProductID, candidates[j].WarehouseID)
+		if ai != aj {
+			return ai > aj
+		}
+		return candidates[i].WarehouseID < candidates[j].WarehouseID
+	})
+}
+
+// largestStockFirstAllocation take the stock from the warehouse with the largest available stock first
+type largestStockFirstAllocation struct{}
+
+func (l *largestStockFirstAllocation) Allocate(orderProducts []*entity.CreateOrderProduct, available *WarehouseAvailability) ([]*entity.CreateOrderProduct, error) {
+	allocated := []*entity.CreateOrderProduct{}
+	for _, op := range orderProducts {
+		candidates := available.Candidates(op.ProductID)
+		sortByAvailable(candidates, available, nil)
+
+		lines, err := allocateInOrder(op, candidates, available)
+		if err != nil {
+			return nil, err
+		}
+		allocated = append(allocated, lines...)
+	}
+
+	return allocated, nil
+}
+
+// fewestSplitsAllocation prefer the warehouse able to fulfill the whole line, and among them the warehouse able
+// to fulfill the most lines of the order so the order is shipped from as few warehouses as possible.
+// Lines no single warehouse is able to fulfill are split by the largest available stock first
+type fewestSplitsAllocation struct{}
+
+func (f *fewestSplitsAllocation) Allocate(orderProducts []*entity.CreateOrderProduct, available *WarehouseAvailability) ([]*entity.CreateOrderProduct, error) {
+	// map[warehouse_id]number of lines the warehouse is able to fulfill
+	fulfillable := map[string]int{}
+	for _, op := range orderProducts {
+		for _, ws := range available.Candidates(op.ProductID) {
+			if available.Available(op.ProductID, ws.WarehouseID) >= op.Stock {
+				fulfillable[ws.WarehouseID]++
+			}
+		}
+	}
+
+	allocated := []*entity.CreateOrderProduct{}
+	for _, op := range orderProducts {
+		candidates := available.Candidates(op.ProductID)
+		sortByAvailable(candidates, available, func(a, b *entity.WarehouseStock) (bool, bool) {
+			aFulfill := available.Available(a.ProductID, a.WarehouseID) >= op.Stock
+			bFulfill := available.Available(b.ProductID, b.WarehouseID) >= op.Stock
+			if aFulfill != bFulfill {
+				return aFulfill, true
+			}
+			if aFulfill && fulfillable[a.WarehouseID] != fulfillable[b.WarehouseID] {
+				return fulfillable[a.WarehouseID] > fulfillable[b.WarehouseID], true
+			}
+			return false, false
+		})
+
+		lines, err := allocateInOrder(op, candidates, available)
+		if err != nil {
+			return nil, err
+		}
+		allocated = append(allocated, lines...)
+	}
+
+	return allocated, nil
+}
+
+// priorityAllocation take the stock by the configured warehouse priority, warehouses without priority
+// are used last by the largest available stock first
+type priorityAllocation struct {
+	// map[warehouse_id]priority, lower is preferred
+	priority map[string]int
+}
+
+func (p *priorityAllocation) Allocate(orderProducts []*entity.CreateOrderProduct, available *WarehouseAvailability) ([]*entity.CreateOrderProduct, error) {
+	allocated := []*entity.CreateOrderProduct{}
+	for _, op := range orderProducts {
+		candidates := available.Candidates(op.ProductID)
+		sortByAvailable(candidates, available, func(a, b *entity.WarehouseStock) (bool, bool) {
+			aPriority, aOk := p.priority[a.WarehouseID]
+			bPriority, bOk := p.priority[b.WarehouseID]
+			if aOk != bOk {
+				return aOk, true
+			}
+			if aOk && aPriority != bPriority {
+				return aPriority < bPriority, true
+			}
+			return false, false
+		})
+
+		lines, err := allocateInOrder(op, candidates, available)
+		if err != nil {
+			return nil, err
+		}
+		allocated = append(allocated, lines...)
+	}
+
+	return allocated, nil
+}
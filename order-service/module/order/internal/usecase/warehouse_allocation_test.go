@@ -0,0 +1,607 @@
+package usecase
+
+import (
+	"order-service/internal/util/liberr"
+	"order-service/module/order/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWarehouseStock(shopID, warehouseID, productID string, available int) *entity.WarehouseStock {
+	return &entity.WarehouseStock{
+		ID:          warehouseID + "-" + productID,
+		WarehouseID: warehouseID,
+		ShopID:      shopID,
+		ProductID:   productID,
+		Stock:       available,
+		Available:   available,
+	}
+}
+
+func assertAllocationError(t *testing.T, expected *liberr.ErrorDetails, err error) {
+	assert.NotNil(t, err)
+
+	berr, ok := err.(*liberr.BaseError)
+	assert.True(t, ok)
+	if ok {
+		assert.Equal(t, expected, berr.GetDetails()[0])
+	}
+}
+
+func TestNewWarehouseAllocationStrategy(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strategy string
+		priority []string
+		assertFn func(WarehouseAllocationStrategy, error)
+	}{
+		{
+			name:     "Largest Stock First",
+			strategy: WarehouseAllocationLargestStockFirst,
+			assertFn: func(result WarehouseAllocationStrategy, err error) {
+				assert.Nil(t, err)
+				assert.IsType(t, &largestStockFirstAllocation{}, result)
+			},
+		},
+		{
+			name:     "Fewest Splits",
+			strategy: WarehouseAllocationFewestSplits,
+			assertFn: func(result WarehouseAllocationStrategy, err error) {
+				assert.Nil(t, err)
+				assert.IsType(t, &fewestSplitsAllocation{}, result)
+			},
+		},
+		{
+			name:     "Priority",
+			strategy: WarehouseAllocationPriority,
+			priority: []string{"3", "1"},
+			assertFn: func(result WarehouseAllocationStrategy, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, &priorityAllocation{priority: map[string]int{"3": 0, "1": 1}}, result)
+			},
+		},
+		{
+			name:     "Unknown Strategy",
+			strategy: "random",
+			assertFn: func(result WarehouseAllocationStrategy, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.assertFn(NewWarehouseAllocationStrategy(tc.strategy, tc.priority))
+		})
+	}
+}
+
+func TestWarehouseAllocationStrategy_Allocate(t *testing.T) {
+	type input struct {
+		strategy        WarehouseAllocationStrategy
+		warehouseStocks []*entity.WarehouseStock
+		orderProducts   []*entity.CreateOrderProduct
+	}
+
+	testCases := []struct {
+		name     string
+		in       input
+		assertFn func([]*entity.CreateOrderProduct, error)
+	}{
+		{
+			name: "Largest Stock First Exact Fit",
+			in: input{
+				strategy: &largestStockFirstAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 3),
+					newTestWarehouseStock("1", "2", "1", 5),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 5},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "2", Stock: 5},
+				}, result)
+			},
+		},
+		{
+			name: "Largest Stock First Split Across Warehouses",
+			in: input{
+				strategy: &largestStockFirstAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 3),
+					newTestWarehouseStock("1", "2", "1", 4),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 6},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "2", Stock: 4},
+					{ProductID: "1", WarehouseID: "1", Stock: 2},
+				}, result)
+			},
+		},
+		{
+			name: "Largest Stock First Lines Of The Same Product Share The Stock",
+			in: input{
+				strategy: &largestStockFirstAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 5),
+					newTestWarehouseStock("1", "2", "1", 4),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 3},
+					{ProductID: "1", Stock: 3},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "1", Stock: 3},
+					{ProductID: "1", WarehouseID: "2", Stock: 3},
+				}, result)
+			},
+		},
+		{
+			name: "Largest Stock First Tie Is Broken By Warehouse ID",
+			in: input{
+				strategy: &largestStockFirstAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "2", "1", 3),
+					newTestWarehouseStock("1", "1", "1", 3),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 4},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "1", Stock: 3},
+					{ProductID: "1", WarehouseID: "2", Stock: 1},
+				}, result)
+			},
+		},
+		{
+			name: "Largest Stock First Insufficient Total Stock",
+			in: input{
+				strategy: &largestStockFirstAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 2),
+					newTestWarehouseStock("1", "2", "1", 1),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 5},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assertAllocationError(t, entity.ErrorProductInsufficientStock, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Largest Stock First Stock Of Another Shop",
+			in: input{
+				strategy: &largestStockFirstAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("2", "1", "1", 10),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 1},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assertAllocationError(t, entity.ErrorProductStockNotFound, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Fewest Splits Prefer The Warehouse Fulfilling The Most Lines",
+			in: input{
+				strategy: &fewestSplitsAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 10),
+					newTestWarehouseStock("1", "2", "1", 5),
+					newTestWarehouseStock("1", "2", "2", 5),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 4},
+					{ProductID: "2", Stock: 2},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "2", Stock: 4},
+					{ProductID: "2", WarehouseID: "2", Stock: 2},
+				}, result)
+			},
+		},
+		{
+			name: "Fewest Splits Exact Fit Over A Larger Split",
+			in: input{
+				strategy: &fewestSplitsAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 4),
+					newTestWarehouseStock("1", "2", "1", 3),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 4},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "1", Stock: 4},
+				}, result)
+			},
+		},
+		{
+			name: "Fewest Splits Tie Is Broken By The Largest Stock",
+			in: input{
+				strategy: &fewestSplitsAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 5),
+					newTestWarehouseStock("1", "2", "1", 6),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 2},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "2", Stock: 2},
+				}, result)
+			},
+		},
+		{
+			name: "Fewest Splits Split When No Warehouse Fulfills The Line",
+			in: input{
+				strategy: &fewestSplitsAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 3),
+					newTestWarehouseStock("1", "2", "1", 2),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 4},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "1", Stock: 3},
+					{ProductID: "1", WarehouseID: "2", Stock: 1},
+				}, result)
+			},
+		},
+		{
+			name: "Fewest Splits Insufficient Total Stock",
+			in: input{
+				strategy: &fewestSplitsAllocation{},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 3),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 4},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assertAllocationError(t, entity.ErrorProductInsufficientStock, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Priority Take The Stock By The Priority",
+			in: input{
+				strategy: &priorityAllocation{priority: map[string]int{"3": 0, "1": 1}},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 2),
+					newTestWarehouseStock("1", "2", "1", 10),
+					newTestWarehouseStock("1", "3", "1", 1),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 5},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "3", Stock: 1},
+					{ProductID: "1", WarehouseID: "1", Stock: 2},
+					{ProductID: "1", WarehouseID: "2", Stock: 2},
+				}, result)
+			},
+		},
+		{
+			name: "Priority Exact Fit On The Preferred Warehouse",
+			in: input{
+				strategy: &priorityAllocation{priority: map[string]int{"1": 0}},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 2),
+					newTestWarehouseStock("1", "2", "1", 10),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 2},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "1", Stock: 2},
+				}, result)
+			},
+		},
+		{
+			name: "Priority Warehouses Not In The List By The Largest Stock",
+			in: input{
+				strategy: &priorityAllocation{priority: map[string]int{"9": 0}},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 2),
+					newTestWarehouseStock("1", "2", "1", 4),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 5},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "2", Stock: 4},
+					{ProductID: "1", WarehouseID: "1", Stock: 1},
+				}, result)
+			},
+		},
+		{
+			name: "Priority Tie Is Broken By Warehouse ID",
+			in: input{
+				strategy: &priorityAllocation{priority: map[string]int{}},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "2", "1", 3),
+					newTestWarehouseStock("1", "1", "1", 3),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 2},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "1", Stock: 2},
+				}, result)
+			},
+		},
+		{
+			name: "Priority Insufficient Total Stock",
+			in: input{
+				strategy: &priorityAllocation{priority: map[string]int{"1": 0}},
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 1),
+					newTestWarehouseStock("1", "2", "1", 1),
+				},
+				orderProducts: []*entity.CreateOrderProduct{
+					{ProductID: "1", Stock: 3},
+				},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, err error) {
+				assertAllocationError(t, entity.ErrorProductInsufficientStock, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			available := NewWarehouseAvailability("1", tc.in.warehouseStocks)
+			tc.assertFn(tc.in.strategy.Allocate(tc.in.orderProducts, available))
+		})
+	}
+}
+
+func TestAllocateInOrder(t *testing.T) {
+	type input struct {
+		warehouseStocks []*entity.WarehouseStock
+		orderProduct    *entity.CreateOrderProduct
+		candidates      []string
+	}
+
+	testCases := []struct {
+		name     string
+		in       input
+		assertFn func([]*entity.CreateOrderProduct, *WarehouseAvailability, error)
+	}{
+		{
+			name: "Exact Fit",
+			in: input{
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 3),
+					newTestWarehouseStock("1", "2", "1", 5),
+				},
+				orderProduct: &entity.CreateOrderProduct{ProductID: "1", Stock: 3},
+				candidates:   []string{"1", "2"},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, available *WarehouseAvailability, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "1", Stock: 3},
+				}, result)
+				assert.Equal(t, 0, available.Available("1", "1"))
+				assert.Equal(t, 5, available.Available("1", "2"))
+			},
+		},
+		{
+			name: "Split In The Candidates Order",
+			in: input{
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 3),
+					newTestWarehouseStock("1", "2", "1", 5),
+				},
+				orderProduct: &entity.CreateOrderProduct{ProductID: "1", Stock: 6},
+				candidates:   []string{"2", "1"},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, available *WarehouseAvailability, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "2", Stock: 5},
+					{ProductID: "1", WarehouseID: "1", Stock: 1},
+				}, result)
+				assert.Equal(t, 2, available.Available("1", "1"))
+				assert.Equal(t, 0, available.Available("1", "2"))
+			},
+		},
+		{
+			name: "Skip Candidate Without Available Stock",
+			in: input{
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 0),
+					newTestWarehouseStock("1", "2", "1", 5),
+				},
+				orderProduct: &entity.CreateOrderProduct{ProductID: "1", Stock: 2},
+				candidates:   []string{"1", "2"},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, available *WarehouseAvailability, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.CreateOrderProduct{
+					{ProductID: "1", WarehouseID: "2", Stock: 2},
+				}, result)
+			},
+		},
+		{
+			name: "Insufficient Total Stock",
+			in: input{
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 3),
+				},
+				orderProduct: &entity.CreateOrderProduct{ProductID: "1", Stock: 4},
+				candidates:   []string{"1"},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, available *WarehouseAvailability, err error) {
+				assertAllocationError(t, entity.ErrorProductInsufficientStock, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Product Without Shop Stock",
+			in: input{
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "2", 3),
+				},
+				orderProduct: &entity.CreateOrderProduct{ProductID: "1", Stock: 1},
+				candidates:   []string{},
+			},
+			assertFn: func(result []*entity.CreateOrderProduct, available *WarehouseAvailability, err error) {
+				assertAllocationError(t, entity.ErrorProductStockNotFound, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			available := NewWarehouseAvailability("1", tc.in.warehouseStocks)
+
+			// map[warehouse_id]warehouse_stock of the ordered product
+			warehouseStockMap := map[string]*entity.WarehouseStock{}
+			for _, ws := range tc.in.warehouseStocks {
+				if ws.ProductID == tc.in.orderProduct.ProductID {
+					warehouseStockMap[ws.WarehouseID] = ws
+				}
+			}
+			candidates := []*entity.WarehouseStock{}
+			for _, warehouseID := range tc.in.candidates {
+				candidates = append(candidates, warehouseStockMap[warehouseID])
+			}
+
+			result, err := allocateInOrder(tc.in.orderProduct, candidates, available)
+			tc.assertFn(result, available, err)
+		})
+	}
+}
+
+func TestSortByAvailable(t *testing.T) {
+	type input struct {
+		warehouseStocks []*entity.WarehouseStock
+		less            func(a, b *entity.WarehouseStock) (bool, bool)
+	}
+
+	testCases := []struct {
+		name     string
+		in       input
+		expected []string
+	}{
+		{
+			name: "Largest Available First",
+			in: input{
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 1),
+					newTestWarehouseStock("1", "2", "1", 7),
+					newTestWarehouseStock("1", "3", "1", 4),
+				},
+			},
+			expected: []string{"2", "3", "1"},
+		},
+		{
+			name: "Tie Is Broken By Warehouse ID",
+			in: input{
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "3", "1", 4),
+					newTestWarehouseStock("1", "1", "1", 4),
+					newTestWarehouseStock("1", "2", "1", 4),
+				},
+			},
+			expected: []string{"1", "2", "3"},
+		},
+		{
+			name: "Decided Less Wins Over The Available Stock",
+			in: input{
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 9),
+					newTestWarehouseStock("1", "2", "1", 1),
+				},
+				less: func(a, b *entity.WarehouseStock) (bool, bool) {
+					if a.WarehouseID == b.WarehouseID {
+						return false, false
+					}
+					return a.WarehouseID == "2", true
+				},
+			},
+			expected: []string{"2", "1"},
+		},
+		{
+			name: "Undecided Less Falls Back To The Available Stock",
+			in: input{
+				warehouseStocks: []*entity.WarehouseStock{
+					newTestWarehouseStock("1", "1", "1", 2),
+					newTestWarehouseStock("1", "2", "1", 5),
+				},
+				less: func(a, b *entity.WarehouseStock) (bool, bool) {
+					return false, false
+				},
+			},
+			expected: []string{"2", "1"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			available := NewWarehouseAvailability("1", tc.in.warehouseStocks)
+			candidates := append([]*entity.WarehouseStock{}, tc.in.warehouseStocks...)
+
+			sortByAvailable(candidates, available, tc.in.less)
+
+			result := []string{}
+			for _, ws := range candidates {
+				result = append(result, ws.WarehouseID)
+			}
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"order-service/internal/util"
+	"order-service/internal/util/liberr"
+	"order-service/internal/util/libpagination"
+	"order-service/internal/util/libvalidate"
+	"order-service/module/order/entity"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	maxWebhookRetryInterval = time.Hour
+
+	webhookSecretSize = 16
+)
+
+func (o *OrderUsecase) CreateWebhookSubscription(ctx context.Context, params *entity.CreateWebhookSubscriptionRequest) (*entity.WebhookSubscription, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	subscription := &entity.WebhookSubscription{
+		ShopID:    params.ShopID,
+		URL:       params.URL,
+		Secret:    secret,
+		Events:    params.Events,
+		Active:    true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := o.repos.WebhookSubscriptionRepo.Create(ctx, subscription, nil); err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Secret is only returned once, the shop keeps it to verify the signature of the deliveries
+	return subscription, nil
+}
+
+func (o *OrderUsecase) ListWebhookSubscription(ctx context.Context, params *entity.ListWebhookSubscriptionRequest) ([]*entity.WebhookSubscription, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	subscriptions, err := o.repos.WebhookSubscriptionRepo.ListByShopID(ctx, params.ShopID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	for _, s := range subscriptions {
+		s.Secret = ""
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteWebhookSubscription disable the subscription, the pending deliveries are failed by the relay
+// while the delivery log of the subscription is kept
+func (o *OrderUsecase) DeleteWebhookSubscription(ctx context.Context, params *entity.DeleteWebhookSubscriptionRequest) error {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	subscription, err := o.repos.WebhookSubscriptionRepo.GetByID(ctx, params.SubscriptionID)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	// Subscription of another shop is never exposed
+	if subscription.ShopID != params.ShopID || !subscription.Active {
+		return liberr.ResolveError(entity.ErrorWebhookNotFound)
+	}
+
+	if _, err := o.repos.WebhookSubscriptionRepo.UpdateActive(ctx, subscription.ID, false); err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	return nil
+}
+
+func (o *OrderUsecase) ListWebhookDelivery(ctx context.Context, params *entity.ListWebhookDeliveryByParams) ([]*entity.WebhookDelivery, *libpagination.OffsetPagination, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	params.Offset = libpagination.Offset(params.Page, params.Limit)
+
+	deliveries, pagination, err := o.repos.WebhookDeliveryRepo.ListByParams(ctx, params)
+	if err != nil {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	return deliveries, pagination, nil
+}
+
+// RedeliverWebhook send the payload of the delivery again as a new delivery, so the log of the original
+// delivery is kept. The new delivery is sent right away and retried by the webhook relay when it fails
+func (o *OrderUsecase) RedeliverWebhook(ctx context.Context, params *entity.RedeliverWebhookRequest) (*entity.WebhookDelivery, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidParameter)
+	}
+
+	delivery, err := o.repos.WebhookDeliveryRepo.GetByID(ctx, params.DeliveryID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Delivery of another shop is never exposed
+	if delivery.ShopID != params.ShopID {
+		return nil, liberr.ResolveError(entity.ErrorWebhookDeliveryNotFound)
+	}
+
+	subscription, err := o.repos.WebhookSubscriptionRepo.GetByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	if !subscription.Active {
+		return nil, liberr.ResolveError(entity.ErrorWebhookNotFound)
+	}
+
+	redelivery := &entity.WebhookDelivery{
+		SubscriptionID: delivery.SubscriptionID,
+		ShopID:         delivery.ShopID,
+		OrderID:        delivery.OrderID,
+		Event:          delivery.Event,
+		Payload:        delivery.Payload,
+		NextAttemptAt:  util.NowUTCWithoutNanoSecond(),
+	}
+
+	if err := o.repos.WebhookDeliveryRepo.Create(ctx, redelivery, nil); err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	// Redelivery is already recorded, failed attempt is retried by the webhook relay
+	if derr := o.sendWebhookDelivery(ctx, subscription, redelivery); derr != nil {
+		o.logger.Warn("Failed on redeliver webhook", zap.String("webhook_delivery_id", redelivery.ID), zap.Error(derr))
+	}
+
+	redelivery, err = o.repos.WebhookDeliveryRepo.GetByID(ctx, redelivery.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return redelivery, nil
+}
+
+// createOrderWebhookDeliveries record the event of the order for every subscription of the shop, it has to be
+// called within the same transaction of the order changes so the event is only sent once the change is committed
+func (o *OrderUsecase) createOrderWebhookDeliveries(ctx context.Context, order *entity.Order, event entity.WebhookEvent, tx util.DatabaseTransaction) error {
+	subscriptions, err := o.repos.WebhookSubscriptionRepo.ListByShopID(ctx, order.ShopID)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	now := util.NowUTCWithoutNanoSecond()
+	var payload []byte
+	for _, s := range subscriptions {
+		if !s.IsSubscribed(event) {
+			continue
+		}
+
+		if payload == nil {
+			payload, err = json.Marshal(&entity.WebhookPayload{
+				EventID:    webhookEventID(event, order.ID),
+				Event:      event,
+				OccurredAt: now,
+				Order:      order,
+			})
+			if err != nil {
+				return liberr.ResolveError(err)
+			}
+		}
+
+		err = o.repos.WebhookDeliveryRepo.Create(ctx, &entity.WebhookDelivery{
+			SubscriptionID: s.ID,
+			ShopID:         order.ShopID,
+			OrderID:        order.ID,
+			Event:          event,
+			Payload:        string(payload),
+			NextAttemptAt:  now,
+		}, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+	}
+
+	return nil
+}
+
+// ExecuteWebhookRelay send the pending deliveries which are due, the deliveries are claimed one by one
+// so the relay is safe to be run by several instances
+func (o *OrderUsecase) ExecuteWebhookRelay(ctx context.Context) error {
+	logFields := []zap.Field{
+		zap.String("function", "ExecuteWebhookRelay"),
+	}
+
+	deliveries, err := o.repos.WebhookDeliveryRepo.ListPending(ctx, util.NowUTCWithoutNanoSecond(), o.configs.WebhookRelayBatchSize)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, d := range deliveries {
+		if err := o.dispatchWebhookDelivery(ctx, d); err != nil {
+			failed++
+			o.logger.Error(fmt.Sprintf("Webhook Delivery ID : %s Failed on Dispatch due %v", d.ID, err), logFields...)
+		}
+	}
+
+	o.logger.Info(fmt.Sprintf("Webhook Relay dispatched %d deliveries with %d failed", len(deliveries), failed), logFields...)
+
+	return nil
+}
+
+// dispatchWebhookDelivery send the delivery to the subscription, the delivery of the disabled
+// subscription is failed without being sent
+func (o *OrderUsecase) dispatchWebhookDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	subscription, err := o.repos.WebhookSubscriptionRepo.GetByID(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	if !subscription.Active {
+		_, err = o.repos.WebhookDeliveryRepo.UpdateAttemptError(ctx, delivery.ID, entity.WebhookDeliveryStateFailed, delivery.ResponseStatus, "Webhook subscription is disabled")
+		return liberr.ResolveError(err)
+	}
+
+	return o.sendWebhookDelivery(ctx, subscription, delivery)
+}
+
+// sendWebhookDelivery claim the delivery and send it. The failed delivery stays pending and is retried
+// with an exponential backoff until the maximum attempt is reached, then it is failed
+func (o *OrderUsecase) sendWebhookDelivery(ctx context.Context, subscription *entity.WebhookSubscription, delivery *entity.WebhookDelivery) error {
+	now := util.NowUTCWithoutNanoSecond()
+	affected, err := o.repos.WebhookDeliveryRepo.Claim(ctx, delivery.ID, delivery.Attempt, now.Add(o.webhookRetryInterval(delivery.Attempt+1)))
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	// Delivery already claimed by another worker
+	if affected <= 0 {
+		return nil
+	}
+	delivery.Attempt++
+
+	responseStatus, err := o.repos.WebhookRepo.Send(ctx, subscription, delivery)
+	if err != nil {
+		toState := entity.WebhookDeliveryStatePending
+		if delivery.Attempt >= o.configs.WebhookMaxAttempt {
+			toState = entity.WebhookDeliveryStateFailed
+		}
+
+		if _, uerr := o.repos.WebhookDeliveryRepo.UpdateAttemptError(ctx, delivery.ID, toState, responseStatus, webhookErrorMessage(err)); uerr != nil {
+			return liberr.ResolveError(uerr)
+		}
+
+		delivery.State = toState
+		delivery.ResponseStatus = responseStatus
+		return liberr.ResolveError(err)
+	}
+
+	_, err = o.repos.WebhookDeliveryRepo.UpdateDelivered(ctx, delivery.ID, responseStatus, util.NowUTCWithoutNanoSecond())
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	delivery.State = entity.WebhookDeliveryStateDelivered
+	delivery.ResponseStatus = responseStatus
+	return nil
+}
+
+func (o *OrderUsecase) webhookRetryInterval(attempt int) time.Duration {
+	interval := time.Duration(o.configs.WebhookRetryIntervalSecond) * time.Second
+	for i := 1; i < attempt && interval < maxWebhookRetryInterval; i++ {
+		interval *= 2
+	}
+
+	if interval > maxWebhookRetryInterval {
+		return maxWebhookRetryInterval
+	}
+	return interval
+}
+
+// webhookEventID identify the event of the order, every order emits each event once at most
+func webhookEventID(event entity.WebhookEvent, orderID string) string {
+	return fmt.Sprintf("%s:%s", event, orderID)
+}
+
+// webhookErrorMessage keep the cause of the failure along with the traced message in the delivery log
+func webhookErrorMessage(err error) string {
+	if cause := errors.Unwrap(err); cause != nil {
+		return fmt.Sprintf("%s: %s", err.Error(), cause.Error())
+	}
+	return err.Error()
+}
+
+func newWebhookSecret() (string, error) {
+	b := make([]byte, webhookSecretSize)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,47 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	CartItem = &entity.CartItem{
+		ID:        "8",
+		UserID:    "2",
+		ShopID:    "3",
+		ProductID: "5",
+		Stock:     2,
+		Price:     decimal.NewFromInt(10000),
+		CreatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewCartItem(obj *entity.CartItem) *entity.CartItem {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.CartItem)
+	res.Price = obj.Price
+
+	return res
+}
+
+func GetCartItemRow(obj *entity.CartItem) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.UserID,
+		obj.ShopID,
+		obj.ProductID,
+		obj.Stock,
+		obj.Price,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
@@ -0,0 +1,43 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	Checkout = &entity.Checkout{
+		ID:         "6",
+		UserID:     "2",
+		TotalStock: 8,
+		TotalPrice: decimal.NewFromInt(80000),
+		CreatedAt:  time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:  time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewCheckout(obj *entity.Checkout) *entity.Checkout {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.Checkout)
+	res.TotalPrice = obj.TotalPrice
+
+	return res
+}
+
+func GetCheckoutRow(obj *entity.Checkout) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.UserID,
+		obj.TotalStock,
+		obj.TotalPrice,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
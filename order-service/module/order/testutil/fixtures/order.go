@@ -11,15 +11,20 @@ import (
 
 var (
 	Order = &entity.Order{
-		ID:         "1",
-		UserID:     "2",
-		ShopID:     "3",
-		State:      entity.OrderStateCreated,
-		TotalStock: 5,
-		TotalPrice: decimal.NewFromInt(50000),
-		ExpiredAt:  time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
-		CreatedAt:  time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
-		UpdatedAt:  time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		ID:               "1",
+		UserID:           "2",
+		ShopID:           "3",
+		State:            entity.OrderStateCreated,
+		TotalStock:       5,
+		SubtotalPrice:    decimal.NewFromInt(50000),
+		DiscountPrice:    decimal.NewFromInt(5000),
+		ShippingPrice:    decimal.NewFromInt(10000),
+		TaxPrice:         decimal.NewFromInt(4950),
+		TotalPrice:       decimal.NewFromInt(59950),
+		ExpirationSecond: 900,
+		ExpiredAt:        time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		CreatedAt:        time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:        time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
 	}
 )
 
@@ -29,6 +34,10 @@ func NewOrder(obj *entity.Order) *entity.Order {
 		return nil
 	}
 	res := r.(*entity.Order)
+	res.SubtotalPrice = obj.SubtotalPrice
+	res.DiscountPrice = obj.DiscountPrice
+	res.ShippingPrice = obj.ShippingPrice
+	res.TaxPrice = obj.TaxPrice
 	res.TotalPrice = obj.TotalPrice
 
 	return res
@@ -39,10 +48,18 @@ func GetOrderRow(obj *entity.Order) []driver.Value {
 		obj.ID,
 		obj.UserID,
 		obj.ShopID,
+		obj.CheckoutID,
 		obj.State,
 		obj.TotalStock,
+		obj.SubtotalPrice,
+		obj.DiscountPrice,
+		obj.ShippingPrice,
+		obj.TaxPrice,
 		obj.TotalPrice,
+		obj.ExpirationPolicyID,
+		obj.ExpirationSecond,
 		obj.ExpiredAt,
+		obj.ManualHandlingReason,
 		obj.CreatedAt,
 		obj.UpdatedAt,
 	}
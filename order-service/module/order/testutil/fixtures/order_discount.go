@@ -0,0 +1,45 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	OrderDiscount = &entity.OrderDiscount{
+		ID:        "12",
+		OrderID:   "1",
+		VoucherID: "10",
+		Code:      "SHOP10",
+		Amount:    decimal.NewFromInt(5000),
+		CreatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewOrderDiscount(obj *entity.OrderDiscount) *entity.OrderDiscount {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.OrderDiscount)
+	res.Amount = obj.Amount
+
+	return res
+}
+
+func GetOrderDiscountRow(obj *entity.OrderDiscount) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.OrderID,
+		obj.VoucherID,
+		obj.Code,
+		obj.Amount,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
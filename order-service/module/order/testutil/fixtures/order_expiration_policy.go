@@ -0,0 +1,40 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	OrderExpirationPolicy = &entity.OrderExpirationPolicy{
+		ID:               "23",
+		Scope:            entity.OrderExpirationPolicyScopeShop,
+		ScopeID:          "3",
+		ExpirationSecond: 86400,
+		CreatedAt:        time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:        time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewOrderExpirationPolicy(obj *entity.OrderExpirationPolicy) *entity.OrderExpirationPolicy {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+
+	return r.(*entity.OrderExpirationPolicy)
+}
+
+func GetOrderExpirationPolicyRow(obj *entity.OrderExpirationPolicy) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.Scope,
+		obj.ScopeID,
+		obj.ExpirationSecond,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
@@ -0,0 +1,46 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	OrderIdempotencyKey = &entity.OrderIdempotencyKey{
+		ID:             "4",
+		UserID:         "2",
+		IdempotencyKey: "0f8fad5b-d9cb-469f-a165-70867728950e",
+		RequestHash:    "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		State:          entity.OrderIdempotencyKeyStateCompleted,
+		OrderID:        "1",
+		LockedUntil:    time.Date(2025, 1, 10, 11, 13, 13, 0, time.UTC),
+		CreatedAt:      time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:      time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewOrderIdempotencyKey(obj *entity.OrderIdempotencyKey) *entity.OrderIdempotencyKey {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.OrderIdempotencyKey)
+	return res
+}
+
+func GetOrderIdempotencyKeyRow(obj *entity.OrderIdempotencyKey) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.UserID,
+		obj.IdempotencyKey,
+		obj.RequestHash,
+		obj.State,
+		obj.OrderID,
+		obj.LockedUntil,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
@@ -0,0 +1,48 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	OrderOutbox = &entity.OrderOutbox{
+		ID:            "5",
+		OrderID:       "1",
+		Command:       entity.OrderOutboxCommandReserveStock,
+		Payload:       `{"warehouse_stocks":[{"warehouse_id":"1","product_id":"1","stock":-2}]}`,
+		State:         entity.OrderOutboxStatePending,
+		Attempt:       1,
+		NextAttemptAt: time.Date(2025, 1, 10, 11, 12, 43, 0, time.UTC),
+		LastError:     "",
+		CreatedAt:     time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:     time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewOrderOutbox(obj *entity.OrderOutbox) *entity.OrderOutbox {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.OrderOutbox)
+	return res
+}
+
+func GetOrderOutboxRow(obj *entity.OrderOutbox) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.OrderID,
+		obj.Command,
+		obj.Payload,
+		obj.State,
+		obj.Attempt,
+		obj.NextAttemptAt,
+		obj.LastError,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
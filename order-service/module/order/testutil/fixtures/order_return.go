@@ -0,0 +1,63 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	OrderReturn = &entity.OrderReturn{
+		ID:            "18",
+		OrderID:       "1",
+		OrderDetailID: "1",
+		UserID:        "1",
+		ProductID:     "1",
+		WarehouseID:   "1",
+		Quantity:      1,
+		RefundAmount:  decimal.NewFromInt(11990),
+		Reason:        "Damaged on arrival",
+		ReviewNote:    "",
+		State:         entity.OrderReturnStateRequested,
+		CreatedAt:     time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:     time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewOrderReturn(obj *entity.OrderReturn) *entity.OrderReturn {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.OrderReturn)
+	res.RefundAmount = obj.RefundAmount
+
+	return res
+}
+
+func GetOrderReturnRow(obj *entity.OrderReturn) []driver.Value {
+	var reviewedAt driver.Value
+	if obj.ReviewedAt != nil {
+		reviewedAt = *obj.ReviewedAt
+	}
+
+	return []driver.Value{
+		obj.ID,
+		obj.OrderID,
+		obj.OrderDetailID,
+		obj.UserID,
+		obj.ProductID,
+		obj.WarehouseID,
+		obj.Quantity,
+		obj.RefundAmount,
+		obj.Reason,
+		obj.ReviewNote,
+		obj.State,
+		reviewedAt,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	OrderStateHistory = &entity.OrderStateHistory{
+		ID:        "21",
+		OrderID:   "1",
+		FromState: entity.OrderStateCreated,
+		ToState:   entity.OrderStateCancelled,
+		ActorType: entity.OrderStateActorTypeUser,
+		ActorID:   "1",
+		Reason:    "Cancelled by user",
+		CreatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewOrderStateHistory(obj *entity.OrderStateHistory) *entity.OrderStateHistory {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+
+	return r.(*entity.OrderStateHistory)
+}
+
+func GetOrderStateHistoryRow(obj *entity.OrderStateHistory) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.OrderID,
+		obj.FromState,
+		obj.ToState,
+		obj.ActorType,
+		obj.ActorID,
+		obj.Reason,
+		obj.CreatedAt,
+	}
+}
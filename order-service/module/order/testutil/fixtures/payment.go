@@ -0,0 +1,62 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	Payment = &entity.Payment{
+		ID:                "15",
+		OrderID:           "1",
+		UserID:            "1",
+		Provider:          "fake",
+		ProviderReference: "fake-4f1c2a9b7d3e5f60",
+		Amount:            decimal.NewFromInt(59950),
+		State:             entity.PaymentStatePending,
+		CreatedAt:         time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:         time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewPayment(obj *entity.Payment) *entity.Payment {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.Payment)
+	res.Amount = obj.Amount
+
+	return res
+}
+
+func GetPaymentRow(obj *entity.Payment) []driver.Value {
+	var capturedAt driver.Value
+	if obj.CapturedAt != nil {
+		capturedAt = *obj.CapturedAt
+	}
+
+	var refundedAt driver.Value
+	if obj.RefundedAt != nil {
+		refundedAt = *obj.RefundedAt
+	}
+
+	return []driver.Value{
+		obj.ID,
+		obj.OrderID,
+		obj.UserID,
+		obj.Provider,
+		obj.ProviderReference,
+		obj.PaymentURL,
+		obj.Amount,
+		obj.State,
+		capturedAt,
+		refundedAt,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
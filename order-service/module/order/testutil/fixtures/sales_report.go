@@ -0,0 +1,44 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	SalesReport = &entity.SalesReport{
+		Period:     time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC),
+		ShopID:     "1",
+		ProductID:  "1",
+		OrderCount: 3,
+		Units:      5,
+		Revenue:    decimal.NewFromInt(50000),
+	}
+)
+
+func NewSalesReport(obj *entity.SalesReport) *entity.SalesReport {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+
+	res := r.(*entity.SalesReport)
+	res.Revenue = obj.Revenue
+
+	return res
+}
+
+func GetSalesReportRow(obj *entity.SalesReport) []driver.Value {
+	return []driver.Value{
+		obj.Period,
+		obj.ShopID,
+		obj.ProductID,
+		obj.OrderCount,
+		obj.Units,
+		obj.Revenue,
+	}
+}
@@ -0,0 +1,50 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ShopConfig = &entity.ShopConfig{
+		ID:               "13",
+		ShopID:           "3",
+		TaxRegion:        "ID-JK",
+		ShippingMethod:   entity.ShippingMethodFlat,
+		ShippingFee:      decimal.NewFromInt(10000),
+		ShippingFeePerKg: decimal.NewFromInt(0),
+		ItemWeightGram:   0,
+		CreatedAt:        time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:        time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewShopConfig(obj *entity.ShopConfig) *entity.ShopConfig {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.ShopConfig)
+	res.ShippingFee = obj.ShippingFee
+	res.ShippingFeePerKg = obj.ShippingFeePerKg
+
+	return res
+}
+
+func GetShopConfigRow(obj *entity.ShopConfig) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.ShopID,
+		obj.TaxRegion,
+		obj.ShippingMethod,
+		obj.ShippingFee,
+		obj.ShippingFeePerKg,
+		obj.ItemWeightGram,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
@@ -0,0 +1,41 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	TaxRate = &entity.TaxRate{
+		ID:        "14",
+		Region:    "ID-JK",
+		Rate:      decimal.NewFromInt(11),
+		CreatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewTaxRate(obj *entity.TaxRate) *entity.TaxRate {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.TaxRate)
+	res.Rate = obj.Rate
+
+	return res
+}
+
+func GetTaxRateRow(obj *entity.TaxRate) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.Region,
+		obj.Rate,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
@@ -0,0 +1,64 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+	"github.com/shopspring/decimal"
+)
+
+var (
+	Voucher = &entity.Voucher{
+		ID:                "10",
+		Code:              "SHOP10",
+		ShopID:            "3",
+		Type:              entity.VoucherTypePercentage,
+		Value:             decimal.NewFromInt(10),
+		MaxDiscount:       decimal.NewFromInt(20000),
+		MinSpend:          decimal.NewFromInt(50000),
+		UsageLimit:        100,
+		UsageLimitPerUser: 1,
+		UsedCount:         4,
+		StartsAt:          time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:            time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:         time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:         time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewVoucher(obj *entity.Voucher) *entity.Voucher {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.Voucher)
+	res.Value = obj.Value
+	res.MaxDiscount = obj.MaxDiscount
+	res.MinSpend = obj.MinSpend
+
+	return res
+}
+
+func GetVoucherRow(obj *entity.Voucher) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.Code,
+		obj.ShopID,
+		obj.ProductID,
+		obj.Type,
+		obj.Value,
+		obj.MaxDiscount,
+		obj.BuyQuantity,
+		obj.GetQuantity,
+		obj.MinSpend,
+		obj.UsageLimit,
+		obj.UsageLimitPerUser,
+		obj.UsedCount,
+		obj.StartsAt,
+		obj.EndsAt,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
@@ -0,0 +1,38 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	VoucherUsage = &entity.VoucherUsage{
+		ID:        "11",
+		VoucherID: "10",
+		UserID:    "2",
+		OrderID:   "1",
+		CreatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewVoucherUsage(obj *entity.VoucherUsage) *entity.VoucherUsage {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.VoucherUsage)
+	return res
+}
+
+func GetVoucherUsageRow(obj *entity.VoucherUsage) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.VoucherID,
+		obj.UserID,
+		obj.OrderID,
+		obj.CreatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	WebhookDelivery = &entity.WebhookDelivery{
+		ID:             "17",
+		SubscriptionID: "16",
+		ShopID:         "3",
+		OrderID:        "1",
+		Event:          entity.WebhookEventOrderCreated,
+		Payload:        `{"event_id":"order.created:1","event":"order.created","occurred_at":"2025-01-10T11:12:13Z","order":{"id":"1"}}`,
+		State:          entity.WebhookDeliveryStatePending,
+		Attempt:        1,
+		NextAttemptAt:  time.Date(2025, 1, 10, 11, 12, 43, 0, time.UTC),
+		ResponseStatus: 503,
+		LastError:      "Error with http status 503 on Webhook.Send",
+		CreatedAt:      time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:      time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewWebhookDelivery(obj *entity.WebhookDelivery) *entity.WebhookDelivery {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.WebhookDelivery)
+	return res
+}
+
+func GetWebhookDeliveryRow(obj *entity.WebhookDelivery) []driver.Value {
+	var deliveredAt driver.Value
+	if obj.DeliveredAt != nil {
+		deliveredAt = *obj.DeliveredAt
+	}
+
+	return []driver.Value{
+		obj.ID,
+		obj.SubscriptionID,
+		obj.ShopID,
+		obj.OrderID,
+		obj.Event,
+		obj.Payload,
+		obj.State,
+		obj.Attempt,
+		obj.NextAttemptAt,
+		obj.ResponseStatus,
+		obj.LastError,
+		deliveredAt,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
@@ -0,0 +1,50 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"order-service/module/order/entity"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	WebhookSubscription = &entity.WebhookSubscription{
+		ID:        "16",
+		ShopID:    "3",
+		URL:       "https://fulfilment.example.com/webhooks/orders",
+		Secret:    "3b0f6c1e9a2d4f7b8c5e1a0d2f4b6c8e",
+		Events:    []entity.WebhookEvent{entity.WebhookEventOrderCreated, entity.WebhookEventOrderExpired},
+		Active:    true,
+		CreatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewWebhookSubscription(obj *entity.WebhookSubscription) *entity.WebhookSubscription {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.WebhookSubscription)
+	return res
+}
+
+func GetWebhookSubscriptionRow(obj *entity.WebhookSubscription) []driver.Value {
+	events := make([]string, len(obj.Events))
+	for i, e := range obj.Events {
+		events[i] = string(e)
+	}
+
+	return []driver.Value{
+		obj.ID,
+		obj.ShopID,
+		obj.URL,
+		obj.Secret,
+		strings.Join(events, ","),
+		obj.Active,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
@@ -16,7 +16,8 @@ type AuthConfig struct {
 }
 
 type repositorySet struct {
-	userRepository *repository.UserRepository
+	userRepository     *repository.UserRepository
+	userShopRepository *repository.UserShopRepository
 }
 
 type usecaseSet struct {
@@ -25,14 +26,16 @@ type usecaseSet struct {
 
 func newRepositories(cfg *AuthConfig) (*repositorySet, error) {
 	return &repositorySet{
-		userRepository: repository.NewUserRepository(cfg.DB),
+		userRepository:     repository.NewUserRepository(cfg.DB),
+		userShopRepository: repository.NewUserShopRepository(cfg.DB),
 	}, nil
 }
 
 func newUsecase(cfg *AuthConfig, repositories *repositorySet) (*usecaseSet, error) {
 	return &usecaseSet{
 		authUsecase: usecase.NewAuthUsecase(&usecase.AuthUsecaseRepos{
-			UserRepo: repositories.userRepository,
+			UserRepo:     repositories.userRepository,
+			UserShopRepo: repositories.userShopRepository,
 		},
 			&usecase.AuthUsecaseConfig{
 				JWTSecret:         cfg.JWTSecret,
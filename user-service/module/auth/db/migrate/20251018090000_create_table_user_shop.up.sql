@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS user_shops (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    user_id         BIGINT NOT NULL,
+    shop_id         BIGINT NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_user_shops_user_id_shop_id ON user_shops (user_id, shop_id);
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// UserShop bind the user to the shop they operate as a seller
+type UserShop struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	ShopID    string    `json:"shop_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"user-service/internal/util/liberr"
+	"user-service/module/auth/entity"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	userShopTable = "user_shops"
+
+	userShopColumns = []string{"id", "user_id", "shop_id", "created_at"}
+)
+
+type UserShopRepository struct {
+	db *sqlx.DB
+}
+
+type userShopObject struct {
+	ID        string    `db:"id"`
+	UserID    string    `db:"user_id"`
+	ShopID    string    `db:"shop_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (o *userShopObject) toEntity() *entity.UserShop {
+	return &entity.UserShop{
+		ID:        o.ID,
+		UserID:    o.UserID,
+		ShopID:    o.ShopID,
+		CreatedAt: o.CreatedAt,
+	}
+}
+
+func NewUserShopRepository(db *sqlx.DB) *UserShopRepository {
+	return &UserShopRepository{db: db}
+}
+
+func (u *UserShopRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.UserShop, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(userShopColumns...)
+	sb.From(userShopTable)
+	sb.Where(sb.Equal("user_id", userID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := u.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on userShop.ListByUserID").Wrap(err)
+	}
+	defer rows.Close()
+
+	userShops := []*entity.UserShop{}
+	for rows.Next() {
+		obj := &userShopObject{}
+
+		if err := rows.StructScan(obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on userShop.ListByUserID").Wrap(err)
+		}
+
+		userShops = append(userShops, obj.toEntity())
+	}
+
+	return userShops, nil
+}
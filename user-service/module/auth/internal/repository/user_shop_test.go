@@ -0,0 +1,114 @@
+package repository_test
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"user-service/internal/testutil"
+	"user-service/module/auth/entity"
+	"user-service/module/auth/internal/repository"
+	"user-service/module/auth/testutil/fixtures"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	userShopAllAttributes = []string{
+		"id",
+		"user_id",
+		"shop_id",
+		"created_at",
+	}
+
+	userShopAllColumnsStr = strings.Join(userShopAllAttributes, ", ")
+)
+
+func TestUserShopRepository_ListByUserID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM user_shops WHERE user_id = ? ORDER BY id ASC", userShopAllColumnsStr)
+	dummyUserShop := fixtures.NewUserShop(fixtures.UserShop)
+
+	type input struct {
+		ctx    context.Context
+		userID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.UserShop, error)
+	}{
+		{
+			name: "Success on ListByUserID",
+			in: input{
+				ctx:    context.TODO(),
+				userID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(userShopAllAttributes).
+							AddRow(fixtures.GetUserShopRow(dummyUserShop)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.UserShop, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.UserShop{dummyUserShop}, result)
+			},
+		},
+		{
+			name: "Success on ListByUserID Without Shop",
+			in: input{
+				ctx:    context.TODO(),
+				userID: "2",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID).
+					WillReturnRows(sqlmock.NewRows(userShopAllAttributes)).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.UserShop, err error) {
+				assert.Nil(t, err)
+				assert.Empty(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:    context.TODO(),
+				userID: "1",
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.userID).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result []*entity.UserShop, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewUserShopRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByUserID(tc.in.ctx, tc.in.userID))
+		})
+	}
+}
@@ -12,7 +12,8 @@ import (
 )
 
 type AuthUsecaseRepos struct {
-	UserRepo UserRepository
+	UserRepo     UserRepository
+	UserShopRepo UserShopRepository
 }
 
 type AuthUsecaseConfig struct {
@@ -46,11 +47,25 @@ func (a *AuthUsecase) Authentication(ctx context.Context, params *entity.Authent
 		return nil, liberr.ResolveError(entity.ErrorAuthInvalid)
 	}
 
+	userShops, err := a.repos.UserShopRepo.ListByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
 	jwtSecret := []byte(a.config.JWTSecret)
 	claims := jwt.MapClaims{
 		"sub": user.ID,
 		"exp": time.Now().Add(time.Hour * time.Duration(a.config.JWTHourExpiration)).Unix(),
 	}
+
+	// shop_ids is only issued to sellers, the order service authorize the seller API by it
+	if len(userShops) > 0 {
+		shopIDs := make([]string, 0, len(userShops))
+		for _, userShop := range userShops {
+			shopIDs = append(shopIDs, userShop.ShopID)
+		}
+		claims["shop_ids"] = shopIDs
+	}
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 
 	tokenString, err := token.SignedString(jwtSecret)
@@ -10,18 +10,21 @@ import (
 	"user-service/module/auth/internal/usecase/mock"
 	"user-service/module/auth/testutil/fixtures"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
 type authUseCaseDependency struct {
-	userRepository *mock.MockUserRepository
-	config         *usecase.AuthUsecaseConfig
+	userRepository     *mock.MockUserRepository
+	userShopRepository *mock.MockUserShopRepository
+	config             *usecase.AuthUsecaseConfig
 }
 
 func NewTestAuthUsecase(ctrl *gomock.Controller) (*usecase.AuthUsecase, authUseCaseDependency) {
 	useCaseDependency := authUseCaseDependency{
-		userRepository: mock.NewMockUserRepository(ctrl),
+		userRepository:     mock.NewMockUserRepository(ctrl),
+		userShopRepository: mock.NewMockUserShopRepository(ctrl),
 		config: &usecase.AuthUsecaseConfig{
 			JWTSecret:         "secret",
 			JWTHourExpiration: 1,
@@ -30,12 +33,25 @@ func NewTestAuthUsecase(ctrl *gomock.Controller) (*usecase.AuthUsecase, authUseC
 
 	return usecase.NewAuthUsecase(
 		&usecase.AuthUsecaseRepos{
-			UserRepo: useCaseDependency.userRepository,
+			UserRepo:     useCaseDependency.userRepository,
+			UserShopRepo: useCaseDependency.userShopRepository,
 		},
 		useCaseDependency.config,
 	), useCaseDependency
 }
 
+// parseTestClaims parse the claims of the access token signed by the test config
+func parseTestClaims(t *testing.T, result *entity.AuthenticationUser) jwt.MapClaims {
+	token, err := jwt.Parse(result.AccessToken, func(token *jwt.Token) (interface{}, error) {
+		return []byte("secret"), nil
+	})
+	assert.Nil(t, err)
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	assert.True(t, ok)
+	return claims
+}
+
 func TestAuth_Authentication(t *testing.T) {
 	type input struct {
 		params *entity.AuthenticationUserRequest
@@ -59,10 +75,67 @@ func TestAuth_Authentication(t *testing.T) {
 				dependency.userRepository.EXPECT().
 					GetByUsername(gomock.Any(), in.params.Username).
 					Return(fixtures.User, nil)
+				dependency.userShopRepository.EXPECT().
+					ListByUserID(gomock.Any(), fixtures.User.ID).
+					Return([]*entity.UserShop{}, nil)
 			},
 			assertFn: func(result *entity.AuthenticationUser, err error) {
 				assert.Nil(t, err)
 				assert.NotNil(t, result)
+
+				claims := parseTestClaims(t, result)
+				assert.Equal(t, fixtures.User.ID, claims["sub"])
+				assert.NotContains(t, claims, "shop_ids")
+			},
+		},
+		{
+			name: "Success Authentication As Seller Issue The Shop IDs",
+			in: input{
+				params: &entity.AuthenticationUserRequest{
+					Username: "jhon.doe@test.com",
+					Password: "test1234",
+				},
+			},
+			mockDependency: func(dependency *authUseCaseDependency, in input) {
+				secondShop := fixtures.NewUserShop(fixtures.UserShop)
+				secondShop.ID = "2"
+				secondShop.ShopID = "5"
+
+				dependency.userRepository.EXPECT().
+					GetByUsername(gomock.Any(), in.params.Username).
+					Return(fixtures.User, nil)
+				dependency.userShopRepository.EXPECT().
+					ListByUserID(gomock.Any(), fixtures.User.ID).
+					Return([]*entity.UserShop{fixtures.NewUserShop(fixtures.UserShop), secondShop}, nil)
+			},
+			assertFn: func(result *entity.AuthenticationUser, err error) {
+				assert.Nil(t, err)
+				assert.NotNil(t, result)
+
+				claims := parseTestClaims(t, result)
+				assert.Equal(t, fixtures.User.ID, claims["sub"])
+				assert.Equal(t, []interface{}{"3", "5"}, claims["shop_ids"])
+			},
+		},
+		{
+			name: "Error On ListByUserID",
+			in: input{
+				params: &entity.AuthenticationUserRequest{
+					Username: "jhon.doe@test.com",
+					Password: "test1234",
+				},
+			},
+			mockDependency: func(dependency *authUseCaseDependency, in input) {
+				dependency.userRepository.EXPECT().
+					GetByUsername(gomock.Any(), in.params.Username).
+					Return(fixtures.User, nil)
+				dependency.userShopRepository.EXPECT().
+					ListByUserID(gomock.Any(), fixtures.User.ID).
+					Return(nil, errors.New("error"))
+			},
+			assertFn: func(result *entity.AuthenticationUser, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
 			},
 		},
 		{
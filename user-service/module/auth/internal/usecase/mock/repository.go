@@ -49,3 +49,41 @@ func (mr *MockUserRepositoryMockRecorder) GetByUsername(ctx, username interface{
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUsername", reflect.TypeOf((*MockUserRepository)(nil).GetByUsername), ctx, username)
 }
+
+// MockUserShopRepository is a mock of UserShopRepository interface.
+type MockUserShopRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserShopRepositoryMockRecorder
+}
+
+// MockUserShopRepositoryMockRecorder is the mock recorder for MockUserShopRepository.
+type MockUserShopRepositoryMockRecorder struct {
+	mock *MockUserShopRepository
+}
+
+// NewMockUserShopRepository creates a new mock instance.
+func NewMockUserShopRepository(ctrl *gomock.Controller) *MockUserShopRepository {
+	mock := &MockUserShopRepository{ctrl: ctrl}
+	mock.recorder = &MockUserShopRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserShopRepository) EXPECT() *MockUserShopRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ListByUserID mocks base method.
+func (m *MockUserShopRepository) ListByUserID(ctx context.Context, userID string) ([]*entity.UserShop, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByUserID", ctx, userID)
+	ret0, _ := ret[0].([]*entity.UserShop)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListByUserID indicates an expected call of ListByUserID.
+func (mr *MockUserShopRepositoryMockRecorder) ListByUserID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByUserID", reflect.TypeOf((*MockUserShopRepository)(nil).ListByUserID), ctx, userID)
+}
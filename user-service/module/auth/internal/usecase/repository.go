@@ -10,3 +10,7 @@ import (
 type UserRepository interface {
 	GetByUsername(ctx context.Context, username string) (*entity.User, error)
 }
+
+type UserShopRepository interface {
+	ListByUserID(ctx context.Context, userID string) ([]*entity.UserShop, error)
+}
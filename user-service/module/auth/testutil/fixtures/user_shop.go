@@ -0,0 +1,36 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"time"
+	"user-service/module/auth/entity"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	UserShop = &entity.UserShop{
+		ID:        "1",
+		UserID:    "1",
+		ShopID:    "3",
+		CreatedAt: time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewUserShop(obj *entity.UserShop) *entity.UserShop {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.UserShop)
+	return res
+}
+
+func GetUserShopRow(obj *entity.UserShop) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.UserID,
+		obj.ShopID,
+		obj.CreatedAt,
+	}
+}
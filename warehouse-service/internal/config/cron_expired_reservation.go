@@ -0,0 +1,20 @@
+package config
+
+import (
+	"warehouse-service/internal/util/libcron"
+	warehouseConfig "warehouse-service/module/warehouse/config"
+)
+
+func NewCronExpiredReservation() (*libcron.Cron, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	authCfg, err := loadAuthConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return warehouseConfig.NewCronExpiredReservation(authCfg)
+}
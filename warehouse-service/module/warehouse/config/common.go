@@ -18,8 +18,13 @@ type WarehouseConfig struct {
 }
 
 type repositorySet struct {
-	warehouseRepository      *repository.WarehouseRepository
-	warehouseStockRepository *repository.WarehouseStockRepository
+	warehouseRepository             *repository.WarehouseRepository
+	warehouseStockRepository        *repository.WarehouseStockRepository
+	stockAdjustmentRepository       *repository.StockAdjustmentRepository
+	stockMovementRepository         *repository.StockMovementRepository
+	stockReservationRepository      *repository.StockReservationRepository
+	warehouseTransferRepository     *repository.WarehouseTransferRepository
+	warehouseTransferLineRepository *repository.WarehouseTransferLineRepository
 }
 
 type usecaseSet struct {
@@ -29,8 +34,13 @@ type usecaseSet struct {
 
 func newRepositories(cfg *WarehouseConfig) (*repositorySet, error) {
 	return &repositorySet{
-		warehouseRepository:      repository.NewWarehouseRepository(cfg.DB),
-		warehouseStockRepository: repository.NewWarehouseStockRepository(cfg.DB),
+		warehouseRepository:             repository.NewWarehouseRepository(cfg.DB),
+		warehouseStockRepository:        repository.NewWarehouseStockRepository(cfg.DB),
+		stockAdjustmentRepository:       repository.NewStockAdjustmentRepository(cfg.DB),
+		stockMovementRepository:         repository.NewStockMovementRepository(cfg.DB),
+		stockReservationRepository:      repository.NewStockReservationRepository(cfg.DB),
+		warehouseTransferRepository:     repository.NewWarehouseTransferRepository(cfg.DB),
+		warehouseTransferLineRepository: repository.NewWarehouseTransferLineRepository(cfg.DB),
 	}, nil
 }
 
@@ -45,6 +55,11 @@ func newUsecase(cfg *WarehouseConfig, repositories *repositorySet) (*usecaseSet,
 			DatabaseTransactionHandler: databaseTransactionHandler,
 			WarehouseRepo:              repositories.warehouseRepository,
 			WarehouseStockRepo:         repositories.warehouseStockRepository,
-		}),
+			StockAdjustmentRepo:        repositories.stockAdjustmentRepository,
+			StockMovementRepo:          repositories.stockMovementRepository,
+			StockReservationRepo:       repositories.stockReservationRepository,
+			WarehouseTransferRepo:      repositories.warehouseTransferRepository,
+			WarehouseTransferLineRepo:  repositories.warehouseTransferLineRepository,
+		}, cfg.Logger),
 	}, nil
 }
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"warehouse-service/internal/util/libcron"
+	"warehouse-service/module/warehouse/internal/cron"
+)
+
+func NewCronExpiredReservation(cfg *WarehouseConfig) (*libcron.Cron, error) {
+	repositories, err := newRepositories(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	usecases, err := newUsecase(cfg, repositories)
+	if err != nil {
+		return nil, err
+	}
+
+	cronHandler := cron.NewExpiredReservationCron(usecases.warehouseStockUsecase)
+
+	return libcron.NewCron(libcron.Config{
+		Name:        "CronWarehouseExpiredReservation",
+		CronHandler: cronHandler,
+		Logger:      cfg.Logger,
+	}), nil
+}
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS stock_adjustments (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    adjustment_id   VARCHAR(64) NOT NULL,
+    request_hash    CHAR(64) NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_stock_adjustments_adjustment_id ON stock_adjustments (adjustment_id);
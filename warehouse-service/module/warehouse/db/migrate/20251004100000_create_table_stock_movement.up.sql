@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    warehouse_id    BIGINT NOT NULL,
+    product_id      BIGINT NOT NULL,
+    delta           INT NOT NULL,
+    quantity        INT NOT NULL,
+    reason          TINYINT NOT NULL,
+    reference_id    VARCHAR(64) NOT NULL DEFAULT '',
+    actor           VARCHAR(64) NOT NULL DEFAULT '',
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_stock_movements_wh_id_p_id_created_at ON stock_movements (warehouse_id, product_id, created_at);
+CREATE INDEX idx_stock_movements_p_id_created_at ON stock_movements (product_id, created_at);
@@ -0,0 +1 @@
+ALTER TABLE warehouse_stocks DROP COLUMN reserved;
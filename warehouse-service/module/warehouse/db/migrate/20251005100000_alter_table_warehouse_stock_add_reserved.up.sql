@@ -0,0 +1 @@
+ALTER TABLE warehouse_stocks ADD COLUMN reserved INT NOT NULL DEFAULT 0 AFTER stock;
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id              BIGINT PRIMARY KEY AUTO_INCREMENT,
+    reservation_id  VARCHAR(64) NOT NULL,
+    warehouse_id    BIGINT NOT NULL,
+    product_id      BIGINT NOT NULL,
+    stock           INT NOT NULL,
+    state           TINYINT NOT NULL,
+    expired_at      DATETIME NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_stock_reservations_r_id_wh_id_p_id ON stock_reservations (reservation_id, warehouse_id, product_id);
+CREATE INDEX idx_stock_reservations_state_expired_at ON stock_reservations (state, expired_at);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS warehouse_transfers (
+    id                          BIGINT PRIMARY KEY AUTO_INCREMENT,
+    original_warehouse_id       BIGINT NOT NULL,
+    destination_warehouse_id    BIGINT NOT NULL,
+    state                       TINYINT NOT NULL,
+    created_by                  VARCHAR(64) NOT NULL DEFAULT '',
+    dispatched_at               DATETIME NULL,
+    received_at                 DATETIME NULL,
+    cancelled_at                DATETIME NULL,
+    created_at                  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at                  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE INDEX idx_warehouse_transfers_original_wh_id_state ON warehouse_transfers (original_warehouse_id, state);
+CREATE INDEX idx_warehouse_transfers_destination_wh_id_state ON warehouse_transfers (destination_warehouse_id, state);
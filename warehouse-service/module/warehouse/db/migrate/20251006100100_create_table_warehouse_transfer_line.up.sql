@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS warehouse_transfer_lines (
+    id                      BIGINT PRIMARY KEY AUTO_INCREMENT,
+    warehouse_transfer_id   BIGINT NOT NULL,
+    product_id              BIGINT NOT NULL,
+    stock                   INT NOT NULL,
+    received_stock          INT NOT NULL DEFAULT 0,
+    created_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE = InnoDB;
+
+CREATE UNIQUE INDEX idx_warehouse_transfer_lines_wt_id_p_id ON warehouse_transfer_lines (warehouse_transfer_id, product_id);
@@ -0,0 +1 @@
+ALTER TABLE stock_adjustments DROP COLUMN error_code;
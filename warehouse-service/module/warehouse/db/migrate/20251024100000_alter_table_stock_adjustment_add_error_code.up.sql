@@ -0,0 +1 @@
+ALTER TABLE stock_adjustments ADD COLUMN error_code VARCHAR(64) NULL AFTER request_hash;
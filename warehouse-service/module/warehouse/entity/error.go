@@ -24,6 +24,16 @@ const (
 	ErrorCodeWarehouseStockDuplicated           = "WAREHOUSE-STOCK_DUPLICATED"
 	ErrorCodeWarehouseStockAdjustmentFailed     = "WAREHOUSE-STOCK_ADJUSTMENT-FAILED"
 	ErrorCodeWarehouseStockAdjustmentOutOfStock = "WAREHOUSE-STOCK_ADJUSTMENT-OUT-OF-STOCK"
+	ErrorCodeStockAdjustmentNotFound            = "STOCK-ADJUSTMENT_NOT-FOUND"
+	ErrorCodeStockAdjustmentDuplicated          = "STOCK-ADJUSTMENT_DUPLICATED"
+	ErrorCodeStockAdjustmentConflicted          = "STOCK-ADJUSTMENT_CONFLICTED"
+	ErrorCodeStockReservationNotFound           = "STOCK-RESERVATION_NOT-FOUND"
+	ErrorCodeStockReservationDuplicated         = "STOCK-RESERVATION_DUPLICATED"
+	ErrorCodeStockReservationConflicted         = "STOCK-RESERVATION_CONFLICTED"
+	ErrorCodeStockReservationInvalidState       = "STOCK-RESERVATION_INVALID-STATE"
+	ErrorCodeWarehouseTransferNotFound          = "WAREHOUSE-TRANSFER_NOT-FOUND"
+	ErrorCodeWarehouseTransferInvalidState      = "WAREHOUSE-TRANSFER_INVALID-STATE"
+	ErrorCodeWarehouseTransferInvalidReceipt    = "WAREHOUSE-TRANSFER_INVALID-RECEIPT"
 )
 
 var (
@@ -35,4 +45,14 @@ var (
 	ErrorWarehouseStockDuplicated           = liberr.NewErrorDetails("Warehouse Stock Already Exists", ErrorCodeWarehouseStockDuplicated, "")
 	ErrorWarehouseStockAdjustmentFailed     = liberr.NewErrorDetails("Failed to Adjust Stock Due Race Condition Happened", ErrorCodeWarehouseStockAdjustmentFailed, "")
 	ErrorWarehouseStockAdjustmentOutOfStock = liberr.NewErrorDetails("Failed to Adjust Stock Due Out of Stock", ErrorCodeWarehouseStockAdjustmentOutOfStock, "")
+	ErrorStockAdjustmentNotFound            = liberr.NewErrorDetails("Stock Adjustment Not Found", ErrorCodeStockAdjustmentNotFound, "")
+	ErrorStockAdjustmentDuplicated          = liberr.NewErrorDetails("Stock Adjustment Already Exists", ErrorCodeStockAdjustmentDuplicated, "")
+	ErrorStockAdjustmentConflicted          = liberr.NewErrorDetails("Stock Adjustment ID Already Used With Different Request", ErrorCodeStockAdjustmentConflicted, "")
+	ErrorStockReservationNotFound           = liberr.NewErrorDetails("Stock Reservation Not Found", ErrorCodeStockReservationNotFound, "")
+	ErrorStockReservationDuplicated         = liberr.NewErrorDetails("Stock Reservation Already Exists", ErrorCodeStockReservationDuplicated, "")
+	ErrorStockReservationConflicted         = liberr.NewErrorDetails("Stock Reservation ID Already Used With Different Request", ErrorCodeStockReservationConflicted, "")
+	ErrorStockReservationInvalidState       = liberr.NewErrorDetails("Stock Reservation State Not Allowed", ErrorCodeStockReservationInvalidState, "")
+	ErrorWarehouseTransferNotFound          = liberr.NewErrorDetails("Warehouse Transfer Not Found", ErrorCodeWarehouseTransferNotFound, "")
+	ErrorWarehouseTransferInvalidState      = liberr.NewErrorDetails("Warehouse Transfer State Not Allowed", ErrorCodeWarehouseTransferInvalidState, "")
+	ErrorWarehouseTransferInvalidReceipt    = liberr.NewErrorDetails("Warehouse Transfer Received Stock Exceeds In Transit Stock", ErrorCodeWarehouseTransferInvalidReceipt, "")
 )
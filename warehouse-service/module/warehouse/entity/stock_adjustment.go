@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+// StockAdjustment is the outcome of the adjustment ID, the error code is kept when the adjustment was rejected
+// so a replay returns the same rejection
+type StockAdjustment struct {
+	ID           string    `json:"id"`
+	AdjustmentID string    `json:"adjustment_id"`
+	RequestHash  string    `json:"request_hash"`
+	ErrorCode    string    `json:"error_code"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+type StockMovementReason int
+
+const (
+	StockMovementReasonUnspecified StockMovementReason = iota
+	StockMovementReasonOrderReservation
+	StockMovementReasonOrderRelease
+	StockMovementReasonTransfer
+	StockMovementReasonManual
+	StockMovementReasonOrderShipment
+	StockMovementReasonOrderReturn
+)
+
+type StockMovement struct {
+	ID          string              `json:"id"`
+	WarehouseID string              `json:"warehouse_id"`
+	ProductID   string              `json:"product_id"`
+	Delta       int                 `json:"delta"`
+	Quantity    int                 `json:"quantity"`
+	Reason      StockMovementReason `json:"reason"`
+	ReferenceID string              `json:"reference_id"`
+	Actor       string              `json:"actor"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+type ListStockMovementByParams struct {
+	Page          int
+	Offset        int
+	Limit         int
+	WarehouseID   string
+	ProductID     string
+	CreatedAtFrom *time.Time
+	CreatedAtTo   *time.Time
+}
+
+type ListStockMovementResponse struct {
+	StockMovements []*StockMovement `json:"stock_movements"`
+	Meta           *ListMeta        `json:"meta"`
+}
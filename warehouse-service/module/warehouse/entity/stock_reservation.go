@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+type StockReservationState int
+
+const (
+	StockReservationStateUnspecified StockReservationState = iota
+	StockReservationStateReserved
+	StockReservationStateCommitted
+	StockReservationStateReleased
+)
+
+type StockReservation struct {
+	ID            string                `json:"id"`
+	ReservationID string                `json:"reservation_id"`
+	WarehouseID   string                `json:"warehouse_id"`
+	ProductID     string                `json:"product_id"`
+	Stock         int                   `json:"stock"`
+	State         StockReservationState `json:"state"`
+	ExpiredAt     time.Time             `json:"expired_at"`
+	CreatedAt     time.Time             `json:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at"`
+}
+
+type StockReservationStock struct {
+	WarehouseID string `json:"warehouse_id" validate:"required"`
+	ProductID   string `json:"product_id" validate:"required"`
+	Stock       int    `json:"stock" validate:"required,gt=0"`
+}
+
+type StockReservationRequest struct {
+	ReservationID   string                   `json:"reservation_id" validate:"required,max=64"`
+	ExpiredAt       time.Time                `json:"expired_at" validate:"required"`
+	Actor           string                   `json:"actor" validate:"max=64"`
+	WarehouseStocks []*StockReservationStock `json:"warehouse_stocks" validate:"required,min=1,dive,required"`
+}
+
+type StockReservationActionRequest struct {
+	ReservationID string `json:"-" validate:"required,max=64"`
+	Actor         string `json:"actor" validate:"max=64"`
+}
+
+// StockReservationExtendRequest move the expiry of the reservation, the reservation past the expiry
+// which has not been released yet is held again
+type StockReservationExtendRequest struct {
+	ReservationID string    `json:"-" validate:"required,max=64"`
+	ExpiredAt     time.Time `json:"expired_at" validate:"required"`
+}
+
+type StockReservationResponse struct {
+	Message           string              `json:"message"`
+	StockReservations []*StockReservation `json:"stock_reservations"`
+	Meta              *Meta               `json:"meta"`
+}
@@ -7,6 +7,8 @@ type WarehouseStock struct {
 	WarehouseID string    `json:"warehouse_id"`
 	ProductID   string    `json:"product_id"`
 	Stock       int       `json:"stock"`
+	Reserved    int       `json:"reserved"`
+	Available   int       `json:"available"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 }
@@ -34,5 +36,9 @@ type WarehouseStockAdjustment struct {
 }
 
 type WarehouseStockAdjustmentRequest struct {
+	AdjustmentID    string                      `json:"adjustment_id" validate:"max=64"`
+	Reason          StockMovementReason         `json:"reason" validate:"omitempty,oneof=1 2 3 4 6"`
+	ReferenceID     string                      `json:"reference_id" validate:"max=64"`
+	Actor           string                      `json:"actor" validate:"max=64"`
 	WarehouseStocks []*WarehouseStockAdjustment `json:"warehouse_stocks" validate:"required,min=1,dive,required"`
 }
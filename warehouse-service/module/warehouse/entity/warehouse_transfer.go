@@ -0,0 +1,76 @@
+package entity
+
+import "time"
+
+type WarehouseTransferState int
+
+const (
+	WarehouseTransferStateUnspecified WarehouseTransferState = iota
+	WarehouseTransferStateDraft
+	WarehouseTransferStateDispatched
+	WarehouseTransferStateReceived
+	WarehouseTransferStateCancelled
+)
+
+type WarehouseTransfer struct {
+	ID                     string                   `json:"id"`
+	OriginalWarehouseID    string                   `json:"original_warehouse_id"`
+	DestinationWarehouseID string                   `json:"destination_warehouse_id"`
+	State                  WarehouseTransferState   `json:"state"`
+	CreatedBy              string                   `json:"created_by"`
+	DispatchedAt           *time.Time               `json:"dispatched_at"`
+	ReceivedAt             *time.Time               `json:"received_at"`
+	CancelledAt            *time.Time               `json:"cancelled_at"`
+	CreatedAt              time.Time                `json:"created_at"`
+	UpdatedAt              time.Time                `json:"updated_at"`
+	Lines                  []*WarehouseTransferLine `json:"lines,omitempty"`
+}
+
+type WarehouseTransferLine struct {
+	ID                  string    `json:"id"`
+	WarehouseTransferID string    `json:"warehouse_transfer_id"`
+	ProductID           string    `json:"product_id"`
+	Stock               int       `json:"stock"`
+	ReceivedStock       int       `json:"received_stock"`
+	InTransitStock      int       `json:"in_transit_stock"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type CreateWarehouseTransferRequest struct {
+	OriginalWarehouseID    string                                  `json:"original_warehouse_id" validate:"required"`
+	DestinationWarehouseID string                                  `json:"destination_warehouse_id" validate:"required,nefield=OriginalWarehouseID"`
+	Actor                  string                                  `json:"actor" validate:"max=64"`
+	Products               []*WarehouseProductStockTransferProduct `json:"products" validate:"required,min=1,dive,required"`
+}
+
+type WarehouseTransferActionRequest struct {
+	ID    string `json:"-" validate:"required"`
+	Actor string `json:"actor" validate:"max=64"`
+}
+
+type ReceiveWarehouseTransferRequest struct {
+	ID       string                                  `json:"-" validate:"required"`
+	Actor    string                                  `json:"actor" validate:"max=64"`
+	Products []*WarehouseProductStockTransferProduct `json:"products" validate:"required,min=1,dive,required"`
+}
+
+type ListWarehouseTransferByParams struct {
+	Page                   int
+	Offset                 int
+	Limit                  int
+	OriginalWarehouseID    string
+	DestinationWarehouseID string
+	States                 []WarehouseTransferState
+}
+
+type WarehouseTransferResponse struct {
+	Message           string             `json:"message,omitempty"`
+	WarehouseTransfer *WarehouseTransfer `json:"warehouse_transfer"`
+	Meta              *Meta              `json:"meta"`
+}
+
+type ListWarehouseTransferResponse struct {
+	WarehouseTransfers []*WarehouseTransfer `json:"warehouse_transfers"`
+	Meta               *ListMeta            `json:"meta"`
+}
@@ -6,6 +6,9 @@ type WarehouseProductStockTransferProduct struct {
 }
 
 type WarehouseStockTransferRequest struct {
+	AdjustmentID           string                                  `json:"adjustment_id" validate:"max=64"`
+	ReferenceID            string                                  `json:"reference_id" validate:"max=64"`
+	Actor                  string                                  `json:"actor" validate:"max=64"`
 	OriginalWarehouseID    string                                  `json:"original_warehouse_id" validate:"required"`
 	DestinationWarehouseID string                                  `json:"destination_warehouse_id" validate:"required"`
 	Products               []*WarehouseProductStockTransferProduct `json:"products" validate:"required,min=1,dive,required"`
@@ -0,0 +1,19 @@
+package cron
+
+import (
+	"context"
+)
+
+type ExpiredReservationCron struct {
+	warehouseStockUsecase WarehouseStockUsecase
+}
+
+func NewExpiredReservationCron(warehouseStockUsecase WarehouseStockUsecase) *ExpiredReservationCron {
+	return &ExpiredReservationCron{
+		warehouseStockUsecase: warehouseStockUsecase,
+	}
+}
+
+func (e ExpiredReservationCron) ExecuteFunction(ctx context.Context, args []string) error {
+	return e.warehouseStockUsecase.ExecuteExpiredReservation(ctx)
+}
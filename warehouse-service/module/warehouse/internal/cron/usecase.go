@@ -0,0 +1,11 @@
+package cron
+
+import (
+	"context"
+)
+
+//go:generate mockgen -destination=mock/usecase.go -package=mock -source=usecase.go
+
+type WarehouseStockUsecase interface {
+	ExecuteExpiredReservation(ctx context.Context) error
+}
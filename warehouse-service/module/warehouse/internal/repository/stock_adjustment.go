@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	stockAdjustmentTable = "stock_adjustments"
+
+	stockAdjustmentInsertColumns = []string{"adjustment_id", "request_hash", "error_code"}
+	stockAdjustmentColumns       = []string{"id", "adjustment_id", "request_hash", "error_code", "created_at", "updated_at"}
+)
+
+type StockAdjustmentRepository struct {
+	db *sqlx.DB
+}
+
+type stockAdjustmentObject struct {
+	ID           string         `db:"id"`
+	AdjustmentID string         `db:"adjustment_id"`
+	RequestHash  string         `db:"request_hash"`
+	ErrorCode    sql.NullString `db:"error_code"`
+	CreatedAt    time.Time      `db:"created_at"`
+	UpdatedAt    time.Time      `db:"updated_at"`
+}
+
+func (o *stockAdjustmentObject) toEntity() *entity.StockAdjustment {
+	return &entity.StockAdjustment{
+		ID:           o.ID,
+		AdjustmentID: o.AdjustmentID,
+		RequestHash:  o.RequestHash,
+		ErrorCode:    o.ErrorCode.String,
+		CreatedAt:    o.CreatedAt,
+		UpdatedAt:    o.UpdatedAt,
+	}
+}
+
+func NewStockAdjustmentRepository(db *sqlx.DB) *StockAdjustmentRepository {
+	return &StockAdjustmentRepository{db: db}
+}
+
+func (s *StockAdjustmentRepository) Create(ctx context.Context, stockAdjustment *entity.StockAdjustment, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(stockAdjustmentTable)
+	ib.Cols(stockAdjustmentInsertColumns...)
+	ib.Values(
+		stockAdjustment.AdjustmentID,
+		stockAdjustment.RequestHash,
+		sql.NullString{String: stockAdjustment.ErrorCode, Valid: stockAdjustment.ErrorCode != ""},
+	)
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(s.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on stockAdjustment.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateError(err) {
+			return entity.ErrorStockAdjustmentDuplicated
+		}
+
+		return liberr.NewTracer("Error when ExecContext on stockAdjustment.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on stockAdjustment.Create").Wrap(err)
+	}
+
+	stockAdjustment.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+func (s *StockAdjustmentRepository) GetByAdjustmentID(ctx context.Context, adjustmentID string) (*entity.StockAdjustment, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(stockAdjustmentColumns...)
+	sb.From(stockAdjustmentTable)
+	sb.Where(sb.Equal("adjustment_id", adjustmentID))
+
+	query, args := sb.Build()
+
+	row := s.db.QueryRowxContext(ctx, query, args...)
+	obj := &stockAdjustmentObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorStockAdjustmentNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on stockAdjustment.GetByAdjustmentID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
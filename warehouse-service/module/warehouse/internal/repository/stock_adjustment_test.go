@@ -0,0 +1,289 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/module/warehouse/entity"
+	"warehouse-service/module/warehouse/internal/repository"
+	"warehouse-service/module/warehouse/testutil/fixtures"
+
+	"warehouse-service/internal/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	stockAdjustmentInsertAttributes = []string{
+		"adjustment_id",
+		"request_hash",
+		"error_code",
+	}
+	stockAdjustmentAllAttributes = []string{
+		"id",
+		"adjustment_id",
+		"request_hash",
+		"error_code",
+		"created_at",
+		"updated_at",
+	}
+
+	stockAdjustmentInsertColumnsStr = strings.Join(stockAdjustmentInsertAttributes, ", ")
+	stockAdjustmentAllColumnsStr    = strings.Join(stockAdjustmentAllAttributes, ", ")
+)
+
+func TestStockAdjustmentRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO stock_adjustments (%s) VALUES (?, ?, ?)", stockAdjustmentInsertColumnsStr)
+
+	type input struct {
+		ctx             context.Context
+		stockAdjustment *entity.StockAdjustment
+		tx              util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:             context.TODO(),
+				stockAdjustment: fixtures.NewStockAdjustment(fixtures.StockAdjustment),
+				tx:              nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stockAdjustment.AdjustmentID, in.stockAdjustment.RequestHash, sql.NullString{}).
+					WillReturnResult(sqlmock.NewResult(7, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Success on Create Rejected Adjustment",
+			in: input{
+				ctx: context.TODO(),
+				stockAdjustment: func() *entity.StockAdjustment {
+					res := fixtures.NewStockAdjustment(fixtures.StockAdjustment)
+					res.ErrorCode = entity.ErrorCodeWarehouseStockAdjustmentOutOfStock
+					return res
+				}(),
+				tx: nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stockAdjustment.AdjustmentID, in.stockAdjustment.RequestHash, sql.NullString{String: entity.ErrorCodeWarehouseStockAdjustmentOutOfStock, Valid: true}).
+					WillReturnResult(sqlmock.NewResult(7, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error on Duplicate Adjustment ID",
+			in: input{
+				ctx:             context.TODO(),
+				stockAdjustment: fixtures.NewStockAdjustment(fixtures.StockAdjustment),
+				tx:              nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stockAdjustment.AdjustmentID, in.stockAdjustment.RequestHash, sql.NullString{}).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+			},
+			assertFn: func(err error) {
+				assert.Equal(t, entity.ErrorStockAdjustmentDuplicated, err)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:             context.TODO(),
+				stockAdjustment: fixtures.NewStockAdjustment(fixtures.StockAdjustment),
+				tx:              nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stockAdjustment.AdjustmentID, in.stockAdjustment.RequestHash, sql.NullString{}).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:             context.TODO(),
+				stockAdjustment: fixtures.NewStockAdjustment(fixtures.StockAdjustment),
+				tx:              nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stockAdjustment.AdjustmentID, in.stockAdjustment.RequestHash, sql.NullString{}).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:             context.TODO(),
+				stockAdjustment: fixtures.NewStockAdjustment(fixtures.StockAdjustment),
+				tx:              &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewStockAdjustmentRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.Create(tc.in.ctx, tc.in.stockAdjustment, tc.in.tx))
+		})
+	}
+}
+
+func TestStockAdjustmentRepository_GetByAdjustmentID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM stock_adjustments WHERE adjustment_id = ?", stockAdjustmentAllColumnsStr)
+	rows := stockAdjustmentAllAttributes
+	dummyStockAdjustment := fixtures.NewStockAdjustment(fixtures.StockAdjustment)
+	dummyRejectedStockAdjustment := fixtures.NewStockAdjustment(fixtures.StockAdjustment)
+	dummyRejectedStockAdjustment.ErrorCode = entity.ErrorCodeWarehouseStockAdjustmentOutOfStock
+
+	type input struct {
+		ctx          context.Context
+		adjustmentID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.StockAdjustment, error)
+	}{
+		{
+			name: "Success on GetByAdjustmentID",
+			in: input{
+				ctx:          context.TODO(),
+				adjustmentID: dummyStockAdjustment.AdjustmentID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.adjustmentID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetStockAdjustmentRow(dummyStockAdjustment)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.StockAdjustment, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyStockAdjustment, result)
+			},
+		},
+		{
+			name: "Success on GetByAdjustmentID Rejected Adjustment",
+			in: input{
+				ctx:          context.TODO(),
+				adjustmentID: dummyRejectedStockAdjustment.AdjustmentID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.adjustmentID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetStockAdjustmentRow(dummyRejectedStockAdjustment)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.StockAdjustment, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyRejectedStockAdjustment, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx:          context.TODO(),
+				adjustmentID: dummyStockAdjustment.AdjustmentID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.adjustmentID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.StockAdjustment, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorStockAdjustmentNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:          context.TODO(),
+				adjustmentID: dummyStockAdjustment.AdjustmentID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.adjustmentID).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result *entity.StockAdjustment, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewStockAdjustmentRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByAdjustmentID(tc.in.ctx, tc.in.adjustmentID))
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/internal/util/libpagination"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	stockMovementTable = "stock_movements"
+
+	stockMovementInsertColumns = []string{"warehouse_id", "product_id", "delta", "quantity", "reason", "reference_id", "actor"}
+	stockMovementColumns       = []string{"id", "warehouse_id", "product_id", "delta", "quantity", "reason", "reference_id", "actor", "created_at"}
+)
+
+type StockMovementRepository struct {
+	db *sqlx.DB
+}
+
+type stockMovementObject struct {
+	ID          string    `db:"id"`
+	WarehouseID string    `db:"warehouse_id"`
+	ProductID   string    `db:"product_id"`
+	Delta       int       `db:"delta"`
+	Quantity    int       `db:"quantity"`
+	Reason      int       `db:"reason"`
+	ReferenceID string    `db:"reference_id"`
+	Actor       string    `db:"actor"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+func (o *stockMovementObject) toEntity() *entity.StockMovement {
+	return &entity.StockMovement{
+		ID:          o.ID,
+		WarehouseID: o.WarehouseID,
+		ProductID:   o.ProductID,
+		Delta:       o.Delta,
+		Quantity:    o.Quantity,
+		Reason:      entity.StockMovementReason(o.Reason),
+		ReferenceID: o.ReferenceID,
+		Actor:       o.Actor,
+		CreatedAt:   o.CreatedAt,
+	}
+}
+
+func NewStockMovementRepository(db *sqlx.DB) *StockMovementRepository {
+	return &StockMovementRepository{db: db}
+}
+
+func (s *StockMovementRepository) Create(ctx context.Context, stockMovement *entity.StockMovement, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(stockMovementTable)
+	ib.Cols(stockMovementInsertColumns...)
+	ib.Values(
+		stockMovement.WarehouseID,
+		stockMovement.ProductID,
+		stockMovement.Delta,
+		stockMovement.Quantity,
+		stockMovement.Reason,
+		stockMovement.ReferenceID,
+		stockMovement.Actor,
+	)
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(s.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on stockMovement.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on stockMovement.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on stockMovement.Create").Wrap(err)
+	}
+
+	stockMovement.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+func (s *StockMovementRepository) filterByParams(sb *sqlbuilder.SelectBuilder, params *entity.ListStockMovementByParams) *sqlbuilder.SelectBuilder {
+	if params.WarehouseID != "" {
+		sb.Where(sb.Equal("warehouse_id", params.WarehouseID))
+	}
+	if params.ProductID != "" {
+		sb.Where(sb.Equal("product_id", params.ProductID))
+	}
+	if params.CreatedAtFrom != nil {
+		sb.Where(sb.GTE("created_at", *params.CreatedAtFrom))
+	}
+	if params.CreatedAtTo != nil {
+		sb.Where(sb.LTE("created_at", *params.CreatedAtTo))
+	}
+
+	return sb
+}
+
+func (s *StockMovementRepository) ListByParams(ctx context.Context, params *entity.ListStockMovementByParams) ([]*entity.StockMovement, *libpagination.OffsetPagination, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(stockMovementColumns...)
+	sb.From(stockMovementTable)
+	sb.OrderBy("id").Desc()
+	sb.Limit(params.Limit)
+	sb.Offset(params.Offset)
+
+	query, args := s.filterByParams(sb, params).Build()
+
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, liberr.NewTracer("Error when QueryxContext on stockMovement.ListByParams").Wrap(err)
+	}
+
+	stockMovements := []*entity.StockMovement{}
+	for rows.Next() {
+		var obj stockMovementObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, nil, liberr.NewTracer("Error when StructScan on stockMovement.ListByParams").Wrap(err)
+		}
+
+		stockMovements = append(stockMovements, obj.toEntity())
+	}
+
+	cb := sqlbuilder.NewSelectBuilder()
+	cb.Select(cb.As("COUNT(id)", "total"))
+	cb.From(stockMovementTable)
+
+	cQuery, cArgs := s.filterByParams(cb, params).Build()
+	row := s.db.QueryRowxContext(ctx, cQuery, cArgs...)
+
+	var total int
+	if err := row.Scan(&total); err != nil {
+		return nil, nil, liberr.NewTracer("Error when Scan on stockMovement.ListByParams").Wrap(err)
+	}
+
+	return stockMovements, &libpagination.OffsetPagination{
+		Total:  total,
+		Offset: params.Offset,
+		Limit:  params.Limit,
+	}, nil
+}
@@ -0,0 +1,302 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/libpagination"
+	"warehouse-service/module/warehouse/entity"
+	"warehouse-service/module/warehouse/internal/repository"
+	"warehouse-service/module/warehouse/testutil/fixtures"
+
+	"warehouse-service/internal/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	stockMovementInsertAttributes = []string{
+		"warehouse_id",
+		"product_id",
+		"delta",
+		"quantity",
+		"reason",
+		"reference_id",
+		"actor",
+	}
+	stockMovementAllAttributes = []string{
+		"id",
+		"warehouse_id",
+		"product_id",
+		"delta",
+		"quantity",
+		"reason",
+		"reference_id",
+		"actor",
+		"created_at",
+	}
+
+	stockMovementInsertColumnsStr = strings.Join(stockMovementInsertAttributes, ", ")
+	stockMovementAllColumnsStr    = strings.Join(stockMovementAllAttributes, ", ")
+)
+
+func TestStockMovementRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO stock_movements (%s) VALUES (?, ?, ?, ?, ?, ?, ?)", stockMovementInsertColumnsStr)
+
+	type input struct {
+		ctx           context.Context
+		stockMovement *entity.StockMovement
+		tx            util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:           context.TODO(),
+				stockMovement: fixtures.NewStockMovement(fixtures.StockMovement),
+				tx:            nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(
+						in.stockMovement.WarehouseID, in.stockMovement.ProductID,
+						in.stockMovement.Delta, in.stockMovement.Quantity, in.stockMovement.Reason,
+						in.stockMovement.ReferenceID, in.stockMovement.Actor,
+					).
+					WillReturnResult(sqlmock.NewResult(8, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(err error) {
+				assert.Nil(t, err)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:           context.TODO(),
+				stockMovement: fixtures.NewStockMovement(fixtures.StockMovement),
+				tx:            nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(
+						in.stockMovement.WarehouseID, in.stockMovement.ProductID,
+						in.stockMovement.Delta, in.stockMovement.Quantity, in.stockMovement.Reason,
+						in.stockMovement.ReferenceID, in.stockMovement.Actor,
+					).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:           context.TODO(),
+				stockMovement: fixtures.NewStockMovement(fixtures.StockMovement),
+				tx:            nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(
+						in.stockMovement.WarehouseID, in.stockMovement.ProductID,
+						in.stockMovement.Delta, in.stockMovement.Quantity, in.stockMovement.Reason,
+						in.stockMovement.ReferenceID, in.stockMovement.Actor,
+					).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:           context.TODO(),
+				stockMovement: fixtures.NewStockMovement(fixtures.StockMovement),
+				tx:            &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewStockMovementRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.Create(tc.in.ctx, tc.in.stockMovement, tc.in.tx))
+		})
+	}
+}
+
+func TestStockMovementRepository_ListByParams(t *testing.T) {
+	columns := stockMovementAllColumnsStr
+	rows := stockMovementAllAttributes
+	dummyStockMovement := fixtures.NewStockMovement(fixtures.StockMovement)
+	createdAtFrom := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdAtTo := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	type input struct {
+		ctx    context.Context
+		params *entity.ListStockMovementByParams
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.StockMovement, *libpagination.OffsetPagination, error)
+	}{
+		{
+			name: "Success on Retrieve List By Params",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.ListStockMovementByParams{
+					Offset:        5,
+					Limit:         20,
+					WarehouseID:   "1",
+					ProductID:     "3",
+					CreatedAtFrom: &createdAtFrom,
+					CreatedAtTo:   &createdAtTo,
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM stock_movements WHERE warehouse_id = ? AND product_id = ? AND created_at >= ? AND created_at <= ? ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.WarehouseID, in.params.ProductID, createdAtFrom, createdAtTo, in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetStockMovementRow(dummyStockMovement)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM stock_movements WHERE warehouse_id = ? AND product_id = ? AND created_at >= ? AND created_at <= ?"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WithArgs(in.params.WarehouseID, in.params.ProductID, createdAtFrom, createdAtTo).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(100)).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.StockMovement, pagination *libpagination.OffsetPagination, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.StockMovement{dummyStockMovement}, result)
+				assert.Equal(t, &libpagination.OffsetPagination{
+					Offset: 5,
+					Limit:  20,
+					Total:  100,
+				}, pagination)
+			},
+		},
+		{
+			name: "Error on Scan Count Query",
+			in: input{
+				ctx:    context.TODO(),
+				params: &entity.ListStockMovementByParams{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM stock_movements ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetStockMovementRow(dummyStockMovement)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM stock_movements"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.StockMovement, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:    context.TODO(),
+				params: &entity.ListStockMovementByParams{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetStockMovementRow(dummyStockMovement)
+				row[len(row)-1] = "invalid"
+
+				expectedQuery := fmt.Sprintf("SELECT %s FROM stock_movements ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.StockMovement, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:    context.TODO(),
+				params: &entity.ListStockMovementByParams{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM stock_movements ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.Limit, in.params.Offset).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result []*entity.StockMovement, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewStockMovementRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByParams(tc.in.ctx, tc.in.params))
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	stockReservationTable = "stock_reservations"
+
+	stockReservationInsertColumns = []string{"reservation_id", "warehouse_id", "product_id", "stock", "state", "expired_at"}
+	stockReservationColumns       = []string{"id", "reservation_id", "warehouse_id", "product_id", "stock", "state", "expired_at", "created_at", "updated_at"}
+)
+
+type StockReservationRepository struct {
+	db *sqlx.DB
+}
+
+type stockReservationObject struct {
+	ID            string    `db:"id"`
+	ReservationID string    `db:"reservation_id"`
+	WarehouseID   string    `db:"warehouse_id"`
+	ProductID     string    `db:"product_id"`
+	Stock         int       `db:"stock"`
+	State         int       `db:"state"`
+	ExpiredAt     time.Time `db:"expired_at"`
+	CreatedAt     time.Time `db:"created_at"`
+	UpdatedAt     time.Time `db:"updated_at"`
+}
+
+func (o *stockReservationObject) toEntity() *entity.StockReservation {
+	return &entity.StockReservation{
+		ID:            o.ID,
+		ReservationID: o.ReservationID,
+		WarehouseID:   o.WarehouseID,
+		ProductID:     o.ProductID,
+		Stock:         o.Stock,
+		State:         entity.StockReservationState(o.State),
+		ExpiredAt:     o.ExpiredAt,
+		CreatedAt:     o.CreatedAt,
+		UpdatedAt:     o.UpdatedAt,
+	}
+}
+
+func NewStockReservationRepository(db *sqlx.DB) *StockReservationRepository {
+	return &StockReservationRepository{db: db}
+}
+
+func (s *StockReservationRepository) Create(ctx context.Context, stockReservation *entity.StockReservation, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(stockReservationTable)
+	ib.Cols(stockReservationInsertColumns...)
+	ib.Values(
+		stockReservation.ReservationID,
+		stockReservation.WarehouseID,
+		stockReservation.ProductID,
+		stockReservation.Stock,
+		entity.StockReservationStateReserved,
+		stockReservation.ExpiredAt,
+	)
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(s.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on stockReservation.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		if isDuplicateError(err) {
+			return entity.ErrorStockReservationDuplicated
+		}
+
+		return liberr.NewTracer("Error when ExecContext on stockReservation.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on stockReservation.Create").Wrap(err)
+	}
+
+	stockReservation.ID = fmt.Sprintf("%d", lastInsertedID)
+	stockReservation.State = entity.StockReservationStateReserved
+	return nil
+}
+
+func (s *StockReservationRepository) ListByReservationID(ctx context.Context, reservationID string) ([]*entity.StockReservation, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(stockReservationColumns...)
+	sb.From(stockReservationTable)
+	sb.Where(sb.Equal("reservation_id", reservationID))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on stockReservation.ListByReservationID").Wrap(err)
+	}
+
+	stockReservations := []*entity.StockReservation{}
+	for rows.Next() {
+		var obj stockReservationObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on stockReservation.ListByReservationID").Wrap(err)
+		}
+
+		stockReservations = append(stockReservations, obj.toEntity())
+	}
+
+	return stockReservations, nil
+}
+
+// ListExpiredReservationIDs list the reservation IDs still holding stock after the expiry
+func (s *StockReservationRepository) ListExpiredReservationIDs(ctx context.Context, now time.Time, limit int) ([]string, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select("reservation_id")
+	sb.Distinct()
+	sb.From(stockReservationTable)
+	sb.Where(
+		sb.Equal("state", entity.StockReservationStateReserved),
+		sb.LessThan("expired_at", now),
+	)
+	sb.Limit(limit)
+
+	query, args := sb.Build()
+
+	rows, err := s.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on stockReservation.ListExpiredReservationIDs").Wrap(err)
+	}
+
+	reservationIDs := []string{}
+	for rows.Next() {
+		var reservationID string
+
+		if err := rows.Scan(&reservationID); err != nil {
+			return nil, liberr.NewTracer("Error when Scan on stockReservation.ListExpiredReservationIDs").Wrap(err)
+		}
+
+		reservationIDs = append(reservationIDs, reservationID)
+	}
+
+	return reservationIDs, nil
+}
+
+// UpdateState move every line of the reservation from the given state,
+// the state guard makes a concurrent commit or release of the same reservation affect no rows
+func (s *StockReservationRepository) UpdateState(ctx context.Context, reservationID string, fromState, toState entity.StockReservationState, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(stockReservationTable).
+		Set(
+			ub.Assign("state", toState),
+		).
+		Where(
+			ub.E("reservation_id", reservationID),
+			ub.E("state", fromState),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(s.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on stockReservation.UpdateState").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on stockReservation.UpdateState").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// UpdateExpiredAt move the expiry of the reservation lines which are still holding stock
+func (s *StockReservationRepository) UpdateExpiredAt(ctx context.Context, reservationID string, expiredAt time.Time, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(stockReservationTable).
+		Set(
+			ub.Assign("expired_at", expiredAt),
+		).
+		Where(
+			ub.E("reservation_id", reservationID),
+			ub.E("state", entity.StockReservationStateReserved),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(s.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on stockReservation.UpdateExpiredAt").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on stockReservation.UpdateExpiredAt").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
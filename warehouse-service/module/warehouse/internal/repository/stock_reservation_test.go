@@ -0,0 +1,519 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"warehouse-service/internal/util"
+	"warehouse-service/module/warehouse/entity"
+	"warehouse-service/module/warehouse/internal/repository"
+	"warehouse-service/module/warehouse/testutil/fixtures"
+
+	"warehouse-service/internal/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	stockReservationInsertAttributes = []string{
+		"reservation_id",
+		"warehouse_id",
+		"product_id",
+		"stock",
+		"state",
+		"expired_at",
+	}
+	stockReservationAllAttributes = []string{
+		"id",
+		"reservation_id",
+		"warehouse_id",
+		"product_id",
+		"stock",
+		"state",
+		"expired_at",
+		"created_at",
+		"updated_at",
+	}
+
+	stockReservationInsertColumnsStr = strings.Join(stockReservationInsertAttributes, ", ")
+	stockReservationAllColumnsStr    = strings.Join(stockReservationAllAttributes, ", ")
+)
+
+func TestStockReservationRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO stock_reservations (%s) VALUES (?, ?, ?, ?, ?, ?)", stockReservationInsertColumnsStr)
+
+	type input struct {
+		ctx              context.Context
+		stockReservation *entity.StockReservation
+		tx               util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.StockReservation, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:              context.TODO(),
+				stockReservation: fixtures.NewStockReservation(fixtures.StockReservation),
+				tx:               nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stockReservation.ReservationID, in.stockReservation.WarehouseID, in.stockReservation.ProductID, in.stockReservation.Stock, entity.StockReservationStateReserved, in.stockReservation.ExpiredAt).
+					WillReturnResult(sqlmock.NewResult(12, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(stockReservation *entity.StockReservation, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "12", stockReservation.ID)
+				assert.Equal(t, entity.StockReservationStateReserved, stockReservation.State)
+			},
+		},
+		{
+			name: "Error on Duplicate Reservation",
+			in: input{
+				ctx:              context.TODO(),
+				stockReservation: fixtures.NewStockReservation(fixtures.StockReservation),
+				tx:               nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stockReservation.ReservationID, in.stockReservation.WarehouseID, in.stockReservation.ProductID, in.stockReservation.Stock, entity.StockReservationStateReserved, in.stockReservation.ExpiredAt).
+					WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+			},
+			assertFn: func(stockReservation *entity.StockReservation, err error) {
+				assert.Equal(t, entity.ErrorStockReservationDuplicated, err)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:              context.TODO(),
+				stockReservation: fixtures.NewStockReservation(fixtures.StockReservation),
+				tx:               nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stockReservation.ReservationID, in.stockReservation.WarehouseID, in.stockReservation.ProductID, in.stockReservation.Stock, entity.StockReservationStateReserved, in.stockReservation.ExpiredAt).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(stockReservation *entity.StockReservation, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:              context.TODO(),
+				stockReservation: fixtures.NewStockReservation(fixtures.StockReservation),
+				tx:               nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stockReservation.ReservationID, in.stockReservation.WarehouseID, in.stockReservation.ProductID, in.stockReservation.Stock, entity.StockReservationStateReserved, in.stockReservation.ExpiredAt).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(stockReservation *entity.StockReservation, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:              context.TODO(),
+				stockReservation: fixtures.NewStockReservation(fixtures.StockReservation),
+				tx:               &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(stockReservation *entity.StockReservation, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewStockReservationRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in.stockReservation, repo.Create(tc.in.ctx, tc.in.stockReservation, tc.in.tx))
+		})
+	}
+}
+
+func TestStockReservationRepository_ListByReservationID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM stock_reservations WHERE reservation_id = ? ORDER BY id ASC", stockReservationAllColumnsStr)
+	rows := stockReservationAllAttributes
+	dummyStockReservation := fixtures.NewStockReservation(fixtures.StockReservation)
+
+	type input struct {
+		ctx           context.Context
+		reservationID string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.StockReservation, error)
+	}{
+		{
+			name: "Success on Retrieve List By Reservation ID",
+			in: input{
+				ctx:           context.TODO(),
+				reservationID: dummyStockReservation.ReservationID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.reservationID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetStockReservationRow(dummyStockReservation)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.StockReservation, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.StockReservation{dummyStockReservation}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:           context.TODO(),
+				reservationID: dummyStockReservation.ReservationID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetStockReservationRow(dummyStockReservation)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.reservationID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.StockReservation, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:           context.TODO(),
+				reservationID: dummyStockReservation.ReservationID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.reservationID).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result []*entity.StockReservation, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewStockReservationRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByReservationID(tc.in.ctx, tc.in.reservationID))
+		})
+	}
+}
+
+func TestStockReservationRepository_ListExpiredReservationIDs(t *testing.T) {
+	expectedQuery := "SELECT DISTINCT reservation_id FROM stock_reservations WHERE state = ? AND expired_at < ? LIMIT ?"
+	now := time.Date(2025, 1, 10, 13, 0, 0, 0, time.UTC)
+
+	type input struct {
+		ctx   context.Context
+		now   time.Time
+		limit int
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]string, error)
+	}{
+		{
+			name: "Success on Retrieve Expired Reservation IDs",
+			in: input{
+				ctx:   context.TODO(),
+				now:   now,
+				limit: 10,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.StockReservationStateReserved, in.now, in.limit).
+					WillReturnRows(
+						sqlmock.
+							NewRows([]string{"reservation_id"}).
+							AddRow("5").
+							AddRow("6"),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []string, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []string{"5", "6"}, result)
+			},
+		},
+		{
+			name: "Error on Scan",
+			in: input{
+				ctx:   context.TODO(),
+				now:   now,
+				limit: 10,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.StockReservationStateReserved, in.now, in.limit).
+					WillReturnRows(
+						sqlmock.
+							NewRows([]string{"reservation_id"}).
+							AddRow(nil),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []string, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:   context.TODO(),
+				now:   now,
+				limit: 10,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(entity.StockReservationStateReserved, in.now, in.limit).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result []string, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewStockReservationRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListExpiredReservationIDs(tc.in.ctx, tc.in.now, tc.in.limit))
+		})
+	}
+}
+
+func TestStockReservationRepository_UpdateState(t *testing.T) {
+	expectedQuery := "UPDATE stock_reservations SET state = ? WHERE reservation_id = ? AND state = ?"
+
+	type input struct {
+		ctx           context.Context
+		reservationID string
+		fromState     entity.StockReservationState
+		toState       entity.StockReservationState
+		tx            util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx:           context.TODO(),
+				reservationID: "5",
+				fromState:     entity.StockReservationStateReserved,
+				toState:       entity.StockReservationStateReleased,
+				tx:            nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, in.reservationID, in.fromState).
+					WillReturnResult(sqlmock.NewResult(0, 2)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(2), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:           context.TODO(),
+				reservationID: "5",
+				fromState:     entity.StockReservationStateReserved,
+				toState:       entity.StockReservationStateReleased,
+				tx:            nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, in.reservationID, in.fromState).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:           context.TODO(),
+				reservationID: "5",
+				fromState:     entity.StockReservationStateReserved,
+				toState:       entity.StockReservationStateReleased,
+				tx:            &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewStockReservationRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateState(tc.in.ctx, tc.in.reservationID, tc.in.fromState, tc.in.toState, tc.in.tx))
+		})
+	}
+}
+
+func TestStockReservationRepository_UpdateExpiredAt(t *testing.T) {
+	expectedQuery := "UPDATE stock_reservations SET expired_at = ? WHERE reservation_id = ? AND state = ?"
+
+	type input struct {
+		ctx           context.Context
+		reservationID string
+		expiredAt     time.Time
+		tx            util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx:           context.TODO(),
+				reservationID: "5",
+				expiredAt:     time.Date(2025, 10, 20, 15, 5, 0, 0, time.UTC),
+				tx:            nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.expiredAt, in.reservationID, entity.StockReservationStateReserved).
+					WillReturnResult(sqlmock.NewResult(0, 2)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(2), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:           context.TODO(),
+				reservationID: "5",
+				expiredAt:     time.Date(2025, 10, 20, 15, 5, 0, 0, time.UTC),
+				tx:            nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.expiredAt, in.reservationID, entity.StockReservationStateReserved).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:           context.TODO(),
+				reservationID: "5",
+				expiredAt:     time.Date(2025, 10, 20, 15, 5, 0, 0, time.UTC),
+				tx:            &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewStockReservationRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateExpiredAt(tc.in.ctx, tc.in.reservationID, tc.in.expiredAt, tc.in.tx))
+		})
+	}
+}
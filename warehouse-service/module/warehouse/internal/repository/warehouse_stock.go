@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 	"warehouse-service/internal/util"
@@ -17,7 +18,7 @@ var (
 	warehouseStockTable = "warehouse_stocks"
 
 	warehouseStockInsertColumns = []string{"warehouse_id", "product_id", "stock"}
-	warehouseStockColumns       = []string{"id", "warehouse_id", "product_id", "stock", "created_at", "updated_at"}
+	warehouseStockColumns       = []string{"id", "warehouse_id", "product_id", "stock", "reserved", "created_at", "updated_at"}
 )
 
 type WarehouseStockRepository struct {
@@ -29,6 +30,7 @@ type warehouseStockObject struct {
 	WarehouseID string    `db:"warehouse_id"`
 	ProductID   string    `db:"product_id"`
 	Stock       int       `db:"stock"`
+	Reserved    int       `db:"reserved"`
 	CreatedAt   time.Time `db:"created_at"`
 	UpdatedAt   time.Time `db:"updated_at"`
 }
@@ -39,6 +41,8 @@ func (o *warehouseStockObject) toEntity() *entity.WarehouseStock {
 		WarehouseID: o.WarehouseID,
 		ProductID:   o.ProductID,
 		Stock:       o.Stock,
+		Reserved:    o.Reserved,
+		Available:   o.Stock - o.Reserved,
 		CreatedAt:   o.CreatedAt,
 		UpdatedAt:   o.UpdatedAt,
 	}
@@ -127,7 +131,7 @@ func (w *WarehouseStockRepository) DecreaseStock(ctx context.Context, params ent
 		Where(
 			ub.E("warehouse_id", params.WarehouseID),
 			ub.E("product_id", params.ProductID),
-			ub.GTE("stock", params.Stock),
+			ub.GTE("stock - reserved", params.Stock),
 		)
 	query, args := ub.Build()
 
@@ -145,6 +149,121 @@ func (w *WarehouseStockRepository) DecreaseStock(ctx context.Context, params ent
 	return rowAffected, nil
 }
 
+// ReserveStock hold the stock for a reservation, only when the available stock (stock - reserved) is sufficient
+func (w *WarehouseStockRepository) ReserveStock(ctx context.Context, params entity.WarehouseStockAdjustmentParams, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(warehouseStockTable).
+		Set(
+			ub.Add("reserved", params.Stock),
+		).
+		Where(
+			ub.E("warehouse_id", params.WarehouseID),
+			ub.E("product_id", params.ProductID),
+			ub.GTE("stock - reserved", params.Stock),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on warehouseStock.ReserveStock").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on warehouseStock.ReserveStock").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// ReleaseReservedStock return the reserved stock to the available stock, the on-hand stock is untouched
+func (w *WarehouseStockRepository) ReleaseReservedStock(ctx context.Context, params entity.WarehouseStockAdjustmentParams, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(warehouseStockTable).
+		Set(
+			ub.Sub("reserved", params.Stock),
+		).
+		Where(
+			ub.E("warehouse_id", params.WarehouseID),
+			ub.E("product_id", params.ProductID),
+			ub.GTE("reserved", params.Stock),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on warehouseStock.ReleaseReservedStock").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on warehouseStock.ReleaseReservedStock").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+// CommitReservedStock take the reserved stock out of the warehouse, both on-hand and reserved stock are decreased
+func (w *WarehouseStockRepository) CommitReservedStock(ctx context.Context, params entity.WarehouseStockAdjustmentParams, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(warehouseStockTable).
+		Set(
+			ub.Sub("stock", params.Stock),
+			ub.Sub("reserved", params.Stock),
+		).
+		Where(
+			ub.E("warehouse_id", params.WarehouseID),
+			ub.E("product_id", params.ProductID),
+			ub.GTE("reserved", params.Stock),
+			ub.GTE("stock", params.Stock),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on warehouseStock.CommitReservedStock").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on warehouseStock.CommitReservedStock").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+func (w *WarehouseStockRepository) GetByWarehouseIDAndProductID(ctx context.Context, warehouseID string, productID string, tx util.DatabaseTransaction) (*entity.WarehouseStock, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(warehouseStockColumns...)
+	sb.From(warehouseStockTable)
+	sb.Where(
+		sb.Equal("warehouse_id", warehouseID),
+		sb.Equal("product_id", productID),
+	)
+
+	query, args := sb.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when GetExecer on warehouseStock.GetByWarehouseIDAndProductID").Wrap(err)
+	}
+
+	row := db.QueryRowxContext(ctx, query, args...)
+	obj := &warehouseStockObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorWarehouseStockNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on warehouseStock.GetByWarehouseIDAndProductID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
 func (w *WarehouseStockRepository) ListByWarehouseIDsAndProductIDs(ctx context.Context, warehouseIDs []string, productIDs []string) ([]*entity.WarehouseStock, error) {
 	sb := sqlbuilder.NewSelectBuilder()
 	sb.Select(warehouseStockColumns...)
@@ -2,12 +2,14 @@ package repository_test
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 	"testing"
 	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
 	"warehouse-service/module/warehouse/entity"
 	"warehouse-service/module/warehouse/internal/repository"
 	"warehouse-service/module/warehouse/testutil/fixtures"
@@ -31,6 +33,7 @@ var (
 		"warehouse_id",
 		"product_id",
 		"stock",
+		"reserved",
 		"created_at",
 		"updated_at",
 	}
@@ -251,7 +254,7 @@ func TestWarehouseStockRepository_IncreaseStock(t *testing.T) {
 }
 
 func TestWarehouseStockRepository_DecreaseStock(t *testing.T) {
-	expectedQuery := "UPDATE warehouse_stocks SET stock = stock - ? WHERE warehouse_id  = ? AND product_id = ? AND stock >= ?"
+	expectedQuery := "UPDATE warehouse_stocks SET stock = stock - ? WHERE warehouse_id  = ? AND product_id = ? AND stock - reserved >= ?"
 
 	type input struct {
 		ctx    context.Context
@@ -345,6 +348,404 @@ func TestWarehouseStockRepository_DecreaseStock(t *testing.T) {
 	}
 }
 
+func TestWarehouseStockRepository_ReserveStock(t *testing.T) {
+	expectedQuery := "UPDATE warehouse_stocks SET reserved = reserved + ? WHERE warehouse_id  = ? AND product_id = ? AND stock - reserved >= ?"
+
+	type input struct {
+		ctx    context.Context
+		params entity.WarehouseStockAdjustmentParams
+		tx     util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx: context.TODO(),
+				params: entity.WarehouseStockAdjustmentParams{
+					WarehouseID: "1",
+					ProductID:   "2",
+					Stock:       10,
+				},
+				tx: nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := regexp.QuoteMeta(expectedQuery)
+				dependency.MockedSQL.
+					ExpectExec(expectedQuery).
+					WithArgs(10, "1", "2", 10).
+					WillReturnResult(sqlmock.NewResult(2, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				params: entity.WarehouseStockAdjustmentParams{
+					WarehouseID: "1",
+					ProductID:   "2",
+					Stock:       10,
+				},
+				tx: nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := regexp.QuoteMeta(expectedQuery)
+				dependency.MockedSQL.
+					ExpectExec(expectedQuery).
+					WithArgs(10, "1", "2", 10).
+					WillReturnResult(sqlmock.NewResult(2, 1)).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx: context.TODO(),
+				params: entity.WarehouseStockAdjustmentParams{
+					WarehouseID: "1",
+					ProductID:   "2",
+					Stock:       10,
+				},
+				tx: &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseStockRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ReserveStock(tc.in.ctx, tc.in.params, tc.in.tx))
+		})
+	}
+}
+
+func TestWarehouseStockRepository_ReleaseReservedStock(t *testing.T) {
+	expectedQuery := "UPDATE warehouse_stocks SET reserved = reserved - ? WHERE warehouse_id  = ? AND product_id = ? AND reserved >= ?"
+
+	type input struct {
+		ctx    context.Context
+		params entity.WarehouseStockAdjustmentParams
+		tx     util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx: context.TODO(),
+				params: entity.WarehouseStockAdjustmentParams{
+					WarehouseID: "1",
+					ProductID:   "2",
+					Stock:       10,
+				},
+				tx: nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := regexp.QuoteMeta(expectedQuery)
+				dependency.MockedSQL.
+					ExpectExec(expectedQuery).
+					WithArgs(10, "1", "2", 10).
+					WillReturnResult(sqlmock.NewResult(2, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				params: entity.WarehouseStockAdjustmentParams{
+					WarehouseID: "1",
+					ProductID:   "2",
+					Stock:       10,
+				},
+				tx: nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := regexp.QuoteMeta(expectedQuery)
+				dependency.MockedSQL.
+					ExpectExec(expectedQuery).
+					WithArgs(10, "1", "2", 10).
+					WillReturnResult(sqlmock.NewResult(2, 1)).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx: context.TODO(),
+				params: entity.WarehouseStockAdjustmentParams{
+					WarehouseID: "1",
+					ProductID:   "2",
+					Stock:       10,
+				},
+				tx: &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseStockRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ReleaseReservedStock(tc.in.ctx, tc.in.params, tc.in.tx))
+		})
+	}
+}
+
+func TestWarehouseStockRepository_CommitReservedStock(t *testing.T) {
+	expectedQuery := "UPDATE warehouse_stocks SET stock = stock - ?, reserved = reserved - ? WHERE warehouse_id  = ? AND product_id = ? AND reserved >= ? AND stock >= ?"
+
+	type input struct {
+		ctx    context.Context
+		params entity.WarehouseStockAdjustmentParams
+		tx     util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update",
+			in: input{
+				ctx: context.TODO(),
+				params: entity.WarehouseStockAdjustmentParams{
+					WarehouseID: "1",
+					ProductID:   "2",
+					Stock:       10,
+				},
+				tx: nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := regexp.QuoteMeta(expectedQuery)
+				dependency.MockedSQL.
+					ExpectExec(expectedQuery).
+					WithArgs(10, 10, "1", "2", 10, 10).
+					WillReturnResult(sqlmock.NewResult(2, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				params: entity.WarehouseStockAdjustmentParams{
+					WarehouseID: "1",
+					ProductID:   "2",
+					Stock:       10,
+				},
+				tx: nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := regexp.QuoteMeta(expectedQuery)
+				dependency.MockedSQL.
+					ExpectExec(expectedQuery).
+					WithArgs(10, 10, "1", "2", 10, 10).
+					WillReturnResult(sqlmock.NewResult(2, 1)).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx: context.TODO(),
+				params: entity.WarehouseStockAdjustmentParams{
+					WarehouseID: "1",
+					ProductID:   "2",
+					Stock:       10,
+				},
+				tx: &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseStockRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.CommitReservedStock(tc.in.ctx, tc.in.params, tc.in.tx))
+		})
+	}
+}
+
+func TestWarehouseStockRepository_GetByWarehouseIDAndProductID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM warehouse_stocks WHERE warehouse_id = ? AND product_id = ?", warehouseStockAllColumnsStr)
+	rows := warehouseStockAllAttributes
+	dummyWarehouseStock := fixtures.NewWarehouseStock(fixtures.WarehouseStock)
+
+	type input struct {
+		ctx         context.Context
+		warehouseID string
+		productID   string
+		tx          util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.WarehouseStock, error)
+	}{
+		{
+			name: "Success on GetByWarehouseIDAndProductID",
+			in: input{
+				ctx:         context.TODO(),
+				warehouseID: dummyWarehouseStock.WarehouseID,
+				productID:   dummyWarehouseStock.ProductID,
+				tx:          nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseID, in.productID).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWarehouseStockRow(dummyWarehouseStock)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.WarehouseStock, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyWarehouseStock, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx:         context.TODO(),
+				warehouseID: dummyWarehouseStock.WarehouseID,
+				productID:   dummyWarehouseStock.ProductID,
+				tx:          nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseID, in.productID).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.WarehouseStock, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorWarehouseStockNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:         context.TODO(),
+				warehouseID: dummyWarehouseStock.WarehouseID,
+				productID:   dummyWarehouseStock.ProductID,
+				tx:          nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseID, in.productID).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result *entity.WarehouseStock, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:         context.TODO(),
+				warehouseID: dummyWarehouseStock.WarehouseID,
+				productID:   dummyWarehouseStock.ProductID,
+				tx:          &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result *entity.WarehouseStock, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseStockRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByWarehouseIDAndProductID(tc.in.ctx, tc.in.warehouseID, tc.in.productID, tc.in.tx))
+		})
+	}
+}
+
 func TestWarehouseStockRepository_ListByWarehouseIDsAndProductIDs(t *testing.T) {
 	columns := warehouseStockAllColumnsStr
 	rows := warehouseStockAllAttributes
@@ -420,7 +821,7 @@ func TestWarehouseStockRepository_ListByWarehouseIDsAndProductIDs(t *testing.T)
 							AddRow(
 								dummyWarehouseStock.ID,
 								dummyWarehouseStock.WarehouseID, dummyWarehouseStock.ProductID,
-								dummyWarehouseStock.Stock, dummyWarehouseStock.CreatedAt, "invalid"),
+								dummyWarehouseStock.Stock, dummyWarehouseStock.Reserved, dummyWarehouseStock.CreatedAt, "invalid"),
 					).RowsWillBeClosed()
 			},
 			assertFn: func(result []*entity.WarehouseStock, err error) {
@@ -537,7 +938,7 @@ func TestWarehouseStockRepository_ListActiveByProductIDs(t *testing.T) {
 							AddRow(
 								dummyWarehouseStock.ID,
 								dummyWarehouseStock.WarehouseID, dummyWarehouseStock.ProductID,
-								dummyWarehouseStock.Stock, dummyWarehouseStock.CreatedAt, "invalid"),
+								dummyWarehouseStock.Stock, dummyWarehouseStock.Reserved, dummyWarehouseStock.CreatedAt, "invalid"),
 					).RowsWillBeClosed()
 			},
 			assertFn: func(result []*entity.WarehouseStock, err error) {
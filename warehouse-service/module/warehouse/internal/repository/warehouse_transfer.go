@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/internal/util/libpagination"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	warehouseTransferTable = "warehouse_transfers"
+
+	warehouseTransferInsertColumns = []string{"original_warehouse_id", "destination_warehouse_id", "state", "created_by"}
+	warehouseTransferColumns       = []string{"id", "original_warehouse_id", "destination_warehouse_id", "state", "created_by", "dispatched_at", "received_at", "cancelled_at", "created_at", "updated_at"}
+
+	// warehouseTransferStateTimeColumns is the column recording the time of the transfer entering the state
+	warehouseTransferStateTimeColumns = map[entity.WarehouseTransferState]string{
+		entity.WarehouseTransferStateDispatched: "dispatched_at",
+		entity.WarehouseTransferStateReceived:   "received_at",
+		entity.WarehouseTransferStateCancelled:  "cancelled_at",
+	}
+)
+
+type WarehouseTransferRepository struct {
+	db *sqlx.DB
+}
+
+type warehouseTransferObject struct {
+	ID                     string       `db:"id"`
+	OriginalWarehouseID    string       `db:"original_warehouse_id"`
+	DestinationWarehouseID string       `db:"destination_warehouse_id"`
+	State                  int          `db:"state"`
+	CreatedBy              string       `db:"created_by"`
+	DispatchedAt           sql.NullTime `db:"dispatched_at"`
+	ReceivedAt             sql.NullTime `db:"received_at"`
+	CancelledAt            sql.NullTime `db:"cancelled_at"`
+	CreatedAt              time.Time    `db:"created_at"`
+	UpdatedAt              time.Time    `db:"updated_at"`
+}
+
+func (o *warehouseTransferObject) toEntity() *entity.WarehouseTransfer {
+	return &entity.WarehouseTransfer{
+		ID:                     o.ID,
+		OriginalWarehouseID:    o.OriginalWarehouseID,
+		DestinationWarehouseID: o.DestinationWarehouseID,
+		State:                  entity.WarehouseTransferState(o.State),
+		CreatedBy:              o.CreatedBy,
+		DispatchedAt:           nullTimeToPointer(o.DispatchedAt),
+		ReceivedAt:             nullTimeToPointer(o.ReceivedAt),
+		CancelledAt:            nullTimeToPointer(o.CancelledAt),
+		CreatedAt:              o.CreatedAt,
+		UpdatedAt:              o.UpdatedAt,
+	}
+}
+
+func nullTimeToPointer(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+func NewWarehouseTransferRepository(db *sqlx.DB) *WarehouseTransferRepository {
+	return &WarehouseTransferRepository{db: db}
+}
+
+func (w *WarehouseTransferRepository) Create(ctx context.Context, warehouseTransfer *entity.WarehouseTransfer, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(warehouseTransferTable)
+	ib.Cols(warehouseTransferInsertColumns...)
+	ib.Values(
+		warehouseTransfer.OriginalWarehouseID,
+		warehouseTransfer.DestinationWarehouseID,
+		entity.WarehouseTransferStateDraft,
+		warehouseTransfer.CreatedBy,
+	)
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on warehouseTransfer.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on warehouseTransfer.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on warehouseTransfer.Create").Wrap(err)
+	}
+
+	warehouseTransfer.ID = fmt.Sprintf("%d", lastInsertedID)
+	warehouseTransfer.State = entity.WarehouseTransferStateDraft
+	return nil
+}
+
+func (w *WarehouseTransferRepository) GetByID(ctx context.Context, id string) (*entity.WarehouseTransfer, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(warehouseTransferColumns...)
+	sb.From(warehouseTransferTable)
+	sb.Where(sb.Equal("id", id))
+
+	query, args := sb.Build()
+
+	row := w.db.QueryRowxContext(ctx, query, args...)
+	obj := &warehouseTransferObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorWarehouseTransferNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on warehouseTransfer.GetByID").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+// GetByIDForUpdate retrieve the transfer and lock it until the transaction ends, so the transfer is advanced one request at a time
+func (w *WarehouseTransferRepository) GetByIDForUpdate(ctx context.Context, id string, tx util.DatabaseTransaction) (*entity.WarehouseTransfer, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(warehouseTransferColumns...)
+	sb.From(warehouseTransferTable)
+	sb.Where(sb.Equal("id", id))
+	sb.ForUpdate()
+
+	query, args := sb.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when GetExecer on warehouseTransfer.GetByIDForUpdate").Wrap(err)
+	}
+
+	row := db.QueryRowxContext(ctx, query, args...)
+	obj := &warehouseTransferObject{}
+
+	if err := row.StructScan(obj); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, liberr.NewBaseError(entity.ErrorWarehouseTransferNotFound)
+		}
+		return nil, liberr.NewTracer("Error when StructScan on warehouseTransfer.GetByIDForUpdate").Wrap(err)
+	}
+
+	return obj.toEntity(), nil
+}
+
+// UpdateState move the transfer from the given state and record the time of entering the new state
+func (w *WarehouseTransferRepository) UpdateState(ctx context.Context, id string, fromState, toState entity.WarehouseTransferState, at time.Time, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(warehouseTransferTable)
+	ub.Set(ub.Assign("state", toState))
+	if column, ok := warehouseTransferStateTimeColumns[toState]; ok {
+		ub.SetMore(ub.Assign(column, at))
+	}
+	ub.Where(
+		ub.E("id", id),
+		ub.E("state", fromState),
+	)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on warehouseTransfer.UpdateState").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on warehouseTransfer.UpdateState").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
+
+func (w *WarehouseTransferRepository) filterByParams(sb *sqlbuilder.SelectBuilder, params *entity.ListWarehouseTransferByParams) *sqlbuilder.SelectBuilder {
+	if params.OriginalWarehouseID != "" {
+		sb.Where(sb.Equal("original_warehouse_id", params.OriginalWarehouseID))
+	}
+	if params.DestinationWarehouseID != "" {
+		sb.Where(sb.Equal("destination_warehouse_id", params.DestinationWarehouseID))
+	}
+	if len(params.States) > 0 {
+		inArgs := make([]any, len(params.States))
+		for i, v := range params.States {
+			inArgs[i] = v
+		}
+		sb.Where(sb.In("state", inArgs...))
+	}
+
+	return sb
+}
+
+func (w *WarehouseTransferRepository) ListByParams(ctx context.Context, params *entity.ListWarehouseTransferByParams) ([]*entity.WarehouseTransfer, *libpagination.OffsetPagination, error) {
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(warehouseTransferColumns...)
+	sb.From(warehouseTransferTable)
+	sb.OrderBy("id").Desc()
+	sb.Limit(params.Limit)
+	sb.Offset(params.Offset)
+
+	query, args := w.filterByParams(sb, params).Build()
+
+	rows, err := w.db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, liberr.NewTracer("Error when QueryxContext on warehouseTransfer.ListByParams").Wrap(err)
+	}
+
+	warehouseTransfers := []*entity.WarehouseTransfer{}
+	for rows.Next() {
+		var obj warehouseTransferObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, nil, liberr.NewTracer("Error when StructScan on warehouseTransfer.ListByParams").Wrap(err)
+		}
+
+		warehouseTransfers = append(warehouseTransfers, obj.toEntity())
+	}
+
+	cb := sqlbuilder.NewSelectBuilder()
+	cb.Select(cb.As("COUNT(id)", "total"))
+	cb.From(warehouseTransferTable)
+
+	cQuery, cArgs := w.filterByParams(cb, params).Build()
+	row := w.db.QueryRowxContext(ctx, cQuery, cArgs...)
+
+	var total int
+	if err := row.Scan(&total); err != nil {
+		return nil, nil, liberr.NewTracer("Error when Scan on warehouseTransfer.ListByParams").Wrap(err)
+	}
+
+	return warehouseTransfers, &libpagination.OffsetPagination{
+		Total:  total,
+		Offset: params.Offset,
+		Limit:  params.Limit,
+	}, nil
+}
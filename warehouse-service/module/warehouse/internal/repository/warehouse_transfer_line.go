@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/huandu/go-sqlbuilder"
+	"github.com/jmoiron/sqlx"
+)
+
+var (
+	warehouseTransferLineTable = "warehouse_transfer_lines"
+
+	warehouseTransferLineInsertColumns = []string{"warehouse_transfer_id", "product_id", "stock"}
+	warehouseTransferLineColumns       = []string{"id", "warehouse_transfer_id", "product_id", "stock", "received_stock", "created_at", "updated_at"}
+)
+
+type WarehouseTransferLineRepository struct {
+	db *sqlx.DB
+}
+
+type warehouseTransferLineObject struct {
+	ID                  string    `db:"id"`
+	WarehouseTransferID string    `db:"warehouse_transfer_id"`
+	ProductID           string    `db:"product_id"`
+	Stock               int       `db:"stock"`
+	ReceivedStock       int       `db:"received_stock"`
+	CreatedAt           time.Time `db:"created_at"`
+	UpdatedAt           time.Time `db:"updated_at"`
+}
+
+func (o *warehouseTransferLineObject) toEntity() *entity.WarehouseTransferLine {
+	return &entity.WarehouseTransferLine{
+		ID:                  o.ID,
+		WarehouseTransferID: o.WarehouseTransferID,
+		ProductID:           o.ProductID,
+		Stock:               o.Stock,
+		ReceivedStock:       o.ReceivedStock,
+		CreatedAt:           o.CreatedAt,
+		UpdatedAt:           o.UpdatedAt,
+	}
+}
+
+func NewWarehouseTransferLineRepository(db *sqlx.DB) *WarehouseTransferLineRepository {
+	return &WarehouseTransferLineRepository{db: db}
+}
+
+func (w *WarehouseTransferLineRepository) Create(ctx context.Context, warehouseTransferLine *entity.WarehouseTransferLine, tx util.DatabaseTransaction) error {
+	ib := sqlbuilder.NewInsertBuilder()
+	ib.InsertInto(warehouseTransferLineTable)
+	ib.Cols(warehouseTransferLineInsertColumns...)
+	ib.Values(
+		warehouseTransferLine.WarehouseTransferID,
+		warehouseTransferLine.ProductID,
+		warehouseTransferLine.Stock,
+	)
+	query, args := ib.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return liberr.NewTracer("Error when GetExecer on warehouseTransferLine.Create").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return liberr.NewTracer("Error when ExecContext on warehouseTransferLine.Create").Wrap(err)
+	}
+
+	lastInsertedID, err := row.LastInsertId()
+	if err != nil {
+		return liberr.NewTracer("Error when retrieve LastInsertId on warehouseTransferLine.Create").Wrap(err)
+	}
+
+	warehouseTransferLine.ID = fmt.Sprintf("%d", lastInsertedID)
+	return nil
+}
+
+func (w *WarehouseTransferLineRepository) ListByWarehouseTransferIDs(ctx context.Context, warehouseTransferIDs []string, tx util.DatabaseTransaction) ([]*entity.WarehouseTransferLine, error) {
+	inArgs := make([]any, len(warehouseTransferIDs))
+	for i, v := range warehouseTransferIDs {
+		inArgs[i] = v
+	}
+
+	sb := sqlbuilder.NewSelectBuilder()
+	sb.Select(warehouseTransferLineColumns...)
+	sb.From(warehouseTransferLineTable)
+	sb.Where(sb.In("warehouse_transfer_id", inArgs...))
+	sb.OrderBy("id").Asc()
+
+	query, args := sb.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when GetExecer on warehouseTransferLine.ListByWarehouseTransferIDs").Wrap(err)
+	}
+
+	rows, err := db.QueryxContext(ctx, query, args...)
+	if err != nil {
+		return nil, liberr.NewTracer("Error when QueryxContext on warehouseTransferLine.ListByWarehouseTransferIDs").Wrap(err)
+	}
+
+	warehouseTransferLines := []*entity.WarehouseTransferLine{}
+	for rows.Next() {
+		var obj warehouseTransferLineObject
+
+		if err := rows.StructScan(&obj); err != nil {
+			return nil, liberr.NewTracer("Error when StructScan on warehouseTransferLine.ListByWarehouseTransferIDs").Wrap(err)
+		}
+
+		warehouseTransferLines = append(warehouseTransferLines, obj.toEntity())
+	}
+
+	return warehouseTransferLines, nil
+}
+
+// IncreaseReceivedStock record the received stock of the line, only up to the dispatched stock
+func (w *WarehouseTransferLineRepository) IncreaseReceivedStock(ctx context.Context, id string, stock int, tx util.DatabaseTransaction) (int64, error) {
+	ub := sqlbuilder.NewUpdateBuilder()
+	ub.Update(warehouseTransferLineTable).
+		Set(
+			ub.Add("received_stock", stock),
+		).
+		Where(
+			ub.E("id", id),
+			ub.GTE("stock - received_stock", stock),
+		)
+	query, args := ub.Build()
+
+	db, err := util.GetExecer(w.db, tx)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when GetExecer on warehouseTransferLine.IncreaseReceivedStock").Wrap(err)
+	}
+
+	row, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, liberr.NewTracer("Error when ExecContext on warehouseTransferLine.IncreaseReceivedStock").Wrap(err)
+	}
+
+	rowAffected, _ := row.RowsAffected()
+	return rowAffected, nil
+}
@@ -0,0 +1,327 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"warehouse-service/internal/util"
+	"warehouse-service/module/warehouse/entity"
+	"warehouse-service/module/warehouse/internal/repository"
+	"warehouse-service/module/warehouse/testutil/fixtures"
+
+	"warehouse-service/internal/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	warehouseTransferLineInsertAttributes = []string{
+		"warehouse_transfer_id",
+		"product_id",
+		"stock",
+	}
+	warehouseTransferLineAllAttributes = []string{
+		"id",
+		"warehouse_transfer_id",
+		"product_id",
+		"stock",
+		"received_stock",
+		"created_at",
+		"updated_at",
+	}
+
+	warehouseTransferLineInsertColumnsStr = strings.Join(warehouseTransferLineInsertAttributes, ", ")
+	warehouseTransferLineAllColumnsStr    = strings.Join(warehouseTransferLineAllAttributes, ", ")
+)
+
+func TestWarehouseTransferLineRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO warehouse_transfer_lines (%s) VALUES (?, ?, ?)", warehouseTransferLineInsertColumnsStr)
+
+	type input struct {
+		ctx                   context.Context
+		warehouseTransferLine *entity.WarehouseTransferLine
+		tx                    util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.WarehouseTransferLine, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:                   context.TODO(),
+				warehouseTransferLine: fixtures.NewWarehouseTransferLine(fixtures.WarehouseTransferLine),
+				tx:                    nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseTransferLine.WarehouseTransferID, in.warehouseTransferLine.ProductID, in.warehouseTransferLine.Stock).
+					WillReturnResult(sqlmock.NewResult(12, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(warehouseTransferLine *entity.WarehouseTransferLine, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "12", warehouseTransferLine.ID)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:                   context.TODO(),
+				warehouseTransferLine: fixtures.NewWarehouseTransferLine(fixtures.WarehouseTransferLine),
+				tx:                    nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseTransferLine.WarehouseTransferID, in.warehouseTransferLine.ProductID, in.warehouseTransferLine.Stock).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(warehouseTransferLine *entity.WarehouseTransferLine, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:                   context.TODO(),
+				warehouseTransferLine: fixtures.NewWarehouseTransferLine(fixtures.WarehouseTransferLine),
+				tx:                    nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseTransferLine.WarehouseTransferID, in.warehouseTransferLine.ProductID, in.warehouseTransferLine.Stock).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(warehouseTransferLine *entity.WarehouseTransferLine, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:                   context.TODO(),
+				warehouseTransferLine: fixtures.NewWarehouseTransferLine(fixtures.WarehouseTransferLine),
+				tx:                    &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(warehouseTransferLine *entity.WarehouseTransferLine, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseTransferLineRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in.warehouseTransferLine, repo.Create(tc.in.ctx, tc.in.warehouseTransferLine, tc.in.tx))
+		})
+	}
+}
+
+func TestWarehouseTransferLineRepository_ListByWarehouseTransferIDs(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM warehouse_transfer_lines WHERE warehouse_transfer_id IN (?, ?) ORDER BY id ASC", warehouseTransferLineAllColumnsStr)
+	rows := warehouseTransferLineAllAttributes
+	dummyWarehouseTransferLine := fixtures.NewWarehouseTransferLine(fixtures.WarehouseTransferLine)
+
+	type input struct {
+		ctx                  context.Context
+		warehouseTransferIDs []string
+		tx                   util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.WarehouseTransferLine, error)
+	}{
+		{
+			name: "Success on Retrieve List By Warehouse Transfer IDs",
+			in: input{
+				ctx:                  context.TODO(),
+				warehouseTransferIDs: []string{"4", "5"},
+				tx:                   nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseTransferIDs[0], in.warehouseTransferIDs[1]).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWarehouseTransferLineRow(dummyWarehouseTransferLine)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WarehouseTransferLine, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.WarehouseTransferLine{dummyWarehouseTransferLine}, result)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:                  context.TODO(),
+				warehouseTransferIDs: []string{"4", "5"},
+				tx:                   nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetWarehouseTransferLineRow(dummyWarehouseTransferLine)
+				row[len(row)-1] = "invalid"
+
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseTransferIDs[0], in.warehouseTransferIDs[1]).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WarehouseTransferLine, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:                  context.TODO(),
+				warehouseTransferIDs: []string{"4", "5"},
+				tx:                   nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseTransferIDs[0], in.warehouseTransferIDs[1]).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result []*entity.WarehouseTransferLine, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:                  context.TODO(),
+				warehouseTransferIDs: []string{"4", "5"},
+				tx:                   &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result []*entity.WarehouseTransferLine, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseTransferLineRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByWarehouseTransferIDs(tc.in.ctx, tc.in.warehouseTransferIDs, tc.in.tx))
+		})
+	}
+}
+
+func TestWarehouseTransferLineRepository_IncreaseReceivedStock(t *testing.T) {
+	expectedQuery := "UPDATE warehouse_transfer_lines SET received_stock = received_stock + ? WHERE id = ? AND stock - received_stock >= ?"
+
+	type input struct {
+		ctx   context.Context
+		id    string
+		stock int
+		tx    util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Increase Received Stock",
+			in: input{
+				ctx:   context.TODO(),
+				id:    "9",
+				stock: 3,
+				tx:    nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stock, in.id, in.stock).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:   context.TODO(),
+				id:    "9",
+				stock: 3,
+				tx:    nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.stock, in.id, in.stock).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:   context.TODO(),
+				id:    "9",
+				stock: 3,
+				tx:    &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseTransferLineRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.IncreaseReceivedStock(tc.in.ctx, tc.in.id, tc.in.stock, tc.in.tx))
+		})
+	}
+}
@@ -0,0 +1,603 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/internal/util/libpagination"
+	"warehouse-service/module/warehouse/entity"
+	"warehouse-service/module/warehouse/internal/repository"
+	"warehouse-service/module/warehouse/testutil/fixtures"
+
+	"warehouse-service/internal/testutil"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	warehouseTransferInsertAttributes = []string{
+		"original_warehouse_id",
+		"destination_warehouse_id",
+		"state",
+		"created_by",
+	}
+	warehouseTransferAllAttributes = []string{
+		"id",
+		"original_warehouse_id",
+		"destination_warehouse_id",
+		"state",
+		"created_by",
+		"dispatched_at",
+		"received_at",
+		"cancelled_at",
+		"created_at",
+		"updated_at",
+	}
+
+	warehouseTransferInsertColumnsStr = strings.Join(warehouseTransferInsertAttributes, ", ")
+	warehouseTransferAllColumnsStr    = strings.Join(warehouseTransferAllAttributes, ", ")
+)
+
+func TestWarehouseTransferRepository_Create(t *testing.T) {
+	expectedQuery := fmt.Sprintf("INSERT INTO warehouse_transfers (%s) VALUES (?, ?, ?, ?)", warehouseTransferInsertColumnsStr)
+
+	type input struct {
+		ctx               context.Context
+		warehouseTransfer *entity.WarehouseTransfer
+		tx                util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.WarehouseTransfer, error)
+	}{
+		{
+			name: "Success on Create",
+			in: input{
+				ctx:               context.TODO(),
+				warehouseTransfer: fixtures.NewWarehouseTransfer(fixtures.WarehouseTransfer),
+				tx:                nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseTransfer.OriginalWarehouseID, in.warehouseTransfer.DestinationWarehouseID, entity.WarehouseTransferStateDraft, in.warehouseTransfer.CreatedBy).
+					WillReturnResult(sqlmock.NewResult(12, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(warehouseTransfer *entity.WarehouseTransfer, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, "12", warehouseTransfer.ID)
+				assert.Equal(t, entity.WarehouseTransferStateDraft, warehouseTransfer.State)
+			},
+		},
+		{
+			name: "Error on LastInsertId",
+			in: input{
+				ctx:               context.TODO(),
+				warehouseTransfer: fixtures.NewWarehouseTransfer(fixtures.WarehouseTransfer),
+				tx:                nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseTransfer.OriginalWarehouseID, in.warehouseTransfer.DestinationWarehouseID, entity.WarehouseTransferStateDraft, in.warehouseTransfer.CreatedBy).
+					WillReturnResult(sqlmock.NewErrorResult(errors.New("error")))
+			},
+			assertFn: func(warehouseTransfer *entity.WarehouseTransfer, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:               context.TODO(),
+				warehouseTransfer: fixtures.NewWarehouseTransfer(fixtures.WarehouseTransfer),
+				tx:                nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.warehouseTransfer.OriginalWarehouseID, in.warehouseTransfer.DestinationWarehouseID, entity.WarehouseTransferStateDraft, in.warehouseTransfer.CreatedBy).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(warehouseTransfer *entity.WarehouseTransfer, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:               context.TODO(),
+				warehouseTransfer: fixtures.NewWarehouseTransfer(fixtures.WarehouseTransfer),
+				tx:                &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(warehouseTransfer *entity.WarehouseTransfer, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseTransferRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(tc.in.warehouseTransfer, repo.Create(tc.in.ctx, tc.in.warehouseTransfer, tc.in.tx))
+		})
+	}
+}
+
+func TestWarehouseTransferRepository_GetByID(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM warehouse_transfers WHERE id = ?", warehouseTransferAllColumnsStr)
+	rows := warehouseTransferAllAttributes
+	dummyWarehouseTransfer := fixtures.NewWarehouseTransfer(fixtures.WarehouseTransfer)
+
+	type input struct {
+		ctx context.Context
+		id  string
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.WarehouseTransfer, error)
+	}{
+		{
+			name: "Success on GetByID",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyWarehouseTransfer.ID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWarehouseTransferRow(dummyWarehouseTransfer)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.WarehouseTransfer, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyWarehouseTransfer, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyWarehouseTransfer.ID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.WarehouseTransfer, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorWarehouseTransferNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyWarehouseTransfer.ID,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result *entity.WarehouseTransfer, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseTransferRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByID(tc.in.ctx, tc.in.id))
+		})
+	}
+}
+
+func TestWarehouseTransferRepository_GetByIDForUpdate(t *testing.T) {
+	expectedQuery := fmt.Sprintf("SELECT %s FROM warehouse_transfers WHERE id = ? FOR UPDATE", warehouseTransferAllColumnsStr)
+	rows := warehouseTransferAllAttributes
+	dummyWarehouseTransfer := fixtures.NewWarehouseTransfer(fixtures.WarehouseTransfer)
+
+	type input struct {
+		ctx context.Context
+		id  string
+		tx  util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(*entity.WarehouseTransfer, error)
+	}{
+		{
+			name: "Success on GetByIDForUpdate",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyWarehouseTransfer.ID,
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWarehouseTransferRow(dummyWarehouseTransfer)...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result *entity.WarehouseTransfer, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, dummyWarehouseTransfer, result)
+			},
+		},
+		{
+			name: "Error on Execute Query with Not Found Row",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyWarehouseTransfer.ID,
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sql.ErrNoRows)
+			},
+			assertFn: func(result *entity.WarehouseTransfer, err error) {
+				assert.NotNil(t, err)
+
+				berr, ok := err.(*liberr.BaseError)
+				assert.True(t, ok)
+
+				assert.Equal(t, entity.ErrorWarehouseTransferNotFound, berr.GetDetails()[0])
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyWarehouseTransfer.ID,
+				tx:  nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.id).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result *entity.WarehouseTransfer, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx: context.TODO(),
+				id:  dummyWarehouseTransfer.ID,
+				tx:  &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result *entity.WarehouseTransfer, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseTransferRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.GetByIDForUpdate(tc.in.ctx, tc.in.id, tc.in.tx))
+		})
+	}
+}
+
+func TestWarehouseTransferRepository_UpdateState(t *testing.T) {
+	at := time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	type input struct {
+		ctx       context.Context
+		id        string
+		fromState entity.WarehouseTransferState
+		toState   entity.WarehouseTransferState
+		at        time.Time
+		tx        util.DatabaseTransaction
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func(int64, error)
+	}{
+		{
+			name: "Success on Update with State Time",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "4",
+				fromState: entity.WarehouseTransferStateDraft,
+				toState:   entity.WarehouseTransferStateDispatched,
+				at:        at,
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := "UPDATE warehouse_transfers SET state = ?, dispatched_at = ? WHERE id = ? AND state = ?"
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, in.at, in.id, in.fromState).
+					WillReturnResult(sqlmock.NewResult(0, 1)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(1), result)
+			},
+		},
+		{
+			name: "Success on Update without State Time",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "4",
+				fromState: entity.WarehouseTransferStateDispatched,
+				toState:   entity.WarehouseTransferStateDraft,
+				at:        at,
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := "UPDATE warehouse_transfers SET state = ? WHERE id = ? AND state = ?"
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, in.id, in.fromState).
+					WillReturnResult(sqlmock.NewResult(0, 0)).
+					WillReturnError(nil)
+			},
+			assertFn: func(result int64, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, int64(0), result)
+			},
+		},
+		{
+			name: "Error on Execute Query",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "4",
+				fromState: entity.WarehouseTransferStateDispatched,
+				toState:   entity.WarehouseTransferStateReceived,
+				at:        at,
+				tx:        nil,
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := "UPDATE warehouse_transfers SET state = ?, received_at = ? WHERE id = ? AND state = ?"
+				dependency.MockedSQL.
+					ExpectExec(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.toState, in.at, in.id, in.fromState).
+					WillReturnError(errors.New("error"))
+			},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+		{
+			name: "Error on GetExecer",
+			in: input{
+				ctx:       context.TODO(),
+				id:        "4",
+				fromState: entity.WarehouseTransferStateDraft,
+				toState:   entity.WarehouseTransferStateCancelled,
+				at:        at,
+				tx:        &testutil.UnknownDatabaseTransaction{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {},
+			assertFn: func(result int64, err error) {
+				assert.NotNil(t, err)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseTransferRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.UpdateState(tc.in.ctx, tc.in.id, tc.in.fromState, tc.in.toState, tc.in.at, tc.in.tx))
+		})
+	}
+}
+
+func TestWarehouseTransferRepository_ListByParams(t *testing.T) {
+	columns := warehouseTransferAllColumnsStr
+	rows := warehouseTransferAllAttributes
+	dummyWarehouseTransfer := fixtures.NewWarehouseTransfer(fixtures.WarehouseTransfer)
+
+	type input struct {
+		ctx    context.Context
+		params *entity.ListWarehouseTransferByParams
+	}
+
+	testCases := []struct {
+		name           string
+		in             input
+		mockDependency func(*testutil.RepositoryDependency, input)
+		assertFn       func([]*entity.WarehouseTransfer, *libpagination.OffsetPagination, error)
+	}{
+		{
+			name: "Success on Retrieve List By Params",
+			in: input{
+				ctx: context.TODO(),
+				params: &entity.ListWarehouseTransferByParams{
+					Offset:                 5,
+					Limit:                  20,
+					OriginalWarehouseID:    "1",
+					DestinationWarehouseID: "2",
+					States:                 []entity.WarehouseTransferState{entity.WarehouseTransferStateDraft, entity.WarehouseTransferStateDispatched},
+				},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM warehouse_transfers WHERE original_warehouse_id = ? AND destination_warehouse_id = ? AND state IN (?, ?) ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.OriginalWarehouseID, in.params.DestinationWarehouseID, entity.WarehouseTransferStateDraft, entity.WarehouseTransferStateDispatched, in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWarehouseTransferRow(dummyWarehouseTransfer)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM warehouse_transfers WHERE original_warehouse_id = ? AND destination_warehouse_id = ? AND state IN (?, ?)"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WithArgs(in.params.OriginalWarehouseID, in.params.DestinationWarehouseID, entity.WarehouseTransferStateDraft, entity.WarehouseTransferStateDispatched).
+					WillReturnRows(sqlmock.NewRows([]string{"total"}).AddRow(100)).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WarehouseTransfer, pagination *libpagination.OffsetPagination, err error) {
+				assert.Nil(t, err)
+				assert.Equal(t, []*entity.WarehouseTransfer{dummyWarehouseTransfer}, result)
+				assert.Equal(t, &libpagination.OffsetPagination{
+					Offset: 5,
+					Limit:  20,
+					Total:  100,
+				}, pagination)
+			},
+		},
+		{
+			name: "Error on Scan Count Query",
+			in: input{
+				ctx:    context.TODO(),
+				params: &entity.ListWarehouseTransferByParams{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM warehouse_transfers ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(fixtures.GetWarehouseTransferRow(dummyWarehouseTransfer)...),
+					).RowsWillBeClosed()
+
+				expectedCountQuery := "SELECT COUNT(id) AS total FROM warehouse_transfers"
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedCountQuery)).
+					WillReturnError(sqlmock.ErrCancelled).
+					RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WarehouseTransfer, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+		{
+			name: "Error on StructScan",
+			in: input{
+				ctx:    context.TODO(),
+				params: &entity.ListWarehouseTransferByParams{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				row := fixtures.GetWarehouseTransferRow(dummyWarehouseTransfer)
+				row[len(row)-1] = "invalid"
+
+				expectedQuery := fmt.Sprintf("SELECT %s FROM warehouse_transfers ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.Limit, in.params.Offset).
+					WillReturnRows(
+						sqlmock.
+							NewRows(rows).
+							AddRow(row...),
+					).RowsWillBeClosed()
+			},
+			assertFn: func(result []*entity.WarehouseTransfer, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+		{
+			name: "Error on QueryxContext",
+			in: input{
+				ctx:    context.TODO(),
+				params: &entity.ListWarehouseTransferByParams{},
+			},
+			mockDependency: func(dependency *testutil.RepositoryDependency, in input) {
+				expectedQuery := fmt.Sprintf("SELECT %s FROM warehouse_transfers ORDER BY id DESC LIMIT ? OFFSET ?", columns)
+				dependency.MockedSQL.
+					ExpectQuery(regexp.QuoteMeta(expectedQuery)).
+					WithArgs(in.params.Limit, in.params.Offset).
+					WillReturnError(sqlmock.ErrCancelled)
+			},
+			assertFn: func(result []*entity.WarehouseTransfer, pagination *libpagination.OffsetPagination, err error) {
+				assert.NotNil(t, err)
+				assert.Nil(t, result)
+				assert.Nil(t, pagination)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repositoryDependency := testutil.NewRepositoryDependency()
+			repo := repository.NewWarehouseTransferRepository(repositoryDependency.MockedDB)
+
+			defer ctrl.Finish()
+
+			tc.mockDependency(&repositoryDependency, tc.in)
+			tc.assertFn(repo.ListByParams(tc.in.ctx, tc.in.params))
+		})
+	}
+}
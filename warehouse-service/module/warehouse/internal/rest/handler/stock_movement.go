@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/internal/util/libpagination"
+	"warehouse-service/internal/util/librest"
+	"warehouse-service/module/warehouse/entity"
+)
+
+const (
+	MinimalPageNum  = 1
+	MinimalPageSize = 1
+
+	DefaultValueStockMovementListPageNum  = 1
+	DefaultValueStockMovementListPageSize = 10
+)
+
+func (ws *WarehouseStockHandler) ListStockMovement(w http.ResponseWriter, r *http.Request) error {
+	// Query parameters
+	qparams := r.URL.Query()
+
+	params := &entity.ListStockMovementByParams{
+		WarehouseID: qparams.Get("warehouse_id"),
+		ProductID:   qparams.Get("product_id"),
+		Page:        util.ConvertStringToIntWithDefault(qparams.Get("page_num"), DefaultValueStockMovementListPageNum),
+		Limit:       util.ConvertStringToIntWithDefault(qparams.Get("page_size"), DefaultValueStockMovementListPageSize),
+	}
+	if params.Page < MinimalPageNum {
+		params.Page = DefaultValueStockMovementListPageNum
+	}
+	if params.Limit < MinimalPageSize {
+		params.Limit = DefaultValueStockMovementListPageSize
+	}
+
+	var err error
+	if params.CreatedAtFrom, err = parseTimeParameter(qparams.Get("created_at_from")); err != nil {
+		return err
+	}
+	if params.CreatedAtTo, err = parseTimeParameter(qparams.Get("created_at_to")); err != nil {
+		return err
+	}
+
+	params.Offset = libpagination.Offset(params.Page, params.Limit)
+
+	stockMovements, pagination, err := ws.warehouseStockUsecase.ListStockMovement(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ListStockMovementResponse{
+		StockMovements: stockMovements,
+		Meta: &entity.ListMeta{
+			Meta: &entity.Meta{
+				HttpStatusCode: code,
+			},
+			PageNum:   pagination.PageNum(),
+			PageSize:  pagination.PageSize(),
+			PageTotal: pagination.PageTotal(),
+		},
+	}, code)
+	return nil
+}
+
+func parseTimeParameter(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, liberr.NewBaseError(entity.ErrorInvalidParameter)
+	}
+
+	return &t, nil
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/internal/util/librest"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/gorilla/mux"
+)
+
+func (ws *WarehouseStockHandler) ReserveStock(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.StockReservationRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+
+	stockReservations, err := ws.warehouseStockUsecase.ReserveStock(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.StockReservationResponse{
+		Message:           "Success reserve stock",
+		StockReservations: stockReservations,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (ws *WarehouseStockHandler) CommitStockReservation(w http.ResponseWriter, r *http.Request) error {
+	params, err := parseStockReservationActionRequest(r)
+	if err != nil {
+		return err
+	}
+
+	err = ws.warehouseStockUsecase.CommitStockReservation(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetMessageResponse{
+		Message: "Success commit stock reservation",
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (ws *WarehouseStockHandler) ReleaseStockReservation(w http.ResponseWriter, r *http.Request) error {
+	params, err := parseStockReservationActionRequest(r)
+	if err != nil {
+		return err
+	}
+
+	err = ws.warehouseStockUsecase.ReleaseStockReservation(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetMessageResponse{
+		Message: "Success release stock reservation",
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (ws *WarehouseStockHandler) ExtendStockReservation(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.StockReservationExtendRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.ReservationID = mux.Vars(r)["reservation_id"]
+
+	err := ws.warehouseStockUsecase.ExtendStockReservation(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.GetMessageResponse{
+		Message: "Success extend stock reservation",
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+// parseStockReservationActionRequest read the reservation ID from path, the body is optional
+func parseStockReservationActionRequest(r *http.Request) (*entity.StockReservationActionRequest, error) {
+	params := new(entity.StockReservationActionRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil && err != io.EOF {
+		return nil, liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+
+	params.ReservationID = mux.Vars(r)["reservation_id"]
+	return params, nil
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"context"
+	"warehouse-service/internal/util/libpagination"
 	"warehouse-service/module/warehouse/entity"
 )
 
@@ -15,4 +16,15 @@ type WarehouseStockUsecase interface {
 	ActiveStock(ctx context.Context, params *entity.ListWarehouseStockByParams) ([]*entity.Warehouse, []*entity.WarehouseStock, error)
 	AdjustmentStock(ctx context.Context, params *entity.WarehouseStockAdjustmentRequest) error
 	TransferStock(ctx context.Context, params *entity.WarehouseStockTransferRequest) error
+	ListStockMovement(ctx context.Context, params *entity.ListStockMovementByParams) ([]*entity.StockMovement, *libpagination.OffsetPagination, error)
+	ReserveStock(ctx context.Context, params *entity.StockReservationRequest) ([]*entity.StockReservation, error)
+	CommitStockReservation(ctx context.Context, params *entity.StockReservationActionRequest) error
+	ReleaseStockReservation(ctx context.Context, params *entity.StockReservationActionRequest) error
+	ExtendStockReservation(ctx context.Context, params *entity.StockReservationExtendRequest) error
+	CreateWarehouseTransfer(ctx context.Context, params *entity.CreateWarehouseTransferRequest) (*entity.WarehouseTransfer, error)
+	DispatchWarehouseTransfer(ctx context.Context, params *entity.WarehouseTransferActionRequest) (*entity.WarehouseTransfer, error)
+	ReceiveWarehouseTransfer(ctx context.Context, params *entity.ReceiveWarehouseTransferRequest) (*entity.WarehouseTransfer, error)
+	CancelWarehouseTransfer(ctx context.Context, params *entity.WarehouseTransferActionRequest) (*entity.WarehouseTransfer, error)
+	GetWarehouseTransfer(ctx context.Context, id string) (*entity.WarehouseTransfer, error)
+	ListWarehouseTransfer(ctx context.Context, params *entity.ListWarehouseTransferByParams) ([]*entity.WarehouseTransfer, *libpagination.OffsetPagination, error)
 }
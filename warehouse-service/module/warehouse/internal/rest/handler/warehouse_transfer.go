@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/internal/util/libpagination"
+	"warehouse-service/internal/util/librest"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	DefaultValueWarehouseTransferListPageNum  = 1
+	DefaultValueWarehouseTransferListPageSize = 10
+)
+
+func (ws *WarehouseStockHandler) CreateWarehouseTransfer(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.CreateWarehouseTransferRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+
+	warehouseTransfer, err := ws.warehouseStockUsecase.CreateWarehouseTransfer(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusCreated
+	librest.WriteHTTPResponse(w, entity.WarehouseTransferResponse{
+		Message:           "Success create warehouse transfer",
+		WarehouseTransfer: warehouseTransfer,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (ws *WarehouseStockHandler) DispatchWarehouseTransfer(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.WarehouseTransferActionRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil && err != io.EOF {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.ID = mux.Vars(r)["id"]
+
+	warehouseTransfer, err := ws.warehouseStockUsecase.DispatchWarehouseTransfer(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.WarehouseTransferResponse{
+		Message:           "Success dispatch warehouse transfer",
+		WarehouseTransfer: warehouseTransfer,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (ws *WarehouseStockHandler) ReceiveWarehouseTransfer(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.ReceiveWarehouseTransferRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.ID = mux.Vars(r)["id"]
+
+	warehouseTransfer, err := ws.warehouseStockUsecase.ReceiveWarehouseTransfer(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.WarehouseTransferResponse{
+		Message:           "Success receive warehouse transfer",
+		WarehouseTransfer: warehouseTransfer,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (ws *WarehouseStockHandler) CancelWarehouseTransfer(w http.ResponseWriter, r *http.Request) error {
+	params := new(entity.WarehouseTransferActionRequest)
+	if err := json.NewDecoder(r.Body).Decode(params); err != nil && err != io.EOF {
+		return liberr.NewBaseError(entity.ErrorInvalidBodyJSON)
+	}
+	params.ID = mux.Vars(r)["id"]
+
+	warehouseTransfer, err := ws.warehouseStockUsecase.CancelWarehouseTransfer(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.WarehouseTransferResponse{
+		Message:           "Success cancel warehouse transfer",
+		WarehouseTransfer: warehouseTransfer,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (ws *WarehouseStockHandler) GetWarehouseTransfer(w http.ResponseWriter, r *http.Request) error {
+	warehouseTransfer, err := ws.warehouseStockUsecase.GetWarehouseTransfer(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.WarehouseTransferResponse{
+		WarehouseTransfer: warehouseTransfer,
+		Meta: &entity.Meta{
+			HttpStatusCode: code,
+		},
+	}, code)
+	return nil
+}
+
+func (ws *WarehouseStockHandler) ListWarehouseTransfer(w http.ResponseWriter, r *http.Request) error {
+	// Query parameters
+	qparams := r.URL.Query()
+
+	params := &entity.ListWarehouseTransferByParams{
+		OriginalWarehouseID:    qparams.Get("original_warehouse_id"),
+		DestinationWarehouseID: qparams.Get("destination_warehouse_id"),
+		Page:                   util.ConvertStringToIntWithDefault(qparams.Get("page_num"), DefaultValueWarehouseTransferListPageNum),
+		Limit:                  util.ConvertStringToIntWithDefault(qparams.Get("page_size"), DefaultValueWarehouseTransferListPageSize),
+	}
+	if params.Page < MinimalPageNum {
+		params.Page = DefaultValueWarehouseTransferListPageNum
+	}
+	if params.Limit < MinimalPageSize {
+		params.Limit = DefaultValueWarehouseTransferListPageSize
+	}
+
+	for _, s := range qparams["state"] {
+		state, err := strconv.Atoi(s)
+		if err != nil {
+			return liberr.NewBaseError(entity.ErrorInvalidParameter)
+		}
+		params.States = append(params.States, entity.WarehouseTransferState(state))
+	}
+
+	params.Offset = libpagination.Offset(params.Page, params.Limit)
+
+	warehouseTransfers, pagination, err := ws.warehouseStockUsecase.ListWarehouseTransfer(r.Context(), params)
+	if err != nil {
+		return err
+	}
+
+	code := http.StatusOK
+	librest.WriteHTTPResponse(w, entity.ListWarehouseTransferResponse{
+		WarehouseTransfers: warehouseTransfers,
+		Meta: &entity.ListMeta{
+			Meta: &entity.Meta{
+				HttpStatusCode: code,
+			},
+			PageNum:   pagination.PageNum(),
+			PageSize:  pagination.PageSize(),
+			PageTotal: pagination.PageTotal(),
+		},
+	}, code)
+	return nil
+}
@@ -13,6 +13,12 @@ var (
 		entity.ErrorCodeWarehouseNotFound:              http.StatusNotFound,
 		entity.ErrorCodeWarehouseStockNotFound:         http.StatusNotFound,
 		entity.ErrorCodeWarehouseStockAdjustmentFailed: http.StatusConflict,
+		entity.ErrorCodeStockAdjustmentConflicted:      http.StatusConflict,
+		entity.ErrorCodeStockReservationNotFound:       http.StatusNotFound,
+		entity.ErrorCodeStockReservationConflicted:     http.StatusConflict,
+		entity.ErrorCodeStockReservationInvalidState:   http.StatusConflict,
+		entity.ErrorCodeWarehouseTransferNotFound:      http.StatusNotFound,
+		entity.ErrorCodeWarehouseTransferInvalidState:  http.StatusConflict,
 	}
 )
 
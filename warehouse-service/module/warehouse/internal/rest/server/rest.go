@@ -33,6 +33,17 @@ func RegisterRESTHandler(serverMux *mux.Router, cfg *ServerConfig) error {
 	registerInternalHandler(serverMux, cfg, http.MethodGet, "/active-stocks", warehouseStock.ActiveStock)
 	registerInternalHandler(serverMux, cfg, http.MethodPost, "/adjustment-stocks", warehouseStock.AdjustmentStock)
 	registerInternalHandler(serverMux, cfg, http.MethodPost, "/transfer-stocks", warehouseStock.TransferStock)
+	registerInternalHandler(serverMux, cfg, http.MethodGet, "/stock-movements", warehouseStock.ListStockMovement)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/stock-reservations", warehouseStock.ReserveStock)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/stock-reservations/{reservation_id}/commit", warehouseStock.CommitStockReservation)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/stock-reservations/{reservation_id}/release", warehouseStock.ReleaseStockReservation)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/stock-reservations/{reservation_id}/extend", warehouseStock.ExtendStockReservation)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/warehouse-transfers", warehouseStock.CreateWarehouseTransfer)
+	registerInternalHandler(serverMux, cfg, http.MethodGet, "/warehouse-transfers", warehouseStock.ListWarehouseTransfer)
+	registerInternalHandler(serverMux, cfg, http.MethodGet, "/warehouse-transfers/{id}", warehouseStock.GetWarehouseTransfer)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/warehouse-transfers/{id}/dispatch", warehouseStock.DispatchWarehouseTransfer)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/warehouse-transfers/{id}/receive", warehouseStock.ReceiveWarehouseTransfer)
+	registerInternalHandler(serverMux, cfg, http.MethodPost, "/warehouse-transfers/{id}/cancel", warehouseStock.CancelWarehouseTransfer)
 
 	return nil
 }
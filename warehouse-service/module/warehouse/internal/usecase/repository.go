@@ -2,7 +2,9 @@ package usecase
 
 import (
 	"context"
+	"time"
 	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/libpagination"
 	"warehouse-service/module/warehouse/entity"
 )
 
@@ -17,6 +19,42 @@ type WarehouseStockRepository interface {
 	Create(ctx context.Context, warehouseStock *entity.WarehouseStock, tx util.DatabaseTransaction) error
 	IncreaseStock(ctx context.Context, params entity.WarehouseStockAdjustmentParams, tx util.DatabaseTransaction) (int64, error)
 	DecreaseStock(ctx context.Context, params entity.WarehouseStockAdjustmentParams, tx util.DatabaseTransaction) (int64, error)
+	ReserveStock(ctx context.Context, params entity.WarehouseStockAdjustmentParams, tx util.DatabaseTransaction) (int64, error)
+	ReleaseReservedStock(ctx context.Context, params entity.WarehouseStockAdjustmentParams, tx util.DatabaseTransaction) (int64, error)
+	CommitReservedStock(ctx context.Context, params entity.WarehouseStockAdjustmentParams, tx util.DatabaseTransaction) (int64, error)
+	GetByWarehouseIDAndProductID(ctx context.Context, warehouseID string, productID string, tx util.DatabaseTransaction) (*entity.WarehouseStock, error)
 	ListByWarehouseIDsAndProductIDs(ctx context.Context, warehouseIDs []string, productIDs []string) ([]*entity.WarehouseStock, error)
 	ListActiveByProductIDs(ctx context.Context, productIDs []string) ([]*entity.WarehouseStock, error)
 }
+
+type StockAdjustmentRepository interface {
+	Create(ctx context.Context, stockAdjustment *entity.StockAdjustment, tx util.DatabaseTransaction) error
+	GetByAdjustmentID(ctx context.Context, adjustmentID string) (*entity.StockAdjustment, error)
+}
+
+type StockMovementRepository interface {
+	Create(ctx context.Context, stockMovement *entity.StockMovement, tx util.DatabaseTransaction) error
+	ListByParams(ctx context.Context, params *entity.ListStockMovementByParams) ([]*entity.StockMovement, *libpagination.OffsetPagination, error)
+}
+
+type StockReservationRepository interface {
+	Create(ctx context.Context, stockReservation *entity.StockReservation, tx util.DatabaseTransaction) error
+	ListByReservationID(ctx context.Context, reservationID string) ([]*entity.StockReservation, error)
+	ListExpiredReservationIDs(ctx context.Context, now time.Time, limit int) ([]string, error)
+	UpdateState(ctx context.Context, reservationID string, fromState, toState entity.StockReservationState, tx util.DatabaseTransaction) (int64, error)
+	UpdateExpiredAt(ctx context.Context, reservationID string, expiredAt time.Time, tx util.DatabaseTransaction) (int64, error)
+}
+
+type WarehouseTransferRepository interface {
+	Create(ctx context.Context, warehouseTransfer *entity.WarehouseTransfer, tx util.DatabaseTransaction) error
+	GetByID(ctx context.Context, id string) (*entity.WarehouseTransfer, error)
+	GetByIDForUpdate(ctx context.Context, id string, tx util.DatabaseTransaction) (*entity.WarehouseTransfer, error)
+	UpdateState(ctx context.Context, id string, fromState, toState entity.WarehouseTransferState, at time.Time, tx util.DatabaseTransaction) (int64, error)
+	ListByParams(ctx context.Context, params *entity.ListWarehouseTransferByParams) ([]*entity.WarehouseTransfer, *libpagination.OffsetPagination, error)
+}
+
+type WarehouseTransferLineRepository interface {
+	Create(ctx context.Context, warehouseTransferLine *entity.WarehouseTransferLine, tx util.DatabaseTransaction) error
+	ListByWarehouseTransferIDs(ctx context.Context, warehouseTransferIDs []string, tx util.DatabaseTransaction) ([]*entity.WarehouseTransferLine, error)
+	IncreaseReceivedStock(ctx context.Context, id string, stock int, tx util.DatabaseTransaction) (int64, error)
+}
@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/internal/util/libpagination"
+	"warehouse-service/module/warehouse/entity"
+)
+
+func (ws *WarehouseStockUsecase) ListStockMovement(ctx context.Context, params *entity.ListStockMovementByParams) ([]*entity.StockMovement, *libpagination.OffsetPagination, error) {
+	params.Offset = libpagination.Offset(params.Page, params.Limit)
+
+	stockMovements, pagination, err := ws.repos.StockMovementRepo.ListByParams(ctx, params)
+	if err != nil {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	return stockMovements, pagination, nil
+}
@@ -0,0 +1,428 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/internal/util/libvalidate"
+	"warehouse-service/module/warehouse/entity"
+
+	"go.uber.org/zap"
+)
+
+const (
+	expiredStockReservationBatchSize = 100
+	expiredStockReservationActor     = "system"
+)
+
+// ReserveStock hold the available stock for the reservation ID without taking it out of the warehouse,
+// a replay of the same reservation returns the existing reservation lines
+func (ws *WarehouseStockUsecase) ReserveStock(ctx context.Context, params *entity.StockReservationRequest) ([]*entity.StockReservation, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	stockReservations := mergeStockReservations(params)
+
+	existing, err := ws.replayStockReservation(ctx, params.ReservationID, stockReservations)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+
+	// Validation available stock, reserved stock is represented as negative adjustment
+	stockAdjustments := make([]*entity.WarehouseStockAdjustment, 0, len(stockReservations))
+	for _, sr := range stockReservations {
+		stockAdjustments = append(stockAdjustments, &entity.WarehouseStockAdjustment{
+			WarehouseID: sr.WarehouseID,
+			ProductID:   sr.ProductID,
+			Stock:       -1 * sr.Stock,
+		})
+	}
+
+	if err := ws.stockAdjustmentValidation(ctx, stockAdjustments); err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	err = ws.reserveStock(ctx, stockReservations, params.Actor)
+	if liberr.ErrorCodeEquals(err, entity.ErrorCodeStockReservationDuplicated) {
+		// Same reservation ID is reserved concurrently by another request
+		existing, err = ws.replayStockReservation(ctx, params.ReservationID, stockReservations)
+		if err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	return stockReservations, nil
+}
+
+// mergeStockReservations build a single reservation line per warehouse & product
+func mergeStockReservations(params *entity.StockReservationRequest) []*entity.StockReservation {
+	stockReservations := []*entity.StockReservation{}
+	stockReservationsMap := make(map[string]*entity.StockReservation)
+
+	for _, s := range params.WarehouseStocks {
+		key := fmt.Sprintf("%s-%s", s.WarehouseID, s.ProductID)
+		if sr, exists := stockReservationsMap[key]; exists {
+			sr.Stock += s.Stock
+			continue
+		}
+
+		sr := &entity.StockReservation{
+			ReservationID: params.ReservationID,
+			WarehouseID:   s.WarehouseID,
+			ProductID:     s.ProductID,
+			Stock:         s.Stock,
+			ExpiredAt:     params.ExpiredAt,
+		}
+		stockReservationsMap[key] = sr
+		stockReservations = append(stockReservations, sr)
+	}
+
+	return stockReservations
+}
+
+// replayStockReservation return the existing reservation lines when the reservation ID has been reserved with the same lines
+func (ws *WarehouseStockUsecase) replayStockReservation(ctx context.Context, reservationID string, stockReservations []*entity.StockReservation) ([]*entity.StockReservation, error) {
+	existing, err := ws.repos.StockReservationRepo.ListByReservationID(ctx, reservationID)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	if len(existing) == 0 {
+		return nil, nil
+	}
+
+	if len(existing) != len(stockReservations) {
+		return nil, liberr.ResolveError(entity.ErrorStockReservationConflicted)
+	}
+
+	existingStockMap := make(map[string]int, len(existing))
+	for _, e := range existing {
+		existingStockMap[fmt.Sprintf("%s-%s", e.WarehouseID, e.ProductID)] = e.Stock
+	}
+
+	for _, sr := range stockReservations {
+		if stock, exists := existingStockMap[fmt.Sprintf("%s-%s", sr.WarehouseID, sr.ProductID)]; !exists || stock != sr.Stock {
+			return nil, liberr.ResolveError(entity.ErrorStockReservationConflicted)
+		}
+	}
+
+	return existing, nil
+}
+
+func (ws *WarehouseStockUsecase) reserveStock(ctx context.Context, stockReservations []*entity.StockReservation, actor string) error {
+	tx, err := ws.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	var affected int64
+	for _, sr := range stockReservations {
+		// Record the reservation first, concurrent request with the same ID waits on the unique index
+		err = ws.repos.StockReservationRepo.Create(ctx, sr, tx)
+		if err != nil {
+			return err
+		}
+
+		affected, err = ws.repos.WarehouseStockRepo.ReserveStock(ctx, entity.WarehouseStockAdjustmentParams{
+			WarehouseID: sr.WarehouseID,
+			ProductID:   sr.ProductID,
+			Stock:       uint32(sr.Stock),
+		}, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+		if affected <= 0 {
+			err = liberr.ResolveError(entity.ErrorWarehouseStockAdjustmentOutOfStock)
+			return err
+		}
+
+		// Record the movement with the resulting available quantity, the on-hand stock is untouched
+		var warehouseStock *entity.WarehouseStock
+		warehouseStock, err = ws.repos.WarehouseStockRepo.GetByWarehouseIDAndProductID(ctx, sr.WarehouseID, sr.ProductID, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+
+		err = ws.repos.StockMovementRepo.Create(ctx, &entity.StockMovement{
+			WarehouseID: sr.WarehouseID,
+			ProductID:   sr.ProductID,
+			Delta:       -1 * sr.Stock,
+			Quantity:    warehouseStock.Available,
+			Reason:      entity.StockMovementReasonOrderReservation,
+			ReferenceID: sr.ReservationID,
+			Actor:       actor,
+		}, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	return nil
+}
+
+// CommitStockReservation take the reserved stock out of the warehouse once the order is shipped
+func (ws *WarehouseStockUsecase) CommitStockReservation(ctx context.Context, params *entity.StockReservationActionRequest) error {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	stockReservations, state, err := ws.getStockReservation(ctx, params.ReservationID)
+	if err != nil {
+		return err
+	}
+
+	switch state {
+	case entity.StockReservationStateCommitted:
+		return nil
+	case entity.StockReservationStateReleased:
+		return liberr.ResolveError(entity.ErrorStockReservationInvalidState)
+	}
+
+	tx, err := ws.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	// State guard, concurrent commit or release of the same reservation affect no rows
+	affected, err := ws.repos.StockReservationRepo.UpdateState(ctx, params.ReservationID, entity.StockReservationStateReserved, entity.StockReservationStateCommitted, tx)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+	if affected <= 0 {
+		err = liberr.ResolveError(entity.ErrorStockReservationInvalidState)
+		return err
+	}
+
+	for _, sr := range stockReservations {
+		affected, err = ws.repos.WarehouseStockRepo.CommitReservedStock(ctx, entity.WarehouseStockAdjustmentParams{
+			WarehouseID: sr.WarehouseID,
+			ProductID:   sr.ProductID,
+			Stock:       uint32(sr.Stock),
+		}, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+		if affected <= 0 {
+			err = liberr.ResolveError(entity.ErrorWarehouseStockAdjustmentFailed)
+			return err
+		}
+
+		// Record the movement with the resulting quantity
+		var warehouseStock *entity.WarehouseStock
+		warehouseStock, err = ws.repos.WarehouseStockRepo.GetByWarehouseIDAndProductID(ctx, sr.WarehouseID, sr.ProductID, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+
+		err = ws.repos.StockMovementRepo.Create(ctx, &entity.StockMovement{
+			WarehouseID: sr.WarehouseID,
+			ProductID:   sr.ProductID,
+			Delta:       -1 * sr.Stock,
+			Quantity:    warehouseStock.Stock,
+			Reason:      entity.StockMovementReasonOrderShipment,
+			ReferenceID: params.ReservationID,
+			Actor:       params.Actor,
+		}, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	return nil
+}
+
+// ReleaseStockReservation return the reserved stock to the available stock, releasing a released reservation is no-op
+func (ws *WarehouseStockUsecase) ReleaseStockReservation(ctx context.Context, params *entity.StockReservationActionRequest) error {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	stockReservations, state, err := ws.getStockReservation(ctx, params.ReservationID)
+	if err != nil {
+		return err
+	}
+
+	switch state {
+	case entity.StockReservationStateReleased:
+		return nil
+	case entity.StockReservationStateCommitted:
+		return liberr.ResolveError(entity.ErrorStockReservationInvalidState)
+	}
+
+	tx, err := ws.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	// State guard, concurrent commit or release of the same reservation affect no rows
+	affected, err := ws.repos.StockReservationRepo.UpdateState(ctx, params.ReservationID, entity.StockReservationStateReserved, entity.StockReservationStateReleased, tx)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+	if affected <= 0 {
+		err = liberr.ResolveError(entity.ErrorStockReservationInvalidState)
+		return err
+	}
+
+	for _, sr := range stockReservations {
+		affected, err = ws.repos.WarehouseStockRepo.ReleaseReservedStock(ctx, entity.WarehouseStockAdjustmentParams{
+			WarehouseID: sr.WarehouseID,
+			ProductID:   sr.ProductID,
+			Stock:       uint32(sr.Stock),
+		}, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+		if affected <= 0 {
+			err = liberr.ResolveError(entity.ErrorWarehouseStockAdjustmentFailed)
+			return err
+		}
+
+		// Record the movement with the resulting available quantity, the on-hand stock is untouched
+		var warehouseStock *entity.WarehouseStock
+		warehouseStock, err = ws.repos.WarehouseStockRepo.GetByWarehouseIDAndProductID(ctx, sr.WarehouseID, sr.ProductID, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+
+		err = ws.repos.StockMovementRepo.Create(ctx, &entity.StockMovement{
+			WarehouseID: sr.WarehouseID,
+			ProductID:   sr.ProductID,
+			Delta:       sr.Stock,
+			Quantity:    warehouseStock.Available,
+			Reason:      entity.StockMovementReasonOrderRelease,
+			ReferenceID: params.ReservationID,
+			Actor:       params.Actor,
+		}, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	return nil
+}
+
+// ExtendStockReservation move the expiry of the reservation which is still holding stock, so the stock
+// of the paid order is not released by the expiry. Extending a committed or released reservation is rejected
+func (ws *WarehouseStockUsecase) ExtendStockReservation(ctx context.Context, params *entity.StockReservationExtendRequest) error {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	_, state, err := ws.getStockReservation(ctx, params.ReservationID)
+	if err != nil {
+		return err
+	}
+
+	if state != entity.StockReservationStateReserved {
+		return liberr.ResolveError(entity.ErrorStockReservationInvalidState)
+	}
+
+	// State guard, the reservation committed or released concurrently is not extended
+	affected, err := ws.repos.StockReservationRepo.UpdateExpiredAt(ctx, params.ReservationID, params.ExpiredAt.UTC(), nil)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	// No rows are affected either when the expiry is unchanged, so the state is checked again
+	if affected <= 0 {
+		_, state, err = ws.getStockReservation(ctx, params.ReservationID)
+		if err != nil {
+			return err
+		}
+		if state != entity.StockReservationStateReserved {
+			return liberr.ResolveError(entity.ErrorStockReservationInvalidState)
+		}
+	}
+
+	return nil
+}
+
+// getStockReservation retrieve the reservation lines along with the state of the reservation
+func (ws *WarehouseStockUsecase) getStockReservation(ctx context.Context, reservationID string) ([]*entity.StockReservation, entity.StockReservationState, error) {
+	stockReservations, err := ws.repos.StockReservationRepo.ListByReservationID(ctx, reservationID)
+	if err != nil {
+		return nil, entity.StockReservationStateUnspecified, liberr.ResolveError(err)
+	}
+
+	if len(stockReservations) == 0 {
+		return nil, entity.StockReservationStateUnspecified, liberr.ResolveError(entity.ErrorStockReservationNotFound)
+	}
+
+	// Every line of the reservation is moved together
+	return stockReservations, stockReservations[0].State, nil
+}
+
+// ExecuteExpiredReservation release the reservations which are still holding stock after the expiry
+func (ws *WarehouseStockUsecase) ExecuteExpiredReservation(ctx context.Context) error {
+	logFields := []zap.Field{
+		zap.String("function", "ExecuteExpiredReservation"),
+	}
+
+	reservationIDs, err := ws.repos.StockReservationRepo.ListExpiredReservationIDs(ctx, util.NowUTCWithoutNanoSecond(), expiredStockReservationBatchSize)
+	if err != nil {
+		return err
+	}
+
+	released := 0
+	for _, reservationID := range reservationIDs {
+		err := ws.ReleaseStockReservation(ctx, &entity.StockReservationActionRequest{
+			ReservationID: reservationID,
+			Actor:         expiredStockReservationActor,
+		})
+		if err != nil {
+			ws.logger.Error(fmt.Sprintf("Expired Reservation ID : %s Failed on Release due %v", reservationID, err), logFields...)
+			continue
+		}
+
+		released++
+	}
+
+	ws.logger.Info(fmt.Sprintf("Expired Reservation Released : %d of %d", released, len(reservationIDs)), logFields...)
+
+	return nil
+}
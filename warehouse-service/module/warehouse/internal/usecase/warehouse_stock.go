@@ -2,27 +2,39 @@ package usecase
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
 	"math"
 	"warehouse-service/internal/util"
 	"warehouse-service/internal/util/liberr"
 	"warehouse-service/internal/util/libvalidate"
 	"warehouse-service/module/warehouse/entity"
+
+	"go.uber.org/zap"
 )
 
 type WarehouseStockUsecaseRepos struct {
 	DatabaseTransactionHandler util.DatabaseTransactionHandler
 	WarehouseRepo              WarehouseRepository
 	WarehouseStockRepo         WarehouseStockRepository
+	StockAdjustmentRepo        StockAdjustmentRepository
+	StockMovementRepo          StockMovementRepository
+	StockReservationRepo       StockReservationRepository
+	WarehouseTransferRepo      WarehouseTransferRepository
+	WarehouseTransferLineRepo  WarehouseTransferLineRepository
 }
 
 type WarehouseStockUsecase struct {
-	repos *WarehouseStockUsecaseRepos
+	repos  *WarehouseStockUsecaseRepos
+	logger *zap.Logger
 }
 
-func NewWarehouseStockUsecase(repos *WarehouseStockUsecaseRepos) *WarehouseStockUsecase {
+func NewWarehouseStockUsecase(repos *WarehouseStockUsecaseRepos, logger *zap.Logger) *WarehouseStockUsecase {
 	return &WarehouseStockUsecase{
-		repos: repos,
+		repos:  repos,
+		logger: logger,
 	}
 }
 
@@ -58,13 +70,17 @@ func (ws *WarehouseStockUsecase) AdjustmentStock(ctx context.Context, params *en
 		return libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
 	}
 
-	// Validation Stock Adjustment
-	if err := ws.stockAdjustmentValidation(ctx, params.WarehouseStocks); err != nil {
-		return liberr.ResolveError(err)
+	reason := params.Reason
+	if reason == entity.StockMovementReasonUnspecified {
+		reason = entity.StockMovementReasonManual
 	}
 
 	// Process Stock Adjustment
-	return ws.stockAdjustment(ctx, params.WarehouseStocks)
+	return ws.processStockAdjustment(ctx, params.AdjustmentID, &entity.StockMovement{
+		Reason:      reason,
+		ReferenceID: stockMovementReferenceID(params.ReferenceID, params.AdjustmentID),
+		Actor:       params.Actor,
+	}, params.WarehouseStocks)
 }
 
 func (ws *WarehouseStockUsecase) TransferStock(ctx context.Context, params *entity.WarehouseStockTransferRequest) error {
@@ -94,13 +110,131 @@ func (ws *WarehouseStockUsecase) TransferStock(ctx context.Context, params *enti
 		})
 	}
 
+	// Process Stock Adjustment
+	return ws.processStockAdjustment(ctx, params.AdjustmentID, &entity.StockMovement{
+		Reason:      entity.StockMovementReasonTransfer,
+		ReferenceID: stockMovementReferenceID(params.ReferenceID, params.AdjustmentID),
+		Actor:       params.Actor,
+	}, stockAdjustments)
+}
+
+func stockMovementReferenceID(referenceID string, adjustmentID string) string {
+	if referenceID != "" {
+		return referenceID
+	}
+	return adjustmentID
+}
+
+// rejectedStockAdjustmentErrors is the rejection of the adjustment kept by the adjustment ID, a replay returns
+// the same rejection rather than being evaluated again
+var rejectedStockAdjustmentErrors = map[string]*liberr.ErrorDetails{
+	entity.ErrorCodeWarehouseNotFound:                  entity.ErrorWarehouseNotFound,
+	entity.ErrorCodeWarehouseStockNotFound:             entity.ErrorWarehouseStockNotFound,
+	entity.ErrorCodeWarehouseStockAdjustmentFailed:     entity.ErrorWarehouseStockAdjustmentFailed,
+	entity.ErrorCodeWarehouseStockAdjustmentOutOfStock: entity.ErrorWarehouseStockAdjustmentOutOfStock,
+}
+
+// processStockAdjustment apply the stock adjustments at most once per adjustment ID, a replay of an applied
+// adjustment ID returns success without touching the warehouse stocks again and a replay of a rejected one
+// returns the same rejection
+func (ws *WarehouseStockUsecase) processStockAdjustment(ctx context.Context, adjustmentID string, stockMovement *entity.StockMovement, stockAdjustments []*entity.WarehouseStockAdjustment) error {
+	var stockAdjustment *entity.StockAdjustment
+
+	if adjustmentID != "" {
+		requestHash, err := hashStockAdjustments(stockAdjustments)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+
+		stockAdjustment = &entity.StockAdjustment{
+			AdjustmentID: adjustmentID,
+			RequestHash:  requestHash,
+		}
+
+		replayed, err := ws.replayStockAdjustment(ctx, stockAdjustment)
+		if err != nil || replayed {
+			return err
+		}
+	}
+
 	// Validation Stock Adjustment
-	if err := ws.stockAdjustmentValidation(ctx, stockAdjustments); err != nil {
-		return liberr.ResolveError(err)
+	err := ws.stockAdjustmentValidation(ctx, stockAdjustments)
+	if err == nil {
+		err = ws.stockAdjustment(ctx, stockAdjustment, stockMovement, stockAdjustments)
 	}
 
-	// Process Stock Adjustment
-	return ws.stockAdjustment(ctx, stockAdjustments)
+	if stockAdjustment != nil && err != nil {
+		err = ws.rejectStockAdjustment(ctx, stockAdjustment, err)
+	}
+
+	if liberr.ErrorCodeEquals(err, entity.ErrorCodeStockAdjustmentDuplicated) {
+		// Same adjustment ID is applied concurrently by another request
+		_, err = ws.replayStockAdjustment(ctx, stockAdjustment)
+		return err
+	}
+
+	return liberr.ResolveError(err)
+}
+
+// rejectStockAdjustment record the rejection under the adjustment ID and return the rejection, other errors are
+// returned as they are so the adjustment is retried
+func (ws *WarehouseStockUsecase) rejectStockAdjustment(ctx context.Context, stockAdjustment *entity.StockAdjustment, cause error) error {
+	berr, ok := cause.(*liberr.BaseError)
+	if !ok {
+		return cause
+	}
+
+	code := ""
+	for c := range rejectedStockAdjustmentErrors {
+		if berr.IsAnyCodeEqual(c) {
+			code = c
+			break
+		}
+	}
+	if code == "" {
+		return cause
+	}
+
+	stockAdjustment.ErrorCode = code
+	if err := ws.repos.StockAdjustmentRepo.Create(ctx, stockAdjustment, nil); err != nil {
+		return err
+	}
+
+	return cause
+}
+
+// replayStockAdjustment report whether the adjustment ID has been processed with the same request,
+// along with the rejection when it was rejected
+func (ws *WarehouseStockUsecase) replayStockAdjustment(ctx context.Context, stockAdjustment *entity.StockAdjustment) (bool, error) {
+	existing, err := ws.repos.StockAdjustmentRepo.GetByAdjustmentID(ctx, stockAdjustment.AdjustmentID)
+	if err != nil {
+		if berr, ok := err.(*liberr.BaseError); ok && berr.IsAnyCodeEqual(entity.ErrorCodeStockAdjustmentNotFound) {
+			return false, nil
+		}
+		return false, liberr.ResolveError(err)
+	}
+
+	if existing.RequestHash != stockAdjustment.RequestHash {
+		return false, liberr.ResolveError(entity.ErrorStockAdjustmentConflicted)
+	}
+
+	if existing.ErrorCode != "" {
+		if rejection, ok := rejectedStockAdjustmentErrors[existing.ErrorCode]; ok {
+			return true, liberr.ResolveError(rejection)
+		}
+	}
+
+	return true, nil
+}
+
+func hashStockAdjustments(stockAdjustments []*entity.WarehouseStockAdjustment) (string, error) {
+	body, err := json.Marshal(stockAdjustments)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(body)
+	return hex.EncodeToString(hash[:]), nil
 }
 
 func (ws *WarehouseStockUsecase) stockAdjustmentValidation(ctx context.Context, stockAdjustments []*entity.WarehouseStockAdjustment) error {
@@ -140,7 +274,8 @@ func (ws *WarehouseStockUsecase) stockAdjustmentValidation(ctx context.Context,
 		if _, exists := warehouseProductStockMap[s.WarehouseID]; !exists {
 			warehouseProductStockMap[s.WarehouseID] = map[string]int{}
 		}
-		warehouseProductStockMap[s.WarehouseID][s.ProductID] = s.Stock
+		// Reserved stock is held for the reservations and can not be taken out
+		warehouseProductStockMap[s.WarehouseID][s.ProductID] = s.Available
 	}
 
 	for _, sa := range stockAdjustments {
@@ -154,7 +289,9 @@ func (ws *WarehouseStockUsecase) stockAdjustmentValidation(ctx context.Context,
 	return nil
 }
 
-func (ws *WarehouseStockUsecase) stockAdjustment(ctx context.Context, stockAdjustments []*entity.WarehouseStockAdjustment) error {
+// stockAdjustment apply the stock adjustments and append the stock movements within a single transaction,
+// the stock movement is used as template of reason, reference ID and actor for every adjusted stock
+func (ws *WarehouseStockUsecase) stockAdjustment(ctx context.Context, stockAdjustment *entity.StockAdjustment, stockMovement *entity.StockMovement, stockAdjustments []*entity.WarehouseStockAdjustment) error {
 	tx, err := ws.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
 	if err != nil {
 		return liberr.ResolveError(err)
@@ -166,6 +303,14 @@ func (ws *WarehouseStockUsecase) stockAdjustment(ctx context.Context, stockAdjus
 		}
 	}()
 
+	// Record the adjustment ID first, concurrent request with the same ID waits on the unique index
+	if stockAdjustment != nil {
+		err = ws.repos.StockAdjustmentRepo.Create(ctx, stockAdjustment, tx)
+		if err != nil {
+			return err
+		}
+	}
+
 	var affected int64
 	for _, sa := range stockAdjustments {
 		stockAbs := uint32(math.Abs(float64(sa.Stock)))
@@ -197,6 +342,26 @@ func (ws *WarehouseStockUsecase) stockAdjustment(ctx context.Context, stockAdjus
 				return err
 			}
 		}
+
+		// Record the movement with the resulting quantity
+		var warehouseStock *entity.WarehouseStock
+		warehouseStock, err = ws.repos.WarehouseStockRepo.GetByWarehouseIDAndProductID(ctx, sa.WarehouseID, sa.ProductID, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
+
+		err = ws.repos.StockMovementRepo.Create(ctx, &entity.StockMovement{
+			WarehouseID: sa.WarehouseID,
+			ProductID:   sa.ProductID,
+			Delta:       sa.Stock,
+			Quantity:    warehouseStock.Stock,
+			Reason:      stockMovement.Reason,
+			ReferenceID: stockMovement.ReferenceID,
+			Actor:       stockMovement.Actor,
+		}, tx)
+		if err != nil {
+			return liberr.ResolveError(err)
+		}
 	}
 
 	err = tx.Commit()
@@ -0,0 +1,421 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"warehouse-service/internal/util"
+	"warehouse-service/internal/util/liberr"
+	"warehouse-service/internal/util/libpagination"
+	"warehouse-service/internal/util/libvalidate"
+	"warehouse-service/module/warehouse/entity"
+)
+
+// CreateWarehouseTransfer record the transfer as draft, no stock is moved until the transfer is dispatched
+func (ws *WarehouseStockUsecase) CreateWarehouseTransfer(ctx context.Context, params *entity.CreateWarehouseTransferRequest) (*entity.WarehouseTransfer, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	// Merge the same product into a single line
+	lines := []*entity.WarehouseTransferLine{}
+	linesMap := make(map[string]*entity.WarehouseTransferLine)
+	for _, p := range params.Products {
+		if line, exists := linesMap[p.ProductID]; exists {
+			line.Stock += p.Stock
+			continue
+		}
+
+		line := &entity.WarehouseTransferLine{
+			ProductID: p.ProductID,
+			Stock:     p.Stock,
+		}
+		linesMap[p.ProductID] = line
+		lines = append(lines, line)
+	}
+
+	// Validation warehouses & stocks, the destination receives into its existing warehouse stock
+	stockAdjustments := []*entity.WarehouseStockAdjustment{}
+	for _, line := range lines {
+		stockAdjustments = append(stockAdjustments,
+			&entity.WarehouseStockAdjustment{
+				WarehouseID: params.OriginalWarehouseID,
+				ProductID:   line.ProductID,
+				Stock:       -1 * line.Stock,
+			},
+			&entity.WarehouseStockAdjustment{
+				WarehouseID: params.DestinationWarehouseID,
+				ProductID:   line.ProductID,
+			},
+		)
+	}
+
+	if err := ws.stockAdjustmentValidation(ctx, stockAdjustments); err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	tx, err := ws.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	warehouseTransfer := &entity.WarehouseTransfer{
+		OriginalWarehouseID:    params.OriginalWarehouseID,
+		DestinationWarehouseID: params.DestinationWarehouseID,
+		CreatedBy:              params.Actor,
+	}
+
+	err = ws.repos.WarehouseTransferRepo.Create(ctx, warehouseTransfer, tx)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	for _, line := range lines {
+		line.WarehouseTransferID = warehouseTransfer.ID
+
+		err = ws.repos.WarehouseTransferLineRepo.Create(ctx, line, tx)
+		if err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	warehouseTransfer.Lines = lines
+	return warehouseTransfer, nil
+}
+
+// DispatchWarehouseTransfer take the stock out of the original warehouse, the stock stays in transit until it is received
+func (ws *WarehouseStockUsecase) DispatchWarehouseTransfer(ctx context.Context, params *entity.WarehouseTransferActionRequest) (*entity.WarehouseTransfer, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	tx, err := ws.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	warehouseTransfer, lines, err := ws.lockWarehouseTransfer(ctx, params.ID, entity.WarehouseTransferStateDraft, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var affected int64
+	for _, line := range lines {
+		affected, err = ws.repos.WarehouseStockRepo.DecreaseStock(ctx, entity.WarehouseStockAdjustmentParams{
+			WarehouseID: warehouseTransfer.OriginalWarehouseID,
+			ProductID:   line.ProductID,
+			Stock:       uint32(line.Stock),
+		}, tx)
+		if err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+		if affected <= 0 {
+			err = liberr.ResolveError(entity.ErrorWarehouseStockAdjustmentOutOfStock)
+			return nil, err
+		}
+
+		err = ws.recordWarehouseTransferMovement(ctx, warehouseTransfer, warehouseTransfer.OriginalWarehouseID, line.ProductID, -1*line.Stock, params.Actor, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = ws.updateWarehouseTransferState(ctx, warehouseTransfer, entity.WarehouseTransferStateDispatched, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	warehouseTransfer.Lines = lines
+	setWarehouseTransferInTransitStock(warehouseTransfer)
+	return warehouseTransfer, nil
+}
+
+// ReceiveWarehouseTransfer put the received stock into the destination warehouse, the stock is allowed to be received
+// partially over several receipts and the transfer is received once every line has been fully received
+func (ws *WarehouseStockUsecase) ReceiveWarehouseTransfer(ctx context.Context, params *entity.ReceiveWarehouseTransferRequest) (*entity.WarehouseTransfer, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	tx, err := ws.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	warehouseTransfer, lines, err := ws.lockWarehouseTransfer(ctx, params.ID, entity.WarehouseTransferStateDispatched, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	linesMap := make(map[string]*entity.WarehouseTransferLine, len(lines))
+	for _, line := range lines {
+		linesMap[line.ProductID] = line
+	}
+
+	var affected int64
+	for _, p := range params.Products {
+		line, exists := linesMap[p.ProductID]
+		if !exists || line.ReceivedStock+p.Stock > line.Stock {
+			err = liberr.ResolveError(entity.ErrorWarehouseTransferInvalidReceipt)
+			return nil, err
+		}
+
+		affected, err = ws.repos.WarehouseTransferLineRepo.IncreaseReceivedStock(ctx, line.ID, p.Stock, tx)
+		if err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+		if affected <= 0 {
+			err = liberr.ResolveError(entity.ErrorWarehouseTransferInvalidReceipt)
+			return nil, err
+		}
+		line.ReceivedStock += p.Stock
+
+		affected, err = ws.repos.WarehouseStockRepo.IncreaseStock(ctx, entity.WarehouseStockAdjustmentParams{
+			WarehouseID: warehouseTransfer.DestinationWarehouseID,
+			ProductID:   line.ProductID,
+			Stock:       uint32(p.Stock),
+		}, tx)
+		if err != nil {
+			return nil, liberr.ResolveError(err)
+		}
+		if affected <= 0 {
+			err = liberr.ResolveError(entity.ErrorWarehouseStockAdjustmentFailed)
+			return nil, err
+		}
+
+		err = ws.recordWarehouseTransferMovement(ctx, warehouseTransfer, warehouseTransfer.DestinationWarehouseID, line.ProductID, p.Stock, params.Actor, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	received := true
+	for _, line := range lines {
+		if line.ReceivedStock < line.Stock {
+			received = false
+			break
+		}
+	}
+
+	if received {
+		err = ws.updateWarehouseTransferState(ctx, warehouseTransfer, entity.WarehouseTransferStateReceived, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	warehouseTransfer.Lines = lines
+	setWarehouseTransferInTransitStock(warehouseTransfer)
+	return warehouseTransfer, nil
+}
+
+// CancelWarehouseTransfer cancel the draft transfer, dispatched transfer has to be received
+func (ws *WarehouseStockUsecase) CancelWarehouseTransfer(ctx context.Context, params *entity.WarehouseTransferActionRequest) (*entity.WarehouseTransfer, error) {
+	// Validation struct
+	if err := libvalidate.Validator().Struct(params); err != nil {
+		return nil, libvalidate.ResolveError(err, entity.ErrorCodeInvalidBodyJSON)
+	}
+
+	tx, err := ws.repos.DatabaseTransactionHandler.Begin(ctx, &sql.TxOptions{})
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	defer func() {
+		if err != nil {
+			tx.Rollback() //nolint
+		}
+	}()
+
+	warehouseTransfer, lines, err := ws.lockWarehouseTransfer(ctx, params.ID, entity.WarehouseTransferStateDraft, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ws.updateWarehouseTransferState(ctx, warehouseTransfer, entity.WarehouseTransferStateCancelled, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	warehouseTransfer.Lines = lines
+	setWarehouseTransferInTransitStock(warehouseTransfer)
+	return warehouseTransfer, nil
+}
+
+func (ws *WarehouseStockUsecase) GetWarehouseTransfer(ctx context.Context, id string) (*entity.WarehouseTransfer, error) {
+	warehouseTransfer, err := ws.repos.WarehouseTransferRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, liberr.ResolveError(err)
+	}
+
+	if err := ws.attachWarehouseTransferLines(ctx, []*entity.WarehouseTransfer{warehouseTransfer}); err != nil {
+		return nil, err
+	}
+
+	return warehouseTransfer, nil
+}
+
+func (ws *WarehouseStockUsecase) ListWarehouseTransfer(ctx context.Context, params *entity.ListWarehouseTransferByParams) ([]*entity.WarehouseTransfer, *libpagination.OffsetPagination, error) {
+	params.Offset = libpagination.Offset(params.Page, params.Limit)
+
+	warehouseTransfers, pagination, err := ws.repos.WarehouseTransferRepo.ListByParams(ctx, params)
+	if err != nil {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	if err := ws.attachWarehouseTransferLines(ctx, warehouseTransfers); err != nil {
+		return nil, nil, err
+	}
+
+	return warehouseTransfers, pagination, nil
+}
+
+func (ws *WarehouseStockUsecase) attachWarehouseTransferLines(ctx context.Context, warehouseTransfers []*entity.WarehouseTransfer) error {
+	if len(warehouseTransfers) == 0 {
+		return nil
+	}
+
+	warehouseTransferIDs := make([]string, 0, len(warehouseTransfers))
+	for _, wt := range warehouseTransfers {
+		warehouseTransferIDs = append(warehouseTransferIDs, wt.ID)
+	}
+
+	lines, err := ws.repos.WarehouseTransferLineRepo.ListByWarehouseTransferIDs(ctx, warehouseTransferIDs, nil)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	linesMap := make(map[string][]*entity.WarehouseTransferLine)
+	for _, line := range lines {
+		linesMap[line.WarehouseTransferID] = append(linesMap[line.WarehouseTransferID], line)
+	}
+
+	for _, wt := range warehouseTransfers {
+		wt.Lines = linesMap[wt.ID]
+		setWarehouseTransferInTransitStock(wt)
+	}
+
+	return nil
+}
+
+// setWarehouseTransferInTransitStock the stock of dispatched transfer which has not been received yet
+func setWarehouseTransferInTransitStock(warehouseTransfer *entity.WarehouseTransfer) {
+	for _, line := range warehouseTransfer.Lines {
+		line.InTransitStock = 0
+		if warehouseTransfer.State == entity.WarehouseTransferStateDispatched {
+			line.InTransitStock = line.Stock - line.ReceivedStock
+		}
+	}
+}
+
+// lockWarehouseTransfer retrieve the transfer in the expected state along with the lines,
+// the transfer is locked so concurrent requests advance the transfer one at a time
+func (ws *WarehouseStockUsecase) lockWarehouseTransfer(ctx context.Context, id string, state entity.WarehouseTransferState, tx util.DatabaseTransaction) (*entity.WarehouseTransfer, []*entity.WarehouseTransferLine, error) {
+	warehouseTransfer, err := ws.repos.WarehouseTransferRepo.GetByIDForUpdate(ctx, id, tx)
+	if err != nil {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	if warehouseTransfer.State != state {
+		return nil, nil, liberr.ResolveError(entity.ErrorWarehouseTransferInvalidState)
+	}
+
+	lines, err := ws.repos.WarehouseTransferLineRepo.ListByWarehouseTransferIDs(ctx, []string{warehouseTransfer.ID}, tx)
+	if err != nil {
+		return nil, nil, liberr.ResolveError(err)
+	}
+
+	return warehouseTransfer, lines, nil
+}
+
+func (ws *WarehouseStockUsecase) updateWarehouseTransferState(ctx context.Context, warehouseTransfer *entity.WarehouseTransfer, toState entity.WarehouseTransferState, tx util.DatabaseTransaction) error {
+	now := util.NowUTCWithoutNanoSecond()
+
+	affected, err := ws.repos.WarehouseTransferRepo.UpdateState(ctx, warehouseTransfer.ID, warehouseTransfer.State, toState, now, tx)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+	if affected <= 0 {
+		return liberr.ResolveError(entity.ErrorWarehouseTransferInvalidState)
+	}
+
+	switch toState {
+	case entity.WarehouseTransferStateDispatched:
+		warehouseTransfer.DispatchedAt = &now
+	case entity.WarehouseTransferStateReceived:
+		warehouseTransfer.ReceivedAt = &now
+	case entity.WarehouseTransferStateCancelled:
+		warehouseTransfer.CancelledAt = &now
+	}
+	warehouseTransfer.State = toState
+
+	return nil
+}
+
+// recordWarehouseTransferMovement append the transfer movement with the resulting quantity
+func (ws *WarehouseStockUsecase) recordWarehouseTransferMovement(ctx context.Context, warehouseTransfer *entity.WarehouseTransfer, warehouseID string, productID string, delta int, actor string, tx util.DatabaseTransaction) error {
+	warehouseStock, err := ws.repos.WarehouseStockRepo.GetByWarehouseIDAndProductID(ctx, warehouseID, productID, tx)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	err = ws.repos.StockMovementRepo.Create(ctx, &entity.StockMovement{
+		WarehouseID: warehouseID,
+		ProductID:   productID,
+		Delta:       delta,
+		Quantity:    warehouseStock.Stock,
+		Reason:      entity.StockMovementReasonTransfer,
+		ReferenceID: warehouseTransferReferenceID(warehouseTransfer),
+		Actor:       actor,
+	}, tx)
+	if err != nil {
+		return liberr.ResolveError(err)
+	}
+
+	return nil
+}
+
+func warehouseTransferReferenceID(warehouseTransfer *entity.WarehouseTransfer) string {
+	return fmt.Sprintf("warehouse-transfer-%s", warehouseTransfer.ID)
+}
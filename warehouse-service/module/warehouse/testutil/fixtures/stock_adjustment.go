@@ -0,0 +1,39 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"time"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	StockAdjustment = &entity.StockAdjustment{
+		ID:           "7",
+		AdjustmentID: "order-outbox-5",
+		RequestHash:  "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		CreatedAt:    time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:    time.Date(2025, 2, 20, 21, 22, 23, 24, time.UTC),
+	}
+)
+
+func NewStockAdjustment(obj *entity.StockAdjustment) *entity.StockAdjustment {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.StockAdjustment)
+	return res
+}
+
+func GetStockAdjustmentRow(obj *entity.StockAdjustment) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.AdjustmentID,
+		obj.RequestHash,
+		obj.ErrorCode,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
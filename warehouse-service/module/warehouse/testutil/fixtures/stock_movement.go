@@ -0,0 +1,46 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"time"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	StockMovement = &entity.StockMovement{
+		ID:          "8",
+		WarehouseID: "1",
+		ProductID:   "3",
+		Delta:       -2,
+		Quantity:    8,
+		Reason:      entity.StockMovementReasonOrderReservation,
+		ReferenceID: "1",
+		Actor:       "order-service",
+		CreatedAt:   time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewStockMovement(obj *entity.StockMovement) *entity.StockMovement {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.StockMovement)
+	return res
+}
+
+func GetStockMovementRow(obj *entity.StockMovement) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.WarehouseID,
+		obj.ProductID,
+		obj.Delta,
+		obj.Quantity,
+		obj.Reason,
+		obj.ReferenceID,
+		obj.Actor,
+		obj.CreatedAt,
+	}
+}
@@ -0,0 +1,46 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"time"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	StockReservation = &entity.StockReservation{
+		ID:            "11",
+		ReservationID: "5",
+		WarehouseID:   "1",
+		ProductID:     "3",
+		Stock:         2,
+		State:         entity.StockReservationStateReserved,
+		ExpiredAt:     time.Date(2025, 1, 10, 12, 12, 13, 14, time.UTC),
+		CreatedAt:     time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:     time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+	}
+)
+
+func NewStockReservation(obj *entity.StockReservation) *entity.StockReservation {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.StockReservation)
+	return res
+}
+
+func GetStockReservationRow(obj *entity.StockReservation) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.ReservationID,
+		obj.WarehouseID,
+		obj.ProductID,
+		obj.Stock,
+		obj.State,
+		obj.ExpiredAt,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
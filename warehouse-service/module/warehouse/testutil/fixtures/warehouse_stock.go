@@ -14,6 +14,8 @@ var (
 		WarehouseID: "1",
 		ProductID:   "3",
 		Stock:       10,
+		Reserved:    2,
+		Available:   8,
 		CreatedAt:   time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
 		UpdatedAt:   time.Date(2025, 2, 20, 21, 22, 23, 24, time.UTC),
 	}
@@ -34,6 +36,7 @@ func GetWarehouseStockRow(obj *entity.WarehouseStock) []driver.Value {
 		obj.WarehouseID,
 		obj.ProductID,
 		obj.Stock,
+		obj.Reserved,
 		obj.CreatedAt,
 		obj.UpdatedAt,
 	}
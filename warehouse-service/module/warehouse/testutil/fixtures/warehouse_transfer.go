@@ -0,0 +1,86 @@
+package fixtures
+
+import (
+	"database/sql/driver"
+	"time"
+	"warehouse-service/module/warehouse/entity"
+
+	"github.com/mitchellh/copystructure"
+)
+
+var (
+	warehouseTransferDispatchedAt = time.Date(2025, 1, 11, 8, 0, 0, 0, time.UTC)
+
+	WarehouseTransfer = &entity.WarehouseTransfer{
+		ID:                     "4",
+		OriginalWarehouseID:    "1",
+		DestinationWarehouseID: "2",
+		State:                  entity.WarehouseTransferStateDispatched,
+		CreatedBy:              "admin",
+		DispatchedAt:           &warehouseTransferDispatchedAt,
+		CreatedAt:              time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:              time.Date(2025, 1, 11, 8, 0, 0, 0, time.UTC),
+	}
+
+	WarehouseTransferLine = &entity.WarehouseTransferLine{
+		ID:                  "9",
+		WarehouseTransferID: "4",
+		ProductID:           "3",
+		Stock:               5,
+		ReceivedStock:       2,
+		CreatedAt:           time.Date(2025, 1, 10, 11, 12, 13, 14, time.UTC),
+		UpdatedAt:           time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC),
+	}
+)
+
+func NewWarehouseTransfer(obj *entity.WarehouseTransfer) *entity.WarehouseTransfer {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.WarehouseTransfer)
+	return res
+}
+
+func GetWarehouseTransferRow(obj *entity.WarehouseTransfer) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.OriginalWarehouseID,
+		obj.DestinationWarehouseID,
+		obj.State,
+		obj.CreatedBy,
+		nullableTime(obj.DispatchedAt),
+		nullableTime(obj.ReceivedAt),
+		nullableTime(obj.CancelledAt),
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
+
+func NewWarehouseTransferLine(obj *entity.WarehouseTransferLine) *entity.WarehouseTransferLine {
+	r, err := copystructure.Copy(obj)
+	if err != nil {
+		return nil
+	}
+	res := r.(*entity.WarehouseTransferLine)
+	return res
+}
+
+func GetWarehouseTransferLineRow(obj *entity.WarehouseTransferLine) []driver.Value {
+	return []driver.Value{
+		obj.ID,
+		obj.WarehouseTransferID,
+		obj.ProductID,
+		obj.Stock,
+		obj.ReceivedStock,
+		obj.CreatedAt,
+		obj.UpdatedAt,
+	}
+}
+
+func nullableTime(t *time.Time) driver.Value {
+	if t == nil {
+		return nil
+	}
+	return *t
+}